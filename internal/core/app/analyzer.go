@@ -23,7 +23,7 @@ func (a *App) HandleChanges(paths []string) {
 
 	for _, path := range paths {
 		if filepath.Base(path) == "go.mod" {
-			a.goModCache = make(map[string]goModuleCacheEntry)
+			a.resetGoModCache()
 		}
 		if !a.codeParser.IsSupportedPath(path) && filepath.Base(path) != "go.mod" {
 			continue
@@ -39,8 +39,11 @@ func (a *App) HandleChanges(paths []string) {
 		affectedSet[path] = true
 
 		if _, err := os.Stat(path); os.IsNotExist(err) {
-			a.Graph.RemoveFile(path)
+			if err := a.Graph.RemoveFile(path); err != nil {
+				slog.Warn("failed to remove file from graph", "path", path, "error", err)
+			}
 			a.dropContent(path)
+			a.forgetParsedTree(path)
 			if err := a.enqueueSymbolWrite(ports.WriteRequest{
 				Operation: ports.WriteOperationDeleteFile,
 				FilePath:  path,
@@ -56,13 +59,18 @@ func (a *App) HandleChanges(paths []string) {
 			continue
 		}
 
-		if err := a.ProcessFile(path); err != nil {
+		if err := a.reprocessChangedFile(path); err != nil {
 			slog.Warn("failed to re-process file", "path", path, "error", err)
 		}
 	}
 
-	cycles := a.Graph.DetectCycles()
-	metrics := a.Graph.ComputeModuleMetrics()
+	for path := range affectedSet {
+		a.Graph.InvalidateSymbolEdges(path)
+	}
+
+	structure := a.structureGraph()
+	cycles := structure.DetectCycles()
+	metrics := structure.ComputeModuleMetrics()
 	hotspots := a.Graph.TopComplexity(a.Config.Architecture.TopComplexity)
 	violations := a.ArchitectureViolations()
 	ruleViolations, ruleSummary := a.ArchitectureRuleViolations()
@@ -6,12 +6,14 @@ import (
 	"circular/internal/core/errors"
 	"circular/internal/core/ports"
 	"circular/internal/core/watcher"
+	"circular/internal/data/history"
 	"circular/internal/engine/architecture"
 	"circular/internal/engine/graph"
 	"circular/internal/engine/parser"
 	"circular/internal/engine/resolver"
 	secretengine "circular/internal/engine/secrets"
 	"context"
+	"log/slog"
 	"sync"
 
 	"github.com/gobwas/glob"
@@ -45,6 +47,7 @@ type App struct {
 	Graph         *graph.Graph
 	secretScanner ports.SecretScanner
 	symbolStore   *graph.SQLiteSymbolStore
+	nodeStorage   *history.GraphStorage
 	writeQueue    ports.WriteQueuePort
 	writeSpool    ports.WriteSpoolPort
 	workerCancel  context.CancelFunc
@@ -53,8 +56,12 @@ type App struct {
 	archRules     []ports.ArchitectureRule
 	archEvaluator *architecture.RuleEvaluator
 	goModCache    map[string]goModuleCacheEntry
+	goModMu       sync.Mutex
 	IncludeTests  bool
 
+	// parseFingerprint tags persisted parses; guarded by configMu.
+	parseFingerprint string
+
 	secretExcludeDirs  []glob.Glob
 	secretExcludeFiles []glob.Glob
 
@@ -123,12 +130,16 @@ func (a *App) UpdateConfig(ctx context.Context, cfg *config.Config) error {
 	}
 
 	a.Config = cfg
+	a.parseFingerprint = parseFingerprint(cfg, parser.ExtractorVersion)
 	return nil
 }
 
 func (a *App) PruneCache(percentage int) {
 	if a.Graph != nil {
 		a.Graph.PruneCache(percentage)
+		if _, err := a.Graph.PageOutModules(percentage); err != nil {
+			slog.Warn("failed to page out graph modules", "error", err)
+		}
 	}
 	if a.fileContents != nil {
 		a.fileContents.Prune(percentage)
@@ -194,6 +205,7 @@ func NewWithDependencies(cfg *config.Config, deps Dependencies) (*App, error) {
 	archRules := helpers.ArchitectureRulesFromConfig(cfg.Architecture)
 	app := &App{
 		Config:             cfg,
+		parseFingerprint:   parseFingerprint(cfg, parser.ExtractorVersion),
 		codeParser:         deps.CodeParser,
 		Graph:              graph.NewGraphWithCapacity(cfg.Caches.Files),
 		secretScanner:      secretScanner,
@@ -210,6 +222,9 @@ func NewWithDependencies(cfg *config.Config, deps Dependencies) (*App, error) {
 	if err := app.initSymbolStore(); err != nil {
 		return nil, errors.Wrap(err, errors.CodeInternal, "failed to initialize symbol store")
 	}
+	if err := app.initNodeStorage(); err != nil {
+		return nil, errors.Wrap(err, errors.CodeInternal, "failed to initialize node storage")
+	}
 	if err := app.initWriteQueue(); err != nil {
 		return nil, errors.Wrap(err, errors.CodeInternal, "failed to initialize write queue")
 	}
@@ -239,6 +254,7 @@ func buildParserRegistry(cfg *config.Config) (map[string]parser.LanguageSpec, er
 				NamespaceNode:   dg.NamespaceNode,
 				ImportNode:      dg.ImportNode,
 				DefinitionNodes: dg.DefinitionNodes,
+				Queries:         dg.Queries,
 			},
 		})
 	}
@@ -12,6 +12,7 @@ import (
 	"context"
 	"os"
 	"path/filepath"
+	"reflect"
 	"strings"
 	"testing"
 	"time"
@@ -709,6 +710,14 @@ func TestResolveDiagramMode(t *testing.T) {
 	if modes[0] != helpers.DiagramModeDependency || modes[1] != helpers.DiagramModeArchitecture || modes[2] != helpers.DiagramModeComponent || modes[3] != helpers.DiagramModeFlow {
 		t.Fatalf("unexpected multi-mode ordering: %#v", modes)
 	}
+
+	modes, err = helpers.ResolveDiagramModes(config.DiagramOutput{Clusters: true})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(modes) != 1 || modes[0] != helpers.DiagramModeClusters || modes[0].Suffix() != "clusters" {
+		t.Fatalf("expected clusters mode alone, got %#v", modes)
+	}
 }
 
 func TestApp_GenerateOutputs_MultipleDiagramModesCreateSuffixedFiles(t *testing.T) {
@@ -863,6 +872,256 @@ func TestApp_TraceImportChain(t *testing.T) {
 	}
 }
 
+func TestApp_OrphanModules_UsesPackageJSONBins(t *testing.T) {
+	tmpDir := t.TempDir()
+	manifest := `{"name": "tool", "bin": {"tool": "./bin/cli.js"}}`
+	if err := os.WriteFile(filepath.Join(tmpDir, "package.json"), []byte(manifest), 0o644); err != nil {
+		t.Fatal(err)
+	}
+
+	cli := filepath.Join(tmpDir, "bin", "cli.js")
+	app := &App{
+		Graph:  graph.NewGraph(),
+		Config: &config.Config{EntryPoints: config.EntryPoints{Detect: []string{"js_bin"}}},
+	}
+	app.Graph.AddFile(&parser.File{Path: cli, Module: "bin/cli", Language: "javascript", Imports: []parser.Import{{Module: "lib/run"}}})
+	app.Graph.AddFile(&parser.File{Path: filepath.Join(tmpDir, "lib", "run.js"), Module: "lib/run", Language: "javascript"})
+	app.Graph.AddFile(&parser.File{Path: filepath.Join(tmpDir, "lib", "old.js"), Module: "lib/old", Language: "javascript"})
+
+	report := app.OrphanModules()
+	if len(report.EntryPoints) != 1 || report.EntryPoints[0] != "bin/cli" {
+		t.Fatalf("expected package.json bin as entry point, got %v", report.EntryPoints)
+	}
+	if len(report.Orphans) != 1 || report.Orphans[0].Module != "lib/old" {
+		t.Fatalf("expected lib/old as the only orphan, got %+v", report.Orphans)
+	}
+}
+
+func TestApp_EntryPointsDriveCriticalModulesAndDeadCode(t *testing.T) {
+	app := &App{
+		Graph: graph.NewGraph(),
+		Config: &config.Config{EntryPoints: config.EntryPoints{
+			Detect:   []string{"go_main"},
+			Patterns: []string{"tools/**"},
+		}},
+	}
+	// cmd and tools both import lib; neither is a root of the other.
+	app.Graph.AddFile(&parser.File{Path: "cmd/main.go", Module: "cmd", Language: "go", PackageName: "main", Imports: []parser.Import{{Module: "lib"}}})
+	app.Graph.AddFile(&parser.File{Path: "tools/gen.py", Module: "tools", Language: "python", Imports: []parser.Import{{Module: "lib"}},
+		Definitions: []parser.Definition{{Name: "Generate", Kind: parser.KindFunction, Exported: true}}})
+	app.Graph.AddFile(&parser.File{Path: "lib/lib.go", Module: "lib", Language: "go", PackageName: "lib",
+		Definitions: []parser.Definition{{Name: "Unused", Kind: parser.KindFunction, Exported: true}}})
+
+	if report := app.CriticalModules(nil); !reflect.DeepEqual(report.EntryPoints, []string{"cmd", "tools"}) {
+		t.Fatalf("expected configured entry modules, got %v", report.EntryPoints)
+	}
+	if report := app.CriticalModules([]string{"tools/gen.py"}); !reflect.DeepEqual(report.EntryPoints, []string{"tools"}) {
+		t.Fatalf("expected explicit entry points to override the config, got %v", report.EntryPoints)
+	}
+	dead := app.DeadCode()
+	if len(dead) != 1 || dead[0].Module != "lib" || dead[0].Name != "Unused" {
+		t.Fatalf("expected only lib.Unused outside entry modules, got %+v", dead)
+	}
+}
+
+func TestApp_TraceImportPaths(t *testing.T) {
+	app := &App{Graph: graph.NewGraph()}
+	app.Graph.AddFile(&parser.File{Path: "a.go", Module: "A", Imports: []parser.Import{
+		{Module: "B", Location: parser.Location{Line: 3}},
+		{Module: "C", Location: parser.Location{Line: 4}},
+	}})
+	app.Graph.AddFile(&parser.File{Path: "b.go", Module: "B", Imports: []parser.Import{{Module: "C", Location: parser.Location{Line: 5}}}})
+	app.Graph.AddFile(&parser.File{Path: "c.go", Module: "C"})
+
+	out, err := app.TraceImportPaths("A", "C", 5, 0)
+	if err != nil {
+		t.Fatalf("expected paths, got error: %v", err)
+	}
+	for _, want := range []string{
+		"Import paths: A -> C (2 found)",
+		"#1 (depth 1)\nA\n  -> C  (a.go:4)",
+		"#2 (depth 2)\nA\n  -> B  (a.go:3)\n  -> C  (b.go:5)",
+	} {
+		if !strings.Contains(out, want) {
+			t.Fatalf("expected %q in output, got:\n%s", want, out)
+		}
+	}
+	if _, err := app.TraceImportPaths("C", "A", 5, 0); err == nil {
+		t.Fatal("expected error when no path exists")
+	}
+}
+
+func TestApp_TraceImportChainAtFileGranularity(t *testing.T) {
+	root := t.TempDir()
+	app := &App{Config: &config.Config{Graph: config.GraphSettings{Granularity: "file"}}, Graph: graph.NewGraph()}
+	app.Graph.AddFile(&parser.File{Path: filepath.Join(root, "pkg", "a.py"), Language: "python", Module: "pkg",
+		Imports: []parser.Import{{Module: "other", Items: []string{"Run"}, Location: parser.Location{Line: 1}}}})
+	app.Graph.AddFile(&parser.File{Path: filepath.Join(root, "pkg", "b.py"), Language: "python", Module: "pkg",
+		Definitions: []parser.Definition{{Name: "Helper"}}})
+	app.Graph.AddFile(&parser.File{Path: filepath.Join(root, "other", "c.py"), Language: "python", Module: "other",
+		Imports:     []parser.Import{{Module: "pkg", Items: []string{"Helper"}, Location: parser.Location{Line: 2}}},
+		Definitions: []parser.Definition{{Name: "Run"}}})
+
+	wd, err := os.Getwd()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := os.Chdir(root); err != nil {
+		t.Fatal(err)
+	}
+	defer os.Chdir(wd)
+
+	if cycles := app.structureGraph().DetectCycles(); len(cycles) != 0 {
+		t.Fatalf("expected no file-level cycles, got %v", cycles)
+	}
+	out, err := app.TraceImportChain(filepath.Join(root, "pkg", "a.py"), "pkg/b.py")
+	if err != nil {
+		t.Fatalf("trace at file granularity: %v", err)
+	}
+	if !strings.Contains(out, "pkg/a.py\n  -> other/c.py\n  -> pkg/b.py") {
+		t.Fatalf("unexpected file-level chain:\n%s", out)
+	}
+
+	app.Config.Graph.Granularity = "module"
+	if cycles := app.structureGraph().DetectCycles(); len(cycles) != 1 {
+		t.Fatalf("expected module-level cycle, got %v", cycles)
+	}
+}
+
+func TestApp_TraceImportChainAtDepth(t *testing.T) {
+	app := &App{Config: &config.Config{Graph: config.GraphSettings{Granularity: "module", Depth: 2}}, Graph: graph.NewGraph()}
+	app.Graph.AddFile(&parser.File{Path: "g.go", Module: "app/engine/graph", Imports: []parser.Import{{Module: "app/shared/util"}}})
+	app.Graph.AddFile(&parser.File{Path: "u.go", Module: "app/shared/util", Imports: []parser.Import{{Module: "app/engine/parser"}}})
+	app.Graph.AddFile(&parser.File{Path: "p.go", Module: "app/engine/parser"})
+
+	if cycles := app.structureGraph().DetectCycles(); len(cycles) != 1 {
+		t.Fatalf("expected app/engine <-> app/shared cycle at depth 2, got %v", cycles)
+	}
+	out, err := app.TraceImportChain("app/engine/graph", "app/shared/util")
+	if err != nil {
+		t.Fatalf("trace at depth: %v", err)
+	}
+	if !strings.Contains(out, "Import chain: app/engine -> app/shared") {
+		t.Fatalf("expected endpoints rolled up to depth 2:\n%s", out)
+	}
+
+	app.Config.Graph.Depth = 0
+	if cycles := app.structureGraph().DetectCycles(); len(cycles) != 0 {
+		t.Fatalf("expected no module-level cycles, got %v", cycles)
+	}
+}
+
+func TestApp_SymbolTraceAndImpact(t *testing.T) {
+	app := &App{Graph: graph.NewGraph()}
+	app.Graph.AddFile(&parser.File{
+		Path:     "a.go",
+		Language: "go",
+		Module:   "A",
+		Imports:  []parser.Import{{Module: "B"}},
+		Definitions: []parser.Definition{
+			{Name: "Run", Kind: parser.KindFunction, Exported: true, Location: parser.Location{File: "a.go", Line: 3}, LOC: 5},
+		},
+		References: []parser.Reference{{Name: "B.Load", Location: parser.Location{File: "a.go", Line: 4}}},
+	})
+	app.Graph.AddFile(&parser.File{
+		Path:     "b.go",
+		Language: "go",
+		Module:   "B",
+		Definitions: []parser.Definition{
+			{Name: "Load", Kind: parser.KindFunction, Exported: true, Location: parser.Location{File: "b.go", Line: 1}, LOC: 2},
+		},
+	})
+
+	out, err := app.TraceSymbolChain(context.Background(), graph.SymbolKey{Module: "A", Name: "Run"}, graph.SymbolKey{Module: "B", Name: "Load"})
+	if err != nil {
+		t.Fatalf("expected symbol trace success, got error: %v", err)
+	}
+	if !strings.Contains(out, "A#Run\n  -> B#Load") {
+		t.Fatalf("unexpected symbol chain: %s", out)
+	}
+
+	report, err := app.AnalyzeImpact(context.Background(), "B#Load")
+	if err != nil {
+		t.Fatalf("expected symbol impact success, got error: %v", err)
+	}
+	if report.Symbol == nil || len(report.Symbol.DirectDependents) != 1 || report.Symbol.DirectDependents[0] != "A#Run" {
+		t.Fatalf("unexpected symbol impact: %+v", report.Symbol)
+	}
+	if len(report.DirectImporters) != 1 || report.DirectImporters[0] != "A" {
+		t.Fatalf("expected module A as direct importer, got %v", report.DirectImporters)
+	}
+	if !strings.Contains(FormatImpactReport(report), "Direct symbol dependents (1)") {
+		t.Fatalf("expected symbol section in formatted report")
+	}
+}
+
+func TestApp_PlanCycleRelocation(t *testing.T) {
+	app := &App{Graph: graph.NewGraph()}
+	app.Graph.AddFile(&parser.File{
+		Path:     "a.go",
+		Language: "go",
+		Module:   "A",
+		Imports:  []parser.Import{{Module: "B"}},
+		Definitions: []parser.Definition{
+			{Name: "Run", Kind: parser.KindFunction, Exported: true, Location: parser.Location{File: "a.go", Line: 3}, LOC: 5},
+		},
+		References: []parser.Reference{{Name: "B.Load", Location: parser.Location{File: "a.go", Line: 4}}},
+	})
+	app.Graph.AddFile(&parser.File{
+		Path:     "b.go",
+		Language: "go",
+		Module:   "B",
+		Imports:  []parser.Import{{Module: "A"}},
+		Definitions: []parser.Definition{
+			{Name: "Load", Kind: parser.KindFunction, Exported: true, Location: parser.Location{File: "b.go", Line: 1}, LOC: 2},
+			{Name: "Serve", Kind: parser.KindFunction, Exported: true, Location: parser.Location{File: "b.go", Line: 5}, LOC: 3},
+		},
+		References: []parser.Reference{{Name: "A.Run", Location: parser.Location{File: "b.go", Line: 6}}},
+	})
+
+	plan, err := app.PlanCycleRelocation(context.Background(), []string{"A", "B"})
+	if err != nil {
+		t.Fatalf("expected move plan, got error: %v", err)
+	}
+	if !plan.Resolved || len(plan.Moves) != 1 {
+		t.Fatalf("expected one resolved move, got %+v", plan)
+	}
+	out := FormatRelocationPlan(plan)
+	if !strings.Contains(out, "Status: resolved in simulation") || !strings.Contains(out, "Definitions to move (1)") {
+		t.Fatalf("unexpected formatted plan: %s", out)
+	}
+}
+
+func TestApp_SimulateRefactor(t *testing.T) {
+	app := &App{Graph: graph.NewGraph()}
+	app.Graph.AddFile(&parser.File{Path: "a.go", Language: "go", Module: "A", Imports: []parser.Import{{Module: "B"}}})
+	app.Graph.AddFile(&parser.File{Path: "b.go", Language: "go", Module: "B", Imports: []parser.Import{{Module: "A"}}})
+
+	result, err := app.SimulateRefactor(context.Background(), []graph.SimulationOp{
+		{Kind: graph.SimulateDeleteEdge, From: "B", To: "A"},
+	})
+	if err != nil {
+		t.Fatalf("expected simulation, got error: %v", err)
+	}
+	if result.CyclesBefore != 1 || result.CyclesAfter != 0 || len(result.CyclesRemoved) != 1 {
+		t.Fatalf("expected the cycle to be removed, got %+v", result)
+	}
+	if len(result.MetricDeltas) != 2 {
+		t.Fatalf("expected metric changes for A and B, got %+v", result.MetricDeltas)
+	}
+	if len(app.Graph.DetectCycles()) != 1 {
+		t.Fatal("expected live graph to keep its cycle")
+	}
+	out := FormatSimulationResult(result)
+	if !strings.Contains(out, "Cycles: 1 -> 0") || !strings.Contains(out, "delete_edge B -> A") {
+		t.Fatalf("unexpected formatted simulation: %s", out)
+	}
+
+	if _, err := app.SimulateRefactor(context.Background(), nil); err == nil {
+		t.Fatal("expected error for empty operation list")
+	}
+}
+
 func TestApp_TraceImportChain_Errors(t *testing.T) {
 	app := &App{Graph: graph.NewGraph()}
 	app.Graph.AddFile(&parser.File{Path: "a.go", Module: "A"})
@@ -1110,3 +1369,225 @@ func TestApp_ResolveGoModule_CacheRelErrorReturnsError(t *testing.T) {
 		t.Fatalf("expected wrapped cache context in error, got: %v", err)
 	}
 }
+
+func TestApp_InitialScan_WarmStartsUnchangedFilesFromSymbolStore(t *testing.T) {
+	tmpDir := t.TempDir()
+	if err := os.WriteFile(filepath.Join(tmpDir, "go.mod"), []byte("module example.com/warm\n"), 0o644); err != nil {
+		t.Fatal(err)
+	}
+	stablePath := filepath.Join(tmpDir, "stable", "stable.go")
+	changedPath := filepath.Join(tmpDir, "changed", "changed.go")
+	for path, src := range map[string]string{
+		stablePath:  "package stable\n\nfunc Stable() {}\n",
+		changedPath: "package changed\n\nfunc Changed() {}\n",
+	} {
+		if err := os.MkdirAll(filepath.Dir(path), 0o755); err != nil {
+			t.Fatal(err)
+		}
+		if err := os.WriteFile(path, []byte(src), 0o644); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	store := newTestSymbolStore(t)
+	defer store.Close()
+	scan := func() *App {
+		t.Helper()
+		app, err := New(&config.Config{GrammarsPath: "./grammars", WatchPaths: []string{tmpDir}})
+		if err != nil {
+			t.Fatal(err)
+		}
+		app.symbolStore = store
+		app.Graph.SetLoader(store)
+		if err := app.InitialScan(context.Background()); err != nil {
+			t.Fatal(err)
+		}
+		return app
+	}
+
+	first := scan()
+	stableBefore, ok := first.Graph.GetFile(stablePath)
+	if !ok || stableBefore.ContentHash == "" {
+		t.Fatalf("expected first scan to record a content hash, got %+v", stableBefore)
+	}
+	changedBefore, _ := first.Graph.GetFile(changedPath)
+
+	if err := os.WriteFile(changedPath, []byte("package changed\n\nimport \"example.com/warm/stable\"\n\nfunc Changed() { stable.Stable() }\n"), 0o644); err != nil {
+		t.Fatal(err)
+	}
+
+	second := scan()
+	stableAfter, ok := second.Graph.GetFile(stablePath)
+	if !ok || !stableAfter.ParsedAt.Equal(stableBefore.ParsedAt) || stableAfter.Module != "example.com/warm/stable" {
+		t.Fatalf("expected unchanged file to be hydrated from the store, got %+v", stableAfter)
+	}
+	changedAfter, ok := second.Graph.GetFile(changedPath)
+	if !ok || changedAfter.ContentHash == changedBefore.ContentHash || len(changedAfter.Imports) != 1 {
+		t.Fatalf("expected changed file to be reparsed, got %+v", changedAfter)
+	}
+	if stored, err := store.LoadFile(changedPath); err != nil || stored == nil || stored.ContentHash != changedAfter.ContentHash {
+		t.Fatalf("expected reparsed file to be persisted, got %+v (err=%v)", stored, err)
+	}
+}
+
+func TestApp_InitialScan_ReparsesWhenParseFingerprintChanges(t *testing.T) {
+	tmpDir := t.TempDir()
+	if err := os.WriteFile(filepath.Join(tmpDir, "go.mod"), []byte("module example.com/warm\n"), 0o644); err != nil {
+		t.Fatal(err)
+	}
+	stablePath := filepath.Join(tmpDir, "stable", "stable.go")
+	if err := os.MkdirAll(filepath.Dir(stablePath), 0o755); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.WriteFile(stablePath, []byte("package stable\n\nfunc Stable() {}\n"), 0o644); err != nil {
+		t.Fatal(err)
+	}
+
+	store := newTestSymbolStore(t)
+	defer store.Close()
+	scan := func(mutate func(*config.Config), extractorVersion int) *parser.File {
+		t.Helper()
+		cfg := &config.Config{GrammarsPath: "./grammars", WatchPaths: []string{tmpDir}}
+		if mutate != nil {
+			mutate(cfg)
+		}
+		app, err := New(cfg)
+		if err != nil {
+			t.Fatal(err)
+		}
+		app.parseFingerprint = parseFingerprint(cfg, extractorVersion)
+		app.symbolStore = store
+		app.Graph.SetLoader(store)
+		if err := app.InitialScan(context.Background()); err != nil {
+			t.Fatal(err)
+		}
+		file, ok := app.Graph.GetFile(stablePath)
+		if !ok {
+			t.Fatalf("expected %s in the graph", stablePath)
+		}
+		return file
+	}
+
+	first := scan(nil, parser.ExtractorVersion)
+	if warm := scan(nil, parser.ExtractorVersion); !warm.ParsedAt.Equal(first.ParsedAt) {
+		t.Fatalf("expected an unchanged fingerprint to warm-start, got %+v", warm)
+	}
+
+	bumped := scan(nil, parser.ExtractorVersion+1)
+	if bumped.ParsedAt.Equal(first.ParsedAt) || bumped.ParseFingerprint == first.ParseFingerprint {
+		t.Fatalf("expected an extractor version bump to force a reparse, got %+v", bumped)
+	}
+
+	reconfigured := scan(func(cfg *config.Config) {
+		cfg.Exclude.Symbols = []string{"ctx."}
+	}, parser.ExtractorVersion+1)
+	if reconfigured.ParsedAt.Equal(bumped.ParsedAt) || reconfigured.ParseFingerprint == bumped.ParseFingerprint {
+		t.Fatalf("expected a config change to force a reparse, got %+v", reconfigured)
+	}
+}
+
+func TestApp_ExternalDependencies_ReadsManifests(t *testing.T) {
+	tmpDir := t.TempDir()
+	gomod := "module example.com/app\n\ngo 1.24\n\nrequire (\n\tgithub.com/spf13/cobra v1.8.0\n\tgithub.com/pkg/errors v0.9.1\n)\n"
+	if err := os.WriteFile(filepath.Join(tmpDir, "go.mod"), []byte(gomod), 0o644); err != nil {
+		t.Fatal(err)
+	}
+
+	app := &App{Graph: graph.NewGraph(), Config: &config.Config{}}
+	app.Graph.AddFile(&parser.File{Path: filepath.Join(tmpDir, "cmd", "main.go"), Module: "example.com/app/cmd", Language: "go", Imports: []parser.Import{
+		{Module: "fmt"},
+		{Module: "example.com/app/internal"},
+		{Module: "github.com/spf13/cobra"},
+	}})
+	app.Graph.AddFile(&parser.File{Path: filepath.Join(tmpDir, "internal", "run.go"), Module: "example.com/app/internal", Language: "go"})
+
+	deps := app.ExternalDependencies()
+	if len(deps) != 2 {
+		t.Fatalf("expected cobra and pkg/errors, got %+v", deps)
+	}
+	if deps[0].Name != "github.com/pkg/errors" || deps[0].Status() != "unused" {
+		t.Fatalf("expected pkg/errors declared but unused, got %+v", deps[0])
+	}
+	if deps[1].Name != "github.com/spf13/cobra" || deps[1].FileCount != 1 || deps[1].Importers[0].Module != "example.com/app/cmd" {
+		t.Fatalf("expected cobra imported by cmd, got %+v", deps[1])
+	}
+
+	out := FormatExternalDependencies(deps)
+	for _, want := range []string{
+		"External dependencies (2):",
+		"  go github.com/pkg/errors@v0.9.1 files=0 modules=0 unused",
+		"  go github.com/spf13/cobra@v1.8.0 files=1 modules=1 declared",
+		"    <- example.com/app/cmd (" + filepath.Join(tmpDir, "cmd", "main.go") + ")",
+	} {
+		if !strings.Contains(out, want) {
+			t.Fatalf("expected %q in output:\n%s", want, out)
+		}
+	}
+}
+
+func TestApp_EncapsulationViolations_UsesCrateBoundaries(t *testing.T) {
+	tmpDir := t.TempDir()
+	for _, crate := range []string{"core", "cli"} {
+		dir := filepath.Join(tmpDir, crate)
+		if err := os.MkdirAll(filepath.Join(dir, "src"), 0o755); err != nil {
+			t.Fatal(err)
+		}
+		if err := os.WriteFile(filepath.Join(dir, "Cargo.toml"), []byte("[package]\nname = \""+crate+"\"\n"), 0o644); err != nil {
+			t.Fatal(err)
+		}
+	}
+	lib := filepath.Join(tmpDir, "core", "src", "lib.rs")
+
+	app := &App{Graph: graph.NewGraph(), Config: &config.Config{}}
+	app.Graph.AddFile(&parser.File{Path: lib, Module: "core", Language: "rust", Definitions: []parser.Definition{
+		{Name: "helper", Kind: parser.KindFunction, Visibility: "internal", Location: parser.Location{File: lib, Line: 1}},
+	}})
+	app.Graph.AddFile(&parser.File{Path: filepath.Join(tmpDir, "core", "src", "util.rs"), Module: "core::util", Language: "rust",
+		Imports: []parser.Import{{Module: "core", Items: []string{"helper"}, Location: parser.Location{Line: 1, Column: 1}}},
+	})
+	app.Graph.AddFile(&parser.File{Path: filepath.Join(tmpDir, "cli", "src", "main.rs"), Module: "cli", Language: "rust",
+		Imports: []parser.Import{{Module: "core", Items: []string{"helper"}, Location: parser.Location{Line: 2, Column: 1}}},
+	})
+
+	violations := app.EncapsulationViolations()
+	if len(violations) != 1 || violations[0].Kind != graph.EncapsulationInternalSymbol || violations[0].From != "cli" || violations[0].Line != 2 {
+		t.Fatalf("expected one cross-crate pub(crate) use, got %+v", violations)
+	}
+}
+
+func TestApp_StaleSampleImports_ChecksMarkdownFences(t *testing.T) {
+	tmpDir := t.TempDir()
+	files := map[string]string{
+		"go.mod":         "module example.com/app\n",
+		"store/store.go": "package store\n\nfunc Open() {}\n",
+		"docs/guide.md":  "# Guide\n\n```go\nimport (\n\t\"fmt\"\n\t\"example.com/app/store\"\n\t\"example.com/app/cache\"\n)\n```\n",
+	}
+	for name, content := range files {
+		path := filepath.Join(tmpDir, name)
+		if err := os.MkdirAll(filepath.Dir(path), 0o755); err != nil {
+			t.Fatal(err)
+		}
+		if err := os.WriteFile(path, []byte(content), 0o644); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	enabled := true
+	app, err := New(&config.Config{
+		GrammarsPath: "./grammars",
+		WatchPaths:   []string{tmpDir},
+		Languages:    map[string]config.Language{"markdown": {Enabled: &enabled}},
+		Caches:       config.Caches{Files: 16},
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := app.InitialScan(context.Background()); err != nil {
+		t.Fatal(err)
+	}
+
+	stale := app.StaleSampleImports()
+	if len(stale) != 1 || stale[0].Module != "example.com/app/cache" || stale[0].Line != 7 || filepath.Base(stale[0].File) != "guide.md" {
+		t.Fatalf("expected the missing example.com/app/cache import on line 7, got %+v", stale)
+	}
+}
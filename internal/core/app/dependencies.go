@@ -0,0 +1,130 @@
+package app
+
+import (
+	"circular/internal/engine/graph"
+	"circular/internal/engine/parser"
+	"circular/internal/engine/resolver"
+	"circular/internal/shared/util"
+	"fmt"
+	"log/slog"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+)
+
+// ExternalDependencies returns the third-party dependency inventory of the
+// scanned files and the manifests above them.
+func (a *App) ExternalDependencies() []graph.ExternalDependency {
+	return a.Graph.ExternalDependencies(a.externalDependencyOptions())
+}
+
+func (a *App) externalDependencyOptions() graph.ExternalDependencyOptions {
+	manifests := a.dependencyManifests()
+	return graph.ExternalDependencyOptions{
+		Manifests: manifests,
+		Resolve:   resolver.NewExternalPackageResolver(manifests).Resolve,
+	}
+}
+
+// dependencyManifests parses the dependency manifests in every directory
+// holding a graph file and its ancestors, stopping at the project root for
+// files below it.
+func (a *App) dependencyManifests() []parser.DependencyManifest {
+	root := a.projectRoot()
+	if absRoot, err := filepath.Abs(root); err == nil {
+		root = absRoot
+	}
+	visited := make(map[string]bool)
+	paths := make([]string, 0)
+	for _, path := range a.Graph.FilePaths() {
+		absPath, err := filepath.Abs(path)
+		if err != nil {
+			absPath = path
+		}
+		underRoot := root != "" && util.HasPathPrefix(absPath, root)
+		for dir := filepath.Dir(absPath); !visited[dir]; dir = filepath.Dir(dir) {
+			visited[dir] = true
+			paths = append(paths, manifestsInDir(dir)...)
+			parent := filepath.Dir(dir)
+			if parent == dir || (underRoot && dir == root) {
+				break
+			}
+		}
+	}
+	sort.Strings(paths)
+
+	manifests := make([]parser.DependencyManifest, 0, len(paths))
+	for _, path := range paths {
+		data, err := os.ReadFile(path)
+		if err != nil {
+			continue
+		}
+		manifest, err := parser.ParseDependencyManifest(path, data)
+		if err != nil {
+			slog.Warn("skipping invalid dependency manifest", "path", path, "error", err)
+			continue
+		}
+		manifests = append(manifests, manifest)
+	}
+	return manifests
+}
+
+// crateBoundary maps a Rust file to the directory of its nearest Cargo.toml,
+// the unit pub(crate) definitions are confined to. Other files have no
+// boundary.
+func (a *App) crateBoundary() func(path string) string {
+	roots := make([]string, 0)
+	for _, manifest := range a.dependencyManifests() {
+		if manifest.Ecosystem == parser.EcosystemCargo {
+			roots = append(roots, filepath.Dir(manifest.Path))
+		}
+	}
+	sort.Slice(roots, func(i, j int) bool { return len(roots[i]) > len(roots[j]) })
+	return func(path string) string {
+		if filepath.Ext(path) != ".rs" {
+			return ""
+		}
+		if absPath, err := filepath.Abs(path); err == nil {
+			path = absPath
+		}
+		for _, root := range roots {
+			if util.HasPathPrefix(path, root) {
+				return root
+			}
+		}
+		return ""
+	}
+}
+
+func manifestsInDir(dir string) []string {
+	entries, err := os.ReadDir(dir)
+	if err != nil {
+		return nil
+	}
+	out := make([]string, 0)
+	for _, entry := range entries {
+		if !entry.IsDir() && parser.DependencyManifestEcosystem(entry.Name()) != "" {
+			out = append(out, filepath.Join(dir, entry.Name()))
+		}
+	}
+	return out
+}
+
+// FormatExternalDependencies lists dependency nodes with their status and the
+// modules and files importing them.
+func FormatExternalDependencies(deps []graph.ExternalDependency) string {
+	var b strings.Builder
+	b.WriteString(fmt.Sprintf("External dependencies (%d):\n", len(deps)))
+	for _, dep := range deps {
+		name := dep.Name
+		if dep.Version != "" {
+			name += "@" + dep.Version
+		}
+		b.WriteString(fmt.Sprintf("  %s %s files=%d modules=%d %s\n", dep.Ecosystem, name, dep.FileCount, len(dep.Importers), dep.Status()))
+		for _, importer := range dep.Importers {
+			b.WriteString(fmt.Sprintf("    <- %s (%s)\n", importer.Module, strings.Join(importer.Files, ", ")))
+		}
+	}
+	return b.String()
+}
@@ -0,0 +1,148 @@
+package app
+
+import (
+	"circular/internal/core/config"
+	"circular/internal/data/history"
+	"circular/internal/engine/graph"
+	"encoding/json"
+	"log/slog"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+)
+
+// OrphanModules reports modules no configured entry point reaches, with the
+// last commit time touching each orphan's files.
+func (a *App) OrphanModules() graph.ReachabilityReport {
+	report := a.Graph.AnalyzeReachability(a.reachabilityOptions())
+	if len(report.Orphans) == 0 {
+		return report
+	}
+	root := a.projectRoot()
+	for i := range report.Orphans {
+		report.Orphans[i].LastModified = history.LastModified(root, report.Orphans[i].Files)
+	}
+	return report
+}
+
+// entryModules resolves the configured [entry_points] to module names. Orphan
+// detection, critical modules, and dead code all start from this set.
+func (a *App) entryModules() []string {
+	return a.Graph.EntryModules(a.reachabilityOptions())
+}
+
+func (a *App) reachabilityOptions() graph.ReachabilityOptions {
+	opts := graph.ReachabilityOptions{}
+	if a.codeParser != nil {
+		opts.IsTestFile = a.codeParser.IsTestFile
+	}
+	if a.Config == nil {
+		return opts
+	}
+	opts.Patterns = append(opts.Patterns, a.Config.EntryPoints.Patterns...)
+	for _, kind := range a.Config.EntryPoints.Detect {
+		switch strings.TrimSpace(kind) {
+		case "go_main":
+			opts.GoMain = true
+		case "python_main":
+			opts.PythonMain = true
+		case "js_bin":
+			opts.EntryFiles = append(opts.EntryFiles, a.jsBinEntryPoints()...)
+		}
+	}
+	return opts
+}
+
+// jsBinEntryPoints returns the graph files named by the "bin" field of a
+// package.json in a directory above them.
+func (a *App) jsBinEntryPoints() []string {
+	visited := make(map[string]bool)
+	bins := make(map[string]bool)
+	candidates := make(map[string]string)
+	for _, path := range a.Graph.FilePaths() {
+		switch filepath.Ext(path) {
+		case ".js", ".mjs", ".cjs", ".ts", ".tsx":
+		default:
+			continue
+		}
+		absPath, err := filepath.Abs(path)
+		if err != nil {
+			absPath = path
+		}
+		candidates[absPath] = path
+		for dir := filepath.Dir(absPath); !visited[dir]; dir = filepath.Dir(dir) {
+			visited[dir] = true
+			for _, bin := range packageJSONBins(dir) {
+				bins[bin] = true
+			}
+			if parent := filepath.Dir(dir); parent == dir {
+				break
+			}
+		}
+	}
+
+	out := make([]string, 0, len(bins))
+	for absPath, path := range candidates {
+		if bins[absPath] {
+			out = append(out, path)
+		}
+	}
+	sort.Strings(out)
+	return out
+}
+
+// packageJSONBins reads dir/package.json and resolves its "bin" field, which
+// is either a single path or a map of command names to paths.
+func packageJSONBins(dir string) []string {
+	data, err := os.ReadFile(filepath.Join(dir, "package.json"))
+	if err != nil {
+		return nil
+	}
+	var manifest struct {
+		Bin json.RawMessage `json:"bin"`
+	}
+	if err := json.Unmarshal(data, &manifest); err != nil {
+		slog.Warn("skipping invalid package.json", "dir", dir, "error", err)
+		return nil
+	}
+	if len(manifest.Bin) == 0 {
+		return nil
+	}
+
+	targets := make([]string, 0)
+	var single string
+	var named map[string]string
+	switch {
+	case json.Unmarshal(manifest.Bin, &single) == nil:
+		targets = append(targets, single)
+	case json.Unmarshal(manifest.Bin, &named) == nil:
+		for _, target := range named {
+			targets = append(targets, target)
+		}
+	}
+
+	out := make([]string, 0, len(targets))
+	for _, target := range targets {
+		if strings.TrimSpace(target) == "" {
+			continue
+		}
+		out = append(out, filepath.Join(dir, filepath.FromSlash(target)))
+	}
+	return out
+}
+
+func (a *App) projectRoot() string {
+	cwd, err := os.Getwd()
+	if err != nil {
+		return ""
+	}
+	if a.Config == nil {
+		return cwd
+	}
+	paths, err := config.ResolvePaths(a.Config, cwd)
+	if err != nil {
+		return cwd
+	}
+	return paths.ProjectRoot
+}
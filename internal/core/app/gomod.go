@@ -13,6 +13,9 @@ type goModuleCacheEntry struct {
 }
 
 func (a *App) resolveGoModule(path string) (string, bool, error) {
+	a.goModMu.Lock()
+	defer a.goModMu.Unlock()
+
 	absPath, err := filepath.Abs(path)
 	if err != nil {
 		absPath = path
@@ -74,3 +77,10 @@ func moduleNameFromCache(cached goModuleCacheEntry, filePath string) (string, er
 	}
 	return cached.ModulePath + "/" + dir, nil
 }
+
+// resetGoModCache forgets every resolved go.mod, e.g. after one changed.
+func (a *App) resetGoModCache() {
+	a.goModMu.Lock()
+	defer a.goModMu.Unlock()
+	a.goModCache = make(map[string]goModuleCacheEntry)
+}
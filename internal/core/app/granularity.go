@@ -0,0 +1,64 @@
+package app
+
+import (
+	"circular/internal/engine/graph"
+	"log/slog"
+	"path/filepath"
+	"strings"
+)
+
+// structureGraph is the graph cycle detection, metrics, diagrams, and tracing
+// run on: the module graph, or its file or directory projection selected by
+// graph.granularity, rolled up to graph.depth path segments. Projection
+// failures fall back to the module graph.
+func (a *App) structureGraph() *graph.Graph {
+	level, depth := a.granularity(), a.depth()
+	if level == graph.GranularityModule && depth == 0 {
+		return a.Graph
+	}
+	projected, err := a.Graph.AtGranularity(level, a.projectRoot())
+	if err != nil {
+		slog.Warn("falling back to module granularity", "granularity", level, "error", err)
+		return a.Graph
+	}
+	rolled, err := projected.AtDepth(depth)
+	if err != nil {
+		slog.Warn("falling back to full node names", "depth", depth, "error", err)
+		return projected
+	}
+	return rolled
+}
+
+func (a *App) granularity() graph.Granularity {
+	if a.Config == nil {
+		return graph.GranularityModule
+	}
+	level, err := graph.ParseGranularity(a.Config.Graph.Granularity)
+	if err != nil {
+		return graph.GranularityModule
+	}
+	return level
+}
+
+func (a *App) depth() int {
+	if a.Config == nil || a.Config.Graph.Depth < 0 {
+		return 0
+	}
+	return a.Config.Graph.Depth
+}
+
+// structureNode maps a trace endpoint to a structure graph node name: file and
+// directory nodes are slash-separated paths relative to the project root, and
+// every name is rolled up to graph.depth segments.
+func (a *App) structureNode(name string) string {
+	if a.granularity() != graph.GranularityModule {
+		name = strings.TrimSpace(name)
+		if filepath.IsAbs(name) {
+			if rel, err := filepath.Rel(a.projectRoot(), name); err == nil && !strings.HasPrefix(rel, "..") {
+				name = rel
+			}
+		}
+		name = filepath.ToSlash(filepath.Clean(name))
+	}
+	return graph.ModulePrefix(name, a.depth())
+}
@@ -15,6 +15,7 @@ const (
 	DiagramModeArchitecture
 	DiagramModeComponent
 	DiagramModeFlow
+	DiagramModeClusters
 )
 
 func ResolveDiagramMode(diagrams config.DiagramOutput) (DiagramMode, error) {
@@ -29,7 +30,7 @@ func ResolveDiagramMode(diagrams config.DiagramOutput) (DiagramMode, error) {
 }
 
 func ResolveDiagramModes(diagrams config.DiagramOutput) ([]DiagramMode, error) {
-	modes := make([]DiagramMode, 0, 4)
+	modes := make([]DiagramMode, 0, 5)
 	selected := 0
 	if diagrams.Architecture {
 		selected++
@@ -43,6 +44,10 @@ func ResolveDiagramModes(diagrams config.DiagramOutput) ([]DiagramMode, error) {
 		selected++
 		modes = append(modes, DiagramModeFlow)
 	}
+	if diagrams.Clusters {
+		selected++
+		modes = append(modes, DiagramModeClusters)
+	}
 	if selected == 0 {
 		return []DiagramMode{DiagramModeDependency}, nil
 	}
@@ -60,6 +65,8 @@ func (m DiagramMode) Suffix() string {
 		return "component"
 	case DiagramModeFlow:
 		return "flow"
+	case DiagramModeClusters:
+		return "clusters"
 	default:
 		return "dependency"
 	}
@@ -101,6 +108,8 @@ func GenerateMermaidByMode(
 		return gen.GenerateComponent(archModel, diagrams.ComponentCfg.ShowInternal)
 	case DiagramModeFlow:
 		return gen.GenerateFlow(diagrams.FlowConfig.EntryPoints, diagrams.FlowConfig.MaxDepth)
+	case DiagramModeClusters:
+		return gen.GenerateClusters()
 	default:
 		return gen.Generate(cycles, violations, archModel)
 	}
@@ -121,6 +130,8 @@ func GeneratePlantUMLByMode(
 		return gen.GenerateComponent(archModel, diagrams.ComponentCfg.ShowInternal)
 	case DiagramModeFlow:
 		return gen.GenerateFlow(diagrams.FlowConfig.EntryPoints, diagrams.FlowConfig.MaxDepth)
+	case DiagramModeClusters:
+		return gen.GenerateClusters()
 	default:
 		return gen.Generate(cycles, violations, archModel)
 	}
@@ -12,6 +12,9 @@ func FormatImpactReport(report graph.ImpactReport) string {
 	b.WriteString("Impact Analysis\n")
 	b.WriteString("==============\n")
 	b.WriteString(fmt.Sprintf("Target module: %s\n", report.TargetModule))
+	if report.Symbol != nil {
+		b.WriteString(fmt.Sprintf("Target symbol: %s\n", report.Symbol.Target.Name))
+	}
 	if report.TargetPath != "" {
 		b.WriteString(fmt.Sprintf("Target file: %s\n", report.TargetPath))
 	}
@@ -34,5 +37,25 @@ func FormatImpactReport(report graph.ImpactReport) string {
 		b.WriteString(fmt.Sprintf("- %s\n", sym))
 	}
 
+	if report.Symbol != nil {
+		b.WriteString("\n")
+		b.WriteString(fmt.Sprintf("Direct symbol dependents (%d)\n", len(report.Symbol.DirectDependents)))
+		for _, dep := range report.Symbol.DirectDependents {
+			b.WriteString(fmt.Sprintf("- %s\n", dep))
+		}
+		b.WriteString("\n")
+
+		b.WriteString(fmt.Sprintf("Transitive symbol dependents (%d)\n", len(report.Symbol.TransitiveDependents)))
+		for _, dep := range report.Symbol.TransitiveDependents {
+			b.WriteString(fmt.Sprintf("- %s\n", dep))
+		}
+		b.WriteString("\n")
+
+		b.WriteString(fmt.Sprintf("Reference sites (%d)\n", len(report.Symbol.CallSites)))
+		for _, site := range report.Symbol.CallSites {
+			b.WriteString(fmt.Sprintf("- %s:%d:%d (%s)\n", site.File, site.Location.Line, site.Location.Column, site.From))
+		}
+	}
+
 	return b.String()
 }
@@ -0,0 +1,65 @@
+package app
+
+import (
+	"circular/internal/core/config"
+	"circular/internal/data/history"
+	"fmt"
+	"os"
+	"strings"
+)
+
+// initNodeStorage enables graph paging when performance.max_resident_modules is
+// set. Paged nodes share the history database with snapshots.
+func (a *App) initNodeStorage() error {
+	if a == nil || a.Config == nil || a.Graph == nil || !a.Config.DB.Enabled {
+		return nil
+	}
+	budget := a.Config.Performance.MaxResidentModules
+	if budget <= 0 {
+		return nil
+	}
+	dbPath := strings.TrimSpace(a.Config.DB.Path)
+	if dbPath == "" {
+		return nil
+	}
+	cwd, err := os.Getwd()
+	if err == nil {
+		if resolved, pathErr := config.ResolvePaths(a.Config, cwd); pathErr == nil {
+			dbPath = resolved.DBPath
+		}
+	}
+	projectKey := strings.TrimSpace(a.Config.Projects.Active)
+	if projectKey == "" {
+		projectKey = "default"
+	}
+
+	storage, err := history.OpenGraphStorage(dbPath)
+	if err != nil {
+		return fmt.Errorf("open sqlite node storage: %w", err)
+	}
+	// Paged nodes from a previous process no longer match any in-memory graph.
+	if err := storage.Reset(projectKey); err != nil {
+		_ = storage.Close()
+		return fmt.Errorf("reset sqlite node storage: %w", err)
+	}
+	if err := a.Graph.SetNodeStorage(storage, projectKey, budget); err != nil {
+		_ = storage.Close()
+		return fmt.Errorf("attach sqlite node storage: %w", err)
+	}
+	a.nodeStorage = storage
+	return nil
+}
+
+func (a *App) closeNodeStorage() error {
+	if a == nil || a.nodeStorage == nil {
+		return nil
+	}
+	if a.Graph != nil {
+		if err := a.Graph.SetNodeStorage(nil, "", 0); err != nil {
+			return fmt.Errorf("fault in paged modules: %w", err)
+		}
+	}
+	err := a.nodeStorage.Close()
+	a.nodeStorage = nil
+	return err
+}
@@ -22,7 +22,7 @@ func (a *App) SetUpdateHandler(handler func(Update)) {
 
 func (a *App) CurrentUpdate(ctx context.Context) Update {
 	return Update{
-		Cycles:         a.Graph.DetectCycles(),
+		Cycles:         a.structureGraph().DetectCycles(),
 		Hallucinations: a.AnalyzeHallucinations(ctx),
 		ModuleCount:    a.Graph.ModuleCount(),
 		FileCount:      a.Graph.FileCount(),
@@ -62,9 +62,10 @@ func (a *App) GenerateOutputs(
 	if err != nil {
 		return err
 	}
+	structure := a.structureGraph()
 
 	if targets.DOT != "" {
-		dotGen := report.NewDOTGenerator(a.Graph)
+		dotGen := report.NewDOTGenerator(structure)
 		dotGen.SetModuleMetrics(metrics)
 		dotGen.SetComplexityHotspots(hotspots)
 		dot, err := dotGen.Generate(cycles)
@@ -77,7 +78,7 @@ func (a *App) GenerateOutputs(
 	}
 
 	if targets.TSV != "" {
-		tsvGen := report.NewTSVGenerator(a.Graph)
+		tsvGen := report.NewTSVGenerator(structure)
 		dependenciesTSV, err := tsvGen.Generate()
 		if err != nil {
 			return fmt.Errorf("generate TSV output: %w", err)
@@ -99,6 +100,34 @@ func (a *App) GenerateOutputs(
 			}
 			tsv = strings.TrimRight(dependenciesTSV, "\n") + "\n\n" + strings.TrimRight(unusedTSV, "\n") + "\n"
 		}
+		if deadCode := a.DeadCode(); len(deadCode) > 0 {
+			deadCodeTSV, err := tsvGen.GenerateDeadCode(deadCode)
+			if err != nil {
+				return fmt.Errorf("generate dead-code TSV block: %w", err)
+			}
+			tsv = strings.TrimRight(tsv, "\n") + "\n\n" + strings.TrimRight(deadCodeTSV, "\n") + "\n"
+		}
+		if encapsulation := a.EncapsulationViolations(); len(encapsulation) > 0 {
+			encapsulationTSV, err := tsvGen.GenerateEncapsulationViolations(encapsulation)
+			if err != nil {
+				return fmt.Errorf("generate encapsulation-violation TSV block: %w", err)
+			}
+			tsv = strings.TrimRight(tsv, "\n") + "\n\n" + strings.TrimRight(encapsulationTSV, "\n") + "\n"
+		}
+		if stale := a.StaleSampleImports(); len(stale) > 0 {
+			staleTSV, err := tsvGen.GenerateStaleSampleImports(stale)
+			if err != nil {
+				return fmt.Errorf("generate stale-sample-import TSV block: %w", err)
+			}
+			tsv = strings.TrimRight(tsv, "\n") + "\n\n" + strings.TrimRight(staleTSV, "\n") + "\n"
+		}
+		if orphans := a.OrphanModules().Orphans; len(orphans) > 0 {
+			orphansTSV, err := tsvGen.GenerateOrphanModules(orphans)
+			if err != nil {
+				return fmt.Errorf("generate orphan-module TSV block: %w", err)
+			}
+			tsv = strings.TrimRight(tsv, "\n") + "\n\n" + strings.TrimRight(orphansTSV, "\n") + "\n"
+		}
 		if len(violations) > 0 {
 			violationsTSV, err := tsvGen.GenerateArchitectureViolations(violations)
 			if err != nil {
@@ -113,6 +142,13 @@ func (a *App) GenerateOutputs(
 			}
 			tsv = strings.TrimRight(tsv, "\n") + "\n\n" + strings.TrimRight(rulesTSV, "\n") + "\n"
 		}
+		if len(metrics) > 0 {
+			metricsTSV, err := tsvGen.GenerateModuleMetrics(metrics)
+			if err != nil {
+				return fmt.Errorf("generate module-metrics TSV block: %w", err)
+			}
+			tsv = strings.TrimRight(tsv, "\n") + "\n\n" + strings.TrimRight(metricsTSV, "\n") + "\n"
+		}
 		allSecrets := a.allSecrets(0)
 		if len(allSecrets) > 0 {
 			secretsTSV, err := tsvGen.GenerateSecrets(allSecrets)
@@ -146,7 +182,7 @@ func (a *App) GenerateOutputs(
 	}
 
 	if needMermaid {
-		mermaidGen := report.NewMermaidGenerator(a.Graph)
+		mermaidGen := report.NewMermaidGenerator(structure)
 		mermaidGen.SetModuleMetrics(metrics)
 		mermaidGen.SetComplexityHotspots(hotspots)
 		for _, mode := range diagramModes {
@@ -165,7 +201,7 @@ func (a *App) GenerateOutputs(
 	}
 
 	if needPlantUML {
-		plantUMLGen := report.NewPlantUMLGenerator(a.Graph)
+		plantUMLGen := report.NewPlantUMLGenerator(structure)
 		plantUMLGen.SetModuleMetrics(metrics)
 		plantUMLGen.SetComplexityHotspots(hotspots)
 		for _, mode := range diagramModes {
@@ -206,19 +242,32 @@ func (a *App) GenerateOutputs(
 		if err != nil {
 			return err
 		}
+		critical := a.CriticalModules(nil)
+		boundaries := a.Graph.DetectCommunities()
+		deadCode := a.DeadCode()
+		encapsulation := a.EncapsulationViolations()
+		reachability := a.OrphanModules()
 		// Use the same logic as PresentationService for consistency
 		md, err := report.NewMarkdownGenerator().Generate(report.MarkdownReportData{
 			TotalModules:      a.Graph.ModuleCount(),
 			TotalFiles:        a.Graph.FileCount(),
 			Cycles:            cycles,
+			CycleCuts:         structure.SuggestCycleCuts(),
 			ProbableBridges:   probableBridges,
 			Unresolved:        unresolved,
 			UnusedImports:     unusedImports,
+			DeadCode:          deadCode,
+			Encapsulation:     encapsulation,
+			StaleSamples:      a.StaleSampleImports(),
 			Violations:        violations,
 			ArchitectureRules: append([]ports.ArchitectureRule(nil), a.archRules...),
 			RuleViolations:    ruleViolations,
 			RuleSummary:       ruleSummary,
 			Hotspots:          hotspots,
+			Metrics:           metrics,
+			Critical:          &critical,
+			Boundaries:        &boundaries,
+			Reachability:      &reachability,
 		}, report.MarkdownReportOptions{
 			ProjectName:         filepath.Base(root),
 			ProjectRoot:         root,
@@ -27,14 +27,20 @@ func newPresentationService(app *App) *PresentationService {
 }
 
 func (p *PresentationService) GenerateMarkdownReport(ctx context.Context, req MarkdownReportRequest) (MarkdownReportResult, error) {
-	cycles := p.app.Graph.DetectCycles()
-	metrics := p.app.Graph.ComputeModuleMetrics()
+	structure := p.app.structureGraph()
+	cycles := structure.DetectCycles()
+	metrics := structure.ComputeModuleMetrics()
 	hotspots := p.app.Graph.TopComplexity(p.app.Config.Architecture.TopComplexity)
 	violations := p.app.ArchitectureViolations()
 	ruleViolations, ruleSummary := p.app.ArchitectureRuleViolations()
 	probableBridges := p.app.AnalyzeProbableBridges(ctx)
 	unresolved := p.app.AnalyzeHallucinations(ctx)
 	unused := p.app.AnalyzeUnusedImports(ctx)
+	critical := p.app.CriticalModules(nil)
+	boundaries := p.app.Graph.DetectCommunities()
+	deadCode := p.app.DeadCode()
+	encapsulation := p.app.EncapsulationViolations()
+	reachability := p.app.OrphanModules()
 
 	root, err := p.app.resolveOutputRoot()
 	if err != nil {
@@ -43,7 +49,7 @@ func (p *PresentationService) GenerateMarkdownReport(ctx context.Context, req Ma
 
 	var mermaidDiagram string
 	if p.app.Config.Output.Report.IncludeMermaidEnabled() && p.app.Config.Output.MermaidEnabled() {
-		mermaidGen := report.NewMermaidGenerator(p.app.Graph)
+		mermaidGen := report.NewMermaidGenerator(structure)
 		mermaidGen.SetModuleMetrics(metrics)
 		mermaidGen.SetComplexityHotspots(hotspots)
 		mermaidDiagram, err = mermaidGen.Generate(cycles, violations, helpers.ArchitectureModelFromConfig(p.app.Config.Architecture))
@@ -60,14 +66,25 @@ func (p *PresentationService) GenerateMarkdownReport(ctx context.Context, req Ma
 		TotalModules:      p.app.Graph.ModuleCount(),
 		TotalFiles:        p.app.Graph.FileCount(),
 		Cycles:            cycles,
+		CycleCuts:         structure.SuggestCycleCuts(),
 		ProbableBridges:   probableBridges,
 		Unresolved:        unresolved,
 		UnusedImports:     unused,
+		DeadCode:          deadCode,
+		Encapsulation:     encapsulation,
+		StaleSamples:      p.app.StaleSampleImports(),
 		Violations:        violations,
 		ArchitectureRules: append([]ports.ArchitectureRule(nil), p.app.archRules...),
 		RuleViolations:    ruleViolations,
 		RuleSummary:       ruleSummary,
 		Hotspots:          hotspots,
+		Metrics:           metrics,
+		Hierarchy:         p.app.Graph.ModuleTree(hotspots, violations),
+		ImportCosts:       structure.ImportEdgeCosts(),
+		Critical:          &critical,
+		Boundaries:        &boundaries,
+		Reachability:      &reachability,
+		External:          p.app.ExternalDependencies(),
 	}, report.MarkdownReportOptions{
 		ProjectName:         filepath.Base(root),
 		ProjectRoot:         root,
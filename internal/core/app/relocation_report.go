@@ -0,0 +1,57 @@
+package app
+
+import (
+	"circular/internal/engine/graph"
+	"fmt"
+	"strings"
+)
+
+func FormatRelocationPlan(plan graph.RelocationPlan) string {
+	var b strings.Builder
+
+	b.WriteString("Cycle Move Plan\n")
+	b.WriteString("===============\n")
+	b.WriteString(fmt.Sprintf("Cycle: %s\n", strings.Join(append(append([]string(nil), plan.Cycle...), plan.Cycle[0]), " -> ")))
+	b.WriteString(fmt.Sprintf("Back-edge: %s -> %s (weight %d)\n", plan.BackEdge.From, plan.BackEdge.To, plan.BackEdge.Weight))
+	if plan.BackEdge.File != "" {
+		b.WriteString(fmt.Sprintf("Import site: %s:%d:%d\n", plan.BackEdge.File, plan.BackEdge.Line, plan.BackEdge.Column))
+	}
+	if plan.Strategy != "" {
+		b.WriteString(fmt.Sprintf("Strategy: %s (%s)\n", plan.Strategy, plan.Destination))
+	}
+	if plan.Resolved {
+		b.WriteString("Status: resolved in simulation\n")
+	} else {
+		b.WriteString(fmt.Sprintf("Status: unresolved (%s)\n", plan.Reason))
+	}
+	b.WriteString("\n")
+
+	b.WriteString(fmt.Sprintf("Definitions to move (%d)\n", len(plan.Moves)))
+	for _, move := range plan.Moves {
+		refs := make([]string, 0, len(move.ReferencedBy))
+		for _, ref := range move.ReferencedBy {
+			refs = append(refs, ref.String())
+		}
+		b.WriteString(fmt.Sprintf("- %s -> %s (%s:%d; used by %s)\n", move.Symbol, move.Destination, move.File, move.Line, strings.Join(refs, ", ")))
+	}
+	b.WriteString("\n")
+
+	b.WriteString(fmt.Sprintf("Removed imports (%d)\n", len(plan.RemovedImports)))
+	for _, edge := range plan.RemovedImports {
+		b.WriteString(fmt.Sprintf("- %s\n", edge))
+	}
+	b.WriteString("\n")
+
+	b.WriteString(fmt.Sprintf("Added imports (%d)\n", len(plan.AddedImports)))
+	for _, edge := range plan.AddedImports {
+		b.WriteString(fmt.Sprintf("- %s\n", edge))
+	}
+	b.WriteString("\n")
+
+	b.WriteString(fmt.Sprintf("Cycles after simulated move (%d)\n", len(plan.SimulatedCycles)))
+	for _, cycle := range plan.SimulatedCycles {
+		b.WriteString(fmt.Sprintf("- %s\n", strings.Join(cycle, " -> ")))
+	}
+
+	return b.String()
+}
@@ -14,14 +14,16 @@ import (
 )
 
 func (a *App) TraceImportChain(from, to string) (string, error) {
-	if _, ok := a.Graph.GetModule(from); !ok {
+	g := a.structureGraph()
+	from, to = a.structureNode(from), a.structureNode(to)
+	if _, ok := g.GetModule(from); !ok {
 		return "", fmt.Errorf("source module not found: %s", from)
 	}
-	if _, ok := a.Graph.GetModule(to); !ok {
+	if _, ok := g.GetModule(to); !ok {
 		return "", fmt.Errorf("target module not found: %s", to)
 	}
 
-	chain, ok := a.Graph.FindImportChain(from, to)
+	chain, ok := g.FindImportChain(from, to)
 	if !ok {
 		return "", fmt.Errorf("no import chain found from %s to %s", from, to)
 	}
@@ -39,10 +41,192 @@ func (a *App) TraceImportChain(from, to string) (string, error) {
 	return strings.TrimRight(b.String(), "\n"), nil
 }
 
+// TraceImportPaths formats up to limit loopless import paths between two
+// modules, shortest first, with the file and line of every import.
+func (a *App) TraceImportPaths(from, to string, limit, maxDepth int) (string, error) {
+	g := a.structureGraph()
+	from, to = a.structureNode(from), a.structureNode(to)
+	if _, ok := g.GetModule(from); !ok {
+		return "", fmt.Errorf("source module not found: %s", from)
+	}
+	if _, ok := g.GetModule(to); !ok {
+		return "", fmt.Errorf("target module not found: %s", to)
+	}
+
+	paths := g.FindImportPaths(from, to, limit, maxDepth)
+	if len(paths) == 0 {
+		return "", fmt.Errorf("no import chain found from %s to %s", from, to)
+	}
+
+	var b strings.Builder
+	b.WriteString(fmt.Sprintf("Import paths: %s -> %s (%d found)\n", from, to, len(paths)))
+	for i, path := range paths {
+		b.WriteString(fmt.Sprintf("\n#%d (depth %d)\n", i+1, path.Depth()))
+		b.WriteString(from)
+		b.WriteString("\n")
+		for _, hop := range path.Hops {
+			b.WriteString("  -> ")
+			b.WriteString(hop.To)
+			if hop.File != "" {
+				b.WriteString(fmt.Sprintf("  (%s:%d)", hop.File, hop.Line))
+			}
+			b.WriteString("\n")
+		}
+	}
+
+	return strings.TrimRight(b.String(), "\n"), nil
+}
+
+// TraceSymbolChain formats the shortest chain of symbol references between two
+// definitions, linking any files whose symbol edges are stale first.
+func (a *App) TraceSymbolChain(ctx context.Context, from, to graph.SymbolKey) (string, error) {
+	a.LinkSymbols(ctx)
+
+	if !a.Graph.HasSymbol(from) {
+		return "", fmt.Errorf("source symbol not found: %s", from)
+	}
+	if !a.Graph.HasSymbol(to) {
+		return "", fmt.Errorf("target symbol not found: %s", to)
+	}
+
+	chain, ok := a.Graph.FindSymbolChain(from, to)
+	if !ok {
+		return "", fmt.Errorf("no symbol chain found from %s to %s", from, to)
+	}
+
+	var b strings.Builder
+	b.WriteString(fmt.Sprintf("Symbol chain: %s -> %s\n\n", from, to))
+	for i, key := range chain {
+		b.WriteString(key.String())
+		b.WriteString("\n")
+		if i < len(chain)-1 {
+			b.WriteString("  -> ")
+		}
+	}
+
+	return strings.TrimRight(b.String(), "\n"), nil
+}
+
+// PlanCycleRelocation links stale symbol edges and proposes definition moves
+// that break the given cycle, validated against a simulated graph.
+func (a *App) PlanCycleRelocation(ctx context.Context, cycle []string) (graph.RelocationPlan, error) {
+	a.LinkSymbols(ctx)
+	return a.Graph.PlanCycleRelocation(cycle)
+}
+
 func (a *App) AnalyzeImpact(ctx context.Context, path string) (graph.ImpactReport, error) {
+	if key, ok := graph.ParseSymbolKey(path); ok {
+		if _, isModule := a.Graph.GetModule(path); !isModule {
+			return a.analyzeSymbolImpact(ctx, key)
+		}
+	}
 	return a.Graph.AnalyzeImpact(path)
 }
 
+func (a *App) analyzeSymbolImpact(ctx context.Context, key graph.SymbolKey) (graph.ImpactReport, error) {
+	a.LinkSymbols(ctx)
+
+	symbolReport, err := a.Graph.AnalyzeSymbolImpact(key)
+	if err != nil {
+		return graph.ImpactReport{}, err
+	}
+
+	directSet := make(map[string]bool)
+	for _, edge := range symbolReport.CallSites {
+		if edge.From.Module != key.Module {
+			directSet[edge.From.Module] = true
+		}
+	}
+	direct := make([]string, 0, len(directSet))
+	transitive := make([]string, 0)
+	for _, mod := range symbolReport.AffectedModules {
+		if directSet[mod] {
+			direct = append(direct, mod)
+		} else {
+			transitive = append(transitive, mod)
+		}
+	}
+
+	report := graph.ImpactReport{
+		TargetPath:          symbolReport.TargetPath,
+		TargetModule:        key.Module,
+		DirectImporters:     direct,
+		TransitiveImporters: transitive,
+		Symbol:              &symbolReport,
+	}
+	if len(symbolReport.CallSites) > 0 {
+		report.ExternallyUsedSymbols = []string{key.Name}
+	}
+	return report, nil
+}
+
+// LinkSymbols refreshes symbol-level edges for files added or invalidated since
+// the last link pass and returns the number of files relinked.
+func (a *App) LinkSymbols(ctx context.Context) int {
+	if a == nil || a.Graph == nil {
+		return 0
+	}
+	pending := a.Graph.PendingSymbolFiles()
+	if len(pending) == 0 {
+		return 0
+	}
+	res := a.newResolver()
+	defer func() { _ = res.Close() }()
+	res.LinkSymbolsForPaths(ctx, pending)
+	return len(pending)
+}
+
+// CriticalModules reports articulation points, bridges, and dominators. Empty
+// entryPoints fall back to the configured [entry_points]; explicit ones are
+// module names, file paths, or globs as in entry_points.patterns.
+func (a *App) CriticalModules(entryPoints []string) graph.CriticalModuleReport {
+	entries := a.entryModules()
+	if len(entryPoints) > 0 {
+		entries = a.Graph.EntryModules(graph.ReachabilityOptions{Patterns: entryPoints})
+	}
+	return a.Graph.AnalyzeCriticalModules(entries)
+}
+
+// DeadCode reports exported definitions no other module references, excluding
+// entry modules, test files, and the dead_code allowlists.
+func (a *App) DeadCode() []graph.DeadDefinition {
+	return a.Graph.FindDeadCode(a.deadCodeOptions())
+}
+
+func (a *App) deadCodeOptions() graph.DeadCodeOptions {
+	opts := graph.DeadCodeOptions{}
+	if a.codeParser != nil {
+		opts.IsTestFile = a.codeParser.IsTestFile
+	}
+	opts.EntryModules = a.entryModules()
+	if a.Config != nil {
+		opts.AllowSymbols = a.Config.DeadCode.AllowSymbols
+		opts.AllowDecorators = a.Config.DeadCode.AllowDecorators
+	}
+	return opts
+}
+
+// EncapsulationViolations reports references and imports reaching into
+// private or internal code of other modules, skipping test files.
+func (a *App) EncapsulationViolations() []graph.EncapsulationViolation {
+	return a.Graph.FindEncapsulationViolations(a.encapsulationOptions())
+}
+
+// StaleSampleImports reports imports in Markdown code samples that name
+// missing internal modules. It is empty unless the markdown language is
+// enabled.
+func (a *App) StaleSampleImports() []graph.StaleSampleImport {
+	return a.Graph.FindStaleSampleImports()
+}
+
+func (a *App) encapsulationOptions() graph.EncapsulationOptions {
+	opts := graph.EncapsulationOptions{Boundary: a.crateBoundary()}
+	if a.codeParser != nil {
+		opts.IsTestFile = a.codeParser.IsTestFile
+	}
+	return opts
+}
+
 func (a *App) ArchitectureViolations() []graph.ArchitectureViolation {
 	return a.archEngine.Validate(a.Graph)
 }
@@ -69,7 +253,10 @@ func (a *App) ArchitectureRuleViolations() ([]ports.ArchitectureRuleViolation, p
 }
 
 func (a *App) BuildQueryService(historyStore ports.HistoryStore, projectKey string) *query.Service {
-	return query.NewService(a.Graph, historyStore, projectKey)
+	svc := query.NewService(a.Graph, historyStore, projectKey)
+	svc.SetDeadCodeOptions(a.deadCodeOptions())
+	svc.SetExternalDependencyOptions(a.externalDependencyOptions())
+	return svc
 }
 
 func (a *App) SecretCount() int {
@@ -0,0 +1,165 @@
+package app
+
+import (
+	"circular/internal/engine/parser"
+	"circular/internal/shared/util"
+	"context"
+	"os"
+	"runtime"
+	"sync"
+)
+
+// heapCheckInterval is how many committed files pass between heap checks.
+const heapCheckInterval = 100
+
+// preparedFile is a worker's result for files[index].
+type preparedFile struct {
+	index   int
+	content []byte
+	file    *parser.File // nil for generated files and warm-start candidates
+	warm    bool         // content matches the stored hash; try the warm start
+	err     error
+}
+
+// scanOptions controls processFiles.
+type scanOptions struct {
+	// storedHashes enables the symbol-store warm start for files whose
+	// content still hashes to the stored value.
+	storedHashes map[string]string
+	upserter     fileUpserter
+	// onError receives files that could not be read or parsed.
+	onError func(path string, err error)
+}
+
+// scanWorkers returns performance.scan_workers, defaulting to one worker per
+// CPU.
+func (a *App) scanWorkers() int {
+	if n := a.Config.Performance.ScanWorkers; n > 0 {
+		return n
+	}
+	return runtime.NumCPU()
+}
+
+// processFiles reads, parses, and secret-scans files on a bounded pool of
+// workers. The calling goroutine is the only stage that mutates the graph: it
+// commits results in input order, warm-starts unchanged files, and prunes
+// caches when the heap exceeds performance.max_heap_mb. At most twice as many
+// files as workers are read but not yet committed. It returns the number of
+// warm-started files, stopping early with ctx.Err() when ctx is cancelled.
+func (a *App) processFiles(ctx context.Context, files []string, opts scanOptions) (int, error) {
+	if len(files) == 0 {
+		return 0, ctx.Err()
+	}
+	workers := min(a.scanWorkers(), len(files))
+
+	ctx, cancel := context.WithCancel(ctx)
+	var wg sync.WaitGroup
+	defer func() {
+		cancel()
+		wg.Wait()
+	}()
+
+	window := make(chan struct{}, 2*workers)
+	jobs := make(chan int)
+	results := make(chan preparedFile, 2*workers)
+
+	wg.Add(1)
+	go func() {
+		defer wg.Done()
+		defer close(jobs)
+		for i := range files {
+			select {
+			case window <- struct{}{}:
+			case <-ctx.Done():
+				return
+			}
+			select {
+			case jobs <- i:
+			case <-ctx.Done():
+				return
+			}
+		}
+	}()
+	for w := 0; w < workers; w++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for i := range jobs {
+				if ctx.Err() != nil {
+					return
+				}
+				select {
+				case results <- a.prepareScannedFile(i, files[i], opts.storedHashes):
+				case <-ctx.Done():
+					return
+				}
+			}
+		}()
+	}
+
+	reused := 0
+	pending := make(map[int]preparedFile, cap(results))
+	for next := 0; next < len(files); {
+		select {
+		case res := <-results:
+			pending[res.index] = res
+		case <-ctx.Done():
+			return reused, ctx.Err()
+		}
+		for {
+			res, ok := pending[next]
+			if !ok {
+				break
+			}
+			delete(pending, next)
+			if a.commitScannedFile(files[next], res, opts) {
+				reused++
+			}
+			<-window
+			next++
+			if next%heapCheckInterval == 0 && util.GetHeapAllocMB() > uint64(a.Config.Performance.MaxHeapMB) {
+				a.PruneCache(20)
+			}
+		}
+	}
+	return reused, nil
+}
+
+// prepareScannedFile is the worker stage of processFiles.
+func (a *App) prepareScannedFile(index int, path string, storedHashes map[string]string) preparedFile {
+	res := preparedFile{index: index}
+	res.content, res.err = os.ReadFile(path)
+	if res.err != nil {
+		return res
+	}
+	if stored := storedHashes[path]; a.symbolStore != nil && stored != "" && stored == contentHash(res.content) {
+		res.warm = true
+		return res
+	}
+	res.file, res.err = a.prepareFile(path, res.content, false)
+	return res
+}
+
+// commitScannedFile is the graph stage of processFiles. It reports whether
+// the file was warm-started.
+func (a *App) commitScannedFile(path string, res preparedFile, opts scanOptions) bool {
+	if res.err != nil {
+		if opts.onError != nil {
+			opts.onError(path, res.err)
+		}
+		return false
+	}
+	if res.warm {
+		if a.warmStartFile(path, res.content, opts.storedHashes[path]) {
+			return true
+		}
+		if err := a.processContentWithUpserter(path, res.content, opts.upserter, false); err != nil && opts.onError != nil {
+			opts.onError(path, err)
+		}
+		return false
+	}
+	if res.file != nil {
+		a.commitFile(res.file, res.content, opts.upserter)
+	}
+	return false
+}
@@ -0,0 +1,112 @@
+package app
+
+import (
+	"circular/internal/core/config"
+	"context"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"reflect"
+	"testing"
+)
+
+func writeScanFixture(t *testing.T, root string, packages int) []string {
+	t.Helper()
+	if err := os.WriteFile(filepath.Join(root, "go.mod"), []byte("module example.com/scan\n"), 0o644); err != nil {
+		t.Fatal(err)
+	}
+	files := make([]string, 0, packages)
+	for i := 0; i < packages; i++ {
+		dir := filepath.Join(root, fmt.Sprintf("p%02d", i))
+		if err := os.MkdirAll(dir, 0o755); err != nil {
+			t.Fatal(err)
+		}
+		src := fmt.Sprintf("package p%02d\n\nfunc F%d() {}\n", i, i)
+		if i > 0 {
+			src = fmt.Sprintf("package p%02d\n\nimport \"example.com/scan/p%02d\"\n\nfunc F%d() { p%02d.F%d() }\n", i, i-1, i, i-1, i-1)
+		}
+		path := filepath.Join(dir, "f.go")
+		if err := os.WriteFile(path, []byte(src), 0o644); err != nil {
+			t.Fatal(err)
+		}
+		files = append(files, path)
+	}
+	return files
+}
+
+func TestApp_InitialScan_ParallelMatchesSerial(t *testing.T) {
+	root := t.TempDir()
+	writeScanFixture(t, root, 40)
+
+	scan := func(workers int) *App {
+		cfg := &config.Config{
+			GrammarsPath: "./grammars",
+			WatchPaths:   []string{root},
+			Performance:  config.Performance{MaxHeapMB: 2048, ScanWorkers: workers},
+		}
+		app, err := New(cfg)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if err := app.InitialScan(context.Background()); err != nil {
+			t.Fatal(err)
+		}
+		return app
+	}
+
+	serial := scan(1)
+	parallel := scan(8)
+	if got := parallel.Graph.FileCount(); got != 40 {
+		t.Fatalf("expected 40 files, got %d", got)
+	}
+	if !reflect.DeepEqual(serial.Graph.FilePaths(), parallel.Graph.FilePaths()) {
+		t.Fatalf("file sets differ:\nserial:   %v\nparallel: %v", serial.Graph.FilePaths(), parallel.Graph.FilePaths())
+	}
+	if !reflect.DeepEqual(serial.Graph.GetImports(), parallel.Graph.GetImports()) {
+		t.Fatal("expected identical import graphs from serial and parallel scans")
+	}
+	if len(parallel.Graph.DetectCycles()) != 0 {
+		t.Fatal("expected an acyclic chain")
+	}
+}
+
+func TestApp_ProcessFiles_ReportsErrorsInOrderAndStopsOnCancel(t *testing.T) {
+	root := t.TempDir()
+	files := writeScanFixture(t, root, 6)
+	missing := filepath.Join(root, "missing", "f.go")
+	files = append(files[:3], append([]string{missing}, files[3:]...)...)
+
+	cfg := &config.Config{
+		GrammarsPath: "./grammars",
+		WatchPaths:   []string{root},
+		Performance:  config.Performance{MaxHeapMB: 2048, ScanWorkers: 4},
+	}
+	app, err := New(cfg)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	var failed []string
+	reused, err := app.processFiles(context.Background(), files, scanOptions{
+		onError: func(path string, err error) { failed = append(failed, path) },
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if reused != 0 {
+		t.Fatalf("expected no warm starts without stored hashes, got %d", reused)
+	}
+	if !reflect.DeepEqual(failed, []string{missing}) {
+		t.Fatalf("expected only the missing file to fail, got %v", failed)
+	}
+	if got := app.Graph.FileCount(); got != 6 {
+		t.Fatalf("expected 6 files, got %d", got)
+	}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	if _, err := app.processFiles(ctx, files, scanOptions{}); !errors.Is(err, context.Canceled) {
+		t.Fatalf("expected context.Canceled, got %v", err)
+	}
+}
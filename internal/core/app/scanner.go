@@ -49,10 +49,21 @@ func (a *App) InitialScan(ctx context.Context) error {
 		}
 	}
 
-	for _, filePath := range files {
-		if err := a.processFileWithUpserter(filePath, batch); err != nil {
-			slog.Warn("failed to process file", "path", filePath, "error", err)
+	reused, err := a.processFiles(ctx, files, scanOptions{
+		storedHashes: a.storedFileHashes(),
+		upserter:     batch,
+		onError: func(path string, err error) {
+			slog.Warn("failed to process file", "path", path, "error", err)
+		},
+	})
+	if err != nil {
+		if batch != nil {
+			_ = batch.Rollback()
 		}
+		return err
+	}
+	if reused > 0 {
+		slog.Info("warm-started files from symbol store", "reused", reused, "parsed", len(files)-reused)
 	}
 	if batch != nil {
 		if err := batch.Commit(); err != nil {
@@ -62,7 +73,7 @@ func (a *App) InitialScan(ctx context.Context) error {
 	}
 	if err := a.enqueueSymbolWrite(ports.WriteRequest{
 		Operation: ports.WriteOperationPruneToPaths,
-		Paths:     a.currentGraphPaths(),
+		Paths:     a.Graph.FilePaths(),
 	}); err != nil {
 		slog.Warn("failed to prune persisted symbol rows after initial scan", "error", err)
 	}
@@ -133,7 +144,20 @@ func (a *App) ScanDirectories(paths []string, excludeDirs, excludeFiles []string
 }
 
 func (a *App) ProcessFile(path string) error {
-	return a.processFileWithUpserter(path, nil)
+	return a.processFileWithUpserter(path, nil, false)
+}
+
+// reprocessChangedFile re-parses a file changed in watch mode, reusing its
+// previous syntax tree when the parser keeps one.
+func (a *App) reprocessChangedFile(path string) error {
+	return a.processFileWithUpserter(path, nil, true)
+}
+
+// incrementalParser is implemented by parsers that keep syntax trees between
+// parses of the same file.
+type incrementalParser interface {
+	ReparseFile(path string, content []byte) (*parser.File, error)
+	ForgetFile(path string)
 }
 
 type fileUpserter interface {
@@ -146,7 +170,28 @@ type batchUpserter interface {
 	Rollback() error
 }
 
-func (a *App) processFileWithUpserter(path string, upserter fileUpserter) error {
+func (a *App) processFileWithUpserter(path string, upserter fileUpserter, incremental bool) error {
+	content, err := os.ReadFile(path)
+	if err != nil {
+		return err
+	}
+	return a.processContentWithUpserter(path, content, upserter, incremental)
+}
+
+func (a *App) processContentWithUpserter(path string, content []byte, upserter fileUpserter, incremental bool) error {
+	file, err := a.prepareFile(path, content, incremental)
+	if err != nil || file == nil {
+		return err
+	}
+	a.commitFile(file, content, upserter)
+	return nil
+}
+
+// prepareFile parses content and fills in everything derived from it alone:
+// module name, qualified definition names, secrets, and import usage. It does
+// not touch the graph, so it may run concurrently for different files. A nil
+// File means the file is generated and skipped.
+func (a *App) prepareFile(path string, content []byte, incremental bool) (*parser.File, error) {
 	start := time.Now()
 	lang := a.codeParser.GetLanguage(path)
 	defer func() {
@@ -155,41 +200,27 @@ func (a *App) processFileWithUpserter(path string, upserter fileUpserter) error
 
 	previousContent := a.contentForPath(path)
 	previousFile, _ := a.Graph.GetFile(path)
-	content, err := os.ReadFile(path)
-	if err != nil {
-		return err
-	}
 
 	// Skip generated files: check after reading so we have the real content.
 	if parser.IsGeneratedFile(content) {
 		slog.Debug("skipping generated file", "path", path)
-		return nil
+		return nil, nil
 	}
 
-	file, err := a.codeParser.ParseFile(path, content)
+	file, err := a.parseContent(path, content, incremental)
 	if err != nil {
-		return err
+		return nil, err
 	}
+	file.ContentHash = contentHash(content)
+	file.ParseFingerprint = a.currentParseFingerprint()
+	file.LOC = countLines(content)
 
-	switch file.Language {
-	case "python":
-		if len(a.Config.WatchPaths) == 0 {
-			return fmt.Errorf("python resolver requires at least one watch path")
-		}
-		matchingPath, err := helpers.FindContainingWatchPath(path, a.Config.WatchPaths)
-		if err != nil {
-			return err
-		}
-		r := resolver.NewPythonResolver(matchingPath)
-		file.Module = r.GetModuleName(path)
-	case "go":
-		moduleName, ok, err := a.resolveGoModule(path)
-		if err != nil {
-			return err
-		}
-		if ok {
-			file.Module = moduleName
-		}
+	moduleName, ok, err := a.resolveFileModule(path, file.Language)
+	if err != nil {
+		return nil, err
+	}
+	if ok {
+		file.Module = moduleName
 	}
 
 	// Update FullName for all definitions now that we have the module name
@@ -208,20 +239,68 @@ func (a *App) processFileWithUpserter(path string, upserter fileUpserter) error
 		}
 		file.Secrets = helpers.DetectSecrets(a.secretScanner, path, previousContent, content, previousSecrets)
 	}
-	a.Graph.AddFile(file)
-	a.cacheContent(path, content)
+	parser.CountImportUsage(file)
+	return file, nil
+}
+
+// commitFile adds a prepared file to the graph and persists its symbols. A
+// file the graph rejects is not cached, so the next change to it retries.
+func (a *App) commitFile(file *parser.File, content []byte, upserter fileUpserter) {
+	if err := a.Graph.AddFile(file); err != nil {
+		slog.Warn("failed to add file to graph", "path", file.Path, "error", err)
+		return
+	}
+	a.cacheContent(file.Path, content)
 	if upserter != nil {
 		if err := upserter.UpsertFile(file); err != nil {
-			slog.Warn("failed to upsert persisted symbol rows", "path", path, "error", err)
+			slog.Warn("failed to upsert persisted symbol rows", "path", file.Path, "error", err)
 		}
 	} else if err := a.enqueueSymbolWrite(ports.WriteRequest{
 		Operation: ports.WriteOperationUpsertFile,
 		File:      file,
 		FilePath:  file.Path,
 	}); err != nil {
-		slog.Warn("failed to upsert persisted symbol rows", "path", path, "error", err)
+		slog.Warn("failed to upsert persisted symbol rows", "path", file.Path, "error", err)
 	}
-	return nil
+}
+
+// parseContent parses content, incrementally when requested and the parser
+// supports it.
+func (a *App) parseContent(path string, content []byte, incremental bool) (*parser.File, error) {
+	if incremental {
+		if p, ok := a.codeParser.(incrementalParser); ok {
+			return p.ReparseFile(path, content)
+		}
+	}
+	return a.codeParser.ParseFile(path, content)
+}
+
+// forgetParsedTree drops the syntax tree an incremental parser keeps for path.
+func (a *App) forgetParsedTree(path string) {
+	if p, ok := a.codeParser.(incrementalParser); ok {
+		p.ForgetFile(path)
+	}
+}
+
+// resolveFileModule derives the module name for path from its watch root
+// (Python) or enclosing go.mod (Go). ok is false for other languages, which
+// keep the module reported by the parser.
+func (a *App) resolveFileModule(path, language string) (string, bool, error) {
+	switch language {
+	case "python":
+		if len(a.Config.WatchPaths) == 0 {
+			return "", false, fmt.Errorf("python resolver requires at least one watch path")
+		}
+		matchingPath, err := helpers.FindContainingWatchPath(path, a.Config.WatchPaths)
+		if err != nil {
+			return "", false, err
+		}
+		r := resolver.NewPythonResolver(matchingPath)
+		return r.GetModuleName(path), true, nil
+	case "go":
+		return a.resolveGoModule(path)
+	}
+	return "", false, nil
 }
 
 func (a *App) shouldSkipSecretScan(path string) bool {
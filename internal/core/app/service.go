@@ -5,7 +5,6 @@ import (
 	"circular/internal/core/errors"
 	"circular/internal/core/ports"
 	"circular/internal/data/history"
-	"circular/internal/data/query"
 	"circular/internal/engine/graph"
 	"circular/internal/engine/parser"
 	"circular/internal/engine/resolver"
@@ -15,6 +14,7 @@ import (
 	"fmt"
 	"os"
 	"path/filepath"
+	"sort"
 	"strings"
 	"time"
 
@@ -70,15 +70,13 @@ func (s *analysisService) RunScan(ctx context.Context, req ports.ScanRequest) (p
 			return ports.ScanResult{}, errors.AddContext(err, errors.CtxOperation, "scan_directories")
 		}
 		filesScanned = len(files)
-		for i, filePath := range files {
-			if err := s.app.ProcessFile(filePath); err != nil {
-				warnings = append(warnings, fmt.Sprintf("process file %s: %v", filePath, err))
-			}
-			if i%100 == 0 {
-				if util.GetHeapAllocMB() > uint64(s.app.Config.Performance.MaxHeapMB) {
-					s.app.PruneCache(20)
-				}
-			}
+		_, err = s.app.processFiles(ctx, files, scanOptions{
+			onError: func(path string, err error) {
+				warnings = append(warnings, fmt.Sprintf("process file %s: %v", path, err))
+			},
+		})
+		if err != nil {
+			return ports.ScanResult{}, errors.AddContext(err, errors.CtxOperation, "process_files")
 		}
 	} else {
 		if err := s.app.InitialScan(ctx); err != nil {
@@ -101,7 +99,20 @@ func (s *analysisService) TraceImportChain(ctx context.Context, from, to string)
 	if s.app == nil {
 		return "", fmt.Errorf("app is required")
 	}
-	chain, err := s.app.TraceImportChain(from, to)
+	var (
+		chain string
+		err   error
+	)
+	fromKey, fromIsSymbol := graph.ParseSymbolKey(from)
+	toKey, toIsSymbol := graph.ParseSymbolKey(to)
+	switch {
+	case fromIsSymbol && toIsSymbol:
+		chain, err = s.app.TraceSymbolChain(ctx, fromKey, toKey)
+	case fromIsSymbol || toIsSymbol:
+		err = fmt.Errorf("trace endpoints must both be modules or both be module%ssymbol keys", graph.SymbolSeparator)
+	default:
+		chain, err = s.app.TraceImportChain(from, to)
+	}
 	if err != nil {
 		err = errors.AddContext(err, "from", from)
 		err = errors.AddContext(err, "to", to)
@@ -110,6 +121,30 @@ func (s *analysisService) TraceImportChain(ctx context.Context, from, to string)
 	return chain, nil
 }
 
+func (s *analysisService) TraceImportPaths(ctx context.Context, from, to string, limit, maxDepth int) (string, error) {
+	if err := ctx.Err(); err != nil {
+		return "", err
+	}
+	if s.app == nil {
+		return "", fmt.Errorf("app is required")
+	}
+	if limit <= 0 {
+		return "", fmt.Errorf("path limit must be > 0")
+	}
+	_, fromIsSymbol := graph.ParseSymbolKey(from)
+	_, toIsSymbol := graph.ParseSymbolKey(to)
+	if fromIsSymbol || toIsSymbol {
+		return "", fmt.Errorf("path enumeration supports module endpoints only")
+	}
+	out, err := s.app.TraceImportPaths(from, to, limit, maxDepth)
+	if err != nil {
+		err = errors.AddContext(err, "from", from)
+		err = errors.AddContext(err, "to", to)
+		return "", err
+	}
+	return out, nil
+}
+
 func (s *analysisService) AnalyzeImpact(ctx context.Context, path string) (graph.ImpactReport, error) {
 	if err := ctx.Err(); err != nil {
 		return graph.ImpactReport{}, err
@@ -131,7 +166,7 @@ func (s *analysisService) DetectCycles(ctx context.Context, limit int) ([][]stri
 	if s.app == nil {
 		return nil, 0, fmt.Errorf("app is required")
 	}
-	cycles := s.app.Graph.DetectCycles()
+	cycles := s.app.structureGraph().DetectCycles()
 	count := len(cycles)
 	if limit > 0 && len(cycles) > limit {
 		cycles = cycles[:limit]
@@ -143,6 +178,50 @@ func (s *analysisService) DetectCycles(ctx context.Context, limit int) ([][]stri
 	return out, count, nil
 }
 
+func (s *analysisService) SuggestCycleCuts(ctx context.Context, limit int) ([]graph.CycleBreakPlan, error) {
+	if err := ctx.Err(); err != nil {
+		return nil, err
+	}
+	if s.app == nil {
+		return nil, fmt.Errorf("app is required")
+	}
+	plans := s.app.structureGraph().SuggestCycleCuts()
+	if limit > 0 && len(plans) > limit {
+		plans = plans[:limit]
+	}
+	return plans, nil
+}
+
+func (s *analysisService) PlanCycleRelocation(ctx context.Context, cycle []string) (graph.RelocationPlan, error) {
+	if err := ctx.Err(); err != nil {
+		return graph.RelocationPlan{}, err
+	}
+	if s.app == nil {
+		return graph.RelocationPlan{}, fmt.Errorf("app is required")
+	}
+	return s.app.PlanCycleRelocation(ctx, cycle)
+}
+
+func (s *analysisService) SimulateRefactor(ctx context.Context, ops []graph.SimulationOp) (ports.SimulationResult, error) {
+	if err := ctx.Err(); err != nil {
+		return ports.SimulationResult{}, err
+	}
+	if s.app == nil {
+		return ports.SimulationResult{}, fmt.Errorf("app is required")
+	}
+	return s.app.SimulateRefactor(ctx, ops)
+}
+
+func (s *analysisService) CriticalModules(ctx context.Context, entryPoints []string) (graph.CriticalModuleReport, error) {
+	if err := ctx.Err(); err != nil {
+		return graph.CriticalModuleReport{}, err
+	}
+	if s.app == nil {
+		return graph.CriticalModuleReport{}, fmt.Errorf("app is required")
+	}
+	return s.app.CriticalModules(entryPoints), nil
+}
+
 func (s *analysisService) ListFiles(ctx context.Context) ([]*parser.File, error) {
 	if err := ctx.Err(); err != nil {
 		return nil, err
@@ -154,7 +233,8 @@ func (s *analysisService) ListFiles(ctx context.Context) ([]*parser.File, error)
 }
 
 func (s *analysisService) QueryService(historyStore ports.HistoryStore, projectKey string) ports.QueryService {
-	return query.NewService(s.app.Graph, historyStore, strings.TrimSpace(projectKey))
+	s.app.LinkSymbols(context.Background())
+	return s.app.BuildQueryService(historyStore, strings.TrimSpace(projectKey))
 }
 
 func (s *analysisService) CaptureHistoryTrend(ctx context.Context, historyStore ports.HistoryStore, req ports.HistoryTrendRequest) (ports.HistoryTrendResult, error) {
@@ -217,6 +297,9 @@ func (s *analysisService) CaptureHistoryTrend(ctx context.Context, historyStore
 		AvgFanOut:         avgFanOut,
 		MaxFanIn:          maxFanIn,
 		MaxFanOut:         maxFanOut,
+		Modules:           snapshotModules(s.app.Graph, metrics),
+		Edges:             snapshotEdges(s.app.Graph),
+		Cycles:            cycles,
 	}
 
 	if err := historyStore.SaveSnapshot(projectKey, snapshot); err != nil {
@@ -243,6 +326,13 @@ func (s *analysisService) CaptureHistoryTrend(ctx context.Context, historyStore
 	if err != nil {
 		return ports.HistoryTrendResult{}, fmt.Errorf("build trend report: %w", err)
 	}
+	if provenanceStore, ok := historyStore.(ports.CycleProvenanceStore); ok && len(cycles) > 0 {
+		provenance, err := provenanceStore.LoadCycleProvenance(projectKey)
+		if err != nil {
+			return ports.HistoryTrendResult{}, fmt.Errorf("load cycle provenance: %w", err)
+		}
+		report.Cycles = currentCycleProvenance(provenance, cycles)
+	}
 	result.Report = &report
 	return result, nil
 }
@@ -260,13 +350,14 @@ func (s *analysisService) SummarySnapshot(ctx context.Context) (ports.SummarySna
 		return ports.SummarySnapshot{}, fmt.Errorf("app is required")
 	}
 
-	cycles := s.app.Graph.DetectCycles()
+	structure := s.app.structureGraph()
+	cycles := structure.DetectCycles()
 	outCycles := make([][]string, 0, len(cycles))
 	for _, cycle := range cycles {
 		outCycles = append(outCycles, append([]string(nil), cycle...))
 	}
 
-	metrics := s.app.Graph.ComputeModuleMetrics()
+	metrics := structure.ComputeModuleMetrics()
 	outMetrics := make(map[string]graph.ModuleMetrics, len(metrics))
 	for module, metric := range metrics {
 		outMetrics[module] = metric
@@ -290,6 +381,9 @@ func (s *analysisService) SummarySnapshot(ctx context.Context) (ports.SummarySna
 		ModuleCount:    s.app.Graph.ModuleCount(),
 		SecretCount:    s.app.SecretCount(),
 		Cycles:         outCycles,
+		CycleCuts:      structure.SuggestCycleCuts(),
+		DeadCode:       s.app.DeadCode(),
+		Encapsulation:  s.app.EncapsulationViolations(),
 		Hallucinations: append([]resolver.UnresolvedReference(nil), hallucinations...),
 		UnusedImports:  append([]resolver.UnusedImport(nil), unusedImports...),
 		Metrics:        outMetrics,
@@ -585,3 +679,58 @@ func summarizeFanMetrics(metrics map[string]graph.ModuleMetrics) (avgIn, avgOut
 	n := float64(len(metrics))
 	return float64(totalIn) / n, float64(totalOut) / n, maxIn, maxOut
 }
+
+func snapshotModules(g *graph.Graph, metrics map[string]graph.ModuleMetrics) []history.SnapshotModule {
+	modules := g.Modules()
+	out := make([]history.SnapshotModule, 0, len(modules))
+	for _, name := range util.SortedStringKeys(modules) {
+		metric := metrics[name]
+		out = append(out, history.SnapshotModule{
+			Name:         name,
+			Files:        len(modules[name].Files),
+			FanIn:        metric.FanIn,
+			FanOut:       metric.FanOut,
+			Depth:        metric.Depth,
+			Instability:  metric.Instability,
+			Abstractness: metric.Abstractness,
+			Distance:     metric.Distance,
+		})
+	}
+	return out
+}
+
+func snapshotEdges(g *graph.Graph) []history.SnapshotEdge {
+	imports := g.GetImports()
+	out := make([]history.SnapshotEdge, 0)
+	for _, from := range util.SortedStringKeys(imports) {
+		for _, to := range util.SortedStringKeys(imports[from]) {
+			edge := imports[from][to]
+			out = append(out, history.SnapshotEdge{
+				From:        from,
+				To:          to,
+				ImportCount: edge.ImportCount,
+				UsageCount:  edge.UsageCount,
+			})
+		}
+	}
+	return out
+}
+
+// currentCycleProvenance keeps the provenance of the given cycles, oldest
+// first.
+func currentCycleProvenance(provenance []history.CycleProvenance, cycles [][]string) []history.CycleProvenance {
+	current := make(map[string]bool, len(cycles))
+	for _, fingerprint := range history.CycleFingerprints(cycles) {
+		current[fingerprint] = true
+	}
+	out := make([]history.CycleProvenance, 0, len(cycles))
+	for _, p := range provenance {
+		if current[p.Fingerprint] {
+			out = append(out, p)
+		}
+	}
+	sort.SliceStable(out, func(i, j int) bool {
+		return out[i].Introduced().Before(out[j].Introduced())
+	})
+	return out
+}
@@ -138,6 +138,41 @@ func TestAnalysisServiceCaptureHistoryTrend(t *testing.T) {
 	}
 }
 
+type provenanceHistoryStoreStub struct {
+	serviceHistoryStoreStub
+	provenance []history.CycleProvenance
+}
+
+func (h *provenanceHistoryStoreStub) LoadCycleProvenance(projectKey string) ([]history.CycleProvenance, error) {
+	return h.provenance, nil
+}
+
+func TestAnalysisServiceCaptureHistoryTrend_CycleProvenance(t *testing.T) {
+	app, err := NewWithDependencies(&config.Config{}, Dependencies{CodeParser: stubCodeParser{}})
+	if err != nil {
+		t.Fatal(err)
+	}
+	app.Graph.AddFile(&parser.File{Path: "a.stub", Module: "app/a", Imports: []parser.Import{{Module: "app/b"}}})
+	app.Graph.AddFile(&parser.File{Path: "b.stub", Module: "app/b", Imports: []parser.Import{{Module: "app/a"}}})
+
+	introduced := time.Date(2026, 5, 2, 12, 0, 0, 0, time.UTC)
+	store := &provenanceHistoryStoreStub{provenance: []history.CycleProvenance{
+		{Fingerprint: "app/a -> app/b", FirstCommit: "abc123", FirstCommitTime: introduced, LastSeen: introduced.Add(41 * 24 * time.Hour)},
+		{Fingerprint: "app/c -> app/d", FirstSeen: introduced},
+	}}
+	result, err := app.AnalysisService().CaptureHistoryTrend(context.Background(), store, ports.HistoryTrendRequest{ProjectKey: "default"})
+	if err != nil {
+		t.Fatalf("capture history trend: %v", err)
+	}
+	if result.Report == nil || len(result.Report.Cycles) != 1 {
+		t.Fatalf("expected provenance for the current cycle only, got %+v", result.Report)
+	}
+	got := FormatCycleProvenance(result.Report.Cycles[0])
+	if got != "app/a -> app/b: introduced in commit abc123 on 2026-05-02 and has existed for 41 days" {
+		t.Fatalf("unexpected provenance line: %q", got)
+	}
+}
+
 func TestAnalysisServiceWatchServiceCurrentUpdate(t *testing.T) {
 	app := &App{
 		Config: &config.Config{},
@@ -0,0 +1,92 @@
+package app
+
+import (
+	"circular/internal/core/ports"
+	"circular/internal/data/history"
+	"circular/internal/engine/graph"
+	"context"
+	"fmt"
+	"sort"
+)
+
+// SimulateRefactor applies ops to a copy of the graph and reports what they
+// would change. The live graph and files on disk are left untouched.
+func (a *App) SimulateRefactor(ctx context.Context, ops []graph.SimulationOp) (ports.SimulationResult, error) {
+	if len(ops) == 0 {
+		return ports.SimulationResult{}, fmt.Errorf("at least one operation is required")
+	}
+	a.LinkSymbols(ctx)
+
+	sim, err := a.Graph.Simulate(ops)
+	if err != nil {
+		return ports.SimulationResult{}, err
+	}
+
+	cyclesBefore := a.Graph.DetectCycles()
+	cyclesAfter := sim.DetectCycles()
+	result := ports.SimulationResult{
+		Operations:    append([]graph.SimulationOp(nil), ops...),
+		ModulesBefore: a.Graph.ModuleCount(),
+		ModulesAfter:  sim.ModuleCount(),
+		CyclesBefore:  len(cyclesBefore),
+		CyclesAfter:   len(cyclesAfter),
+	}
+	result.CyclesAdded, result.CyclesRemoved = diffByKey(cyclesBefore, cyclesAfter, history.CycleFingerprint)
+	result.ViolationsAdded, result.ViolationsRemoved = diffByKey(
+		a.archEngine.Validate(a.Graph), a.archEngine.Validate(sim), layerViolationKey)
+	result.RuleViolationsAdded, result.RuleViolationsRemoved = diffByKey(
+		a.archEvaluator.Evaluate(a.Graph).Violations, a.archEvaluator.Evaluate(sim).Violations, ruleViolationKey)
+	result.MetricDeltas = metricDeltas(a.Graph.ComputeModuleMetrics(), sim.ComputeModuleMetrics())
+	return result, nil
+}
+
+// diffByKey returns the items only present after and only present before,
+// matched by key and kept in their original order.
+func diffByKey[T any](before, after []T, key func(T) string) (added, removed []T) {
+	seenBefore := make(map[string]bool, len(before))
+	for _, item := range before {
+		seenBefore[key(item)] = true
+	}
+	seenAfter := make(map[string]bool, len(after))
+	for _, item := range after {
+		k := key(item)
+		seenAfter[k] = true
+		if !seenBefore[k] {
+			added = append(added, item)
+		}
+	}
+	for _, item := range before {
+		if !seenAfter[key(item)] {
+			removed = append(removed, item)
+		}
+	}
+	return added, removed
+}
+
+func layerViolationKey(v graph.ArchitectureViolation) string {
+	return v.RuleName + "|" + v.FromModule + "|" + v.ToModule
+}
+
+func ruleViolationKey(v ports.ArchitectureRuleViolation) string {
+	return v.RuleName + "|" + v.Module + "|" + v.Target + "|" + v.Type
+}
+
+func metricDeltas(before, after map[string]graph.ModuleMetrics) []ports.ModuleMetricDelta {
+	deltas := make([]ports.ModuleMetricDelta, 0)
+	for module, b := range before {
+		a, ok := after[module]
+		switch {
+		case !ok:
+			deltas = append(deltas, ports.ModuleMetricDelta{Module: module, Before: b, Removed: true})
+		case a != b:
+			deltas = append(deltas, ports.ModuleMetricDelta{Module: module, Before: b, After: a})
+		}
+	}
+	for module, a := range after {
+		if _, ok := before[module]; !ok {
+			deltas = append(deltas, ports.ModuleMetricDelta{Module: module, After: a, Added: true})
+		}
+	}
+	sort.Slice(deltas, func(i, j int) bool { return deltas[i].Module < deltas[j].Module })
+	return deltas
+}
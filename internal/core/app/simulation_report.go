@@ -0,0 +1,65 @@
+package app
+
+import (
+	"circular/internal/core/ports"
+	"fmt"
+	"strings"
+)
+
+func FormatSimulationResult(result ports.SimulationResult) string {
+	var b strings.Builder
+
+	b.WriteString("Refactor Simulation\n")
+	b.WriteString("===================\n")
+	for i, op := range result.Operations {
+		b.WriteString(fmt.Sprintf("%d. %s\n", i+1, op))
+	}
+	b.WriteString(fmt.Sprintf("Modules: %d -> %d\n", result.ModulesBefore, result.ModulesAfter))
+	b.WriteString(fmt.Sprintf("Cycles: %d -> %d\n", result.CyclesBefore, result.CyclesAfter))
+	b.WriteString("\n")
+
+	writeCycles := func(title string, cycles [][]string) {
+		b.WriteString(fmt.Sprintf("%s (%d)\n", title, len(cycles)))
+		for _, cycle := range cycles {
+			b.WriteString(fmt.Sprintf("- %s\n", strings.Join(append(append([]string(nil), cycle...), cycle[0]), " -> ")))
+		}
+		b.WriteString("\n")
+	}
+	writeCycles("Cycles removed", result.CyclesRemoved)
+	writeCycles("Cycles added", result.CyclesAdded)
+
+	b.WriteString(fmt.Sprintf("Layer violations removed (%d)\n", len(result.ViolationsRemoved)))
+	for _, v := range result.ViolationsRemoved {
+		b.WriteString(fmt.Sprintf("- [%s] %s -> %s\n", v.RuleName, v.FromModule, v.ToModule))
+	}
+	b.WriteString(fmt.Sprintf("Layer violations added (%d)\n", len(result.ViolationsAdded)))
+	for _, v := range result.ViolationsAdded {
+		b.WriteString(fmt.Sprintf("- [%s] %s -> %s\n", v.RuleName, v.FromModule, v.ToModule))
+	}
+	b.WriteString("\n")
+
+	b.WriteString(fmt.Sprintf("Rule violations removed (%d)\n", len(result.RuleViolationsRemoved)))
+	for _, v := range result.RuleViolationsRemoved {
+		b.WriteString(fmt.Sprintf("- [%s] %s\n", v.RuleName, v.Message))
+	}
+	b.WriteString(fmt.Sprintf("Rule violations added (%d)\n", len(result.RuleViolationsAdded)))
+	for _, v := range result.RuleViolationsAdded {
+		b.WriteString(fmt.Sprintf("- [%s] %s\n", v.RuleName, v.Message))
+	}
+	b.WriteString("\n")
+
+	b.WriteString(fmt.Sprintf("Metric changes (%d)\n", len(result.MetricDeltas)))
+	for _, d := range result.MetricDeltas {
+		switch {
+		case d.Added:
+			b.WriteString(fmt.Sprintf("- %s (new): fan-in %d, fan-out %d, depth %d\n", d.Module, d.After.FanIn, d.After.FanOut, d.After.Depth))
+		case d.Removed:
+			b.WriteString(fmt.Sprintf("- %s (removed)\n", d.Module))
+		default:
+			b.WriteString(fmt.Sprintf("- %s: fan-in %d -> %d, fan-out %d -> %d, depth %d -> %d\n",
+				d.Module, d.Before.FanIn, d.After.FanIn, d.Before.FanOut, d.After.FanOut, d.Before.Depth, d.After.Depth))
+		}
+	}
+
+	return b.String()
+}
@@ -0,0 +1,81 @@
+package app
+
+import (
+	"circular/internal/data/history"
+	"fmt"
+	"strings"
+	"time"
+)
+
+func FormatSnapshotDiff(diff history.SnapshotDiff) string {
+	var b strings.Builder
+
+	b.WriteString("Snapshot Diff\n")
+	b.WriteString("=============\n")
+	b.WriteString(fmt.Sprintf("From: %s\n", formatSnapshotRef(diff.From)))
+	b.WriteString(fmt.Sprintf("To:   %s\n", formatSnapshotRef(diff.To)))
+	b.WriteString(fmt.Sprintf("Modules %+d, files %+d, cycles %+d, unresolved %+d, violations %+d\n",
+		diff.DeltaModules, diff.DeltaFiles, diff.DeltaCycles, diff.DeltaUnresolved, diff.DeltaViolations))
+	b.WriteString("\n")
+
+	writeList := func(title string, values []string) {
+		b.WriteString(fmt.Sprintf("%s (%d)\n", title, len(values)))
+		for _, value := range values {
+			b.WriteString(fmt.Sprintf("- %s\n", value))
+		}
+	}
+	writeList("Modules added", diff.AddedModules)
+	writeList("Modules removed", diff.RemovedModules)
+	b.WriteString("\n")
+
+	writeEdges := func(title string, edges []history.SnapshotEdge) {
+		b.WriteString(fmt.Sprintf("%s (%d)\n", title, len(edges)))
+		for _, edge := range edges {
+			b.WriteString(fmt.Sprintf("- %s -> %s (%d imports, %d refs)\n", edge.From, edge.To, edge.ImportCount, edge.UsageCount))
+		}
+	}
+	writeEdges("Edges added", diff.AddedEdges)
+	writeEdges("Edges removed", diff.RemovedEdges)
+	b.WriteString("\n")
+
+	writeCycles := func(title string, cycles [][]string) {
+		b.WriteString(fmt.Sprintf("%s (%d)\n", title, len(cycles)))
+		for _, cycle := range cycles {
+			b.WriteString(fmt.Sprintf("- %s\n", strings.Join(append(append([]string(nil), cycle...), cycle[0]), " -> ")))
+		}
+	}
+	writeCycles("New cycles", diff.NewCycles)
+	writeCycles("Fixed cycles", diff.FixedCycles)
+	b.WriteString("\n")
+
+	b.WriteString(fmt.Sprintf("Metric changes (%d)\n", len(diff.MetricDeltas)))
+	for _, d := range diff.MetricDeltas {
+		b.WriteString(fmt.Sprintf("- %s: fan-in %+d, fan-out %+d, depth %+d, I %+.2f, A %+.2f, D %+.2f\n",
+			d.Module, d.FanIn, d.FanOut, d.Depth, d.Instability, d.Abstractness, d.Distance))
+	}
+	return b.String()
+}
+
+func formatSnapshotRef(ref history.SnapshotRef) string {
+	out := ref.Timestamp.Format(time.RFC3339)
+	if ref.CommitHash != "" {
+		out += " (" + ref.CommitHash + ")"
+	}
+	return out
+}
+
+// FormatCycleProvenance describes when a cycle was introduced and how long it
+// has existed, e.g. "a -> b: introduced in commit abc123 on 2026-05-02 and has
+// existed for 41 days".
+func FormatCycleProvenance(p history.CycleProvenance) string {
+	days := int(p.Age().Hours() / 24)
+	unit := "days"
+	if days == 1 {
+		unit = "day"
+	}
+	introduced := p.Introduced().Format("2006-01-02")
+	if p.FirstCommit != "" {
+		return fmt.Sprintf("%s: introduced in commit %s on %s and has existed for %d %s", p.Fingerprint, p.FirstCommit, introduced, days, unit)
+	}
+	return fmt.Sprintf("%s: first seen on %s and has existed for %d %s", p.Fingerprint, introduced, days, unit)
+}
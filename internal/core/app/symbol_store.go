@@ -1,10 +1,15 @@
 package app
 
 import (
+	"bytes"
 	"circular/internal/core/config"
 	"circular/internal/engine/graph"
 	"circular/internal/engine/parser"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
 	"fmt"
+	"log/slog"
 	"os"
 	"strings"
 )
@@ -56,5 +61,91 @@ func (a *App) pruneSymbolStorePaths() error {
 	if a == nil || a.symbolStore == nil {
 		return nil
 	}
-	return a.symbolStore.PruneToPaths(a.currentGraphPaths())
+	return a.symbolStore.PruneToPaths(a.Graph.FilePaths())
+}
+
+// storedFileHashes returns the content hashes persisted by a previous run
+// under the current parse fingerprint, or nil when no symbol store is open.
+func (a *App) storedFileHashes() map[string]string {
+	if a == nil || a.symbolStore == nil {
+		return nil
+	}
+	hashes, err := a.symbolStore.LoadFileHashes(a.currentParseFingerprint())
+	if err != nil {
+		slog.Warn("failed to load persisted file hashes; reparsing all files", "error", err)
+		return nil
+	}
+	return hashes
+}
+
+// warmStartFile adds the persisted parse of path to the graph when content
+// still hashes to storedHash and the file resolves to the same module. It
+// reports false when the file must be reparsed.
+func (a *App) warmStartFile(path string, content []byte, storedHash string) bool {
+	if a.symbolStore == nil || storedHash == "" || storedHash != contentHash(content) {
+		return false
+	}
+	file, err := a.symbolStore.LoadFile(path)
+	if err != nil {
+		slog.Warn("failed to load persisted file blob", "path", path, "error", err)
+		return false
+	}
+	if file == nil {
+		return false
+	}
+	if moduleName, ok, err := a.resolveFileModule(path, file.Language); err != nil || (ok && moduleName != file.Module) {
+		return false
+	}
+	if err := a.Graph.AddFile(file); err != nil {
+		slog.Warn("failed to add persisted file to graph", "path", path, "error", err)
+		return false
+	}
+	a.cacheContent(path, content)
+	return true
+}
+
+func (a *App) currentParseFingerprint() string {
+	a.configMu.RLock()
+	defer a.configMu.RUnlock()
+	return a.parseFingerprint
+}
+
+// parseFingerprint identifies what besides file content shapes a stored
+// parse: the extractor version and the grammar, language, exclude, and secret
+// settings. A stored parse with another fingerprint is never warm-started.
+func parseFingerprint(cfg *config.Config, extractorVersion int) string {
+	payload, err := json.Marshal(struct {
+		ExtractorVersion int
+		GrammarsPath     string
+		Languages        map[string]config.Language
+		DynamicGrammars  []config.DynamicGrammar
+		Exclude          config.Exclude
+		Secrets          config.Secrets
+	}{
+		ExtractorVersion: extractorVersion,
+		GrammarsPath:     cfg.GrammarsPath,
+		Languages:        cfg.Languages,
+		DynamicGrammars:  cfg.DynamicGrammars,
+		Exclude:          cfg.Exclude,
+		Secrets:          cfg.Secrets,
+	})
+	if err != nil {
+		// The fields are plain data; fall back to the version alone.
+		return fmt.Sprintf("v%d", extractorVersion)
+	}
+	return contentHash(payload)
+}
+
+func contentHash(content []byte) string {
+	sum := sha256.Sum256(content)
+	return hex.EncodeToString(sum[:])
+}
+
+// countLines counts newline-terminated lines plus a final unterminated one.
+func countLines(content []byte) int {
+	lines := bytes.Count(content, []byte{'\n'})
+	if len(content) > 0 && content[len(content)-1] != '\n' {
+		lines++
+	}
+	return lines
 }
@@ -402,5 +402,5 @@ func (a *App) Close(ctx context.Context) error {
 		}
 		a.symbolStore = nil
 	}
-	return nil
+	return a.closeNodeStorage()
 }
@@ -17,6 +17,9 @@ type Config struct {
 	DynamicGrammars     []DynamicGrammar    `toml:"dynamic_grammars"`
 	WatchPaths          []string            `toml:"watch_paths"`
 	Exclude             Exclude             `toml:"exclude"`
+	DeadCode            DeadCode            `toml:"dead_code"`
+	EntryPoints         EntryPoints         `toml:"entry_points"`
+	Graph               GraphSettings       `toml:"graph"`
 	Watch               Watch               `toml:"watch"`
 	Output              Output              `toml:"output"`
 	Alerts              Alerts              `toml:"alerts"`
@@ -30,7 +33,9 @@ type Config struct {
 }
 
 type Performance struct {
-	MaxHeapMB int `toml:"max_heap_mb"`
+	MaxHeapMB          int `toml:"max_heap_mb"`
+	MaxResidentModules int `toml:"max_resident_modules"` // 0 keeps every module in memory; bounds module nodes and adjacency only, not symbol tables or the file cache
+	ScanWorkers        int `toml:"scan_workers"`         // 0 uses one worker per CPU
 }
 
 type Observability struct {
@@ -68,6 +73,7 @@ type DynamicGrammar struct {
 	NamespaceNode   string   `toml:"namespace_node"`
 	ImportNode      string   `toml:"import_node"`
 	DefinitionNodes []string `toml:"definition_nodes"`
+	Queries         []string `toml:"queries"`
 }
 
 type Paths struct {
@@ -150,6 +156,31 @@ type Exclude struct {
 	Imports []string `toml:"imports"` // Import paths to ignore for unused check
 }
 
+// DeadCode allowlists exported definitions that are reached without a
+// parser-visible reference, such as interface methods or framework hooks.
+type DeadCode struct {
+	AllowSymbols    []string `toml:"allow_symbols"`    // Globs matched against definition names
+	AllowDecorators []string `toml:"allow_decorators"` // Globs matched against decorators, without "@"
+}
+
+// EntryPoints selects the modules reachability analysis starts from. Detect
+// names built-in kinds: "go_main" (Go main packages), "python_main"
+// (__main__.py files and __name__ == "__main__" guards), and "js_bin"
+// (package.json bin targets).
+type EntryPoints struct {
+	Detect   []string `toml:"detect"`   // Defaults to all detectors
+	Patterns []string `toml:"patterns"` // Module names, file paths, or globs
+}
+
+// GraphSettings selects what cycle detection, metrics, diagrams, and tracing
+// treat as a node: "module" (default), "file", or "directory" (files rolled up
+// to their directory). Depth above zero further rolls node names up to their
+// first Depth path segments.
+type GraphSettings struct {
+	Granularity string `toml:"granularity"`
+	Depth       int    `toml:"depth"`
+}
+
 type Watch struct {
 	Debounce time.Duration `toml:"debounce"`
 }
@@ -184,6 +215,7 @@ type DiagramOutput struct {
 	Architecture bool                   `toml:"architecture"`
 	Component    bool                   `toml:"component"`
 	Flow         bool                   `toml:"flow"`
+	Clusters     bool                   `toml:"clusters"`
 	FlowConfig   FlowDiagramConfig      `toml:"flow_config"`
 	ComponentCfg ComponentDiagramConfig `toml:"component_config"`
 }
@@ -290,6 +290,113 @@ probable_threshold = 5
 	}
 }
 
+func TestLoadDeadCodeAllowlist(t *testing.T) {
+	content := `
+grammars_path = "./grammars"
+
+[dead_code]
+allow_symbols = ["ServeHTTP", "*.String"]
+allow_decorators = ["@app.route"]
+`
+	tmpfile, err := os.CreateTemp("", "config-dead-code*.toml")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.Remove(tmpfile.Name())
+	if _, err := tmpfile.Write([]byte(content)); err != nil {
+		t.Fatal(err)
+	}
+	if err := tmpfile.Close(); err != nil {
+		t.Fatal(err)
+	}
+
+	cfg, err := Load(tmpfile.Name())
+	if err != nil {
+		t.Fatalf("Load failed: %v", err)
+	}
+	if len(cfg.DeadCode.AllowSymbols) != 2 || cfg.DeadCode.AllowDecorators[0] != "@app.route" {
+		t.Fatalf("unexpected dead_code config: %+v", cfg.DeadCode)
+	}
+
+	cfg.DeadCode.AllowSymbols = []string{"[unclosed"}
+	if err := validateDeadCode(cfg); err == nil || !strings.Contains(err.Error(), "dead_code.allow_symbols[0]") {
+		t.Fatalf("expected invalid allow_symbols glob error, got %v", err)
+	}
+}
+
+func TestLoadEntryPoints(t *testing.T) {
+	content := `
+grammars_path = "./grammars"
+
+[entry_points]
+detect = ["go_main"]
+patterns = ["cmd/**", "scripts/*.py"]
+`
+	tmpfile, err := os.CreateTemp("", "config-entry-points*.toml")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.Remove(tmpfile.Name())
+	if _, err := tmpfile.Write([]byte(content)); err != nil {
+		t.Fatal(err)
+	}
+	if err := tmpfile.Close(); err != nil {
+		t.Fatal(err)
+	}
+
+	cfg, err := Load(tmpfile.Name())
+	if err != nil {
+		t.Fatalf("Load failed: %v", err)
+	}
+	if len(cfg.EntryPoints.Detect) != 1 || len(cfg.EntryPoints.Patterns) != 2 {
+		t.Fatalf("unexpected entry_points config: %+v", cfg.EntryPoints)
+	}
+
+	cfg.EntryPoints.Detect = []string{"rust_main"}
+	if err := validateEntryPoints(cfg); err == nil || !strings.Contains(err.Error(), "entry_points.detect[0]") {
+		t.Fatalf("expected unknown detector error, got %v", err)
+	}
+}
+
+func TestLoadGraphGranularity(t *testing.T) {
+	content := `
+grammars_path = "./grammars"
+
+[graph]
+granularity = "file"
+depth = 2
+`
+	tmpfile, err := os.CreateTemp("", "config-graph*.toml")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.Remove(tmpfile.Name())
+	if _, err := tmpfile.Write([]byte(content)); err != nil {
+		t.Fatal(err)
+	}
+	if err := tmpfile.Close(); err != nil {
+		t.Fatal(err)
+	}
+
+	cfg, err := Load(tmpfile.Name())
+	if err != nil {
+		t.Fatalf("Load failed: %v", err)
+	}
+	if cfg.Graph.Granularity != "file" || cfg.Graph.Depth != 2 {
+		t.Fatalf("unexpected graph config: %+v", cfg.Graph)
+	}
+
+	cfg.Graph.Granularity = "package"
+	if err := validateGraph(cfg); err == nil || !strings.Contains(err.Error(), "graph.granularity") {
+		t.Fatalf("expected unknown granularity error, got %v", err)
+	}
+	cfg.Graph.Granularity = "module"
+	cfg.Graph.Depth = -1
+	if err := validateGraph(cfg); err == nil || !strings.Contains(err.Error(), "graph.depth") {
+		t.Fatalf("expected negative depth error, got %v", err)
+	}
+}
+
 func TestLoadArchitectureRules(t *testing.T) {
 	content := `
 grammars_path = "./grammars"
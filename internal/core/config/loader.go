@@ -174,6 +174,12 @@ func applyDefaults(cfg *Config) {
 	if len(cfg.WatchPaths) == 0 {
 		cfg.WatchPaths = []string{"."}
 	}
+	if cfg.EntryPoints.Detect == nil {
+		cfg.EntryPoints.Detect = []string{"go_main", "python_main", "js_bin"}
+	}
+	if strings.TrimSpace(cfg.Graph.Granularity) == "" {
+		cfg.Graph.Granularity = "module"
+	}
 
 	// Keep architecture checks optional and backward compatible.
 	if cfg.Architecture.TopComplexity <= 0 {
@@ -248,6 +254,12 @@ func applyDefaults(cfg *Config) {
 	if cfg.Performance.MaxHeapMB <= 0 {
 		cfg.Performance.MaxHeapMB = 2048
 	}
+	if cfg.Performance.MaxResidentModules < 0 {
+		cfg.Performance.MaxResidentModules = 0
+	}
+	if cfg.Performance.ScanWorkers < 0 {
+		cfg.Performance.ScanWorkers = 0
+	}
 
 	if cfg.Observability.Port == 0 {
 		cfg.Observability.Port = 9090
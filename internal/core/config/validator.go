@@ -8,6 +8,8 @@ import (
 	"regexp"
 	"strings"
 	"time"
+
+	"github.com/gobwas/glob"
 )
 
 func validateVersion(cfg *Config) error {
@@ -468,6 +470,52 @@ func validateResolver(cfg *Config) error {
 	return nil
 }
 
+func validateDeadCode(cfg *Config) error {
+	for i, pattern := range cfg.DeadCode.AllowSymbols {
+		if _, err := glob.Compile(strings.TrimSpace(pattern)); err != nil {
+			return fmt.Errorf("dead_code.allow_symbols[%d] is invalid: %w", i, err)
+		}
+	}
+	for i, pattern := range cfg.DeadCode.AllowDecorators {
+		if _, err := glob.Compile(strings.TrimPrefix(strings.TrimSpace(pattern), "@")); err != nil {
+			return fmt.Errorf("dead_code.allow_decorators[%d] is invalid: %w", i, err)
+		}
+	}
+	return nil
+}
+
+func validateEntryPoints(cfg *Config) error {
+	for i, kind := range cfg.EntryPoints.Detect {
+		switch strings.TrimSpace(kind) {
+		case "go_main", "python_main", "js_bin":
+		default:
+			return fmt.Errorf("entry_points.detect[%d] must be one of: go_main, python_main, js_bin", i)
+		}
+	}
+	for i, pattern := range cfg.EntryPoints.Patterns {
+		pattern = strings.TrimSpace(pattern)
+		if pattern == "" {
+			return fmt.Errorf("entry_points.patterns[%d] must not be empty", i)
+		}
+		if _, err := glob.Compile(pattern, '/'); err != nil {
+			return fmt.Errorf("entry_points.patterns[%d] is invalid: %w", i, err)
+		}
+	}
+	return nil
+}
+
+func validateGraph(cfg *Config) error {
+	switch strings.ToLower(strings.TrimSpace(cfg.Graph.Granularity)) {
+	case "", "module", "file", "directory":
+	default:
+		return fmt.Errorf("graph.granularity must be one of: module, file, directory")
+	}
+	if cfg.Graph.Depth < 0 {
+		return fmt.Errorf("graph.depth must be >= 0")
+	}
+	return nil
+}
+
 func validateWriteQueue(cfg *Config) error {
 	q := cfg.WriteQueue
 	if q.MemoryCapacity < 1 {
@@ -532,6 +580,14 @@ func validateDynamicGrammars(cfg *Config) error {
 		if len(dg.Extensions) == 0 && len(dg.Filenames) == 0 {
 			return fmt.Errorf("%s must define at least one extension or filename", ref)
 		}
+		if len(dg.Queries) > 0 {
+			for j, query := range dg.Queries {
+				if strings.TrimSpace(query) == "" {
+					return fmt.Errorf("%s.queries[%d] must not be empty", ref, j)
+				}
+			}
+			continue
+		}
 		if dg.NamespaceNode == "" {
 			return fmt.Errorf("%s.namespace_node must not be empty", ref)
 		}
@@ -578,6 +634,15 @@ func Validate(cfg *Config) []error {
 	if err := validateResolver(cfg); err != nil {
 		errs = append(errs, err)
 	}
+	if err := validateDeadCode(cfg); err != nil {
+		errs = append(errs, err)
+	}
+	if err := validateEntryPoints(cfg); err != nil {
+		errs = append(errs, err)
+	}
+	if err := validateGraph(cfg); err != nil {
+		errs = append(errs, err)
+	}
 	if err := validateWriteQueue(cfg); err != nil {
 		errs = append(errs, err)
 	}
@@ -95,3 +95,25 @@ func TestValidateWriteQueueRetryOrder(t *testing.T) {
 		t.Fatalf("expected write_queue retry delay validation error, got %v", errs)
 	}
 }
+
+func TestValidateDynamicGrammars_QueriesReplaceNodeKinds(t *testing.T) {
+	cfg := &Config{DynamicGrammars: []DynamicGrammar{{
+		Name:       "kotlin",
+		Library:    "./grammars/kotlin/kotlin.so",
+		Extensions: []string{".kt"},
+		Queries:    []string{"./grammars/kotlin/circular.scm"},
+	}}}
+	if err := validateDynamicGrammars(cfg); err != nil {
+		t.Fatalf("expected queries without node kinds to be valid, got %v", err)
+	}
+
+	cfg.DynamicGrammars[0].Queries = nil
+	if err := validateDynamicGrammars(cfg); err == nil || err.Error() != "dynamic_grammars[0].namespace_node must not be empty" {
+		t.Fatalf("expected node kinds to be required without queries, got %v", err)
+	}
+
+	cfg.DynamicGrammars[0].Queries = []string{" "}
+	if err := validateDynamicGrammars(cfg); err == nil || err.Error() != "dynamic_grammars[0].queries[0] must not be empty" {
+		t.Fatalf("expected empty query path to be rejected, got %v", err)
+	}
+}
@@ -45,6 +45,13 @@ type HistoryStore interface {
 	LoadSnapshots(projectKey string, since time.Time) ([]history.Snapshot, error)
 }
 
+// CycleProvenanceStore is an optional extension for stores that track when each
+// cycle fingerprint was first and last captured.
+type CycleProvenanceStore interface {
+	HistoryStore
+	LoadCycleProvenance(projectKey string) ([]history.CycleProvenance, error)
+}
+
 type WriteOperation string
 
 const (
@@ -133,6 +140,9 @@ type SummarySnapshot struct {
 	ModuleCount    int
 	SecretCount    int
 	Cycles         [][]string
+	CycleCuts      []graph.CycleBreakPlan
+	DeadCode       []graph.DeadDefinition
+	Encapsulation  []graph.EncapsulationViolation
 	Hallucinations []resolver.UnresolvedReference
 	UnusedImports  []resolver.UnusedImport
 	Metrics        map[string]graph.ModuleMetrics
@@ -196,6 +206,33 @@ type ArchitectureRuleGuide struct {
 	Violations []ArchitectureRuleViolation
 }
 
+// SimulationResult reports how a sequence of hypothetical refactoring steps
+// changes cycles, architecture violations, and module metrics.
+type SimulationResult struct {
+	Operations            []graph.SimulationOp
+	ModulesBefore         int
+	ModulesAfter          int
+	CyclesBefore          int
+	CyclesAfter           int
+	CyclesAdded           [][]string
+	CyclesRemoved         [][]string
+	ViolationsAdded       []graph.ArchitectureViolation
+	ViolationsRemoved     []graph.ArchitectureViolation
+	RuleViolationsAdded   []ArchitectureRuleViolation
+	RuleViolationsRemoved []ArchitectureRuleViolation
+	MetricDeltas          []ModuleMetricDelta
+}
+
+// ModuleMetricDelta holds metrics for a module whose metrics changed in a
+// simulation. Added and Removed mark modules that only exist on one side.
+type ModuleMetricDelta struct {
+	Module  string
+	Before  graph.ModuleMetrics
+	After   graph.ModuleMetrics
+	Added   bool
+	Removed bool
+}
+
 // SummaryPrintRequest captures terminal-summary rendering inputs.
 type SummaryPrintRequest struct {
 	Duration time.Duration
@@ -205,9 +242,14 @@ type SummaryPrintRequest struct {
 // QueryService exposes read-only dependency query operations for driving adapters.
 type QueryService interface {
 	ListModules(ctx context.Context, filter string, limit int) ([]query.ModuleSummary, error)
+	ListModulesAtDepth(ctx context.Context, filter string, depth, limit int) ([]query.ModuleSummary, error)
 	ModuleDetails(ctx context.Context, moduleName string) (query.ModuleDetails, error)
 	DependencyTrace(ctx context.Context, from, to string, maxDepth int) (query.TraceResult, error)
+	DependencyPaths(ctx context.Context, from, to string, limit, maxDepth int) (query.TracePathsResult, error)
+	SymbolDetails(ctx context.Context, symbol string) (query.SymbolDetails, error)
 	TrendSlice(ctx context.Context, since time.Time, limit int) (query.TrendSlice, error)
+	SnapshotDiff(ctx context.Context, fromRef, toRef string) (history.SnapshotDiff, error)
+	ExternalDependencies(ctx context.Context, filter query.ExternalDependencyFilter, limit int) ([]graph.ExternalDependency, error)
 }
 
 // WatchUpdate contains state emitted to driving adapters during watch-mode updates.
@@ -235,8 +277,13 @@ type Reloadable interface {
 type AnalysisService interface {
 	RunScan(ctx context.Context, req ScanRequest) (ScanResult, error)
 	TraceImportChain(ctx context.Context, from, to string) (string, error)
+	TraceImportPaths(ctx context.Context, from, to string, limit, maxDepth int) (string, error)
 	AnalyzeImpact(ctx context.Context, path string) (graph.ImpactReport, error)
 	DetectCycles(ctx context.Context, limit int) ([][]string, int, error)
+	SuggestCycleCuts(ctx context.Context, limit int) ([]graph.CycleBreakPlan, error)
+	PlanCycleRelocation(ctx context.Context, cycle []string) (graph.RelocationPlan, error)
+	SimulateRefactor(ctx context.Context, ops []graph.SimulationOp) (SimulationResult, error)
+	CriticalModules(ctx context.Context, entryPoints []string) (graph.CriticalModuleReport, error)
 	ListFiles(ctx context.Context) ([]*parser.File, error)
 	QueryService(historyStore HistoryStore, projectKey string) QueryService
 	CaptureHistoryTrend(ctx context.Context, historyStore HistoryStore, req HistoryTrendRequest) (HistoryTrendResult, error)
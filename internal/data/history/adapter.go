@@ -20,3 +20,7 @@ func (a *Adapter) SaveSnapshot(projectKey string, snapshot Snapshot) error {
 func (a *Adapter) LoadSnapshots(projectKey string, since time.Time) ([]Snapshot, error) {
 	return a.store.LoadSnapshots(projectKey, since)
 }
+
+func (a *Adapter) LoadCycleProvenance(projectKey string) ([]CycleProvenance, error) {
+	return a.store.LoadCycleProvenance(projectKey)
+}
@@ -0,0 +1,204 @@
+package history
+
+import (
+	"circular/internal/shared/util"
+	"fmt"
+	"strings"
+	"time"
+)
+
+// SnapshotRef identifies one side of a SnapshotDiff.
+type SnapshotRef struct {
+	Timestamp  time.Time `json:"timestamp"`
+	CommitHash string    `json:"commit_hash,omitempty"`
+}
+
+// ModuleMetricDelta is the change in one module's metrics between two
+// snapshots (to minus from).
+type ModuleMetricDelta struct {
+	Module       string  `json:"module"`
+	FanIn        int     `json:"fan_in"`
+	FanOut       int     `json:"fan_out"`
+	Depth        int     `json:"depth"`
+	Instability  float64 `json:"instability"`
+	Abstractness float64 `json:"abstractness"`
+	Distance     float64 `json:"distance"`
+}
+
+// SnapshotDiff lists the structural changes between two snapshots.
+type SnapshotDiff struct {
+	From            SnapshotRef         `json:"from"`
+	To              SnapshotRef         `json:"to"`
+	AddedModules    []string            `json:"added_modules"`
+	RemovedModules  []string            `json:"removed_modules"`
+	AddedEdges      []SnapshotEdge      `json:"added_edges"`
+	RemovedEdges    []SnapshotEdge      `json:"removed_edges"`
+	NewCycles       [][]string          `json:"new_cycles"`
+	FixedCycles     [][]string          `json:"fixed_cycles"`
+	MetricDeltas    []ModuleMetricDelta `json:"metric_deltas"`
+	DeltaModules    int                 `json:"delta_modules"`
+	DeltaFiles      int                 `json:"delta_files"`
+	DeltaCycles     int                 `json:"delta_cycles"`
+	DeltaUnresolved int                 `json:"delta_unresolved"`
+	DeltaViolations int                 `json:"delta_violations"`
+}
+
+// HasGraphDetail reports whether the snapshot carries module, edge, and cycle
+// detail. Snapshots written before schema 6 only hold aggregate counts.
+func (s Snapshot) HasGraphDetail() bool {
+	return len(s.Modules) > 0 || s.ModuleCount == 0
+}
+
+// FindSnapshot resolves ref against snapshots ordered oldest first. ref may be
+// "latest", "previous", an RFC3339 or YYYY-MM-DD timestamp (the last snapshot
+// at or before it), or a commit hash prefix of at least four characters (the
+// latest snapshot for that commit).
+func FindSnapshot(snapshots []Snapshot, ref string) (Snapshot, error) {
+	raw := strings.TrimSpace(ref)
+	if len(snapshots) == 0 {
+		return Snapshot{}, fmt.Errorf("no snapshots available")
+	}
+
+	switch strings.ToLower(raw) {
+	case "", "latest":
+		return snapshots[len(snapshots)-1], nil
+	case "previous":
+		if len(snapshots) < 2 {
+			return Snapshot{}, fmt.Errorf("no previous snapshot available")
+		}
+		return snapshots[len(snapshots)-2], nil
+	}
+
+	if at, ok := parseSnapshotTime(raw); ok {
+		for i := len(snapshots) - 1; i >= 0; i-- {
+			if !snapshots[i].Timestamp.After(at) {
+				return snapshots[i], nil
+			}
+		}
+		return Snapshot{}, fmt.Errorf("no snapshot at or before %s", raw)
+	}
+
+	if len(raw) >= 4 {
+		prefix := strings.ToLower(raw)
+		for i := len(snapshots) - 1; i >= 0; i-- {
+			if strings.HasPrefix(strings.ToLower(snapshots[i].CommitHash), prefix) {
+				return snapshots[i], nil
+			}
+		}
+	}
+	return Snapshot{}, fmt.Errorf("no snapshot matches %q", raw)
+}
+
+func parseSnapshotTime(raw string) (time.Time, bool) {
+	if parsed, err := time.Parse(time.RFC3339Nano, raw); err == nil {
+		return parsed.UTC(), true
+	}
+	if parsed, err := time.Parse("2006-01-02", raw); err == nil {
+		// A bare date covers the whole day.
+		return parsed.UTC().Add(24*time.Hour - time.Nanosecond), true
+	}
+	return time.Time{}, false
+}
+
+// DiffSnapshots compares two snapshots. Cycles are matched regardless of
+// their starting module.
+func DiffSnapshots(from, to Snapshot) (SnapshotDiff, error) {
+	for _, snapshot := range []Snapshot{from, to} {
+		if !snapshot.HasGraphDetail() {
+			return SnapshotDiff{}, fmt.Errorf("snapshot %s has no graph detail (schema version %d)", snapshot.Timestamp.Format(time.RFC3339), snapshot.SchemaVersion)
+		}
+	}
+
+	diff := SnapshotDiff{
+		From:            SnapshotRef{Timestamp: from.Timestamp, CommitHash: from.CommitHash},
+		To:              SnapshotRef{Timestamp: to.Timestamp, CommitHash: to.CommitHash},
+		DeltaModules:    to.ModuleCount - from.ModuleCount,
+		DeltaFiles:      to.FileCount - from.FileCount,
+		DeltaCycles:     to.CycleCount - from.CycleCount,
+		DeltaUnresolved: to.UnresolvedCount - from.UnresolvedCount,
+		DeltaViolations: to.ViolationCount - from.ViolationCount,
+	}
+
+	before := make(map[string]SnapshotModule, len(from.Modules))
+	for _, module := range from.Modules {
+		before[module.Name] = module
+	}
+	after := make(map[string]SnapshotModule, len(to.Modules))
+	for _, module := range to.Modules {
+		after[module.Name] = module
+	}
+	diff.AddedModules = missingKeys(after, before)
+	diff.RemovedModules = missingKeys(before, after)
+	diff.MetricDeltas = make([]ModuleMetricDelta, 0)
+	for _, name := range util.SortedStringKeys(after) {
+		prev, ok := before[name]
+		if !ok {
+			continue
+		}
+		cur := after[name]
+		delta := ModuleMetricDelta{
+			Module:       name,
+			FanIn:        cur.FanIn - prev.FanIn,
+			FanOut:       cur.FanOut - prev.FanOut,
+			Depth:        cur.Depth - prev.Depth,
+			Instability:  round2(cur.Instability - prev.Instability),
+			Abstractness: round2(cur.Abstractness - prev.Abstractness),
+			Distance:     round2(cur.Distance - prev.Distance),
+		}
+		if delta != (ModuleMetricDelta{Module: name}) {
+			diff.MetricDeltas = append(diff.MetricDeltas, delta)
+		}
+	}
+
+	beforeEdges := make(map[string]SnapshotEdge, len(from.Edges))
+	for _, edge := range from.Edges {
+		beforeEdges[edge.From+"->"+edge.To] = edge
+	}
+	afterEdges := make(map[string]SnapshotEdge, len(to.Edges))
+	for _, edge := range to.Edges {
+		afterEdges[edge.From+"->"+edge.To] = edge
+	}
+	diff.AddedEdges = make([]SnapshotEdge, 0)
+	for _, key := range missingKeys(afterEdges, beforeEdges) {
+		diff.AddedEdges = append(diff.AddedEdges, afterEdges[key])
+	}
+	diff.RemovedEdges = make([]SnapshotEdge, 0)
+	for _, key := range missingKeys(beforeEdges, afterEdges) {
+		diff.RemovedEdges = append(diff.RemovedEdges, beforeEdges[key])
+	}
+
+	beforeCycles := cycleIndex(from.Cycles)
+	afterCycles := cycleIndex(to.Cycles)
+	diff.NewCycles = make([][]string, 0)
+	for _, key := range missingKeys(afterCycles, beforeCycles) {
+		diff.NewCycles = append(diff.NewCycles, afterCycles[key])
+	}
+	diff.FixedCycles = make([][]string, 0)
+	for _, key := range missingKeys(beforeCycles, afterCycles) {
+		diff.FixedCycles = append(diff.FixedCycles, beforeCycles[key])
+	}
+	return diff, nil
+}
+
+// cycleIndex keys each cycle by its fingerprint.
+func cycleIndex(cycles [][]string) map[string][]string {
+	index := make(map[string][]string, len(cycles))
+	for _, cycle := range cycles {
+		if len(cycle) == 0 {
+			continue
+		}
+		index[CycleFingerprint(cycle)] = CanonicalCycle(cycle)
+	}
+	return index
+}
+
+// missingKeys returns the sorted keys of a that are absent from b.
+func missingKeys[A, B any](a map[string]A, b map[string]B) []string {
+	out := make([]string, 0)
+	for _, key := range util.SortedStringKeys(a) {
+		if _, ok := b[key]; !ok {
+			out = append(out, key)
+		}
+	}
+	return out
+}
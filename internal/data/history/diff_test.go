@@ -0,0 +1,139 @@
+package history
+
+import (
+	"path/filepath"
+	"reflect"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestDiffSnapshots(t *testing.T) {
+	base := time.Date(2026, 3, 1, 9, 0, 0, 0, time.UTC)
+	from := Snapshot{
+		Timestamp:   base,
+		ModuleCount: 3,
+		CycleCount:  1,
+		Modules: []SnapshotModule{
+			{Name: "app/a", FanOut: 1},
+			{Name: "app/b", FanIn: 1, FanOut: 1},
+			{Name: "app/old", FanIn: 1},
+		},
+		Edges: []SnapshotEdge{
+			{From: "app/a", To: "app/b", ImportCount: 1},
+			{From: "app/b", To: "app/old", ImportCount: 1},
+		},
+		Cycles: [][]string{{"app/b", "app/old"}},
+	}
+	to := Snapshot{
+		Timestamp:   base.Add(time.Hour),
+		ModuleCount: 3,
+		CycleCount:  1,
+		Modules: []SnapshotModule{
+			{Name: "app/a", FanIn: 1, FanOut: 1, Instability: 0.5},
+			{Name: "app/b", FanIn: 1, FanOut: 1},
+			{Name: "app/new", FanIn: 1},
+		},
+		Edges: []SnapshotEdge{
+			{From: "app/a", To: "app/b", ImportCount: 2},
+			{From: "app/b", To: "app/a", ImportCount: 1, UsageCount: 3},
+		},
+		Cycles: [][]string{{"app/b", "app/a"}},
+	}
+
+	diff, err := DiffSnapshots(from, to)
+	if err != nil {
+		t.Fatalf("diff snapshots: %v", err)
+	}
+	if !reflect.DeepEqual(diff.AddedModules, []string{"app/new"}) || !reflect.DeepEqual(diff.RemovedModules, []string{"app/old"}) {
+		t.Fatalf("unexpected module changes: +%v -%v", diff.AddedModules, diff.RemovedModules)
+	}
+	if len(diff.AddedEdges) != 1 || diff.AddedEdges[0].To != "app/a" || diff.AddedEdges[0].UsageCount != 3 {
+		t.Fatalf("unexpected added edges: %+v", diff.AddedEdges)
+	}
+	if len(diff.RemovedEdges) != 1 || diff.RemovedEdges[0].To != "app/old" {
+		t.Fatalf("unexpected removed edges: %+v", diff.RemovedEdges)
+	}
+	if !reflect.DeepEqual(diff.NewCycles, [][]string{{"app/a", "app/b"}}) || !reflect.DeepEqual(diff.FixedCycles, [][]string{{"app/b", "app/old"}}) {
+		t.Fatalf("unexpected cycle changes: new=%v fixed=%v", diff.NewCycles, diff.FixedCycles)
+	}
+	if len(diff.MetricDeltas) != 1 || diff.MetricDeltas[0].Module != "app/a" || diff.MetricDeltas[0].FanIn != 1 || diff.MetricDeltas[0].Instability != 0.5 {
+		t.Fatalf("unexpected metric deltas: %+v", diff.MetricDeltas)
+	}
+
+	legacy := Snapshot{Timestamp: base, ModuleCount: 4}
+	if _, err := DiffSnapshots(legacy, to); err == nil || !strings.Contains(err.Error(), "no graph detail") {
+		t.Fatalf("expected error for snapshot without graph detail, got %v", err)
+	}
+}
+
+func TestFindSnapshot(t *testing.T) {
+	base := time.Date(2026, 3, 1, 9, 0, 0, 0, time.UTC)
+	snapshots := []Snapshot{
+		{Timestamp: base, CommitHash: "abc1234"},
+		{Timestamp: base.Add(24 * time.Hour), CommitHash: "def5678"},
+		{Timestamp: base.Add(26 * time.Hour), CommitHash: "def5678"},
+	}
+
+	cases := []struct {
+		ref  string
+		want time.Time
+	}{
+		{ref: "latest", want: base.Add(26 * time.Hour)},
+		{ref: "previous", want: base.Add(24 * time.Hour)},
+		{ref: "ABC1", want: base},
+		{ref: "def5678", want: base.Add(26 * time.Hour)},
+		{ref: "2026-03-01", want: base},
+		{ref: base.Add(25 * time.Hour).Format(time.RFC3339), want: base.Add(24 * time.Hour)},
+	}
+	for _, tc := range cases {
+		got, err := FindSnapshot(snapshots, tc.ref)
+		if err != nil {
+			t.Fatalf("find %q: %v", tc.ref, err)
+		}
+		if !got.Timestamp.Equal(tc.want) {
+			t.Fatalf("find %q: got %s, want %s", tc.ref, got.Timestamp, tc.want)
+		}
+	}
+	for _, ref := range []string{"abc", "999999", "2026-02-01"} {
+		if _, err := FindSnapshot(snapshots, ref); err == nil {
+			t.Fatalf("expected no match for %q", ref)
+		}
+	}
+}
+
+func TestStore_PersistsSnapshotGraphDetail(t *testing.T) {
+	store, err := Open(filepath.Join(t.TempDir(), "history.db"))
+	if err != nil {
+		t.Fatalf("open store: %v", err)
+	}
+	defer store.Close()
+
+	snapshot := Snapshot{
+		Timestamp:   time.Date(2026, 3, 1, 9, 0, 0, 0, time.UTC),
+		ModuleCount: 2,
+		Modules:     []SnapshotModule{{Name: "app/a", FanOut: 1, Instability: 1}, {Name: "app/b", FanIn: 1}},
+		Edges:       []SnapshotEdge{{From: "app/a", To: "app/b", ImportCount: 2, UsageCount: 5}},
+		Cycles:      [][]string{{"app/a", "app/b"}},
+	}
+	if err := store.SaveSnapshot("default", snapshot); err != nil {
+		t.Fatalf("save snapshot: %v", err)
+	}
+	if err := store.SaveSnapshot("default", Snapshot{Timestamp: snapshot.Timestamp.Add(time.Hour)}); err != nil {
+		t.Fatalf("save empty snapshot: %v", err)
+	}
+
+	got, err := store.LoadSnapshots("default", time.Time{})
+	if err != nil {
+		t.Fatalf("load snapshots: %v", err)
+	}
+	if len(got) != 2 {
+		t.Fatalf("expected 2 snapshots, got %d", len(got))
+	}
+	if !reflect.DeepEqual(got[0].Modules, snapshot.Modules) || !reflect.DeepEqual(got[0].Edges, snapshot.Edges) || !reflect.DeepEqual(got[0].Cycles, snapshot.Cycles) {
+		t.Fatalf("graph detail did not round-trip: %+v", got[0])
+	}
+	if got[1].Modules != nil || got[1].Edges != nil || got[1].Cycles != nil {
+		t.Fatalf("expected nil detail for empty snapshot, got %+v", got[1])
+	}
+}
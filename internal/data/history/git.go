@@ -30,3 +30,21 @@ func runGit(projectRoot string, args ...string) string {
 	}
 	return strings.TrimSpace(stdout.String())
 }
+
+// LastModified returns the committer time of the latest commit touching any
+// of paths, or the zero time outside a git work tree or for untracked files.
+func LastModified(projectRoot string, paths []string) time.Time {
+	if len(paths) == 0 {
+		return time.Time{}
+	}
+	args := append([]string{"log", "-1", "--format=%cI", "--"}, paths...)
+	raw := runGit(projectRoot, args...)
+	if raw == "" {
+		return time.Time{}
+	}
+	modified, err := time.Parse(time.RFC3339, raw)
+	if err != nil {
+		return time.Time{}
+	}
+	return modified.UTC()
+}
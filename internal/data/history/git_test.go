@@ -0,0 +1,43 @@
+package history
+
+import (
+	"os"
+	"os/exec"
+	"path/filepath"
+	"testing"
+	"time"
+)
+
+func TestLastModified(t *testing.T) {
+	if _, err := exec.LookPath("git"); err != nil {
+		t.Skip("git not available")
+	}
+	root := t.TempDir()
+	git := func(args ...string) {
+		t.Helper()
+		cmd := exec.Command("git", append([]string{"-C", root}, args...)...)
+		cmd.Env = append(os.Environ(),
+			"GIT_AUTHOR_NAME=test", "GIT_AUTHOR_EMAIL=test@example.com",
+			"GIT_COMMITTER_NAME=test", "GIT_COMMITTER_EMAIL=test@example.com",
+			"GIT_COMMITTER_DATE=2026-03-01T10:00:00Z",
+		)
+		if out, err := cmd.CombinedOutput(); err != nil {
+			t.Fatalf("git %v: %v\n%s", args, err, out)
+		}
+	}
+	git("init", "-q")
+	tracked := filepath.Join(root, "a.go")
+	if err := os.WriteFile(tracked, []byte("package a\n"), 0o644); err != nil {
+		t.Fatal(err)
+	}
+	git("add", "a.go")
+	git("commit", "-q", "-m", "add a")
+
+	want := time.Date(2026, 3, 1, 10, 0, 0, 0, time.UTC)
+	if got := LastModified(root, []string{tracked}); !got.Equal(want) {
+		t.Fatalf("expected %v, got %v", want, got)
+	}
+	if got := LastModified(root, []string{filepath.Join(root, "missing.go")}); !got.IsZero() {
+		t.Fatalf("expected zero time for untracked file, got %v", got)
+	}
+}
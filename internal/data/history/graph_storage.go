@@ -0,0 +1,320 @@
+package history
+
+import (
+	"circular/internal/engine/graph"
+	"circular/internal/engine/parser"
+	"database/sql"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"strings"
+)
+
+// GraphStorage is the SQLite graph.NodeStorage adapter. It keeps paged module
+// nodes and their import adjacency in the history database so the in-memory
+// graph can stay under its resident budget.
+type GraphStorage struct {
+	store *Store
+}
+
+var _ graph.NodeStorage = (*GraphStorage)(nil)
+
+func NewGraphStorage(store *Store) *GraphStorage {
+	return &GraphStorage{store: store}
+}
+
+// OpenGraphStorage opens (or creates) the history database at path and wraps it
+// as node storage. Closing the returned storage closes the database.
+func OpenGraphStorage(path string) (*GraphStorage, error) {
+	store, err := Open(path)
+	if err != nil {
+		return nil, err
+	}
+	return NewGraphStorage(store), nil
+}
+
+func (s *GraphStorage) SaveNode(projectKey, moduleName string, mod *graph.Module) error {
+	if mod == nil {
+		return fmt.Errorf("module %q must not be nil", moduleName)
+	}
+	files, err := json.Marshal(nonNilStrings(mod.Files))
+	if err != nil {
+		return fmt.Errorf("encode files for %q: %w", moduleName, err)
+	}
+	exports := mod.Exports
+	if exports == nil {
+		exports = map[string]*parser.Definition{}
+	}
+	exportsJSON, err := json.Marshal(exports)
+	if err != nil {
+		return fmt.Errorf("encode exports for %q: %w", moduleName, err)
+	}
+
+	s.store.mu.Lock()
+	defer s.store.mu.Unlock()
+
+	return s.store.withRetry("save graph node", func() error {
+		_, err := s.store.db.Exec(`
+INSERT INTO graph_nodes (project_key, module_name, root_path, max_complexity, files, exports)
+VALUES (?, ?, ?, ?, ?, ?)
+ON CONFLICT(project_key, module_name) DO UPDATE SET
+  root_path=excluded.root_path,
+  max_complexity=excluded.max_complexity,
+  files=excluded.files,
+  exports=excluded.exports
+`, normalizeProjectKey(projectKey), moduleName, mod.RootPath, mod.MaxComplexity, string(files), string(exportsJSON))
+		return err
+	})
+}
+
+func (s *GraphStorage) LoadNode(projectKey, moduleName string) (*graph.Module, error) {
+	s.store.mu.Lock()
+	defer s.store.mu.Unlock()
+
+	var (
+		mod         graph.Module
+		filesJSON   string
+		exportsJSON string
+	)
+	err := s.store.withRetry("load graph node", func() error {
+		return s.store.db.QueryRow(`
+SELECT root_path, max_complexity, files, exports
+FROM graph_nodes
+WHERE project_key = ? AND module_name = ?
+`, normalizeProjectKey(projectKey), moduleName).Scan(&mod.RootPath, &mod.MaxComplexity, &filesJSON, &exportsJSON)
+	})
+	if errors.Is(err, sql.ErrNoRows) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	mod.Name = moduleName
+	if err := json.Unmarshal([]byte(filesJSON), &mod.Files); err != nil {
+		return nil, fmt.Errorf("decode files for %q: %w", moduleName, err)
+	}
+	if err := json.Unmarshal([]byte(exportsJSON), &mod.Exports); err != nil {
+		return nil, fmt.Errorf("decode exports for %q: %w", moduleName, err)
+	}
+	if mod.Exports == nil {
+		mod.Exports = make(map[string]*parser.Definition)
+	}
+	return &mod, nil
+}
+
+func (s *GraphStorage) SaveAdjacency(projectKey, moduleName string, imports []*graph.ImportEdge, importedBy []string) error {
+	projectKey = normalizeProjectKey(projectKey)
+
+	s.store.mu.Lock()
+	defer s.store.mu.Unlock()
+
+	return s.store.withRetry("save graph adjacency", func() error {
+		tx, err := s.store.db.Begin()
+		if err != nil {
+			return err
+		}
+		if err := replaceAdjacency(tx, projectKey, moduleName, imports, importedBy); err != nil {
+			_ = tx.Rollback()
+			return err
+		}
+		return tx.Commit()
+	})
+}
+
+func replaceAdjacency(tx *sql.Tx, projectKey, moduleName string, imports []*graph.ImportEdge, importedBy []string) error {
+	if err := deleteAdjacency(tx, projectKey, moduleName); err != nil {
+		return err
+	}
+	for _, edge := range imports {
+		if edge == nil {
+			continue
+		}
+		files, err := json.Marshal(nonNilStrings(edge.Files))
+		if err != nil {
+			return fmt.Errorf("encode edge files for %q -> %q: %w", moduleName, edge.To, err)
+		}
+		symbols, err := json.Marshal(nonNilStrings(edge.Symbols))
+		if err != nil {
+			return fmt.Errorf("encode edge symbols for %q -> %q: %w", moduleName, edge.To, err)
+		}
+		if _, err := tx.Exec(`
+INSERT OR REPLACE INTO graph_edges (project_key, from_module, to_module, imported_by, location_file, line, column_no, import_count, usage_count, files, symbols)
+VALUES (?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?)
+`, projectKey, moduleName, edge.To, edge.ImportedBy, edge.Location.File, edge.Location.Line, edge.Location.Column,
+			edge.ImportCount, edge.UsageCount, string(files), string(symbols)); err != nil {
+			return err
+		}
+	}
+	for _, importer := range importedBy {
+		if _, err := tx.Exec(`
+INSERT OR IGNORE INTO graph_importers (project_key, module_name, importer) VALUES (?, ?, ?)
+`, projectKey, moduleName, importer); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func deleteAdjacency(tx *sql.Tx, projectKey, moduleName string) error {
+	if _, err := tx.Exec(`DELETE FROM graph_edges WHERE project_key = ? AND from_module = ?`, projectKey, moduleName); err != nil {
+		return err
+	}
+	_, err := tx.Exec(`DELETE FROM graph_importers WHERE project_key = ? AND module_name = ?`, projectKey, moduleName)
+	return err
+}
+
+func (s *GraphStorage) LoadAdjacency(projectKey, moduleName string) ([]*graph.ImportEdge, []string, error) {
+	projectKey = normalizeProjectKey(projectKey)
+
+	s.store.mu.Lock()
+	defer s.store.mu.Unlock()
+
+	edges := make([]*graph.ImportEdge, 0)
+	err := s.store.withRetry("load graph edges", func() error {
+		edges = edges[:0]
+		rows, err := s.store.db.Query(`
+SELECT to_module, imported_by, location_file, line, column_no, import_count, usage_count, files, symbols
+FROM graph_edges
+WHERE project_key = ? AND from_module = ?
+ORDER BY to_module
+`, projectKey, moduleName)
+		if err != nil {
+			return err
+		}
+		defer rows.Close()
+		for rows.Next() {
+			var filesJSON, symbolsJSON string
+			edge := &graph.ImportEdge{From: moduleName}
+			if err := rows.Scan(&edge.To, &edge.ImportedBy, &edge.Location.File, &edge.Location.Line, &edge.Location.Column,
+				&edge.ImportCount, &edge.UsageCount, &filesJSON, &symbolsJSON); err != nil {
+				return fmt.Errorf("scan graph edge row: %w", err)
+			}
+			if err := json.Unmarshal([]byte(filesJSON), &edge.Files); err != nil {
+				return fmt.Errorf("decode edge files for %q -> %q: %w", moduleName, edge.To, err)
+			}
+			if err := json.Unmarshal([]byte(symbolsJSON), &edge.Symbols); err != nil {
+				return fmt.Errorf("decode edge symbols for %q -> %q: %w", moduleName, edge.To, err)
+			}
+			edges = append(edges, edge)
+		}
+		return rows.Err()
+	})
+	if err != nil {
+		return nil, nil, err
+	}
+
+	importers, err := s.queryStrings("load graph importers", `
+SELECT importer FROM graph_importers
+WHERE project_key = ? AND module_name = ?
+ORDER BY importer
+`, projectKey, moduleName)
+	if err != nil {
+		return nil, nil, err
+	}
+	return edges, importers, nil
+}
+
+func (s *GraphStorage) QueryEdges(projectKey, from string) ([]string, error) {
+	s.store.mu.Lock()
+	defer s.store.mu.Unlock()
+
+	return s.queryStrings("query graph edges", `
+SELECT to_module FROM graph_edges
+WHERE project_key = ? AND from_module = ?
+ORDER BY to_module
+`, normalizeProjectKey(projectKey), from)
+}
+
+func (s *GraphStorage) DeleteNode(projectKey, moduleName string) error {
+	projectKey = normalizeProjectKey(projectKey)
+
+	s.store.mu.Lock()
+	defer s.store.mu.Unlock()
+
+	return s.store.withRetry("delete graph node", func() error {
+		tx, err := s.store.db.Begin()
+		if err != nil {
+			return err
+		}
+		if _, err := tx.Exec(`DELETE FROM graph_nodes WHERE project_key = ? AND module_name = ?`, projectKey, moduleName); err != nil {
+			_ = tx.Rollback()
+			return err
+		}
+		if err := deleteAdjacency(tx, projectKey, moduleName); err != nil {
+			_ = tx.Rollback()
+			return err
+		}
+		return tx.Commit()
+	})
+}
+
+// Reset drops every stored node and edge for the project. Paged state does not
+// survive a restart, so callers clear leftovers before paging starts.
+func (s *GraphStorage) Reset(projectKey string) error {
+	projectKey = normalizeProjectKey(projectKey)
+
+	s.store.mu.Lock()
+	defer s.store.mu.Unlock()
+
+	return s.store.withRetry("reset graph storage", func() error {
+		tx, err := s.store.db.Begin()
+		if err != nil {
+			return err
+		}
+		for _, table := range []string{"graph_nodes", "graph_edges", "graph_importers"} {
+			if _, err := tx.Exec(`DELETE FROM `+table+` WHERE project_key = ?`, projectKey); err != nil {
+				_ = tx.Rollback()
+				return err
+			}
+		}
+		return tx.Commit()
+	})
+}
+
+func (s *GraphStorage) Close() error {
+	if s == nil || s.store == nil {
+		return nil
+	}
+	return s.store.Close()
+}
+
+// queryStrings runs a single-column query; the caller holds store.mu.
+func (s *GraphStorage) queryStrings(op, query string, args ...any) ([]string, error) {
+	out := make([]string, 0)
+	err := s.store.withRetry(op, func() error {
+		out = out[:0]
+		rows, err := s.store.db.Query(query, args...)
+		if err != nil {
+			return err
+		}
+		defer rows.Close()
+		for rows.Next() {
+			var value string
+			if err := rows.Scan(&value); err != nil {
+				return err
+			}
+			out = append(out, value)
+		}
+		return rows.Err()
+	})
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func normalizeProjectKey(projectKey string) string {
+	projectKey = strings.TrimSpace(projectKey)
+	if projectKey == "" {
+		return "default"
+	}
+	return projectKey
+}
+
+func nonNilStrings(values []string) []string {
+	if values == nil {
+		return []string{}
+	}
+	return values
+}
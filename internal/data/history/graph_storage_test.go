@@ -0,0 +1,115 @@
+package history
+
+import (
+	"circular/internal/engine/graph"
+	"circular/internal/engine/parser"
+	"path/filepath"
+	"reflect"
+	"testing"
+)
+
+func TestGraphStorage_RoundTrip(t *testing.T) {
+	storage, err := OpenGraphStorage(filepath.Join(t.TempDir(), "history.db"))
+	if err != nil {
+		t.Fatalf("open graph storage: %v", err)
+	}
+	defer storage.Close()
+
+	if mod, err := storage.LoadNode("project-a", "app/api"); err != nil || mod != nil {
+		t.Fatalf("expected missing node, got %+v (%v)", mod, err)
+	}
+
+	mod := &graph.Module{
+		Name:          "app/api",
+		Files:         []string{"api/handler.go", "api/routes.go"},
+		MaxComplexity: 7,
+		RootPath:      "api",
+		Exports: map[string]*parser.Definition{
+			"Serve": {Name: "Serve", Kind: parser.KindFunction, Exported: true, Location: parser.Location{File: "api/handler.go", Line: 6}, LOC: 10},
+		},
+	}
+	if err := storage.SaveNode("project-a", mod.Name, mod); err != nil {
+		t.Fatalf("save node: %v", err)
+	}
+	edges := []*graph.ImportEdge{
+		{
+			From: "app/api", To: "app/store", ImportedBy: "api/handler.go", Location: parser.Location{File: "api/handler.go", Line: 3, Column: 2},
+			Files: []string{"api/handler.go", "api/routes.go"}, ImportCount: 2, UsageCount: 5, Symbols: []string{"Get", "Put"},
+		},
+		{
+			From: "app/api", To: "fmt", ImportedBy: "api/routes.go", Location: parser.Location{File: "api/routes.go", Line: 4},
+			Files: []string{"api/routes.go"}, ImportCount: 1, Symbols: []string{},
+		},
+	}
+	if err := storage.SaveAdjacency("project-a", mod.Name, edges, []string{"app/cmd"}); err != nil {
+		t.Fatalf("save adjacency: %v", err)
+	}
+
+	loaded, err := storage.LoadNode("project-a", "app/api")
+	if err != nil {
+		t.Fatalf("load node: %v", err)
+	}
+	if !reflect.DeepEqual(loaded, mod) {
+		t.Fatalf("node mismatch:\n got %+v\nwant %+v", loaded, mod)
+	}
+
+	gotEdges, importers, err := storage.LoadAdjacency("project-a", "app/api")
+	if err != nil {
+		t.Fatalf("load adjacency: %v", err)
+	}
+	if !reflect.DeepEqual(gotEdges, edges) {
+		t.Fatalf("edge mismatch: %+v", gotEdges)
+	}
+	if !reflect.DeepEqual(importers, []string{"app/cmd"}) {
+		t.Fatalf("unexpected importers %v", importers)
+	}
+	targets, err := storage.QueryEdges("project-a", "app/api")
+	if err != nil || !reflect.DeepEqual(targets, []string{"app/store", "fmt"}) {
+		t.Fatalf("unexpected edge targets %v (%v)", targets, err)
+	}
+
+	// Other projects do not see the node.
+	if other, err := storage.LoadNode("project-b", "app/api"); err != nil || other != nil {
+		t.Fatalf("expected project isolation, got %+v (%v)", other, err)
+	}
+
+	if err := storage.DeleteNode("project-a", "app/api"); err != nil {
+		t.Fatalf("delete node: %v", err)
+	}
+	if gone, _ := storage.LoadNode("project-a", "app/api"); gone != nil {
+		t.Fatalf("expected node to be deleted, got %+v", gone)
+	}
+	if targets, _ := storage.QueryEdges("project-a", "app/api"); len(targets) != 0 {
+		t.Fatalf("expected edges to be deleted, got %v", targets)
+	}
+}
+
+func TestGraphStorage_BacksGraphPaging(t *testing.T) {
+	storage, err := OpenGraphStorage(filepath.Join(t.TempDir(), "history.db"))
+	if err != nil {
+		t.Fatalf("open graph storage: %v", err)
+	}
+	defer storage.Close()
+
+	g := graph.NewGraph()
+	if err := g.SetNodeStorage(storage, "default", 1); err != nil {
+		t.Fatalf("SetNodeStorage: %v", err)
+	}
+	g.AddFile(&parser.File{Path: "a.go", Module: "A", Imports: []parser.Import{{Module: "B"}}})
+	g.AddFile(&parser.File{Path: "b.go", Module: "B", Imports: []parser.Import{{Module: "A"}}})
+	g.AddFile(&parser.File{Path: "c.go", Module: "C", Imports: []parser.Import{{Module: "A"}}})
+
+	if stats := g.PagingStats(); stats.PagedModules != 2 || stats.LastError != nil {
+		t.Fatalf("expected two paged modules, got %+v", stats)
+	}
+	if cycles := g.DetectCycles(); len(cycles) != 1 {
+		t.Fatalf("expected one cycle through paged modules, got %v", cycles)
+	}
+
+	if err := storage.Reset("default"); err != nil {
+		t.Fatalf("reset: %v", err)
+	}
+	if targets, _ := storage.QueryEdges("default", "A"); len(targets) != 0 {
+		t.Fatalf("expected reset to drop edges, got %v", targets)
+	}
+}
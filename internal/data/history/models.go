@@ -2,7 +2,7 @@ package history
 
 import "time"
 
-const SchemaVersion = 3
+const SchemaVersion = 7
 
 type Snapshot struct {
 	SchemaVersion     int       `json:"schema_version"`
@@ -21,6 +21,33 @@ type Snapshot struct {
 	AvgFanOut         float64   `json:"avg_fan_out"`
 	MaxFanIn          int       `json:"max_fan_in"`
 	MaxFanOut         int       `json:"max_fan_out"`
+	// Graph detail captured since schema 6; older snapshots leave these empty.
+	Modules []SnapshotModule `json:"modules,omitempty"`
+	Edges   []SnapshotEdge   `json:"edges,omitempty"`
+	Cycles  [][]string       `json:"cycles,omitempty"`
+	// CycleFingerprints identify Cycles across snapshots (schema 7); SaveSnapshot
+	// derives them from Cycles.
+	CycleFingerprints []string `json:"cycle_fingerprints,omitempty"`
+}
+
+// SnapshotModule is the per-module metric state recorded with a snapshot.
+type SnapshotModule struct {
+	Name         string  `json:"name"`
+	Files        int     `json:"files"`
+	FanIn        int     `json:"fan_in"`
+	FanOut       int     `json:"fan_out"`
+	Depth        int     `json:"depth"`
+	Instability  float64 `json:"instability"`
+	Abstractness float64 `json:"abstractness"`
+	Distance     float64 `json:"distance"`
+}
+
+// SnapshotEdge is one module import edge recorded with a snapshot.
+type SnapshotEdge struct {
+	From        string `json:"from"`
+	To          string `json:"to"`
+	ImportCount int    `json:"import_count"`
+	UsageCount  int    `json:"usage_count"`
 }
 
 type TrendPoint struct {
@@ -59,4 +86,7 @@ type TrendReport struct {
 	Window        string       `json:"window"`
 	ScanCount     int          `json:"scan_count"`
 	Points        []TrendPoint `json:"points"`
+	// Cycles carries the provenance of the cycles in the latest snapshot when
+	// the store tracks it.
+	Cycles []CycleProvenance `json:"cycles,omitempty"`
 }
@@ -0,0 +1,68 @@
+package history
+
+import (
+	"strings"
+	"time"
+)
+
+// CycleProvenance records when a cycle, identified by its fingerprint, was
+// first and last captured in a snapshot of a project.
+type CycleProvenance struct {
+	Fingerprint     string    `json:"fingerprint"`
+	Modules         []string  `json:"modules"`
+	FirstSeen       time.Time `json:"first_seen"`
+	FirstCommit     string    `json:"first_commit,omitempty"`
+	FirstCommitTime time.Time `json:"first_commit_time,omitempty"`
+	LastSeen        time.Time `json:"last_seen"`
+	LastCommit      string    `json:"last_commit,omitempty"`
+}
+
+// Introduced is the commit time of the first snapshot containing the cycle,
+// or that snapshot's scan time outside git.
+func (p CycleProvenance) Introduced() time.Time {
+	if !p.FirstCommitTime.IsZero() {
+		return p.FirstCommitTime
+	}
+	return p.FirstSeen
+}
+
+// Age is the time from Introduced to the last snapshot containing the cycle.
+func (p CycleProvenance) Age() time.Duration {
+	if p.LastSeen.Before(p.Introduced()) {
+		return 0
+	}
+	return p.LastSeen.Sub(p.Introduced())
+}
+
+// CanonicalCycle rotates a cycle to start at its smallest module name, so the
+// same cycle found from different start modules compares equal.
+func CanonicalCycle(cycle []string) []string {
+	if len(cycle) == 0 {
+		return nil
+	}
+	start := 0
+	for i, module := range cycle {
+		if module < cycle[start] {
+			start = i
+		}
+	}
+	return append(append([]string(nil), cycle[start:]...), cycle[:start]...)
+}
+
+// CycleFingerprint is the stable identity of a cycle across scans: its
+// canonical rotation joined by " -> ".
+func CycleFingerprint(cycle []string) string {
+	return strings.Join(CanonicalCycle(cycle), " -> ")
+}
+
+// CycleFingerprints fingerprints each non-empty cycle, in order.
+func CycleFingerprints(cycles [][]string) []string {
+	out := make([]string, 0, len(cycles))
+	for _, cycle := range cycles {
+		if len(cycle) == 0 {
+			continue
+		}
+		out = append(out, CycleFingerprint(cycle))
+	}
+	return out
+}
@@ -0,0 +1,90 @@
+package history
+
+import (
+	"path/filepath"
+	"reflect"
+	"testing"
+	"time"
+)
+
+func TestCycleFingerprint_IgnoresRotation(t *testing.T) {
+	a := CycleFingerprint([]string{"app/c", "app/a", "app/b"})
+	b := CycleFingerprint([]string{"app/a", "app/b", "app/c"})
+	if a != b || a != "app/a -> app/b -> app/c" {
+		t.Fatalf("expected rotation-independent fingerprint, got %q and %q", a, b)
+	}
+	if CycleFingerprint([]string{"app/a", "app/c", "app/b"}) == a {
+		t.Fatal("expected reversed cycle to have a different fingerprint")
+	}
+}
+
+func TestCycleProvenance_IntroducedAndAge(t *testing.T) {
+	firstSeen := time.Date(2026, 5, 10, 0, 0, 0, 0, time.UTC)
+	committed := time.Date(2026, 5, 2, 0, 0, 0, 0, time.UTC)
+	p := CycleProvenance{FirstSeen: firstSeen, FirstCommitTime: committed, LastSeen: committed.Add(41 * 24 * time.Hour)}
+	if !p.Introduced().Equal(committed) {
+		t.Fatalf("expected commit time as introduction, got %v", p.Introduced())
+	}
+	if p.Age() != 41*24*time.Hour {
+		t.Fatalf("unexpected age: %v", p.Age())
+	}
+
+	p.FirstCommitTime = time.Time{}
+	if !p.Introduced().Equal(firstSeen) {
+		t.Fatalf("expected first scan as introduction without git, got %v", p.Introduced())
+	}
+}
+
+func TestStore_CycleProvenance(t *testing.T) {
+	store, err := Open(filepath.Join(t.TempDir(), "history.db"))
+	if err != nil {
+		t.Fatalf("open store: %v", err)
+	}
+	defer store.Close()
+
+	base := time.Date(2026, 5, 2, 9, 0, 0, 0, time.UTC)
+	snapshots := []Snapshot{
+		{Timestamp: base, CommitHash: "abc123", CommitTimestamp: base.Add(-time.Hour), Cycles: [][]string{{"app/b", "app/a"}}},
+		{Timestamp: base.Add(24 * time.Hour), CommitHash: "def456", Cycles: [][]string{{"app/a", "app/b"}, {"app/c", "app/d"}}},
+		{Timestamp: base.Add(48 * time.Hour), CommitHash: "fed789", Cycles: [][]string{{"app/c", "app/d"}}},
+	}
+	for _, snapshot := range snapshots {
+		if err := store.SaveSnapshot("default", snapshot); err != nil {
+			t.Fatalf("save snapshot: %v", err)
+		}
+	}
+
+	provenance, err := store.LoadCycleProvenance("default")
+	if err != nil {
+		t.Fatalf("load cycle provenance: %v", err)
+	}
+	if len(provenance) != 2 {
+		t.Fatalf("expected two fingerprints, got %+v", provenance)
+	}
+	ab := provenance[0]
+	if ab.Fingerprint != "app/a -> app/b" || !reflect.DeepEqual(ab.Modules, []string{"app/a", "app/b"}) {
+		t.Fatalf("unexpected first fingerprint: %+v", ab)
+	}
+	if ab.FirstCommit != "abc123" || !ab.FirstCommitTime.Equal(base.Add(-time.Hour)) || !ab.FirstSeen.Equal(base) {
+		t.Fatalf("unexpected first sighting: %+v", ab)
+	}
+	if ab.LastCommit != "def456" || !ab.LastSeen.Equal(base.Add(24*time.Hour)) {
+		t.Fatalf("unexpected last sighting: %+v", ab)
+	}
+	if cd := provenance[1]; cd.FirstCommit != "def456" || cd.LastCommit != "fed789" {
+		t.Fatalf("unexpected second fingerprint: %+v", cd)
+	}
+
+	loaded, err := store.LoadSnapshots("default", time.Time{})
+	if err != nil {
+		t.Fatalf("load snapshots: %v", err)
+	}
+	if !reflect.DeepEqual(loaded[1].CycleFingerprints, []string{"app/a -> app/b", "app/c -> app/d"}) {
+		t.Fatalf("unexpected stored fingerprints: %v", loaded[1].CycleFingerprints)
+	}
+
+	other, err := store.LoadCycleProvenance("other")
+	if err != nil || len(other) != 0 {
+		t.Fatalf("expected project isolation, got %+v (%v)", other, err)
+	}
+}
@@ -104,6 +104,70 @@ CREATE TABLE IF NOT EXISTS symbols (
 CREATE INDEX IF NOT EXISTS idx_symbols_project_canonical ON symbols(project_key, canonical_name);
 CREATE INDEX IF NOT EXISTS idx_symbols_project_service_key ON symbols(project_key, service_key);
 CREATE INDEX IF NOT EXISTS idx_symbols_project_file ON symbols(project_key, file_path);
+`,
+	},
+	{
+		version: 4,
+		sql: `
+CREATE TABLE IF NOT EXISTS graph_nodes (
+  project_key TEXT NOT NULL,
+  module_name TEXT NOT NULL,
+  root_path TEXT NOT NULL DEFAULT '',
+  max_complexity INTEGER NOT NULL DEFAULT 0,
+  files TEXT NOT NULL DEFAULT '[]',
+  exports TEXT NOT NULL DEFAULT '{}',
+  PRIMARY KEY (project_key, module_name)
+);
+CREATE TABLE IF NOT EXISTS graph_edges (
+  project_key TEXT NOT NULL,
+  from_module TEXT NOT NULL,
+  to_module TEXT NOT NULL,
+  imported_by TEXT NOT NULL DEFAULT '',
+  location_file TEXT NOT NULL DEFAULT '',
+  line INTEGER NOT NULL DEFAULT 0,
+  column_no INTEGER NOT NULL DEFAULT 0,
+  PRIMARY KEY (project_key, from_module, to_module)
+);
+CREATE TABLE IF NOT EXISTS graph_importers (
+  project_key TEXT NOT NULL,
+  module_name TEXT NOT NULL,
+  importer TEXT NOT NULL,
+  PRIMARY KEY (project_key, module_name, importer)
+);
+`,
+	},
+	{
+		version: 5,
+		sql: `
+ALTER TABLE graph_edges ADD COLUMN import_count INTEGER NOT NULL DEFAULT 0;
+ALTER TABLE graph_edges ADD COLUMN usage_count INTEGER NOT NULL DEFAULT 0;
+ALTER TABLE graph_edges ADD COLUMN files TEXT NOT NULL DEFAULT '[]';
+ALTER TABLE graph_edges ADD COLUMN symbols TEXT NOT NULL DEFAULT '[]';
+`,
+	},
+	{
+		version: 6,
+		sql: `
+ALTER TABLE snapshots ADD COLUMN modules TEXT NOT NULL DEFAULT '[]';
+ALTER TABLE snapshots ADD COLUMN edges TEXT NOT NULL DEFAULT '[]';
+ALTER TABLE snapshots ADD COLUMN cycles TEXT NOT NULL DEFAULT '[]';
+`,
+	},
+	{
+		version: 7,
+		sql: `
+ALTER TABLE snapshots ADD COLUMN cycle_fingerprints TEXT NOT NULL DEFAULT '[]';
+CREATE TABLE IF NOT EXISTS cycle_provenance (
+  project_key TEXT NOT NULL,
+  fingerprint TEXT NOT NULL,
+  modules TEXT NOT NULL DEFAULT '[]',
+  first_seen_ts_utc TEXT NOT NULL,
+  first_commit_hash TEXT NOT NULL DEFAULT '',
+  first_commit_ts_utc TEXT NOT NULL DEFAULT '',
+  last_seen_ts_utc TEXT NOT NULL,
+  last_commit_hash TEXT NOT NULL DEFAULT '',
+  PRIMARY KEY (project_key, fingerprint)
+);
 `,
 	},
 }
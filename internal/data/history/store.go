@@ -2,6 +2,7 @@ package history
 
 import (
 	"database/sql"
+	"encoding/json"
 	"errors"
 	"fmt"
 	"os"
@@ -103,13 +104,33 @@ func (s *Store) SaveSnapshot(projectKey string, snapshot Snapshot) error {
 	if !snapshot.CommitTimestamp.IsZero() {
 		commitTS = snapshot.CommitTimestamp.UTC().Format(time.RFC3339Nano)
 	}
+	modules, err := marshalDetail(snapshot.Modules)
+	if err != nil {
+		return fmt.Errorf("encode snapshot modules: %w", err)
+	}
+	edges, err := marshalDetail(snapshot.Edges)
+	if err != nil {
+		return fmt.Errorf("encode snapshot edges: %w", err)
+	}
+	cycles, err := marshalDetail(snapshot.Cycles)
+	if err != nil {
+		return fmt.Errorf("encode snapshot cycles: %w", err)
+	}
+	if len(snapshot.CycleFingerprints) == 0 {
+		snapshot.CycleFingerprints = CycleFingerprints(snapshot.Cycles)
+	}
+	fingerprints, err := marshalDetail(snapshot.CycleFingerprints)
+	if err != nil {
+		return fmt.Errorf("encode snapshot cycle fingerprints: %w", err)
+	}
+	seenAt := snapshot.Timestamp.UTC().Format(time.RFC3339Nano)
 
 	query := `
 INSERT INTO snapshots (
   project_key, schema_version, ts_utc, commit_hash, commit_ts_utc, module_count, file_count,
   cycle_count, unresolved_count, unused_import_count, violation_count, hotspot_count,
-  avg_fan_in, avg_fan_out, max_fan_in, max_fan_out
-) VALUES (?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?)
+  avg_fan_in, avg_fan_out, max_fan_in, max_fan_out, modules, edges, cycles, cycle_fingerprints
+) VALUES (?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?)
 ON CONFLICT(project_key, ts_utc, commit_hash) DO UPDATE SET
   schema_version=excluded.schema_version,
   commit_ts_utc=excluded.commit_ts_utc,
@@ -123,14 +144,22 @@ ON CONFLICT(project_key, ts_utc, commit_hash) DO UPDATE SET
   avg_fan_in=excluded.avg_fan_in,
   avg_fan_out=excluded.avg_fan_out,
   max_fan_in=excluded.max_fan_in,
-  max_fan_out=excluded.max_fan_out
+  max_fan_out=excluded.max_fan_out,
+  modules=excluded.modules,
+  edges=excluded.edges,
+  cycles=excluded.cycles,
+  cycle_fingerprints=excluded.cycle_fingerprints
 `
 	return s.withRetry("save snapshot", func() error {
-		_, err := s.db.Exec(
+		tx, err := s.db.Begin()
+		if err != nil {
+			return err
+		}
+		_, err = tx.Exec(
 			query,
 			projectKey,
 			snapshot.SchemaVersion,
-			snapshot.Timestamp.UTC().Format(time.RFC3339Nano),
+			seenAt,
 			snapshot.CommitHash,
 			commitTS,
 			snapshot.ModuleCount,
@@ -144,9 +173,140 @@ ON CONFLICT(project_key, ts_utc, commit_hash) DO UPDATE SET
 			snapshot.AvgFanOut,
 			snapshot.MaxFanIn,
 			snapshot.MaxFanOut,
+			modules,
+			edges,
+			cycles,
+			fingerprints,
 		)
-		return err
+		if err == nil {
+			err = saveCycleProvenance(tx, projectKey, seenAt, commitTS, snapshot)
+		}
+		if err != nil {
+			_ = tx.Rollback()
+			return err
+		}
+		return tx.Commit()
+	})
+}
+
+// saveCycleProvenance records the snapshot as a sighting of each of its
+// cycles, keeping the earliest and latest sighting per fingerprint.
+func saveCycleProvenance(tx *sql.Tx, projectKey, seenAt, commitTS string, snapshot Snapshot) error {
+	const query = `
+INSERT INTO cycle_provenance (
+  project_key, fingerprint, modules, first_seen_ts_utc, first_commit_hash, first_commit_ts_utc,
+  last_seen_ts_utc, last_commit_hash
+) VALUES (?, ?, ?, ?, ?, ?, ?, ?)
+ON CONFLICT(project_key, fingerprint) DO UPDATE SET
+  first_commit_hash=CASE WHEN excluded.first_seen_ts_utc < cycle_provenance.first_seen_ts_utc
+    THEN excluded.first_commit_hash ELSE cycle_provenance.first_commit_hash END,
+  first_commit_ts_utc=CASE WHEN excluded.first_seen_ts_utc < cycle_provenance.first_seen_ts_utc
+    THEN excluded.first_commit_ts_utc ELSE cycle_provenance.first_commit_ts_utc END,
+  first_seen_ts_utc=MIN(excluded.first_seen_ts_utc, cycle_provenance.first_seen_ts_utc),
+  last_commit_hash=CASE WHEN excluded.last_seen_ts_utc >= cycle_provenance.last_seen_ts_utc
+    THEN excluded.last_commit_hash ELSE cycle_provenance.last_commit_hash END,
+  last_seen_ts_utc=MAX(excluded.last_seen_ts_utc, cycle_provenance.last_seen_ts_utc)
+`
+	for _, cycle := range snapshot.Cycles {
+		if len(cycle) == 0 {
+			continue
+		}
+		modules, err := marshalDetail(CanonicalCycle(cycle))
+		if err != nil {
+			return fmt.Errorf("encode cycle modules: %w", err)
+		}
+		if _, err := tx.Exec(
+			query,
+			projectKey,
+			CycleFingerprint(cycle),
+			modules,
+			seenAt,
+			snapshot.CommitHash,
+			commitTS,
+			seenAt,
+			snapshot.CommitHash,
+		); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// LoadCycleProvenance returns the provenance of every cycle ever captured for
+// the project, ordered by fingerprint.
+func (s *Store) LoadCycleProvenance(projectKey string) ([]CycleProvenance, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	projectKey = strings.TrimSpace(projectKey)
+	if projectKey == "" {
+		projectKey = "default"
+	}
+
+	const query = `
+SELECT fingerprint, modules, first_seen_ts_utc, first_commit_hash, first_commit_ts_utc,
+  last_seen_ts_utc, last_commit_hash
+FROM cycle_provenance
+WHERE project_key = ?
+ORDER BY fingerprint ASC
+`
+	var rows *sql.Rows
+	err := s.withRetry("load cycle provenance", func() error {
+		var qErr error
+		rows, qErr = s.db.Query(query, projectKey)
+		return qErr
 	})
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	out := make([]CycleProvenance, 0)
+	for rows.Next() {
+		var (
+			modulesRaw    string
+			firstSeenRaw  string
+			firstCommitTS string
+			lastSeenRaw   string
+			provenance    CycleProvenance
+		)
+		if err := rows.Scan(
+			&provenance.Fingerprint,
+			&modulesRaw,
+			&firstSeenRaw,
+			&provenance.FirstCommit,
+			&firstCommitTS,
+			&lastSeenRaw,
+			&provenance.LastCommit,
+		); err != nil {
+			return nil, fmt.Errorf("scan cycle provenance row: %w", err)
+		}
+		if err := unmarshalDetail(modulesRaw, &provenance.Modules); err != nil {
+			return nil, fmt.Errorf("decode cycle modules: %w", err)
+		}
+		for _, field := range []struct {
+			raw string
+			dst *time.Time
+		}{
+			{firstSeenRaw, &provenance.FirstSeen},
+			{firstCommitTS, &provenance.FirstCommitTime},
+			{lastSeenRaw, &provenance.LastSeen},
+		} {
+			if field.raw == "" {
+				continue
+			}
+			ts, err := time.Parse(time.RFC3339Nano, field.raw)
+			if err != nil {
+				return nil, fmt.Errorf("parse cycle provenance timestamp %q: %w", field.raw, err)
+			}
+			*field.dst = ts.UTC()
+		}
+		out = append(out, provenance)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("iterate cycle provenance rows: %w", err)
+	}
+	return out, nil
 }
 
 func (s *Store) LoadSnapshots(projectKey string, since time.Time) ([]Snapshot, error) {
@@ -162,7 +322,7 @@ func (s *Store) LoadSnapshots(projectKey string, since time.Time) ([]Snapshot, e
 SELECT
   project_key, schema_version, ts_utc, commit_hash, commit_ts_utc, module_count, file_count,
   cycle_count, unresolved_count, unused_import_count, violation_count, hotspot_count,
-  avg_fan_in, avg_fan_out, max_fan_in, max_fan_out
+  avg_fan_in, avg_fan_out, max_fan_in, max_fan_out, modules, edges, cycles, cycle_fingerprints
 FROM snapshots
 `
 	base += " WHERE project_key = ?"
@@ -190,6 +350,10 @@ FROM snapshots
 		var (
 			tsRaw       string
 			commitTSRaw string
+			modulesRaw  string
+			edgesRaw    string
+			cyclesRaw   string
+			fpRaw       string
 			snapshot    Snapshot
 		)
 		if err := rows.Scan(
@@ -209,6 +373,10 @@ FROM snapshots
 			&snapshot.AvgFanOut,
 			&snapshot.MaxFanIn,
 			&snapshot.MaxFanOut,
+			&modulesRaw,
+			&edgesRaw,
+			&cyclesRaw,
+			&fpRaw,
 		); err != nil {
 			return nil, fmt.Errorf("scan snapshot row: %w", err)
 		}
@@ -226,6 +394,18 @@ FROM snapshots
 			}
 			snapshot.CommitTimestamp = commitTS.UTC()
 		}
+		if err := unmarshalDetail(modulesRaw, &snapshot.Modules); err != nil {
+			return nil, fmt.Errorf("decode snapshot modules: %w", err)
+		}
+		if err := unmarshalDetail(edgesRaw, &snapshot.Edges); err != nil {
+			return nil, fmt.Errorf("decode snapshot edges: %w", err)
+		}
+		if err := unmarshalDetail(cyclesRaw, &snapshot.Cycles); err != nil {
+			return nil, fmt.Errorf("decode snapshot cycles: %w", err)
+		}
+		if err := unmarshalDetail(fpRaw, &snapshot.CycleFingerprints); err != nil {
+			return nil, fmt.Errorf("decode snapshot cycle fingerprints: %w", err)
+		}
 
 		snapshots = append(snapshots, snapshot)
 	}
@@ -236,6 +416,26 @@ FROM snapshots
 	return snapshots, nil
 }
 
+// marshalDetail encodes a snapshot detail slice, storing nil as an empty array.
+func marshalDetail[T any](values []T) (string, error) {
+	if values == nil {
+		values = []T{}
+	}
+	raw, err := json.Marshal(values)
+	if err != nil {
+		return "", err
+	}
+	return string(raw), nil
+}
+
+// unmarshalDetail decodes a snapshot detail column, leaving dst nil when empty.
+func unmarshalDetail[T any](raw string, dst *[]T) error {
+	if raw == "" || raw == "[]" {
+		return nil
+	}
+	return json.Unmarshal([]byte(raw), dst)
+}
+
 func (s *Store) withRetry(op string, fn func() error) error {
 	var lastErr error
 	for attempt := 1; attempt <= maxAttempts; attempt++ {
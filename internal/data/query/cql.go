@@ -8,35 +8,45 @@ import (
 )
 
 var (
-	cqlSelectRE       = regexp.MustCompile(`(?i)^\s*SELECT\s+modules(?:\s+WHERE\s+(.+))?\s*$`)
+	cqlSelectRE       = regexp.MustCompile(`(?i)^\s*SELECT\s+modules(?:\s+AT\s+DEPTH\s+([0-9]+))?(?:\s+WHERE\s+(.+))?\s*$`)
 	cqlAndSplitRE     = regexp.MustCompile(`(?i)\s+AND\s+`)
-	cqlNumericCondRE  = regexp.MustCompile(`(?i)^\s*([a-z_]+)\s*(>=|<=|!=|=|>|<)\s*(-?[0-9]+)\s*$`)
+	cqlNumericCondRE  = regexp.MustCompile(`(?i)^\s*([a-z_]+)\s*(>=|<=|!=|=|>|<)\s*(-?[0-9]+(?:\.[0-9]+)?)\s*$`)
 	cqlContainsCondRE = regexp.MustCompile(`(?i)^\s*([a-z_]+)\s+CONTAINS\s+['"]([^'"]+)['"]\s*$`)
 	cqlStringCondRE   = regexp.MustCompile(`(?i)^\s*([a-z_]+)\s*(=|!=)\s*['"]([^'"]+)['"]\s*$`)
 )
 
 type CQLQuery struct {
 	Target     string
+	Depth      int // AT DEPTH n: roll modules up to n path segments; 0 keeps full names
 	Conditions []CQLCondition
 }
 
 type CQLCondition struct {
-	Field  string
-	Op     string
-	IntVal int
-	StrVal string
-	IsInt  bool
-	IsStr  bool
+	Field    string
+	Op       string
+	IntVal   int
+	FloatVal float64 // set for every numeric literal, integer or decimal
+	StrVal   string
+	IsInt    bool
+	IsFloat  bool // decimal literal such as 0.5
+	IsStr    bool
 }
 
 func ParseCQL(raw string) (CQLQuery, error) {
 	matches := cqlSelectRE.FindStringSubmatch(strings.TrimSpace(raw))
 	if len(matches) == 0 {
-		return CQLQuery{}, fmt.Errorf("invalid CQL query: expected SELECT modules [WHERE ...]")
+		return CQLQuery{}, fmt.Errorf("invalid CQL query: expected SELECT modules [AT DEPTH n] [WHERE ...]")
 	}
 
 	query := CQLQuery{Target: "modules"}
-	where := strings.TrimSpace(matches[1])
+	if matches[1] != "" {
+		depth, err := parseInt(matches[1])
+		if err != nil {
+			return CQLQuery{}, fmt.Errorf("invalid depth %q: %w", matches[1], err)
+		}
+		query.Depth = depth
+	}
+	where := strings.TrimSpace(matches[2])
 	if where == "" {
 		return query, nil
 	}
@@ -55,15 +65,28 @@ func ParseCQL(raw string) (CQLQuery, error) {
 
 func parseCQLCondition(raw string) (CQLCondition, error) {
 	if match := cqlNumericCondRE.FindStringSubmatch(raw); len(match) == 4 {
+		if strings.Contains(match[3], ".") {
+			value, err := strconv.ParseFloat(match[3], 64)
+			if err != nil {
+				return CQLCondition{}, fmt.Errorf("invalid numeric value %q: %w", match[3], err)
+			}
+			return CQLCondition{
+				Field:    strings.ToLower(strings.TrimSpace(match[1])),
+				Op:       strings.TrimSpace(match[2]),
+				FloatVal: value,
+				IsFloat:  true,
+			}, nil
+		}
 		value, err := parseInt(match[3])
 		if err != nil {
 			return CQLCondition{}, fmt.Errorf("invalid numeric value %q: %w", match[3], err)
 		}
 		return CQLCondition{
-			Field:  strings.ToLower(strings.TrimSpace(match[1])),
-			Op:     strings.TrimSpace(match[2]),
-			IntVal: value,
-			IsInt:  true,
+			Field:    strings.ToLower(strings.TrimSpace(match[1])),
+			Op:       strings.TrimSpace(match[2]),
+			IntVal:   value,
+			FloatVal: float64(value),
+			IsInt:    true,
 		}, nil
 	}
 
@@ -1,6 +1,8 @@
 package query
 
 import (
+	"circular/internal/engine/graph"
+	"circular/internal/engine/parser"
 	"context"
 	"testing"
 )
@@ -38,3 +40,87 @@ func TestService_ExecuteCQL(t *testing.T) {
 		t.Fatalf("unexpected module set: %+v", rows)
 	}
 }
+
+func TestService_ExecuteCQL_CouplingMetrics(t *testing.T) {
+	svc := NewService(seedGraph(), nil, "default")
+
+	rows, err := svc.ExecuteCQL(context.Background(), `SELECT modules WHERE instability > 0.4 AND instability < 1`, 0)
+	if err != nil {
+		t.Fatalf("execute cql: %v", err)
+	}
+	if len(rows) != 1 || rows[0].Name != "app/b" {
+		t.Fatalf("expected only app/b with I=0.5, got %+v", rows)
+	}
+
+	rows, err = svc.ExecuteCQL(context.Background(), `SELECT modules WHERE distance >= 0.5 AND ca >= 1 AND abstractness = 0`, 0)
+	if err != nil {
+		t.Fatalf("execute cql: %v", err)
+	}
+	if len(rows) != 2 || rows[0].Name != "app/b" || rows[1].Name != "app/c" {
+		t.Fatalf("expected app/b and app/c off the main sequence, got %+v", rows)
+	}
+}
+
+func TestService_ExecuteCQL_DeadCode(t *testing.T) {
+	svc := NewService(seedGraph(), nil, "default")
+
+	rows, err := svc.ExecuteCQL(context.Background(), `SELECT modules WHERE dead_code > 0`, 0)
+	if err != nil {
+		t.Fatalf("execute cql: %v", err)
+	}
+	// app/a is the root entry module, so only app/b's export is reported.
+	if len(rows) != 1 || rows[0].Name != "app/b" || rows[0].DeadCodeCount != 1 {
+		t.Fatalf("expected app/b with one dead export, got %+v", rows)
+	}
+
+	svc.SetDeadCodeOptions(graph.DeadCodeOptions{AllowSymbols: []string{"Exported*"}})
+	rows, err = svc.ExecuteCQL(context.Background(), `SELECT modules WHERE dead_code > 0`, 0)
+	if err != nil {
+		t.Fatalf("execute cql: %v", err)
+	}
+	if len(rows) != 0 {
+		t.Fatalf("expected allowlist to clear dead code, got %+v", rows)
+	}
+}
+
+func TestService_ExecuteCQL_AtDepth(t *testing.T) {
+	query, err := ParseCQL(`SELECT modules AT DEPTH 1 WHERE module_count >= 2`)
+	if err != nil {
+		t.Fatalf("parse cql: %v", err)
+	}
+	if query.Depth != 1 || len(query.Conditions) != 1 {
+		t.Fatalf("unexpected parsed query: %+v", query)
+	}
+
+	svc := NewService(seedGraph(), nil, "default")
+	rows, err := svc.ExecuteCQL(context.Background(), `SELECT modules AT DEPTH 1 WHERE module_count >= 2 AND dependency_count = 0`, 0)
+	if err != nil {
+		t.Fatalf("execute cql: %v", err)
+	}
+	if len(rows) != 1 || rows[0].Name != "app" || rows[0].ModuleCount != 3 || rows[0].FileCount != 3 {
+		t.Fatalf("expected a single rolled-up app row, got %+v", rows)
+	}
+}
+
+func TestService_ExecuteCQL_TransitiveCost(t *testing.T) {
+	g := graph.NewGraph()
+	g.AddFile(&parser.File{Path: "a.go", Module: "app/a", LOC: 10, Imports: []parser.Import{{Module: "app/b"}}})
+	g.AddFile(&parser.File{Path: "b.go", Module: "app/b", LOC: 200, Imports: []parser.Import{{Module: "app/c"}}})
+	g.AddFile(&parser.File{Path: "c.go", Module: "app/c", LOC: 300})
+	svc := NewService(g, nil, "default")
+
+	rows, err := svc.ExecuteCQL(context.Background(), `SELECT modules WHERE transitive_loc > 400 AND transitive_modules >= 1`, 0)
+	if err != nil {
+		t.Fatalf("execute cql: %v", err)
+	}
+	if len(rows) != 1 || rows[0].Name != "app/a" {
+		t.Fatalf("expected app/a to pull in 500 lines, got %+v", rows)
+	}
+	rows, err = svc.ExecuteCQL(context.Background(), `SELECT modules WHERE transitive_files = 1`, 0)
+	if err != nil {
+		t.Fatalf("execute cql: %v", err)
+	}
+	if len(rows) != 1 || rows[0].Name != "app/b" {
+		t.Fatalf("expected app/b to pull in one file, got %+v", rows)
+	}
+}
@@ -8,6 +8,15 @@ type ModuleSummary struct {
 	ExportCount            int
 	DependencyCount        int
 	ReverseDependencyCount int
+	DeadCodeCount          int // exported definitions no other module references
+	ModuleCount            int // modules rolled up into this row; 1 at full depth
+}
+
+// ExternalDependencyFilter narrows the external dependency inventory.
+type ExternalDependencyFilter struct {
+	Name     string // substring of the package name or an import path
+	MaxFiles int    // keep dependencies imported by 1..MaxFiles files; 0 disables
+	Unused   bool   // keep declared direct dependencies no file imports
 }
 
 type ModuleDetails struct {
@@ -16,6 +25,16 @@ type ModuleDetails struct {
 	ExportedSymbols     []string
 	Dependencies        []DependencyEdge
 	ReverseDependencies []string
+	Coupling            CouplingMetrics
+}
+
+// CouplingMetrics are Martin's package-coupling metrics for one module.
+type CouplingMetrics struct {
+	Afferent     int // Ca: modules importing this module
+	Efferent     int // Ce: modules this module imports
+	Instability  float64
+	Abstractness float64
+	Distance     float64
 }
 
 type DependencyEdge struct {
@@ -33,6 +52,48 @@ type TraceResult struct {
 	Depth int
 }
 
+// TraceHop is one import along a TracePath.
+type TraceHop struct {
+	From   string
+	To     string
+	File   string
+	Line   int
+	Column int
+}
+
+type TracePath struct {
+	Path  []string
+	Depth int
+	Hops  []TraceHop
+}
+
+// TracePathsResult lists the import paths between two modules, shortest
+// first.
+type TracePathsResult struct {
+	From  string
+	To    string
+	Paths []TracePath
+}
+
+type SymbolDetails struct {
+	Symbol       string
+	Module       string
+	Name         string
+	File         string
+	Line         int
+	Exported     bool
+	Dependencies []SymbolDependency
+	Dependents   []SymbolDependency
+}
+
+type SymbolDependency struct {
+	From   string
+	To     string
+	File   string
+	Line   int
+	Column int
+}
+
 type TrendSlice struct {
 	Since     string
 	Until     string
@@ -18,6 +18,8 @@ type Service struct {
 	graph      *graph.Graph
 	history    snapshotReader
 	projectKey string
+	deadCode   graph.DeadCodeOptions
+	external   graph.ExternalDependencyOptions
 }
 
 func NewService(g *graph.Graph, h snapshotReader, projectKey string) *Service {
@@ -28,13 +30,39 @@ func NewService(g *graph.Graph, h snapshotReader, projectKey string) *Service {
 	}
 }
 
+// SetDeadCodeOptions configures the exclusions used for the CQL dead_code field.
+func (s *Service) SetDeadCodeOptions(opts graph.DeadCodeOptions) {
+	s.deadCode = opts
+}
+
+// SetExternalDependencyOptions configures the manifests and import mapping
+// used by ExternalDependencies.
+func (s *Service) SetExternalDependencyOptions(opts graph.ExternalDependencyOptions) {
+	s.external = opts
+}
+
 func (s *Service) ListModules(ctx context.Context, filter string, limit int) ([]ModuleSummary, error) {
+	return s.ListModulesAtDepth(ctx, filter, 0, limit)
+}
+
+// ListModulesAtDepth lists modules rolled up to their first depth path
+// segments, so "internal/engine/graph" and "internal/engine/parser" become one
+// "internal/engine" row at depth 2. Depth 0 lists modules as they are.
+func (s *Service) ListModulesAtDepth(ctx context.Context, filter string, depth, limit int) ([]ModuleSummary, error) {
 	if err := ctx.Err(); err != nil {
 		return nil, err
 	}
+	if depth < 0 {
+		return nil, fmt.Errorf("depth must be >= 0")
+	}
+	g, err := s.graph.AtDepth(depth)
+	if err != nil {
+		return nil, err
+	}
 
-	modules := s.graph.Modules()
-	imports := s.graph.GetImports()
+	modules := g.Modules()
+	imports := g.GetImports()
+	moduleCounts := s.rolledModuleCounts(depth)
 
 	reverseCounts := make(map[string]int)
 	for _, edges := range imports {
@@ -58,6 +86,7 @@ func (s *Service) ListModules(ctx context.Context, filter string, limit int) ([]
 			ExportCount:            len(module.Exports),
 			DependencyCount:        len(imports[name]),
 			ReverseDependencyCount: reverseCounts[name],
+			ModuleCount:            moduleCounts[name],
 		})
 	}
 
@@ -71,6 +100,15 @@ func (s *Service) ListModules(ctx context.Context, filter string, limit int) ([]
 	return rows, nil
 }
 
+// rolledModuleCounts counts the modules under each prefix of depth segments.
+func (s *Service) rolledModuleCounts(depth int) map[string]int {
+	counts := make(map[string]int)
+	for name := range s.graph.Modules() {
+		counts[graph.ModulePrefix(name, depth)]++
+	}
+	return counts
+}
+
 func (s *Service) ModuleDetails(ctx context.Context, moduleName string) (ModuleDetails, error) {
 	if err := ctx.Err(); err != nil {
 		return ModuleDetails{}, err
@@ -129,12 +167,20 @@ func (s *Service) ModuleDetails(ctx context.Context, moduleName string) (ModuleD
 	}
 	sort.Strings(symbols)
 
+	metric := s.graph.ComputeModuleMetrics()[moduleName]
 	return ModuleDetails{
 		Name:                moduleName,
 		Files:               files,
 		ExportedSymbols:     symbols,
 		Dependencies:        dependencies,
 		ReverseDependencies: reverse,
+		Coupling: CouplingMetrics{
+			Afferent:     metric.FanIn,
+			Efferent:     metric.FanOut,
+			Instability:  metric.Instability,
+			Abstractness: metric.Abstractness,
+			Distance:     metric.Distance,
+		},
 	}, nil
 }
 
@@ -143,7 +189,25 @@ func (s *Service) DependencyTrace(ctx context.Context, from, to string, maxDepth
 		return TraceResult{}, err
 	}
 
-	path, ok := s.graph.FindImportChain(from, to)
+	fromKey, fromIsSymbol := graph.ParseSymbolKey(from)
+	toKey, toIsSymbol := graph.ParseSymbolKey(to)
+	if fromIsSymbol != toIsSymbol {
+		return TraceResult{}, fmt.Errorf("trace endpoints must both be modules or both be module%ssymbol keys", graph.SymbolSeparator)
+	}
+
+	var (
+		path []string
+		ok   bool
+	)
+	if fromIsSymbol {
+		var chain []graph.SymbolKey
+		chain, ok = s.graph.FindSymbolChain(fromKey, toKey)
+		for _, key := range chain {
+			path = append(path, key.String())
+		}
+	} else {
+		path, ok = s.graph.FindImportChain(from, to)
+	}
 	if !ok {
 		return TraceResult{}, fmt.Errorf("no path from %s to %s", from, to)
 	}
@@ -160,6 +224,93 @@ func (s *Service) DependencyTrace(ctx context.Context, from, to string, maxDepth
 	}, nil
 }
 
+// DependencyPaths returns up to limit loopless import paths between two
+// modules, shortest first, each hop located at its import site. maxDepth > 0
+// drops longer paths.
+func (s *Service) DependencyPaths(ctx context.Context, from, to string, limit, maxDepth int) (TracePathsResult, error) {
+	if err := ctx.Err(); err != nil {
+		return TracePathsResult{}, err
+	}
+	if limit <= 0 {
+		return TracePathsResult{}, fmt.Errorf("path limit must be > 0")
+	}
+	_, fromIsSymbol := graph.ParseSymbolKey(from)
+	_, toIsSymbol := graph.ParseSymbolKey(to)
+	if fromIsSymbol || toIsSymbol {
+		return TracePathsResult{}, fmt.Errorf("path enumeration supports module endpoints only")
+	}
+
+	found := s.graph.FindImportPaths(from, to, limit, maxDepth)
+	if len(found) == 0 {
+		if maxDepth > 0 {
+			return TracePathsResult{}, fmt.Errorf("no path from %s to %s within max_depth %d", from, to, maxDepth)
+		}
+		return TracePathsResult{}, fmt.Errorf("no path from %s to %s", from, to)
+	}
+
+	result := TracePathsResult{From: from, To: to, Paths: make([]TracePath, 0, len(found))}
+	for _, path := range found {
+		hops := make([]TraceHop, 0, len(path.Hops))
+		for _, hop := range path.Hops {
+			hops = append(hops, TraceHop{
+				From:   hop.From,
+				To:     hop.To,
+				File:   hop.File,
+				Line:   hop.Line,
+				Column: hop.Column,
+			})
+		}
+		result.Paths = append(result.Paths, TracePath{
+			Path:  append([]string(nil), path.Modules...),
+			Depth: path.Depth(),
+			Hops:  hops,
+		})
+	}
+	return result, nil
+}
+
+// SymbolDetails returns the symbol-level dependencies and dependents of a
+// module#Symbol definition.
+func (s *Service) SymbolDetails(ctx context.Context, symbol string) (SymbolDetails, error) {
+	if err := ctx.Err(); err != nil {
+		return SymbolDetails{}, err
+	}
+
+	key, ok := graph.ParseSymbolKey(symbol)
+	if !ok {
+		return SymbolDetails{}, fmt.Errorf("symbol must be formatted as <module>%s<symbol>: %s", graph.SymbolSeparator, symbol)
+	}
+	def, ok := s.graph.LookupDefinition(key.Module, key.Name)
+	if !ok {
+		return SymbolDetails{}, fmt.Errorf("symbol not found: %s", symbol)
+	}
+
+	return SymbolDetails{
+		Symbol:       key.String(),
+		Module:       key.Module,
+		Name:         key.Name,
+		File:         def.Location.File,
+		Line:         def.Location.Line,
+		Exported:     def.Exported,
+		Dependencies: toSymbolDependencies(s.graph.SymbolDependencies(key)),
+		Dependents:   toSymbolDependencies(s.graph.SymbolDependents(key)),
+	}, nil
+}
+
+func toSymbolDependencies(edges []graph.SymbolEdge) []SymbolDependency {
+	out := make([]SymbolDependency, 0, len(edges))
+	for _, edge := range edges {
+		out = append(out, SymbolDependency{
+			From:   edge.From.String(),
+			To:     edge.To.String(),
+			File:   edge.File,
+			Line:   edge.Location.Line,
+			Column: edge.Location.Column,
+		})
+	}
+	return out
+}
+
 func (s *Service) TrendSlice(ctx context.Context, since time.Time, limit int) (TrendSlice, error) {
 	if err := ctx.Err(); err != nil {
 		return TrendSlice{}, err
@@ -188,6 +339,75 @@ func (s *Service) TrendSlice(ctx context.Context, since time.Time, limit int) (T
 	return out, nil
 }
 
+// SnapshotDiff compares two stored snapshots resolved with history.FindSnapshot.
+func (s *Service) SnapshotDiff(ctx context.Context, fromRef, toRef string) (history.SnapshotDiff, error) {
+	if err := ctx.Err(); err != nil {
+		return history.SnapshotDiff{}, err
+	}
+	if s.history == nil {
+		return history.SnapshotDiff{}, fmt.Errorf("history store unavailable")
+	}
+
+	snapshots, err := s.history.LoadSnapshots(s.projectKey, time.Time{})
+	if err != nil {
+		return history.SnapshotDiff{}, err
+	}
+	from, err := history.FindSnapshot(snapshots, fromRef)
+	if err != nil {
+		return history.SnapshotDiff{}, fmt.Errorf("resolve snapshot %q: %w", fromRef, err)
+	}
+	to, err := history.FindSnapshot(snapshots, toRef)
+	if err != nil {
+		return history.SnapshotDiff{}, fmt.Errorf("resolve snapshot %q: %w", toRef, err)
+	}
+	return history.DiffSnapshots(from, to)
+}
+
+// ExternalDependencies lists third-party dependency nodes matching filter,
+// sorted by ecosystem then name.
+func (s *Service) ExternalDependencies(ctx context.Context, filter ExternalDependencyFilter, limit int) ([]graph.ExternalDependency, error) {
+	if err := ctx.Err(); err != nil {
+		return nil, err
+	}
+	if filter.MaxFiles < 0 {
+		return nil, fmt.Errorf("max files must be >= 0")
+	}
+	if filter.Unused && filter.MaxFiles > 0 {
+		return nil, fmt.Errorf("unused and max files filters cannot be combined")
+	}
+
+	name := strings.TrimSpace(filter.Name)
+	out := make([]graph.ExternalDependency, 0)
+	for _, dep := range s.graph.ExternalDependencies(s.external) {
+		if name != "" && !externalDependencyMatches(dep, name) {
+			continue
+		}
+		if filter.Unused && !dep.Unused() {
+			continue
+		}
+		if filter.MaxFiles > 0 && (dep.FileCount == 0 || dep.FileCount > filter.MaxFiles) {
+			continue
+		}
+		out = append(out, dep)
+		if limit > 0 && len(out) >= limit {
+			break
+		}
+	}
+	return out, nil
+}
+
+func externalDependencyMatches(dep graph.ExternalDependency, name string) bool {
+	if strings.Contains(dep.Name, name) {
+		return true
+	}
+	for _, path := range dep.ImportPaths {
+		if strings.Contains(path, name) {
+			return true
+		}
+	}
+	return false
+}
+
 // ExecuteCQL evaluates a read-only CQL query over in-memory module/graph state.
 func (s *Service) ExecuteCQL(ctx context.Context, raw string, limit int) ([]ModuleSummary, error) {
 	if err := ctx.Err(); err != nil {
@@ -202,9 +422,18 @@ func (s *Service) ExecuteCQL(ctx context.Context, raw string, limit int) ([]Modu
 		return nil, fmt.Errorf("unsupported CQL target %q", query.Target)
 	}
 
-	imports := s.graph.GetImports()
-	metrics := s.graph.ComputeModuleMetrics()
-	modules := s.graph.Modules()
+	g, err := s.graph.AtDepth(query.Depth)
+	if err != nil {
+		return nil, err
+	}
+	imports := g.GetImports()
+	metrics := g.ComputeModuleMetrics()
+	modules := g.Modules()
+	moduleCounts := s.rolledModuleCounts(query.Depth)
+	deadCounts := make(map[string]int)
+	for module, count := range graph.DeadCodeCounts(s.graph.FindDeadCode(s.deadCode)) {
+		deadCounts[graph.ModulePrefix(module, query.Depth)] += count
+	}
 
 	reverseCounts := make(map[string]int)
 	for _, edges := range imports {
@@ -224,6 +453,8 @@ func (s *Service) ExecuteCQL(ctx context.Context, raw string, limit int) ([]Modu
 			ExportCount:            len(module.Exports),
 			DependencyCount:        len(imports[name]),
 			ReverseDependencyCount: reverseCounts[name],
+			DeadCodeCount:          deadCounts[name],
+			ModuleCount:            moduleCounts[name],
 		}
 		if !matchesCQLConditions(row, metrics[name], query.Conditions) {
 			continue
@@ -266,12 +497,24 @@ func matchesSingleCQLCondition(summary ModuleSummary, metric graph.ModuleMetrics
 		default:
 			return false
 		}
-	case "fan_in":
+	case "fan_in", "ca":
 		return compareCQLInt(metric.FanIn, condition)
-	case "fan_out":
+	case "fan_out", "ce":
 		return compareCQLInt(metric.FanOut, condition)
+	case "instability":
+		return compareCQLFloat(metric.Instability, condition)
+	case "abstractness":
+		return compareCQLFloat(metric.Abstractness, condition)
+	case "distance":
+		return compareCQLFloat(metric.Distance, condition)
 	case "depth":
 		return compareCQLInt(metric.Depth, condition)
+	case "transitive_modules":
+		return compareCQLInt(metric.TransitiveModules, condition)
+	case "transitive_files":
+		return compareCQLInt(metric.TransitiveFiles, condition)
+	case "transitive_loc":
+		return compareCQLInt(metric.TransitiveLOC, condition)
 	case "file_count":
 		return compareCQLInt(summary.FileCount, condition)
 	case "export_count":
@@ -280,12 +523,19 @@ func matchesSingleCQLCondition(summary ModuleSummary, metric graph.ModuleMetrics
 		return compareCQLInt(summary.DependencyCount, condition)
 	case "reverse_dependency_count":
 		return compareCQLInt(summary.ReverseDependencyCount, condition)
+	case "dead_code":
+		return compareCQLInt(summary.DeadCodeCount, condition)
+	case "module_count":
+		return compareCQLInt(summary.ModuleCount, condition)
 	default:
 		return false
 	}
 }
 
 func compareCQLInt(value int, condition CQLCondition) bool {
+	if condition.IsFloat {
+		return compareCQLFloat(float64(value), condition)
+	}
 	if !condition.IsInt {
 		return false
 	}
@@ -306,3 +556,25 @@ func compareCQLInt(value int, condition CQLCondition) bool {
 		return false
 	}
 }
+
+func compareCQLFloat(value float64, condition CQLCondition) bool {
+	if !condition.IsInt && !condition.IsFloat {
+		return false
+	}
+	switch condition.Op {
+	case ">":
+		return value > condition.FloatVal
+	case ">=":
+		return value >= condition.FloatVal
+	case "<":
+		return value < condition.FloatVal
+	case "<=":
+		return value <= condition.FloatVal
+	case "=":
+		return value == condition.FloatVal
+	case "!=":
+		return value != condition.FloatVal
+	default:
+		return false
+	}
+}
@@ -74,6 +74,30 @@ func TestService_ListModules(t *testing.T) {
 	}
 }
 
+func TestService_ListModulesAtDepth(t *testing.T) {
+	g := seedGraph()
+	g.AddFile(&parser.File{
+		Path:    "d.go",
+		Module:  "lib/d",
+		Imports: []parser.Import{{Module: "app/a"}, {Module: "app/c"}},
+	})
+	svc := NewService(g, nil, "default")
+
+	got, err := svc.ListModulesAtDepth(context.Background(), "", 1, 0)
+	if err != nil {
+		t.Fatalf("list modules at depth: %v", err)
+	}
+	if len(got) != 2 || got[0].Name != "app" || got[1].Name != "lib" {
+		t.Fatalf("unexpected rolled-up rows: %+v", got)
+	}
+	if got[0].ModuleCount != 3 || got[0].ReverseDependencyCount != 1 || got[1].DependencyCount != 1 {
+		t.Fatalf("unexpected rolled-up counts: %+v", got)
+	}
+	if _, err := svc.ListModulesAtDepth(context.Background(), "", -1, 0); err == nil {
+		t.Fatal("expected error for negative depth")
+	}
+}
+
 func TestService_ModuleDetails(t *testing.T) {
 	svc := NewService(seedGraph(), nil, "default")
 	details, err := svc.ModuleDetails(context.Background(), "app/b")
@@ -86,6 +110,9 @@ func TestService_ModuleDetails(t *testing.T) {
 	if len(details.ReverseDependencies) != 1 || details.ReverseDependencies[0] != "app/a" {
 		t.Fatalf("unexpected reverse dependencies: %+v", details.ReverseDependencies)
 	}
+	if c := details.Coupling; c.Afferent != 1 || c.Efferent != 1 || c.Instability != 0.5 || c.Distance != 0.5 {
+		t.Fatalf("unexpected coupling metrics: %+v", c)
+	}
 }
 
 func TestService_DependencyTrace(t *testing.T) {
@@ -103,6 +130,39 @@ func TestService_DependencyTrace(t *testing.T) {
 	}
 }
 
+func TestService_SymbolDetailsAndTrace(t *testing.T) {
+	g := seedGraph()
+	g.SetSymbolEdges("a.go", []graph.SymbolEdge{{
+		From:     graph.SymbolKey{Module: "app/a", Name: "ExportedA"},
+		To:       graph.SymbolKey{Module: "app/b", Name: "ExportedB"},
+		File:     "a.go",
+		Location: parser.Location{File: "a.go", Line: 9, Column: 2},
+	}})
+	svc := NewService(g, nil, "default")
+
+	details, err := svc.SymbolDetails(context.Background(), "app/b#ExportedB")
+	if err != nil {
+		t.Fatalf("symbol details: %v", err)
+	}
+	if len(details.Dependents) != 1 || details.Dependents[0].From != "app/a#ExportedA" || details.Dependents[0].Line != 9 {
+		t.Fatalf("unexpected dependents: %+v", details.Dependents)
+	}
+	if _, err := svc.SymbolDetails(context.Background(), "app/b"); err == nil {
+		t.Fatal("expected error for non-symbol key")
+	}
+
+	trace, err := svc.DependencyTrace(context.Background(), "app/a#ExportedA", "app/b#ExportedB", 0)
+	if err != nil {
+		t.Fatalf("symbol trace: %v", err)
+	}
+	if strings.Join(trace.Path, " -> ") != "app/a#ExportedA -> app/b#ExportedB" {
+		t.Fatalf("unexpected symbol trace: %v", trace.Path)
+	}
+	if _, err := svc.DependencyTrace(context.Background(), "app/a#ExportedA", "app/b", 0); err == nil {
+		t.Fatal("expected error when mixing module and symbol endpoints")
+	}
+}
+
 func TestService_TrendSlice(t *testing.T) {
 	base := time.Date(2026, 2, 13, 12, 0, 0, 0, time.UTC)
 	store := &fakeHistoryStore{
@@ -128,3 +188,113 @@ func TestService_TrendSlice(t *testing.T) {
 		t.Fatalf("expected project key team-alpha, got %q", store.lastKey)
 	}
 }
+
+func TestService_SnapshotDiff(t *testing.T) {
+	base := time.Date(2026, 2, 13, 12, 0, 0, 0, time.UTC)
+	store := &fakeHistoryStore{
+		snapshots: []history.Snapshot{
+			{Timestamp: base, ModuleCount: 1, Modules: []history.SnapshotModule{{Name: "app/a"}}},
+			{
+				Timestamp:   base.Add(time.Hour),
+				CommitHash:  "feedbeef",
+				ModuleCount: 2,
+				Modules:     []history.SnapshotModule{{Name: "app/a", FanOut: 1}, {Name: "app/b", FanIn: 1}},
+				Edges:       []history.SnapshotEdge{{From: "app/a", To: "app/b", ImportCount: 1}},
+			},
+		},
+	}
+
+	svc := NewService(seedGraph(), store, "default")
+	diff, err := svc.SnapshotDiff(context.Background(), "previous", "feed")
+	if err != nil {
+		t.Fatalf("snapshot diff: %v", err)
+	}
+	if len(diff.AddedModules) != 1 || diff.AddedModules[0] != "app/b" || len(diff.AddedEdges) != 1 {
+		t.Fatalf("unexpected diff: %+v", diff)
+	}
+	if diff.To.CommitHash != "feedbeef" || diff.DeltaModules != 1 {
+		t.Fatalf("unexpected diff refs: %+v", diff)
+	}
+	if _, err := svc.SnapshotDiff(context.Background(), "latest", "cafe"); err == nil {
+		t.Fatal("expected error for unknown snapshot reference")
+	}
+	if _, err := NewService(seedGraph(), nil, "default").SnapshotDiff(context.Background(), "previous", "latest"); err == nil {
+		t.Fatal("expected error without history store")
+	}
+}
+
+func TestService_DependencyPaths(t *testing.T) {
+	g := seedGraph()
+	g.AddFile(&parser.File{
+		Path:    "a2.go",
+		Module:  "app/a",
+		Imports: []parser.Import{{Module: "app/c", Location: parser.Location{Line: 6, Column: 1}}},
+	})
+	svc := NewService(g, nil, "default")
+
+	result, err := svc.DependencyPaths(context.Background(), "app/a", "app/c", 5, 0)
+	if err != nil {
+		t.Fatalf("dependency paths: %v", err)
+	}
+	if len(result.Paths) != 2 {
+		t.Fatalf("expected direct and transitive paths, got %+v", result.Paths)
+	}
+	if strings.Join(result.Paths[0].Path, " -> ") != "app/a -> app/c" || result.Paths[0].Hops[0].File != "a2.go" || result.Paths[0].Hops[0].Line != 6 {
+		t.Fatalf("unexpected shortest path: %+v", result.Paths[0])
+	}
+	if result.Paths[1].Depth != 2 || result.Paths[1].Hops[1].File != "b.go" {
+		t.Fatalf("unexpected second path: %+v", result.Paths[1])
+	}
+
+	if _, err := svc.DependencyPaths(context.Background(), "app/c", "app/a", 5, 0); err == nil {
+		t.Fatal("expected error when no path exists")
+	}
+	if _, err := svc.DependencyPaths(context.Background(), "app/a#ExportedA", "app/c", 5, 0); err == nil {
+		t.Fatal("expected error for symbol endpoints")
+	}
+}
+
+func TestService_ExternalDependencies(t *testing.T) {
+	g := graph.NewGraph()
+	g.AddFile(&parser.File{Path: "a.py", Module: "app.a", Language: "python", Imports: []parser.Import{{Module: "requests"}, {Module: "yaml.loader"}}})
+	g.AddFile(&parser.File{Path: "b.py", Module: "app.b", Language: "python", Imports: []parser.Import{{Module: "requests.adapters"}}})
+	svc := NewService(g, nil, "default")
+	svc.SetExternalDependencyOptions(graph.ExternalDependencyOptions{
+		Manifests: []parser.DependencyManifest{{Path: "requirements.txt", Ecosystem: parser.EcosystemPyPI, Dependencies: []parser.DeclaredDependency{
+			{Name: "requests"}, {Name: "rich"},
+		}}},
+		Resolve: func(language, importPath string) (string, string, bool) {
+			top, _, _ := strings.Cut(importPath, ".")
+			return parser.EcosystemPyPI, top, true
+		},
+	})
+	ctx := context.Background()
+
+	all, err := svc.ExternalDependencies(ctx, ExternalDependencyFilter{}, 0)
+	if err != nil || len(all) != 3 {
+		t.Fatalf("expected three dependencies, got %+v (%v)", all, err)
+	}
+	users, err := svc.ExternalDependencies(ctx, ExternalDependencyFilter{Name: "requests.adapters"}, 0)
+	if err != nil || len(users) != 1 || users[0].Name != "requests" || len(users[0].Importers) != 2 {
+		t.Fatalf("expected requests with two importers, got %+v (%v)", users, err)
+	}
+	unused, err := svc.ExternalDependencies(ctx, ExternalDependencyFilter{Unused: true}, 0)
+	if err != nil || len(unused) != 1 || unused[0].Name != "rich" {
+		t.Fatalf("expected rich as the only unused dependency, got %+v (%v)", unused, err)
+	}
+	single, err := svc.ExternalDependencies(ctx, ExternalDependencyFilter{MaxFiles: 1}, 0)
+	if err != nil || len(single) != 1 || single[0].Name != "yaml" {
+		t.Fatalf("expected yaml as the only single-file dependency, got %+v (%v)", single, err)
+	}
+	limited, err := svc.ExternalDependencies(ctx, ExternalDependencyFilter{}, 1)
+	if err != nil || len(limited) != 1 {
+		t.Fatalf("expected limit to apply, got %+v (%v)", limited, err)
+	}
+
+	if _, err := svc.ExternalDependencies(ctx, ExternalDependencyFilter{MaxFiles: -1}, 0); err == nil {
+		t.Fatal("expected error for negative max files")
+	}
+	if _, err := svc.ExternalDependencies(ctx, ExternalDependencyFilter{Unused: true, MaxFiles: 1}, 0); err == nil {
+		t.Fatal("expected error when combining unused and max files")
+	}
+}
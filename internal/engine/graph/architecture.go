@@ -104,15 +104,19 @@ func (e *LayerRuleEngine) Validate(g *Graph) []ArchitectureViolation {
 	g.mu.RLock()
 	defer g.mu.RUnlock()
 
-	moduleLayer := make(map[string]string, len(g.modules))
-	for modName, mod := range g.modules {
-		moduleLayer[modName] = e.layerFor(modName, mod)
+	moduleNames := g.moduleNamesLocked()
+	moduleLayer := make(map[string]string, len(moduleNames))
+	for _, modName := range moduleNames {
+		if mod, ok := g.moduleLocked(modName); ok {
+			moduleLayer[modName] = e.layerFor(modName, mod)
+		}
 	}
 
-	fromMods := util.SortedStringKeys(g.imports)
+	imports := g.allImportsLocked()
+	fromMods := util.SortedStringKeys(imports)
 	violations := make([]ArchitectureViolation, 0)
 	for _, from := range fromMods {
-		toMap := g.imports[from]
+		toMap := imports[from]
 		if len(toMap) == 0 {
 			continue
 		}
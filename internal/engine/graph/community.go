@@ -0,0 +1,273 @@
+package graph
+
+// internal/engine/graph/community.go
+
+import (
+	"path"
+	"sort"
+	"strings"
+)
+
+// ModuleCommunity is one cluster found by community detection. Group is the
+// directory (or package prefix) most of its modules live in.
+type ModuleCommunity struct {
+	ID      int
+	Group   string
+	Modules []string
+}
+
+// BoundarySuggestion is a module whose directory differs from the dominant
+// directory of the cluster it was placed in.
+type BoundarySuggestion struct {
+	Module         string
+	CurrentGroup   string
+	SuggestedGroup string
+	Community      int
+}
+
+// CommunityReport is the result of DetectCommunities.
+type CommunityReport struct {
+	Communities []ModuleCommunity
+	Assignment  map[string]int // module -> ModuleCommunity.ID
+	Modularity  float64
+	Misplaced   []BoundarySuggestion
+}
+
+// ModuleGroup returns the directory a module lives in: the parent path for
+// slash-separated names and the parent package for dotted names. Top-level
+// modules map to ".".
+func ModuleGroup(name string) string {
+	if strings.Contains(name, "/") {
+		return path.Dir(name)
+	}
+	if idx := strings.LastIndex(name, "."); idx > 0 {
+		return name[:idx]
+	}
+	return "."
+}
+
+// DetectCommunities clusters modules with the Louvain method over the
+// undirected import graph, weighting each module pair by the cut weight of
+// its imports in both directions (import sites plus referenced symbols).
+// Clusters are compared with the directory layout: a module is reported as
+// misplaced when its own group differs from its cluster's dominant group.
+func (g *Graph) DetectCommunities() CommunityReport {
+	g.mu.RLock()
+	defer g.mu.RUnlock()
+
+	nodes := g.moduleNamesLocked()
+	index := make(map[string]int, len(nodes))
+	for i, name := range nodes {
+		index[name] = i
+	}
+	adj := make([]map[int]float64, len(nodes))
+	for i := range adj {
+		adj[i] = make(map[int]float64)
+	}
+	for _, from := range nodes {
+		for _, to := range g.getSortedNeighbors(from) {
+			if to == from {
+				continue
+			}
+			weight := float64(g.weighCutLocked(from, to).Weight)
+			adj[index[from]][index[to]] += weight
+			adj[index[to]][index[from]] += weight
+		}
+	}
+
+	membership, modularity := louvain(adj)
+
+	byCommunity := make(map[int][]string)
+	for i, name := range nodes {
+		byCommunity[membership[i]] = append(byCommunity[membership[i]], name)
+	}
+	communities := make([]ModuleCommunity, 0, len(byCommunity))
+	for _, members := range byCommunity {
+		sort.Strings(members)
+		communities = append(communities, ModuleCommunity{Modules: members, Group: dominantGroup(members)})
+	}
+	sort.Slice(communities, func(i, j int) bool {
+		if len(communities[i].Modules) != len(communities[j].Modules) {
+			return len(communities[i].Modules) > len(communities[j].Modules)
+		}
+		return communities[i].Modules[0] < communities[j].Modules[0]
+	})
+
+	report := CommunityReport{
+		Communities: communities,
+		Assignment:  make(map[string]int, len(nodes)),
+		Modularity:  modularity,
+		Misplaced:   make([]BoundarySuggestion, 0),
+	}
+	for id := range report.Communities {
+		community := &report.Communities[id]
+		community.ID = id
+		for _, module := range community.Modules {
+			report.Assignment[module] = id
+			if len(community.Modules) < 2 {
+				continue
+			}
+			if group := ModuleGroup(module); group != community.Group {
+				report.Misplaced = append(report.Misplaced, BoundarySuggestion{
+					Module:         module,
+					CurrentGroup:   group,
+					SuggestedGroup: community.Group,
+					Community:      id,
+				})
+			}
+		}
+	}
+	sort.Slice(report.Misplaced, func(i, j int) bool {
+		return report.Misplaced[i].Module < report.Misplaced[j].Module
+	})
+	return report
+}
+
+// dominantGroup returns the most common ModuleGroup among members, breaking
+// ties alphabetically.
+func dominantGroup(members []string) string {
+	counts := make(map[string]int)
+	for _, member := range members {
+		counts[ModuleGroup(member)]++
+	}
+	best, bestCount := "", 0
+	for group, count := range counts {
+		if count > bestCount || (count == bestCount && group < best) {
+			best, bestCount = group, count
+		}
+	}
+	return best
+}
+
+// louvain runs the Louvain modularity optimisation on a symmetric weighted
+// adjacency and returns each node's community plus the final modularity.
+// Nodes are visited in index order so results are deterministic.
+func louvain(adj []map[int]float64) ([]int, float64) {
+	n := len(adj)
+	membership := make([]int, n)
+	for i := range membership {
+		membership[i] = i
+	}
+	selfLoops := make([]float64, n)
+	total := 0.0
+	for i := range adj {
+		for _, w := range adj[i] {
+			total += w
+		}
+	}
+	if total == 0 {
+		return membership, 0
+	}
+
+	level := adj
+	for {
+		community, moved := louvainLocalMoves(level, selfLoops, total)
+		if !moved {
+			break
+		}
+		for i := range membership {
+			membership[i] = community[membership[i]]
+		}
+		level, selfLoops = louvainAggregate(level, selfLoops, community)
+	}
+
+	modularity := 0.0
+	for c := range level {
+		degree := selfLoops[c]
+		for _, w := range level[c] {
+			degree += w
+		}
+		modularity += selfLoops[c]/total - (degree/total)*(degree/total)
+	}
+	return membership, modularity
+}
+
+// louvainLocalMoves greedily moves nodes to the neighbouring community with
+// the largest modularity gain until no move improves it. Communities are
+// renumbered densely in order of first appearance.
+func louvainLocalMoves(adj []map[int]float64, selfLoops []float64, total float64) ([]int, bool) {
+	n := len(adj)
+	community := make([]int, n)
+	degree := make([]float64, n)
+	communityDegree := make([]float64, n)
+	for i := range adj {
+		community[i] = i
+		degree[i] = selfLoops[i]
+		for _, w := range adj[i] {
+			degree[i] += w
+		}
+		communityDegree[i] = degree[i]
+	}
+
+	moved := false
+	for improved := true; improved; {
+		improved = false
+		for i := 0; i < n; i++ {
+			current := community[i]
+			communityDegree[current] -= degree[i]
+
+			links := make(map[int]float64)
+			for j, w := range adj[i] {
+				links[community[j]] += w
+			}
+			candidates := make([]int, 0, len(links))
+			for c := range links {
+				candidates = append(candidates, c)
+			}
+			sort.Ints(candidates)
+
+			best := current
+			bestGain := links[current] - communityDegree[current]*degree[i]/total
+			for _, c := range candidates {
+				gain := links[c] - communityDegree[c]*degree[i]/total
+				if gain > bestGain+1e-12 {
+					best, bestGain = c, gain
+				}
+			}
+
+			communityDegree[best] += degree[i]
+			if best != current {
+				community[i] = best
+				improved = true
+				moved = true
+			}
+		}
+	}
+
+	renumber := make(map[int]int)
+	for i, c := range community {
+		if _, ok := renumber[c]; !ok {
+			renumber[c] = len(renumber)
+		}
+		community[i] = renumber[c]
+	}
+	return community, moved
+}
+
+// louvainAggregate collapses each community into a single node. Internal
+// weights become self-loops, counted in both directions like adj entries.
+func louvainAggregate(adj []map[int]float64, selfLoops []float64, community []int) ([]map[int]float64, []float64) {
+	size := 0
+	for _, c := range community {
+		if c+1 > size {
+			size = c + 1
+		}
+	}
+	next := make([]map[int]float64, size)
+	for i := range next {
+		next[i] = make(map[int]float64)
+	}
+	nextLoops := make([]float64, size)
+	for i := range adj {
+		ci := community[i]
+		nextLoops[ci] += selfLoops[i]
+		for j, w := range adj[i] {
+			if cj := community[j]; cj == ci {
+				nextLoops[ci] += w
+			} else {
+				next[ci][cj] += w
+			}
+		}
+	}
+	return next, nextLoops
+}
@@ -0,0 +1,61 @@
+package graph
+
+import (
+	"circular/internal/engine/parser"
+	"reflect"
+	"testing"
+)
+
+func TestDetectCommunities(t *testing.T) {
+	g := NewGraph()
+	add := func(module string, imports ...string) {
+		file := &parser.File{Path: module + "/x.go", Module: module}
+		for _, imp := range imports {
+			file.Imports = append(file.Imports, parser.Import{Module: imp})
+		}
+		g.AddFile(file)
+	}
+	// Two tightly coupled triangles joined by a single import. billing/tax
+	// sits with the billing modules in the graph but under the users directory.
+	add("billing/api", "billing/store", "users/tax")
+	add("billing/store", "users/tax")
+	add("users/tax", "billing/api")
+	add("users/api", "users/store", "users/auth", "billing/api")
+	add("users/store", "users/auth")
+	add("users/auth", "users/api")
+
+	report := g.DetectCommunities()
+
+	if len(report.Communities) != 2 {
+		t.Fatalf("expected 2 communities, got %+v", report.Communities)
+	}
+	if report.Assignment["billing/api"] != report.Assignment["users/tax"] ||
+		report.Assignment["billing/api"] == report.Assignment["users/api"] {
+		t.Fatalf("unexpected assignment: %v", report.Assignment)
+	}
+	want := []BoundarySuggestion{{
+		Module:         "users/tax",
+		CurrentGroup:   "users",
+		SuggestedGroup: "billing",
+		Community:      report.Assignment["users/tax"],
+	}}
+	if !reflect.DeepEqual(report.Misplaced, want) {
+		t.Fatalf("unexpected boundary suggestions: %+v", report.Misplaced)
+	}
+	if report.Modularity <= 0.3 {
+		t.Fatalf("expected clear community structure, got modularity %.3f", report.Modularity)
+	}
+}
+
+func TestModuleGroup(t *testing.T) {
+	cases := map[string]string{
+		"internal/engine/graph": "internal/engine",
+		"pkg.sub.mod":           "pkg.sub",
+		"main":                  ".",
+	}
+	for name, want := range cases {
+		if got := ModuleGroup(name); got != want {
+			t.Fatalf("ModuleGroup(%q) = %q, want %q", name, got, want)
+		}
+	}
+}
@@ -0,0 +1,221 @@
+package graph
+
+// internal/engine/graph/cost.go
+
+import (
+	"math/bits"
+	"sort"
+)
+
+// EdgeCost is the marginal cost of one import edge: what the importer's
+// transitive dependency closure loses when only that edge is removed.
+type EdgeCost struct {
+	From    string
+	To      string
+	Modules int // modules no longer pulled in
+	Files   int // files of those modules
+	LOC     int // source lines of those files
+}
+
+// ImportEdgeCosts returns the marginal cost of every import between internal
+// modules, most expensive first (by LOC, then modules, then edge names). An
+// edge whose target stays reachable through another import costs nothing.
+func (g *Graph) ImportEdgeCosts() []EdgeCost {
+	g.mu.RLock()
+	defer g.mu.RUnlock()
+
+	names, adjacency := g.moduleAdjacencyLocked()
+	closure := g.importClosureLocked(names, adjacency)
+
+	out := make([]EdgeCost, 0)
+	for from := range closure.names {
+		for _, to := range closure.succ[from] {
+			lost := closure.reach[from].andNot(closure.reachWithout(from, to))
+			cost := EdgeCost{From: closure.names[from], To: closure.names[to]}
+			cost.Modules, cost.Files, cost.LOC = closure.weigh(lost)
+			out = append(out, cost)
+		}
+	}
+	sort.Slice(out, func(i, j int) bool {
+		if out[i].LOC != out[j].LOC {
+			return out[i].LOC > out[j].LOC
+		}
+		if out[i].Modules != out[j].Modules {
+			return out[i].Modules > out[j].Modules
+		}
+		if out[i].From != out[j].From {
+			return out[i].From < out[j].From
+		}
+		return out[i].To < out[j].To
+	})
+	return out
+}
+
+// moduleAdjacencyLocked lists module names and, per module, the sorted
+// internal modules it imports.
+func (g *Graph) moduleAdjacencyLocked() ([]string, map[string][]string) {
+	names := g.moduleNamesLocked()
+	adjacency := make(map[string][]string, len(names))
+	for _, name := range names {
+		targets := make([]string, 0)
+		for to := range g.importsOfLocked(name) {
+			if to != name && g.hasModuleLocked(to) {
+				targets = append(targets, to)
+			}
+		}
+		sort.Strings(targets)
+		adjacency[name] = targets
+	}
+	return names, adjacency
+}
+
+// importClosure indexes the transitive dependency closure of every module.
+type importClosure struct {
+	names  []string
+	succ   [][]int
+	reach  []bitset // modules reachable from each module, itself excluded
+	cyclic []bool   // module lies on an import cycle
+	files  []int
+	loc    []int
+}
+
+func (g *Graph) importClosureLocked(names []string, adjacency map[string][]string) *importClosure {
+	index := make(map[string]int, len(names))
+	for i, name := range names {
+		index[name] = i
+	}
+	c := &importClosure{
+		names:  names,
+		succ:   make([][]int, len(names)),
+		reach:  make([]bitset, len(names)),
+		cyclic: make([]bool, len(names)),
+		files:  make([]int, len(names)),
+		loc:    make([]int, len(names)),
+	}
+	for i, name := range names {
+		for _, to := range adjacency[name] {
+			c.succ[i] = append(c.succ[i], index[to])
+		}
+	}
+	for path, module := range g.fileToModule {
+		if i, ok := index[module]; ok {
+			c.files[i]++
+			c.loc[i] += g.fileToLOC[path]
+		}
+	}
+
+	// Closures are shared by every member of a strongly connected component;
+	// resolve them over the condensation, memoized per component.
+	componentOf, components := stronglyConnectedComponents(names, adjacency)
+	compReach := make(map[int]bitset, len(components))
+	var resolve func(int) bitset
+	resolve = func(comp int) bitset {
+		if set, ok := compReach[comp]; ok {
+			return set
+		}
+		set := newBitset(len(names))
+		members := components[comp]
+		if len(members) > 1 {
+			for _, member := range members {
+				set.add(index[member])
+			}
+		}
+		for _, member := range members {
+			for _, to := range c.succ[index[member]] {
+				next := componentOf[names[to]]
+				if next == comp {
+					continue
+				}
+				set.add(to)
+				set.or(resolve(next))
+			}
+		}
+		compReach[comp] = set
+		return set
+	}
+	for i, name := range names {
+		comp := componentOf[name]
+		c.cyclic[i] = len(components[comp]) > 1
+		c.reach[i] = resolve(comp).clone()
+		c.reach[i].remove(i)
+	}
+	return c
+}
+
+// reachWithout is the closure of from when its import of to is removed.
+func (c *importClosure) reachWithout(from, to int) bitset {
+	set := newBitset(len(c.names))
+	if !c.cyclic[from] {
+		// No other path leads back to from, so the closure is the union of
+		// the remaining direct imports and their closures.
+		for _, next := range c.succ[from] {
+			if next == to {
+				continue
+			}
+			set.add(next)
+			set.or(c.reach[next])
+		}
+		return set
+	}
+	queue := []int{from}
+	for len(queue) > 0 {
+		current := queue[0]
+		queue = queue[1:]
+		for _, next := range c.succ[current] {
+			if (current == from && next == to) || next == from || set.has(next) {
+				continue
+			}
+			set.add(next)
+			queue = append(queue, next)
+		}
+	}
+	return set
+}
+
+// weigh sums the module, file, and line counts of a closure.
+func (c *importClosure) weigh(set bitset) (modules, files, loc int) {
+	set.each(func(i int) {
+		modules++
+		files += c.files[i]
+		loc += c.loc[i]
+	})
+	return modules, files, loc
+}
+
+type bitset []uint64
+
+func newBitset(size int) bitset {
+	return make(bitset, (size+63)/64)
+}
+
+func (b bitset) add(i int)      { b[i/64] |= 1 << (uint(i) % 64) }
+func (b bitset) remove(i int)   { b[i/64] &^= 1 << (uint(i) % 64) }
+func (b bitset) has(i int) bool { return b[i/64]&(1<<(uint(i)%64)) != 0 }
+
+func (b bitset) or(other bitset) {
+	for i := range b {
+		b[i] |= other[i]
+	}
+}
+
+func (b bitset) andNot(other bitset) bitset {
+	out := make(bitset, len(b))
+	for i := range b {
+		out[i] = b[i] &^ other[i]
+	}
+	return out
+}
+
+func (b bitset) clone() bitset {
+	return append(bitset(nil), b...)
+}
+
+func (b bitset) each(fn func(int)) {
+	for word, bitsLeft := range b {
+		for bitsLeft != 0 {
+			offset := bits.TrailingZeros64(bitsLeft)
+			fn(word*64 + offset)
+			bitsLeft &= bitsLeft - 1
+		}
+	}
+}
@@ -0,0 +1,82 @@
+package graph
+
+import (
+	"circular/internal/engine/parser"
+	"testing"
+)
+
+func newCostFixture() *Graph {
+	g := NewGraph()
+	// app -> api -> core -> util, app -> util, plus a heavy leaf behind api.
+	g.AddFile(&parser.File{Path: "app.go", Module: "app", LOC: 10, Imports: []parser.Import{{Module: "api"}, {Module: "util"}, {Module: "fmt"}}})
+	g.AddFile(&parser.File{Path: "api.go", Module: "api", LOC: 20, Imports: []parser.Import{{Module: "core"}, {Module: "heavy"}}})
+	g.AddFile(&parser.File{Path: "core.go", Module: "core", LOC: 30, Imports: []parser.Import{{Module: "util"}}})
+	g.AddFile(&parser.File{Path: "util.go", Module: "util", LOC: 5})
+	g.AddFile(&parser.File{Path: "heavy1.go", Module: "heavy", LOC: 400})
+	g.AddFile(&parser.File{Path: "heavy2.go", Module: "heavy", LOC: 600})
+	return g
+}
+
+func TestComputeModuleMetrics_TransitiveClosure(t *testing.T) {
+	metrics := newCostFixture().ComputeModuleMetrics()
+
+	app := metrics["app"]
+	if app.TransitiveModules != 4 || app.TransitiveFiles != 5 || app.TransitiveLOC != 1055 {
+		t.Fatalf("unexpected app closure: %+v", app)
+	}
+	if util := metrics["util"]; util.TransitiveModules != 0 || util.TransitiveLOC != 0 {
+		t.Fatalf("expected empty closure for leaf module, got %+v", util)
+	}
+}
+
+func TestComputeModuleMetrics_TransitiveClosureWithCycle(t *testing.T) {
+	g := newCostFixture()
+	g.AddFile(&parser.File{Path: "util.go", Module: "util", LOC: 5, Imports: []parser.Import{{Module: "core"}}})
+
+	metrics := g.ComputeModuleMetrics()
+	if core := metrics["core"]; core.TransitiveModules != 1 || core.TransitiveLOC != 5 {
+		t.Fatalf("expected core to pull in util only, got %+v", core)
+	}
+	if app := metrics["app"]; app.TransitiveModules != 4 {
+		t.Fatalf("unexpected app closure with cycle: %+v", app)
+	}
+}
+
+func TestImportEdgeCosts(t *testing.T) {
+	costs := newCostFixture().ImportEdgeCosts()
+	byEdge := make(map[string]EdgeCost, len(costs))
+	for _, cost := range costs {
+		byEdge[cost.From+"->"+cost.To] = cost
+	}
+
+	if len(costs) != 5 || costs[0].From != "app" || costs[0].To != "api" || costs[1].To != "heavy" {
+		t.Fatalf("expected edges ordered by lost LOC, got %+v", costs)
+	}
+	if got := byEdge["app->api"]; got.Modules != 3 || got.Files != 4 || got.LOC != 1050 {
+		t.Fatalf("unexpected app -> api cost: %+v", got)
+	}
+	if got := byEdge["app->util"]; got.Modules != 0 || got.LOC != 0 {
+		t.Fatalf("expected app -> util to be free (util stays reachable via api), got %+v", got)
+	}
+	if _, ok := byEdge["app->fmt"]; ok {
+		t.Fatal("expected external imports to be skipped")
+	}
+}
+
+func TestImportEdgeCosts_Cycle(t *testing.T) {
+	g := NewGraph()
+	g.AddFile(&parser.File{Path: "a.go", Module: "a", LOC: 1, Imports: []parser.Import{{Module: "b"}}})
+	g.AddFile(&parser.File{Path: "b.go", Module: "b", LOC: 2, Imports: []parser.Import{{Module: "a"}, {Module: "c"}}})
+	g.AddFile(&parser.File{Path: "c.go", Module: "c", LOC: 3})
+
+	byEdge := make(map[string]EdgeCost)
+	for _, cost := range g.ImportEdgeCosts() {
+		byEdge[cost.From+"->"+cost.To] = cost
+	}
+	if got := byEdge["a->b"]; got.Modules != 2 || got.LOC != 5 {
+		t.Fatalf("expected a -> b to carry b and c, got %+v", got)
+	}
+	if got := byEdge["b->a"]; got.Modules != 1 || got.LOC != 1 {
+		t.Fatalf("expected b -> a to carry only a, got %+v", got)
+	}
+}
@@ -0,0 +1,51 @@
+package graph
+
+// internal/engine/graph/coupling.go
+
+import (
+	"circular/internal/engine/parser"
+	"math"
+)
+
+// CalculateInstability returns Martin's instability I = Ce / (Ca + Ce), where
+// Ca is afferent coupling (fan-in) and Ce is efferent coupling (fan-out).
+// A module with no couplings is reported as 0.
+func CalculateInstability(afferent, efferent int) float64 {
+	if afferent+efferent == 0 {
+		return 0
+	}
+	return float64(efferent) / float64(afferent+efferent)
+}
+
+// CalculateAbstractness returns Martin's abstractness A: the share of a
+// module's types (classes, interfaces, and type declarations) that are
+// interfaces or abstract types. Functions, methods, and variables are not
+// counted; a module without types is reported as 0.
+func CalculateAbstractness(defs map[string]*parser.Definition) float64 {
+	types, abstract := 0, 0
+	for _, def := range defs {
+		if def == nil {
+			continue
+		}
+		switch def.Kind {
+		case parser.KindInterface:
+			types++
+			abstract++
+		case parser.KindClass, parser.KindType:
+			types++
+			if def.TypeHint == parser.TypeHintAbstract {
+				abstract++
+			}
+		}
+	}
+	if types == 0 {
+		return 0
+	}
+	return float64(abstract) / float64(types)
+}
+
+// CalculateMainSequenceDistance returns D = |A + I - 1|, how far a module sits
+// from the ideal balance of abstractness and instability.
+func CalculateMainSequenceDistance(abstractness, instability float64) float64 {
+	return math.Abs(abstractness + instability - 1)
+}
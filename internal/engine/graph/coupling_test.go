@@ -0,0 +1,61 @@
+package graph
+
+import (
+	"circular/internal/engine/parser"
+	"math"
+	"testing"
+)
+
+func TestComputeModuleMetrics_MartinCoupling(t *testing.T) {
+	g := NewGraph()
+	g.AddFile(&parser.File{
+		Path:   "api.go",
+		Module: "api",
+		Definitions: []parser.Definition{
+			{Name: "Store", Kind: parser.KindInterface, Exported: true},
+			{Name: "Base", Kind: parser.KindClass, TypeHint: parser.TypeHintAbstract, Exported: true},
+			{Name: "Config", Kind: parser.KindType, Exported: true},
+			{Name: "cache", Kind: parser.KindInterface},
+			// Functions are not types and do not count towards abstractness.
+			{Name: "New", Kind: parser.KindFunction, Exported: true},
+			{Name: "helper", Kind: parser.KindFunction},
+		},
+	})
+	g.AddFile(&parser.File{Path: "impl.go", Module: "impl", Imports: []parser.Import{{Module: "api"}}})
+	g.AddFile(&parser.File{Path: "cmd.go", Module: "cmd", Imports: []parser.Import{{Module: "api"}, {Module: "impl"}}})
+
+	metrics := g.ComputeModuleMetrics()
+	near := func(a, b float64) bool { return math.Abs(a-b) < 1e-9 }
+
+	api := metrics["api"]
+	if api.FanIn != 2 || api.FanOut != 0 || !near(api.Instability, 0) {
+		t.Fatalf("expected stable api module, got %+v", api)
+	}
+	if !near(api.Abstractness, 0.75) || !near(api.Distance, 0.25) {
+		t.Fatalf("expected A=0.75 and D=0.25 for api, got %+v", api)
+	}
+
+	impl := metrics["impl"]
+	if !near(impl.Instability, 0.5) || !near(impl.Abstractness, 0) || !near(impl.Distance, 0.5) {
+		t.Fatalf("expected I=0.5, A=0, D=0.5 for impl, got %+v", impl)
+	}
+
+	cmd := metrics["cmd"]
+	if !near(cmd.Instability, 1) || !near(cmd.Distance, 0) {
+		t.Fatalf("expected fully unstable cmd on the main sequence, got %+v", cmd)
+	}
+}
+
+func TestComputeModuleMetrics_AbstractnessParsedTypeScript(t *testing.T) {
+	g := NewGraph()
+	g.AddFile(parseSource(t, "typescript", "src/shapes.ts", "app/shapes",
+		"export interface Shape {\n  area(): number;\n}\n"+
+			"export abstract class Base implements Shape {\n  abstract area(): number;\n}\n"+
+			"export class Square extends Base {\n  area(): number { return 1; }\n}\n"+
+			"export function unit(): Square { return new Square(); }\n"))
+
+	metrics := g.ComputeModuleMetrics()
+	if got := metrics["app/shapes"].Abstractness; math.Abs(got-2.0/3.0) > 1e-9 {
+		t.Fatalf("expected A=2/3 for the interface, abstract class, and concrete class, got %v", got)
+	}
+}
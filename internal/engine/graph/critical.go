@@ -0,0 +1,304 @@
+package graph
+
+// internal/engine/graph/critical.go
+
+import (
+	"sort"
+)
+
+// ModuleBridge is an import whose removal disconnects the undirected module
+// graph. From is the importing module; when both modules import each other the
+// direction first reached by the depth-first search is reported.
+type ModuleBridge struct {
+	From string
+	To   string
+}
+
+// ModuleDominator is a module that every import path from the entry points to
+// Dominated passes through.
+type ModuleDominator struct {
+	Module    string
+	Dominated []string
+}
+
+// CriticalModuleReport collects the single points of failure in the module
+// graph. Articulation points and bridges are computed on the undirected graph;
+// dominators on the directed graph reachable from EntryPoints.
+type CriticalModuleReport struct {
+	EntryPoints        []string
+	ArticulationPoints []string
+	Bridges            []ModuleBridge
+	// ImmediateDominators maps every module reachable from the entry points to
+	// its immediate dominator. Entry points and modules reachable from several
+	// entry points through disjoint paths map to "".
+	ImmediateDominators map[string]string
+	// Dominators lists non-entry modules that dominate at least one other
+	// module, most dominated modules first.
+	Dominators []ModuleDominator
+}
+
+// AnalyzeCriticalModules computes articulation points, bridges, and the
+// dominator tree rooted at entryModules, module names as resolved by
+// EntryModules. Names that are not modules are ignored; without any entry
+// module, modules nothing imports are used.
+func (g *Graph) AnalyzeCriticalModules(entryModules []string) CriticalModuleReport {
+	g.mu.RLock()
+	defer g.mu.RUnlock()
+
+	nodes := g.moduleNamesLocked()
+	adjacency := make(map[string][]string, len(nodes))
+	for _, name := range nodes {
+		adjacency[name] = g.getSortedNeighbors(name)
+	}
+
+	entries := entryModulesOrRoots(nodes, adjacency, entryModules)
+	points, bridges := articulationPoints(nodes, adjacency)
+	idom := dominatorTree(nodes, adjacency, entries)
+
+	entrySet := make(map[string]bool, len(entries))
+	for _, entry := range entries {
+		entrySet[entry] = true
+	}
+	dominated := make(map[string][]string)
+	for module := range idom {
+		for dom := idom[module]; dom != ""; dom = idom[dom] {
+			if !entrySet[dom] {
+				dominated[dom] = append(dominated[dom], module)
+			}
+		}
+	}
+	dominators := make([]ModuleDominator, 0, len(dominated))
+	for module, list := range dominated {
+		sort.Strings(list)
+		dominators = append(dominators, ModuleDominator{Module: module, Dominated: list})
+	}
+	sort.Slice(dominators, func(i, j int) bool {
+		if len(dominators[i].Dominated) != len(dominators[j].Dominated) {
+			return len(dominators[i].Dominated) > len(dominators[j].Dominated)
+		}
+		return dominators[i].Module < dominators[j].Module
+	})
+
+	return CriticalModuleReport{
+		EntryPoints:         entries,
+		ArticulationPoints:  points,
+		Bridges:             bridges,
+		ImmediateDominators: idom,
+		Dominators:          dominators,
+	}
+}
+
+// entryModulesOrRoots keeps the entry modules present in nodes, falling back
+// to the modules nothing else imports.
+func entryModulesOrRoots(nodes []string, adjacency map[string][]string, entryModules []string) []string {
+	wanted := make(map[string]bool, len(entryModules))
+	for _, name := range entryModules {
+		wanted[name] = true
+	}
+	matched := make([]string, 0, len(entryModules))
+	for _, name := range nodes {
+		if wanted[name] {
+			matched = append(matched, name)
+		}
+	}
+	if len(matched) > 0 {
+		return matched
+	}
+
+	imported := make(map[string]bool, len(nodes))
+	for _, name := range nodes {
+		for _, next := range adjacency[name] {
+			if next != name {
+				imported[next] = true
+			}
+		}
+	}
+	roots := make([]string, 0)
+	for _, name := range nodes {
+		if !imported[name] {
+			roots = append(roots, name)
+		}
+	}
+	return roots
+}
+
+// articulationPoints runs Tarjan's low-link search over the undirected view of
+// adjacency and returns its cut vertices and bridges in sorted order.
+func articulationPoints(nodes []string, adjacency map[string][]string) ([]string, []ModuleBridge) {
+	undirected := make(map[string][]string, len(nodes))
+	seen := make(map[[2]string]bool)
+	link := func(a, b string) {
+		if a == b || seen[[2]string{a, b}] {
+			return
+		}
+		seen[[2]string{a, b}] = true
+		seen[[2]string{b, a}] = true
+		undirected[a] = append(undirected[a], b)
+		undirected[b] = append(undirected[b], a)
+	}
+	imports := make(map[[2]string]bool)
+	for _, from := range nodes {
+		for _, to := range adjacency[from] {
+			imports[[2]string{from, to}] = true
+			link(from, to)
+		}
+	}
+	for _, name := range nodes {
+		sort.Strings(undirected[name])
+	}
+
+	timer := 0
+	disc := make(map[string]int, len(nodes))
+	low := make(map[string]int, len(nodes))
+	cut := make(map[string]bool)
+	bridges := make([]ModuleBridge, 0)
+
+	var visit func(v, parent string, root bool)
+	visit = func(v, parent string, root bool) {
+		timer++
+		disc[v] = timer
+		low[v] = timer
+		children := 0
+		for _, w := range undirected[v] {
+			if !root && w == parent {
+				continue
+			}
+			if disc[w] == 0 {
+				children++
+				visit(w, v, false)
+				if low[w] < low[v] {
+					low[v] = low[w]
+				}
+				if !root && low[w] >= disc[v] {
+					cut[v] = true
+				}
+				if low[w] > disc[v] {
+					if imports[[2]string{v, w}] {
+						bridges = append(bridges, ModuleBridge{From: v, To: w})
+					} else {
+						bridges = append(bridges, ModuleBridge{From: w, To: v})
+					}
+				}
+			} else if disc[w] < low[v] {
+				low[v] = disc[w]
+			}
+		}
+		if root && children > 1 {
+			cut[v] = true
+		}
+	}
+	for _, name := range nodes {
+		if disc[name] == 0 {
+			visit(name, "", true)
+		}
+	}
+
+	points := make([]string, 0, len(cut))
+	for name := range cut {
+		points = append(points, name)
+	}
+	sort.Strings(points)
+	sort.Slice(bridges, func(i, j int) bool {
+		if bridges[i].From != bridges[j].From {
+			return bridges[i].From < bridges[j].From
+		}
+		return bridges[i].To < bridges[j].To
+	})
+	return points, bridges
+}
+
+// dominatorTree computes immediate dominators with the Cooper-Harvey-Kennedy
+// iterative algorithm. A virtual root precedes every entry, so modules whose
+// only common dominator is that root map to "".
+func dominatorTree(nodes []string, adjacency map[string][]string, entries []string) map[string]string {
+	ids := make(map[string]int, len(nodes)+1)
+	names := make([]string, 0, len(nodes)+1)
+	names = append(names, "")
+	for _, name := range nodes {
+		ids[name] = len(names)
+		names = append(names, name)
+	}
+	succ := make([][]int, len(names))
+	for _, entry := range entries {
+		if id, ok := ids[entry]; ok {
+			succ[0] = append(succ[0], id)
+		}
+	}
+	for _, name := range nodes {
+		for _, next := range adjacency[name] {
+			if id, ok := ids[next]; ok {
+				succ[ids[name]] = append(succ[ids[name]], id)
+			}
+		}
+	}
+
+	post := make([]int, len(names))
+	visited := make([]bool, len(names))
+	order := make([]int, 0, len(names))
+	var visit func(v int)
+	visit = func(v int) {
+		visited[v] = true
+		for _, w := range succ[v] {
+			if !visited[w] {
+				visit(w)
+			}
+		}
+		post[v] = len(order)
+		order = append(order, v)
+	}
+	visit(0)
+
+	pred := make([][]int, len(names))
+	for _, v := range order {
+		for _, w := range succ[v] {
+			pred[w] = append(pred[w], v)
+		}
+	}
+
+	idom := make([]int, len(names))
+	for i := range idom {
+		idom[i] = -1
+	}
+	idom[0] = 0
+	intersect := func(a, b int) int {
+		for a != b {
+			for post[a] < post[b] {
+				a = idom[a]
+			}
+			for post[b] < post[a] {
+				b = idom[b]
+			}
+		}
+		return a
+	}
+	for changed := true; changed; {
+		changed = false
+		for i := len(order) - 2; i >= 0; i-- {
+			v := order[i]
+			next := -1
+			for _, p := range pred[v] {
+				if idom[p] == -1 {
+					continue
+				}
+				if next == -1 {
+					next = p
+				} else {
+					next = intersect(p, next)
+				}
+			}
+			if idom[v] != next {
+				idom[v] = next
+				changed = true
+			}
+		}
+	}
+
+	tree := make(map[string]string, len(order))
+	for _, v := range order {
+		if v == 0 {
+			continue
+		}
+		tree[names[v]] = names[idom[v]]
+	}
+	return tree
+}
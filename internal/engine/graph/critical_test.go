@@ -0,0 +1,78 @@
+package graph
+
+import (
+	"circular/internal/engine/parser"
+	"reflect"
+	"testing"
+)
+
+func TestAnalyzeCriticalModules(t *testing.T) {
+	g := NewGraph()
+	// cmd -> svc -> {repo, cache}; repo -> db; cache -> db; db -> log
+	g.AddFile(&parser.File{Path: "cmd/main.go", Module: "cmd", Language: "go", PackageName: "main", Imports: []parser.Import{{Module: "svc"}}})
+	g.AddFile(&parser.File{Path: "svc/svc.go", Module: "svc", Imports: []parser.Import{{Module: "repo"}, {Module: "cache"}}})
+	g.AddFile(&parser.File{Path: "repo/repo.go", Module: "repo", Imports: []parser.Import{{Module: "db"}}})
+	g.AddFile(&parser.File{Path: "cache/cache.go", Module: "cache", Imports: []parser.Import{{Module: "db"}}})
+	g.AddFile(&parser.File{Path: "db/db.go", Module: "db", Imports: []parser.Import{{Module: "log"}}})
+	g.AddFile(&parser.File{Path: "log/log.go", Module: "log"})
+
+	report := g.AnalyzeCriticalModules(nil)
+
+	if !reflect.DeepEqual(report.EntryPoints, []string{"cmd"}) {
+		t.Fatalf("expected the unimported cmd module as default entry, got %v", report.EntryPoints)
+	}
+	if !reflect.DeepEqual(report.ArticulationPoints, []string{"db", "svc"}) {
+		t.Fatalf("unexpected articulation points: %v", report.ArticulationPoints)
+	}
+	wantBridges := []ModuleBridge{{From: "cmd", To: "svc"}, {From: "db", To: "log"}}
+	if !reflect.DeepEqual(report.Bridges, wantBridges) {
+		t.Fatalf("unexpected bridges: %+v", report.Bridges)
+	}
+	if report.ImmediateDominators["db"] != "svc" || report.ImmediateDominators["log"] != "db" || report.ImmediateDominators["cmd"] != "" {
+		t.Fatalf("unexpected dominator tree: %v", report.ImmediateDominators)
+	}
+	if len(report.Dominators) != 2 || report.Dominators[0].Module != "svc" ||
+		!reflect.DeepEqual(report.Dominators[0].Dominated, []string{"cache", "db", "log", "repo"}) ||
+		report.Dominators[1].Module != "db" {
+		t.Fatalf("unexpected dominators: %+v", report.Dominators)
+	}
+}
+
+func TestAnalyzeCriticalModules_ConfiguredEntries(t *testing.T) {
+	g := NewGraph()
+	g.AddFile(&parser.File{Path: "a/main.py", Module: "a", Imports: []parser.Import{{Module: "shared"}}})
+	g.AddFile(&parser.File{Path: "b/main.py", Module: "b", Imports: []parser.Import{{Module: "shared"}}})
+	g.AddFile(&parser.File{Path: "shared/core.py", Module: "shared", Imports: []parser.Import{{Module: "util"}}})
+	g.AddFile(&parser.File{Path: "util/util.py", Module: "util"})
+
+	entries := g.EntryModules(ReachabilityOptions{Patterns: []string{"a/main.py", "b"}})
+	report := g.AnalyzeCriticalModules(entries)
+	if !reflect.DeepEqual(report.EntryPoints, []string{"a", "b"}) {
+		t.Fatalf("unexpected entry points: %v", report.EntryPoints)
+	}
+	if report.ImmediateDominators["shared"] != "" || report.ImmediateDominators["util"] != "shared" {
+		t.Fatalf("unexpected dominator tree: %v", report.ImmediateDominators)
+	}
+	if len(report.Dominators) != 1 || report.Dominators[0].Module != "shared" {
+		t.Fatalf("expected shared to dominate util, got %+v", report.Dominators)
+	}
+}
+
+type mapFileLoader map[string]*parser.File
+
+func (m mapFileLoader) LoadFile(path string) (*parser.File, error) {
+	return m[path], nil
+}
+
+func TestEntryModules_GoMainEvictedFromCache(t *testing.T) {
+	mainFile := &parser.File{Path: "cmd/main.go", Module: "cmd", Language: "go", PackageName: "main", Imports: []parser.Import{{Module: "svc"}}}
+	g := NewGraphWithCapacity(1)
+	g.SetLoader(mapFileLoader{mainFile.Path: mainFile})
+	g.AddFile(mainFile)
+	g.AddFile(&parser.File{Path: "svc/svc.go", Module: "svc", Language: "go", PackageName: "svc"})
+	g.AddFile(&parser.File{Path: "tool/tool.go", Module: "tool", Language: "go", PackageName: "tool", Imports: []parser.Import{{Module: "svc"}}})
+
+	if entries := g.EntryModules(ReachabilityOptions{GoMain: true}); !reflect.DeepEqual(entries, []string{"cmd"}) {
+		t.Fatalf("expected the evicted Go main package as entry, got %v", entries)
+	}
+}
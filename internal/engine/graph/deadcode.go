@@ -0,0 +1,193 @@
+package graph
+
+// internal/engine/graph/deadcode.go
+
+import (
+	"circular/internal/engine/parser"
+	"path/filepath"
+	"sort"
+	"strings"
+
+	"github.com/gobwas/glob"
+)
+
+// DeadCodeOptions controls which definitions FindDeadCode may report.
+type DeadCodeOptions struct {
+	// EntryModules are module names, e.g. from EntryModules, whose definitions
+	// are never reported. Without any, modules nothing imports are used.
+	EntryModules []string
+	// AllowSymbols are glob patterns matched against a definition's name and
+	// full name, e.g. "ServeHTTP" or "*.String".
+	AllowSymbols []string
+	// AllowDecorators are glob patterns matched against a definition's
+	// decorators, e.g. "app.route" or "pytest.*".
+	AllowDecorators []string
+	// IsTestFile reports test files whose definitions are skipped. Nil falls
+	// back to Go and Python test file naming.
+	IsTestFile func(path string) bool
+}
+
+// DeadDefinition is an exported definition that no other module references.
+type DeadDefinition struct {
+	Module   string
+	Name     string
+	FullName string
+	Kind     parser.DefinitionKind
+	File     string
+	Line     int
+}
+
+// FindDeadCode reports exported definitions with no inbound reference from
+// another module. References are matched to definitions by their last name
+// segment through the universal symbol table, so a same-named symbol anywhere
+// else keeps a definition alive. Entry modules, main/init functions, test
+// files and modules, and allowlisted symbols are excluded. Results are sorted
+// by module, file, and line.
+func (g *Graph) FindDeadCode(opts DeadCodeOptions) []DeadDefinition {
+	g.mu.RLock()
+	nodes := g.moduleNamesLocked()
+	adjacency := make(map[string][]string, len(nodes))
+	for _, name := range nodes {
+		adjacency[name] = g.getSortedNeighbors(name)
+	}
+	entries := entryModulesOrRoots(nodes, adjacency, opts.EntryModules)
+	g.mu.RUnlock()
+
+	entrySet := make(map[string]bool, len(entries))
+	for _, entry := range entries {
+		entrySet[entry] = true
+	}
+	isTestFile := opts.IsTestFile
+	if isTestFile == nil {
+		isTestFile = isDefaultTestFile
+	}
+	allowSymbols := compileDeadCodeGlobs(opts.AllowSymbols)
+	allowDecorators := compileDeadCodeGlobs(opts.AllowDecorators)
+
+	table := g.BuildUniversalSymbolTable()
+	referenced := make(map[string]bool)
+	looked := make(map[string]bool)
+	for _, path := range g.FilePaths() {
+		file, ok := g.GetFile(path)
+		if !ok {
+			continue
+		}
+		for _, ref := range file.References {
+			base := lastNameSegment(ref.Name)
+			if base == "" || looked[file.Module+"\x00"+base] {
+				continue
+			}
+			looked[file.Module+"\x00"+base] = true
+			for _, rec := range table.Lookup(base) {
+				if rec.Module != file.Module {
+					referenced[rec.Module+"\x00"+rec.Name] = true
+				}
+			}
+		}
+	}
+
+	dead := make([]DeadDefinition, 0)
+	seen := make(map[string]bool)
+	for _, rec := range table.Symbols() {
+		key := rec.Module + "\x00" + rec.Name
+		if !rec.Exported || referenced[key] || seen[key] || entrySet[rec.Module] {
+			continue
+		}
+		if isEntryFunctionName(rec.Name) || isTestHelperModule(rec.Module) || isTestFile(rec.File) {
+			continue
+		}
+		if matchesAnyGlob(allowSymbols, rec.Name, rec.FullName) || matchesAnyGlob(allowDecorators, rec.Decorators...) {
+			continue
+		}
+		seen[key] = true
+		dead = append(dead, DeadDefinition{
+			Module:   rec.Module,
+			Name:     rec.Name,
+			FullName: rec.FullName,
+			Kind:     rec.Kind,
+			File:     rec.File,
+			Line:     rec.Line,
+		})
+	}
+	sort.Slice(dead, func(i, j int) bool {
+		if dead[i].Module != dead[j].Module {
+			return dead[i].Module < dead[j].Module
+		}
+		if dead[i].File != dead[j].File {
+			return dead[i].File < dead[j].File
+		}
+		if dead[i].Line != dead[j].Line {
+			return dead[i].Line < dead[j].Line
+		}
+		return dead[i].Name < dead[j].Name
+	})
+	return dead
+}
+
+// DeadCodeCounts returns the number of dead definitions per module.
+func DeadCodeCounts(dead []DeadDefinition) map[string]int {
+	counts := make(map[string]int)
+	for _, d := range dead {
+		counts[d.Module]++
+	}
+	return counts
+}
+
+// lastNameSegment strips qualifiers from a reference such as "pkg.Func",
+// "self.method", or "crate::mod::Item".
+func lastNameSegment(name string) string {
+	name = strings.TrimSpace(name)
+	if idx := strings.LastIndexAny(name, ".:>"); idx >= 0 {
+		name = name[idx+1:]
+	}
+	return name
+}
+
+func isEntryFunctionName(name string) bool {
+	switch name {
+	case "main", "init", "__init__", "__main__":
+		return true
+	}
+	return false
+}
+
+// isTestHelperModule reports modules whose last path segment names a test
+// package, e.g. "internal/testutil" or "tests.fixtures".
+func isTestHelperModule(module string) bool {
+	last := strings.ToLower(module)
+	if idx := strings.LastIndexAny(last, "/."); idx >= 0 {
+		last = last[idx+1:]
+	}
+	return strings.Contains(last, "test")
+}
+
+func isDefaultTestFile(path string) bool {
+	base := strings.ToLower(filepath.Base(path))
+	return strings.HasSuffix(base, "_test.go") || strings.HasSuffix(base, "_test.py") ||
+		(strings.HasPrefix(base, "test_") && strings.HasSuffix(base, ".py"))
+}
+
+func compileDeadCodeGlobs(patterns []string) []glob.Glob {
+	out := make([]glob.Glob, 0, len(patterns))
+	for _, pattern := range patterns {
+		pattern = strings.TrimSpace(strings.TrimPrefix(strings.TrimSpace(pattern), "@"))
+		if pattern == "" {
+			continue
+		}
+		if compiled, err := glob.Compile(pattern); err == nil {
+			out = append(out, compiled)
+		}
+	}
+	return out
+}
+
+func matchesAnyGlob(globs []glob.Glob, values ...string) bool {
+	for _, g := range globs {
+		for _, value := range values {
+			if value != "" && g.Match(value) {
+				return true
+			}
+		}
+	}
+	return false
+}
@@ -0,0 +1,109 @@
+package graph
+
+import (
+	"circular/internal/engine/parser"
+	"reflect"
+	"testing"
+)
+
+// parseSource runs the real extractor for language over code, so tests see the
+// definitions and visibility the scanner would produce.
+func parseSource(t *testing.T, language, path, module, code string) *parser.File {
+	t.Helper()
+	enabled := true
+	registry, err := parser.BuildLanguageRegistry(map[string]parser.LanguageOverride{
+		language: {Enabled: &enabled},
+	}, nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	loader, err := parser.NewGrammarLoaderWithRegistry("./grammars", registry, false)
+	if err != nil {
+		t.Fatal(err)
+	}
+	p := parser.NewParser(loader)
+	if err := p.RegisterDefaultExtractors(); err != nil {
+		t.Fatal(err)
+	}
+	file, err := p.ParseFile(path, []byte(code))
+	if err != nil {
+		t.Fatal(err)
+	}
+	file.Module = module
+	return file
+}
+
+func TestFindDeadCode(t *testing.T) {
+	g := NewGraph()
+	g.AddFile(&parser.File{
+		Path:     "cmd/app/main.go",
+		Language: "go",
+		Module:   "app/cmd",
+		Imports:  []parser.Import{{Module: "app/lib"}},
+		Definitions: []parser.Definition{
+			{Name: "Run", Kind: parser.KindFunction, Exported: true},
+		},
+		References: []parser.Reference{{Name: "lib.Used"}},
+	})
+	g.AddFile(&parser.File{
+		Path:     "lib/lib.go",
+		Language: "go",
+		Module:   "app/lib",
+		Definitions: []parser.Definition{
+			{Name: "Used", Kind: parser.KindFunction, Exported: true},
+			{Name: "Unused", Kind: parser.KindFunction, Exported: true, Location: parser.Location{File: "lib/lib.go", Line: 7}},
+			{Name: "internal", Kind: parser.KindFunction},
+			{Name: "ServeHTTP", Kind: parser.KindMethod, Exported: true},
+			{Name: "Hook", Kind: parser.KindFunction, Exported: true, Decorators: []string{"app.route"}},
+			{Name: "init", Kind: parser.KindFunction, Exported: true},
+		},
+		// Self-references do not keep a definition alive.
+		References: []parser.Reference{{Name: "Unused"}},
+	})
+	g.AddFile(&parser.File{
+		Path:        "lib/lib_test.go",
+		Language:    "go",
+		Module:      "app/lib",
+		Definitions: []parser.Definition{{Name: "TestHelper", Kind: parser.KindFunction, Exported: true, Location: parser.Location{File: "lib/lib_test.go"}}},
+	})
+	g.AddFile(&parser.File{
+		Path:        "internal/testutil/util.go",
+		Language:    "go",
+		Module:      "app/internal/testutil",
+		Definitions: []parser.Definition{{Name: "Fixture", Kind: parser.KindFunction, Exported: true}},
+	})
+
+	dead := g.FindDeadCode(DeadCodeOptions{
+		EntryModules:    []string{"app/cmd"},
+		AllowSymbols:    []string{"ServeHTTP"},
+		AllowDecorators: []string{"@app.*"},
+	})
+
+	if len(dead) != 1 {
+		t.Fatalf("expected only Unused to be reported, got %+v", dead)
+	}
+	if d := dead[0]; d.Module != "app/lib" || d.Name != "Unused" || d.File != "lib/lib.go" || d.Line != 7 {
+		t.Fatalf("unexpected dead definition: %+v", d)
+	}
+	if counts := DeadCodeCounts(dead); counts["app/lib"] != 1 || len(counts) != 1 {
+		t.Fatalf("unexpected per-module counts: %v", counts)
+	}
+}
+
+func TestFindDeadCode_ParsedJava(t *testing.T) {
+	g := NewGraph()
+	g.AddFile(parseSource(t, "java", "src/app/store/Store.java", "app.store",
+		"package app.store;\n\npublic class Store {\n  public void flush() {}\n  public void drop() {}\n  void helper() {}\n  private void reset() {}\n}\n"))
+	g.AddFile(parseSource(t, "java", "src/app/api/Api.java", "app.api",
+		"package app.api;\n\npublic class Api {\n  public static void main(String[] args) {\n    new app.store.Store().flush();\n  }\n}\n"))
+
+	dead := g.FindDeadCode(DeadCodeOptions{EntryModules: []string{"app.api"}})
+	names := make([]string, 0, len(dead))
+	for _, d := range dead {
+		names = append(names, d.Module+"#"+d.Name)
+	}
+	// Package-private and private members are never exports.
+	if !reflect.DeepEqual(names, []string{"app.store#drop"}) {
+		t.Fatalf("unexpected dead code: %v", names)
+	}
+}
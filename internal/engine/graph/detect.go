@@ -12,11 +12,7 @@ func (g *Graph) DetectCycles() [][]string {
 	onStack := make(map[string]bool)
 
 	// To keep detection deterministic, sort modules
-	moduleNames := make([]string, 0, len(g.modules))
-	for name := range g.modules {
-		moduleNames = append(moduleNames, name)
-	}
-	sort.Strings(moduleNames)
+	moduleNames := g.moduleNamesLocked()
 
 	for _, startMod := range moduleNames {
 		if visited[startMod] {
@@ -84,10 +80,11 @@ func (g *Graph) DetectCycles() [][]string {
 }
 
 func (g *Graph) getSortedNeighbors(mod string) []string {
-	neighbors := make([]string, 0, len(g.imports[mod]))
-	for next := range g.imports[mod] {
+	imports := g.importsOfLocked(mod)
+	neighbors := make([]string, 0, len(imports))
+	for next := range imports {
 		// Only consider neighbors that are in the internal module set
-		if _, ok := g.modules[next]; ok {
+		if g.hasModuleLocked(next) {
 			neighbors = append(neighbors, next)
 		}
 	}
@@ -99,11 +96,11 @@ func (g *Graph) getSortedNeighbors(mod string) []string {
 func (g *Graph) FindImportChain(from, to string) ([]string, bool) {
 	g.mu.RLock()
 	defer g.mu.RUnlock()
+	return g.findImportChainLocked(from, to)
+}
 
-	if _, ok := g.modules[from]; !ok {
-		return nil, false
-	}
-	if _, ok := g.modules[to]; !ok {
+func (g *Graph) findImportChainLocked(from, to string) ([]string, bool) {
+	if !g.hasModuleLocked(from) || !g.hasModuleLocked(to) {
 		return nil, false
 	}
 	if from == to {
@@ -118,16 +115,7 @@ func (g *Graph) FindImportChain(from, to string) ([]string, bool) {
 		curr := queue[0]
 		queue = queue[1:]
 
-		neighbors := make([]string, 0, len(g.imports[curr]))
-		for next := range g.imports[curr] {
-			if _, ok := g.modules[next]; !ok {
-				continue
-			}
-			neighbors = append(neighbors, next)
-		}
-		sort.Strings(neighbors)
-
-		for _, next := range neighbors {
+		for _, next := range g.getSortedNeighbors(curr) {
 			if visited[next] {
 				continue
 			}
@@ -175,13 +163,13 @@ func (g *Graph) InvalidateTransitive(changedFile string) []string {
 		mod := queue[0]
 		queue = queue[1:]
 
-		for importer := range g.importedBy[mod] {
+		for importer := range g.importersOfLocked(mod) {
 			if modSeen[importer] {
 				continue
 			}
 			modSeen[importer] = true
 
-			if importerMod, ok := g.modules[importer]; ok {
+			if importerMod, ok := g.moduleLocked(importer); ok {
 				for _, f := range importerMod.Files {
 					if !seen[f] {
 						seen[f] = true
@@ -0,0 +1,236 @@
+package graph
+
+// internal/engine/graph/encapsulation.go
+
+import (
+	"circular/internal/engine/parser"
+	"sort"
+	"strings"
+)
+
+// Encapsulation violation kinds.
+const (
+	// EncapsulationPrivateSymbol is a reference to a private definition from
+	// outside the package of the module defining it.
+	EncapsulationPrivateSymbol = "private_symbol"
+	// EncapsulationInternalSymbol is a reference to an internal definition
+	// (e.g. Rust `pub(crate)`) from another boundary.
+	EncapsulationInternalSymbol = "internal_symbol"
+	// EncapsulationInternalImport is an import of an `internal` (or Python
+	// `_private`) module from outside the tree rooted at its parent.
+	EncapsulationInternalImport = "internal_import"
+)
+
+// EncapsulationOptions controls FindEncapsulationViolations.
+type EncapsulationOptions struct {
+	// Boundary returns the unit an internal-visibility definition in the file
+	// at path is confined to, such as the directory of the Rust crate
+	// manifest. "" (or a nil func) places no constraint, so internal
+	// definitions are only checked where Boundary knows their scope.
+	Boundary func(path string) string
+	// IsTestFile reports test files whose references are skipped. Nil falls
+	// back to Go and Python test file naming.
+	IsTestFile func(path string) bool
+}
+
+// EncapsulationViolation is a reference or import crossing a module boundary
+// into code its owner marked private or internal.
+type EncapsulationViolation struct {
+	Kind       string
+	From       string // referencing module
+	To         string // module owning the symbol, or the imported internal module
+	Symbol     string // empty for internal imports
+	Visibility string // visibility of the symbol; empty for internal imports
+	File       string
+	Line       int
+	Column     int
+}
+
+// FindEncapsulationViolations checks every file's imports and the references
+// qualified through them against the visibility of their targets:
+//   - private definitions may only be used from the package (parent module)
+//     of their own module, or from the module itself at the top level
+//   - internal definitions may only be used from the same Boundary
+//   - modules below an `internal` segment, or a Python segment starting with
+//     `_`, may only be imported from the tree rooted at that segment's parent
+//
+// Test files are skipped. Results are sorted by file, line, and column.
+func (g *Graph) FindEncapsulationViolations(opts EncapsulationOptions) []EncapsulationViolation {
+	isTestFile := opts.IsTestFile
+	if isTestFile == nil {
+		isTestFile = isDefaultTestFile
+	}
+	modules := g.Modules()
+
+	out := make([]EncapsulationViolation, 0)
+	seen := make(map[EncapsulationViolation]bool)
+	add := func(v EncapsulationViolation) {
+		if seen[v] {
+			return
+		}
+		seen[v] = true
+		out = append(out, v)
+	}
+
+	for _, path := range g.FilePaths() {
+		if isTestFile(path) {
+			continue
+		}
+		file, ok := g.GetFile(path)
+		if !ok {
+			continue
+		}
+		for _, imp := range file.Imports {
+			if imp.Module == "" || imp.Module == file.Module {
+				continue
+			}
+			if _, ok := modules[imp.Module]; ok {
+				if root, guarded := internalImportRoot(file.Language, imp.Module); guarded && !withinModuleTree(file.Module, root) {
+					add(EncapsulationViolation{
+						Kind:   EncapsulationInternalImport,
+						From:   file.Module,
+						To:     imp.Module,
+						File:   path,
+						Line:   imp.Location.Line,
+						Column: imp.Location.Column,
+					})
+				}
+			}
+			if !g.HasDefinitions(imp.Module) {
+				continue
+			}
+
+			check := func(symbol string, loc parser.Location) {
+				def, ok := g.lookupQualifiedDefinition(imp.Module, symbol)
+				if !ok {
+					return
+				}
+				kind := ""
+				switch def.Visibility {
+				case "private":
+					if !withinModuleTree(file.Module, modulePackage(imp.Module)) {
+						kind = EncapsulationPrivateSymbol
+					}
+				case "internal":
+					if opts.Boundary == nil {
+						return
+					}
+					owner := opts.Boundary(def.Location.File)
+					if owner != "" && owner != opts.Boundary(path) {
+						kind = EncapsulationInternalSymbol
+					}
+				}
+				if kind == "" {
+					return
+				}
+				add(EncapsulationViolation{
+					Kind:       kind,
+					From:       file.Module,
+					To:         imp.Module,
+					Symbol:     def.Name,
+					Visibility: def.Visibility,
+					File:       path,
+					Line:       loc.Line,
+					Column:     loc.Column,
+				})
+			}
+
+			for _, item := range imp.Items {
+				check(item, imp.Location)
+			}
+			for _, ref := range file.References {
+				if symbol := qualifiedSymbol(file.Language, imp, ref.Name); symbol != "" {
+					check(symbol, ref.Location)
+				}
+			}
+		}
+	}
+
+	sort.Slice(out, func(i, j int) bool {
+		if out[i].File != out[j].File {
+			return out[i].File < out[j].File
+		}
+		if out[i].Line != out[j].Line {
+			return out[i].Line < out[j].Line
+		}
+		if out[i].Column != out[j].Column {
+			return out[i].Column < out[j].Column
+		}
+		if out[i].Kind != out[j].Kind {
+			return out[i].Kind < out[j].Kind
+		}
+		return out[i].Symbol < out[j].Symbol
+	})
+	return out
+}
+
+// lookupQualifiedDefinition resolves "Name" or "Type.member" in module,
+// preferring the member the way the resolver does.
+func (g *Graph) lookupQualifiedDefinition(module, symbol string) (*parser.Definition, bool) {
+	if def, ok := g.LookupDefinition(module, symbol); ok {
+		return def, true
+	}
+	parts := strings.Split(symbol, ".")
+	if len(parts) < 2 {
+		return nil, false
+	}
+	if def, ok := g.LookupDefinition(module, parts[len(parts)-1]); ok {
+		return def, true
+	}
+	return g.LookupDefinition(module, parts[0])
+}
+
+// qualifiedSymbol returns the part of a reference following the import's
+// alias, reference base, or full module name, or an imported item the
+// reference starts with; "" when the reference does not go through imp.
+func qualifiedSymbol(language string, imp parser.Import, ref string) string {
+	prefixes := []string{imp.Alias, parser.ModuleReferenceBase(language, imp.Module), imp.Module}
+	for _, prefix := range prefixes {
+		if prefix != "" && strings.HasPrefix(ref, prefix+".") {
+			return strings.TrimPrefix(ref, prefix+".")
+		}
+	}
+	for _, item := range imp.Items {
+		if ref == item || strings.HasPrefix(ref, item+".") {
+			return ref
+		}
+	}
+	return ""
+}
+
+// internalImportRoot returns the parent of the deepest guarded segment of
+// module: `internal` in any language, or a Python segment starting with a
+// single `_`. An empty root means the whole project may import the module.
+func internalImportRoot(language, module string) (string, bool) {
+	segments := ModuleSegments(module)
+	for i := len(segments) - 1; i >= 0; i-- {
+		segment := segments[i]
+		guarded := segment == "internal"
+		if language == "python" && strings.HasPrefix(segment, "_") && !strings.HasPrefix(segment, "__") {
+			guarded = true
+		}
+		if guarded {
+			return strings.Join(segments[:i], moduleSeparator(module)), true
+		}
+	}
+	return "", false
+}
+
+// modulePackage returns the parent of module, or module itself when it has a
+// single segment.
+func modulePackage(module string) string {
+	segments := ModuleSegments(module)
+	if len(segments) < 2 {
+		return module
+	}
+	return strings.Join(segments[:len(segments)-1], moduleSeparator(module))
+}
+
+// withinModuleTree reports whether module is root or lies below it. An empty
+// root contains every module.
+func withinModuleTree(module, root string) bool {
+	if root == "" || module == root {
+		return true
+	}
+	return strings.HasPrefix(module, root+moduleSeparator(root)) || strings.HasPrefix(module, root+moduleSeparator(module))
+}
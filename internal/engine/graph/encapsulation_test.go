@@ -0,0 +1,106 @@
+package graph
+
+import (
+	"circular/internal/engine/parser"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+func TestFindEncapsulationViolations_PrivateSymbols(t *testing.T) {
+	g := NewGraph()
+	g.AddFile(&parser.File{Path: "pkg/core.py", Module: "pkg.core", Language: "python", Definitions: []parser.Definition{
+		{Name: "run", Kind: parser.KindFunction, Visibility: "public", Exported: true},
+		{Name: "_cache", Kind: parser.KindFunction, Visibility: "private"},
+		{Name: "_reset", Kind: parser.KindFunction, Visibility: "private"},
+	}})
+	g.AddFile(&parser.File{Path: "pkg/api.py", Module: "pkg.api", Language: "python",
+		Imports:    []parser.Import{{Module: "pkg.core", Items: []string{"_cache"}, Location: parser.Location{Line: 1, Column: 1}}},
+		References: []parser.Reference{{Name: "_cache", Location: parser.Location{Line: 4, Column: 5}}},
+	})
+	g.AddFile(&parser.File{Path: "app/main.py", Module: "app.main", Language: "python",
+		Imports: []parser.Import{
+			{Module: "pkg.core", Items: []string{"_cache", "run"}, Location: parser.Location{Line: 1, Column: 1}},
+			{Module: "pkg.core", Alias: "c", Location: parser.Location{Line: 2, Column: 1}},
+		},
+		References: []parser.Reference{
+			{Name: "run", Location: parser.Location{Line: 5, Column: 5}},
+			{Name: "c._reset", Location: parser.Location{Line: 6, Column: 5}},
+		},
+	})
+	g.AddFile(&parser.File{Path: "app/test_main.py", Module: "app.test_main", Language: "python",
+		Imports: []parser.Import{{Module: "pkg.core", Items: []string{"_reset"}, Location: parser.Location{Line: 1, Column: 1}}},
+	})
+
+	got := g.FindEncapsulationViolations(EncapsulationOptions{})
+	if len(got) != 2 {
+		t.Fatalf("expected two violations from app.main, got %+v", got)
+	}
+	want := []EncapsulationViolation{
+		{Kind: EncapsulationPrivateSymbol, From: "app.main", To: "pkg.core", Symbol: "_cache", Visibility: "private", File: "app/main.py", Line: 1, Column: 1},
+		{Kind: EncapsulationPrivateSymbol, From: "app.main", To: "pkg.core", Symbol: "_reset", Visibility: "private", File: "app/main.py", Line: 6, Column: 5},
+	}
+	for i := range want {
+		if got[i] != want[i] {
+			t.Fatalf("violation %d: expected %+v, got %+v", i, want[i], got[i])
+		}
+	}
+}
+
+func TestFindEncapsulationViolations_InternalImports(t *testing.T) {
+	g := NewGraph()
+	g.AddFile(&parser.File{Path: "svc/internal/store/db.go", Module: "example.com/svc/internal/store", Language: "go"})
+	g.AddFile(&parser.File{Path: "svc/api/api.go", Module: "example.com/svc/api", Language: "go",
+		Imports: []parser.Import{{Module: "example.com/svc/internal/store", Location: parser.Location{Line: 3, Column: 2}}},
+	})
+	g.AddFile(&parser.File{Path: "tools/gen.go", Module: "example.com/tools", Language: "go",
+		Imports: []parser.Import{{Module: "example.com/svc/internal/store", Location: parser.Location{Line: 4, Column: 2}}},
+	})
+	g.AddFile(&parser.File{Path: "lib/_impl.py", Module: "lib._impl", Language: "python"})
+	g.AddFile(&parser.File{Path: "lib/__init__.py", Module: "lib", Language: "python",
+		Imports: []parser.Import{{Module: "lib._impl", Location: parser.Location{Line: 1, Column: 1}}},
+	})
+	g.AddFile(&parser.File{Path: "cli.py", Module: "cli", Language: "python",
+		Imports: []parser.Import{{Module: "lib._impl", Location: parser.Location{Line: 2, Column: 1}}},
+	})
+
+	got := g.FindEncapsulationViolations(EncapsulationOptions{})
+	if len(got) != 2 {
+		t.Fatalf("expected two internal imports, got %+v", got)
+	}
+	if got[0].Kind != EncapsulationInternalImport || got[0].From != "cli" || got[0].To != "lib._impl" || got[0].Line != 2 {
+		t.Fatalf("unexpected python violation: %+v", got[0])
+	}
+	if got[1].From != "example.com/tools" || got[1].To != "example.com/svc/internal/store" || got[1].File != "tools/gen.go" {
+		t.Fatalf("unexpected go violation: %+v", got[1])
+	}
+}
+
+func TestFindEncapsulationViolations_InternalSymbolsAcrossBoundaries(t *testing.T) {
+	g := NewGraph()
+	g.AddFile(&parser.File{Path: "crates/core/src/lib.rs", Module: "core", Language: "rust", Definitions: []parser.Definition{
+		{Name: "helper", Kind: parser.KindFunction, Visibility: "internal", Location: parser.Location{File: "crates/core/src/lib.rs", Line: 3}},
+	}})
+	g.AddFile(&parser.File{Path: "crates/core/src/util.rs", Module: "core::util", Language: "rust",
+		Imports: []parser.Import{{Module: "core", Items: []string{"helper"}, Location: parser.Location{Line: 1, Column: 1}}},
+	})
+	g.AddFile(&parser.File{Path: "crates/cli/src/main.rs", Module: "cli", Language: "rust",
+		Imports: []parser.Import{{Module: "core", Items: []string{"helper"}, Location: parser.Location{Line: 2, Column: 1}}},
+	})
+
+	if got := g.FindEncapsulationViolations(EncapsulationOptions{}); len(got) != 0 {
+		t.Fatalf("expected internal symbols unchecked without a boundary, got %+v", got)
+	}
+
+	crate := func(path string) string {
+		parts := strings.Split(filepath.ToSlash(path), "/")
+		if len(parts) < 2 || parts[0] != "crates" {
+			return ""
+		}
+		return parts[1]
+	}
+	got := g.FindEncapsulationViolations(EncapsulationOptions{Boundary: crate})
+	if len(got) != 1 || got[0].Kind != EncapsulationInternalSymbol || got[0].From != "cli" || got[0].Symbol != "helper" || got[0].Visibility != "internal" {
+		t.Fatalf("expected one cross-crate internal symbol use, got %+v", got)
+	}
+}
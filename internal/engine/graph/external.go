@@ -0,0 +1,199 @@
+package graph
+
+// internal/engine/graph/external.go
+
+import (
+	"circular/internal/engine/parser"
+	"circular/internal/shared/util"
+	"sort"
+)
+
+// ExternalDependencyOptions supplies the manifests and import mapping used by
+// ExternalDependencies.
+type ExternalDependencyOptions struct {
+	// Manifests seed declared dependencies; lockfiles only feed Resolve.
+	Manifests []parser.DependencyManifest
+	// Resolve maps an import made by a file in language to the ecosystem and
+	// name of the third-party package providing it. Standard library,
+	// relative, and project-internal imports are rejected. Nil resolves
+	// nothing, leaving only declared dependencies.
+	Resolve func(language, importPath string) (ecosystem, name string, ok bool)
+}
+
+// ExternalImporter is an internal module importing an external dependency,
+// with the files that do so.
+type ExternalImporter struct {
+	Module string
+	Files  []string
+}
+
+// ExternalDependency is a third-party package node: what manifests declare
+// it and which internal modules and files import it.
+type ExternalDependency struct {
+	Name        string
+	Ecosystem   string
+	Version     string   // declared version, from the first declaring manifest
+	Manifests   []string // manifests declaring it; empty when undeclared
+	Dev         bool     // declared only in development/test scopes
+	Indirect    bool     // declared only as a go.mod `// indirect` requirement
+	ImportPaths []string // distinct import paths resolved to it
+	Importers   []ExternalImporter
+	FileCount   int
+}
+
+// Declared reports whether a manifest lists the dependency.
+func (d ExternalDependency) Declared() bool {
+	return len(d.Manifests) > 0
+}
+
+// Unused reports a declared, direct dependency no file imports.
+func (d ExternalDependency) Unused() bool {
+	return d.Declared() && !d.Indirect && d.FileCount == 0
+}
+
+// Status is "unused", "undeclared", "indirect", "dev", or "declared".
+func (d ExternalDependency) Status() string {
+	switch {
+	case d.Unused():
+		return "unused"
+	case !d.Declared():
+		return "undeclared"
+	case d.Indirect:
+		return "indirect"
+	case d.Dev:
+		return "dev"
+	default:
+		return "declared"
+	}
+}
+
+// ExternalDependencies builds the third-party dependency inventory: every
+// package declared by a manifest or imported by a graph file, sorted by
+// ecosystem then name. Imports of internal modules, of a prefix of one, or of
+// a path below one are never external.
+func (g *Graph) ExternalDependencies(opts ExternalDependencyOptions) []ExternalDependency {
+	type key struct{ ecosystem, name string }
+	type node struct {
+		dep       ExternalDependency
+		devOnly   bool
+		indirect  bool
+		importers map[string]map[string]bool
+		paths     map[string]bool
+	}
+	nodes := make(map[key]*node)
+	nodeFor := func(ecosystem, name string) *node {
+		k := key{ecosystem, name}
+		n, ok := nodes[k]
+		if !ok {
+			n = &node{
+				dep:       ExternalDependency{Name: name, Ecosystem: ecosystem},
+				devOnly:   true,
+				indirect:  true,
+				importers: make(map[string]map[string]bool),
+				paths:     make(map[string]bool),
+			}
+			nodes[k] = n
+		}
+		return n
+	}
+
+	manifests := append([]parser.DependencyManifest(nil), opts.Manifests...)
+	sort.SliceStable(manifests, func(i, j int) bool { return manifests[i].Path < manifests[j].Path })
+	for _, manifest := range manifests {
+		if manifest.Lockfile {
+			continue
+		}
+		for _, declared := range manifest.Dependencies {
+			n := nodeFor(manifest.Ecosystem, declared.Name)
+			if len(n.dep.Manifests) == 0 || n.dep.Manifests[len(n.dep.Manifests)-1] != manifest.Path {
+				n.dep.Manifests = append(n.dep.Manifests, manifest.Path)
+			}
+			if n.dep.Version == "" {
+				n.dep.Version = declared.Version
+			}
+			n.devOnly = n.devOnly && declared.Dev
+			n.indirect = n.indirect && declared.Indirect
+		}
+	}
+
+	if opts.Resolve != nil {
+		internal := g.internalPrefixes()
+		for _, path := range g.FilePaths() {
+			file, ok := g.GetFile(path)
+			if !ok {
+				continue
+			}
+			for _, imp := range file.Imports {
+				if imp.Module == "" || internal.covers(imp.Module) {
+					continue
+				}
+				ecosystem, name, ok := opts.Resolve(file.Language, imp.Module)
+				if !ok {
+					continue
+				}
+				n := nodeFor(ecosystem, name)
+				n.paths[imp.Module] = true
+				files, ok := n.importers[file.Module]
+				if !ok {
+					files = make(map[string]bool)
+					n.importers[file.Module] = files
+				}
+				files[path] = true
+			}
+		}
+	}
+
+	out := make([]ExternalDependency, 0, len(nodes))
+	for _, n := range nodes {
+		dep := n.dep
+		if dep.Declared() {
+			dep.Dev = n.devOnly
+			dep.Indirect = n.indirect
+		}
+		dep.ImportPaths = util.SortedStringKeys(n.paths)
+		for _, module := range util.SortedStringKeys(n.importers) {
+			files := util.SortedStringKeys(n.importers[module])
+			dep.Importers = append(dep.Importers, ExternalImporter{Module: module, Files: files})
+			dep.FileCount += len(files)
+		}
+		out = append(out, dep)
+	}
+	sort.Slice(out, func(i, j int) bool {
+		if out[i].Ecosystem != out[j].Ecosystem {
+			return out[i].Ecosystem < out[j].Ecosystem
+		}
+		return out[i].Name < out[j].Name
+	})
+	return out
+}
+
+// moduleNameSet is every internal module name together with all its prefixes.
+type moduleNameSet struct {
+	modules  map[string]bool
+	prefixes map[string]bool
+}
+
+func (g *Graph) internalPrefixes() moduleNameSet {
+	set := moduleNameSet{modules: make(map[string]bool), prefixes: make(map[string]bool)}
+	for name := range g.Modules() {
+		set.modules[name] = true
+		for depth := 1; depth < len(ModuleSegments(name)); depth++ {
+			set.prefixes[ModulePrefix(name, depth)] = true
+		}
+	}
+	return set
+}
+
+// covers reports whether name is an internal module, a prefix of one, or a
+// path below one.
+func (s moduleNameSet) covers(name string) bool {
+	if s.modules[name] || s.prefixes[name] {
+		return true
+	}
+	for depth := 1; depth < len(ModuleSegments(name)); depth++ {
+		if s.modules[ModulePrefix(name, depth)] {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,95 @@
+package graph
+
+import (
+	"circular/internal/engine/parser"
+	"strings"
+	"testing"
+)
+
+func TestExternalDependencies_DeclaredAndImported(t *testing.T) {
+	g := NewGraph()
+	g.AddFile(&parser.File{Path: "cmd/main.go", Module: "example.com/app/cmd", Language: "go", Imports: []parser.Import{
+		{Module: "example.com/app/internal"},
+		{Module: "github.com/spf13/cobra"},
+		{Module: "github.com/acme/undeclared/sub"},
+		{Module: "fmt"},
+	}})
+	g.AddFile(&parser.File{Path: "internal/run.go", Module: "example.com/app/internal", Language: "go", Imports: []parser.Import{
+		{Module: "github.com/spf13/cobra/doc"},
+		{Module: "example.com/app"},
+	}})
+	g.AddFile(&parser.File{Path: "internal/x.go", Module: "example.com/app/internal", Language: "go", Imports: []parser.Import{
+		{Module: "example.com/app/internal/gen"},
+	}})
+
+	opts := ExternalDependencyOptions{
+		Manifests: []parser.DependencyManifest{
+			{Path: "go.mod", Ecosystem: parser.EcosystemGo, Dependencies: []parser.DeclaredDependency{
+				{Name: "github.com/pkg/errors", Version: "v0.9.1"},
+				{Name: "github.com/spf13/cobra", Version: "v1.8.0"},
+				{Name: "golang.org/x/sys", Version: "v0.20.0", Indirect: true},
+			}},
+			{Path: "go.sum", Ecosystem: parser.EcosystemGo, Lockfile: true, Dependencies: []parser.DeclaredDependency{
+				{Name: "golang.org/x/text", Version: "v0.14.0"},
+			}},
+		},
+		Resolve: func(language, importPath string) (string, string, bool) {
+			if importPath == "fmt" {
+				return "", "", false
+			}
+			if strings.HasPrefix(importPath, "github.com/spf13/cobra") {
+				return parser.EcosystemGo, "github.com/spf13/cobra", true
+			}
+			return parser.EcosystemGo, importPath, true
+		},
+	}
+
+	deps := g.ExternalDependencies(opts)
+	byName := make(map[string]ExternalDependency, len(deps))
+	for _, dep := range deps {
+		byName[dep.Name] = dep
+	}
+	if len(deps) != 4 {
+		t.Fatalf("expected 4 dependencies, got %+v", deps)
+	}
+	if _, ok := byName["golang.org/x/text"]; ok {
+		t.Fatal("expected lockfile-only modules to stay out of the inventory")
+	}
+
+	cobra := byName["github.com/spf13/cobra"]
+	if cobra.Status() != "declared" || cobra.FileCount != 2 || len(cobra.Importers) != 2 || cobra.Version != "v1.8.0" {
+		t.Fatalf("unexpected cobra node: %+v", cobra)
+	}
+	if strings.Join(cobra.ImportPaths, ",") != "github.com/spf13/cobra,github.com/spf13/cobra/doc" {
+		t.Fatalf("unexpected cobra import paths: %v", cobra.ImportPaths)
+	}
+	if errs := byName["github.com/pkg/errors"]; !errs.Unused() || errs.Status() != "unused" {
+		t.Fatalf("expected pkg/errors to be unused, got %+v", errs)
+	}
+	if sys := byName["golang.org/x/sys"]; sys.Unused() || sys.Status() != "indirect" {
+		t.Fatalf("expected indirect requirement not to be reported unused, got %+v", sys)
+	}
+	undeclared := byName["github.com/acme/undeclared/sub"]
+	if undeclared.Status() != "undeclared" || undeclared.FileCount != 1 || undeclared.Importers[0].Files[0] != "cmd/main.go" {
+		t.Fatalf("unexpected undeclared node: %+v", undeclared)
+	}
+}
+
+func TestExternalDependencies_DevOnlyAcrossManifests(t *testing.T) {
+	g := NewGraph()
+	opts := ExternalDependencyOptions{Manifests: []parser.DependencyManifest{
+		{Path: "web/package.json", Ecosystem: parser.EcosystemNPM, Dependencies: []parser.DeclaredDependency{{Name: "vitest", Dev: true}, {Name: "react"}}},
+		{Path: "api/package.json", Ecosystem: parser.EcosystemNPM, Dependencies: []parser.DeclaredDependency{{Name: "vitest", Dev: true}, {Name: "react", Dev: true}}},
+	}}
+
+	deps := g.ExternalDependencies(opts)
+	if len(deps) != 2 || deps[0].Name != "react" || deps[1].Name != "vitest" {
+		t.Fatalf("unexpected dependencies: %+v", deps)
+	}
+	if deps[0].Dev || !deps[1].Dev {
+		t.Fatalf("expected only vitest to be dev-only, got %+v", deps)
+	}
+	if strings.Join(deps[1].Manifests, ",") != "api/package.json,web/package.json" {
+		t.Fatalf("expected manifests sorted by path, got %v", deps[1].Manifests)
+	}
+}
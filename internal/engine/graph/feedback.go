@@ -0,0 +1,309 @@
+package graph
+
+import (
+	"sort"
+)
+
+// exactCutSearchLimit bounds the number of intra-component edges for which
+// the minimum feedback edge set is found by exhaustive search. Larger
+// components fall back to the Eades-Lin-Smyth ordering heuristic.
+const exactCutSearchLimit = 16
+
+// CycleCut is one import edge suggested for removal to break a cycle.
+// Weight estimates the effort of removing it: the number of files that import
+// the target plus the number of references to the imported names.
+type CycleCut struct {
+	From        string
+	To          string
+	File        string
+	Line        int
+	Column      int
+	ImportSites int
+	SymbolRefs  int
+	Weight      int
+}
+
+// CycleBreakPlan lists the cheapest cuts found for one strongly connected
+// component. Removing every cut leaves the component acyclic.
+type CycleBreakPlan struct {
+	Modules     []string
+	Cuts        []CycleCut
+	TotalWeight int
+	Exact       bool // true when the cut set is a proven minimum
+}
+
+// SuggestCycleCuts computes a minimum (or, for large components, heuristic)
+// weighted feedback edge set for every cyclic strongly connected component.
+// Plans are ordered by their first module name.
+func (g *Graph) SuggestCycleCuts() []CycleBreakPlan {
+	g.mu.RLock()
+	defer g.mu.RUnlock()
+
+	nodes := g.moduleNamesLocked()
+	adjacency := make(map[string][]string, len(nodes))
+	for _, name := range nodes {
+		adjacency[name] = g.getSortedNeighbors(name)
+	}
+	componentOf, components := stronglyConnectedComponents(nodes, adjacency)
+
+	plans := make([]CycleBreakPlan, 0)
+	for compID, members := range components {
+		edges := make([]CycleCut, 0)
+		for _, from := range members {
+			for _, to := range adjacency[from] {
+				if componentOf[to] != compID {
+					continue
+				}
+				edges = append(edges, g.weighCutLocked(from, to))
+			}
+		}
+		if len(members) == 1 && len(edges) == 0 {
+			continue
+		}
+
+		var cuts []CycleCut
+		exact := len(edges) <= exactCutSearchLimit
+		if exact {
+			cuts = exactFeedbackEdges(members, edges)
+		} else {
+			cuts = minimizeFeedbackEdges(members, edges, orderingFeedbackEdges(members, edges))
+		}
+
+		plan := CycleBreakPlan{
+			Modules: append([]string(nil), members...),
+			Cuts:    cuts,
+			Exact:   exact,
+		}
+		for _, cut := range cuts {
+			plan.TotalWeight += cut.Weight
+		}
+		plans = append(plans, plan)
+	}
+
+	sort.Slice(plans, func(i, j int) bool { return plans[i].Modules[0] < plans[j].Modules[0] })
+	return plans
+}
+
+// weighCutLocked derives the removal weight of from -> to from the aggregated
+// import edge: the files importing the target and the references through
+// those imports.
+func (g *Graph) weighCutLocked(from, to string) CycleCut {
+	cut := CycleCut{From: from, To: to}
+	if edge := g.importsOfLocked(from)[to]; edge != nil {
+		cut.File = edge.ImportedBy
+		cut.Line = edge.Location.Line
+		cut.Column = edge.Location.Column
+		cut.ImportSites = len(edge.Files)
+		cut.SymbolRefs = edge.UsageCount
+	}
+	if cut.ImportSites == 0 {
+		cut.ImportSites = 1
+	}
+	cut.Weight = cut.ImportSites + cut.SymbolRefs
+	return cut
+}
+
+// exactFeedbackEdges tries every subset of edges and keeps the lightest one
+// whose removal is acyclic, preferring fewer cuts and then the earliest subset.
+func exactFeedbackEdges(members []string, edges []CycleCut) []CycleCut {
+	sortCuts(edges)
+	bestMask, bestWeight, bestCount := -1, 0, 0
+	for mask := 0; mask < 1<<len(edges); mask++ {
+		weight, count := 0, 0
+		for i := range edges {
+			if mask&(1<<i) != 0 {
+				weight += edges[i].Weight
+				count++
+			}
+		}
+		if bestMask >= 0 && (weight > bestWeight || (weight == bestWeight && count >= bestCount)) {
+			continue
+		}
+		removed := make(map[int]bool, count)
+		for i := range edges {
+			if mask&(1<<i) != 0 {
+				removed[i] = true
+			}
+		}
+		if isAcyclicWithout(members, edges, removed) {
+			bestMask, bestWeight, bestCount = mask, weight, count
+		}
+	}
+
+	cuts := make([]CycleCut, 0, bestCount)
+	for i := range edges {
+		if bestMask&(1<<i) != 0 {
+			cuts = append(cuts, edges[i])
+		}
+	}
+	return cuts
+}
+
+// orderingFeedbackEdges applies the weighted Eades-Lin-Smyth heuristic: it
+// orders the component by peeling sinks and sources, otherwise taking the node
+// with the largest outgoing-minus-incoming weight, and cuts every edge that
+// points backwards in that order. It returns indexes into edges.
+func orderingFeedbackEdges(members []string, edges []CycleCut) map[int]bool {
+	remaining := make(map[string]bool, len(members))
+	inWeight := make(map[string]int, len(members))
+	outWeight := make(map[string]int, len(members))
+	inCount := make(map[string]int, len(members))
+	outCount := make(map[string]int, len(members))
+	incoming := make(map[string][]int, len(members))
+	outgoing := make(map[string][]int, len(members))
+	for _, m := range members {
+		remaining[m] = true
+	}
+	for i, e := range edges {
+		if e.From == e.To {
+			continue
+		}
+		outWeight[e.From] += e.Weight
+		outCount[e.From]++
+		inWeight[e.To] += e.Weight
+		inCount[e.To]++
+		outgoing[e.From] = append(outgoing[e.From], i)
+		incoming[e.To] = append(incoming[e.To], i)
+	}
+	take := func(n string) {
+		delete(remaining, n)
+		for _, i := range outgoing[n] {
+			inWeight[edges[i].To] -= edges[i].Weight
+			inCount[edges[i].To]--
+		}
+		for _, i := range incoming[n] {
+			outWeight[edges[i].From] -= edges[i].Weight
+			outCount[edges[i].From]--
+		}
+	}
+
+	head := make([]string, 0, len(members))
+	tail := make([]string, 0)
+	for len(remaining) > 0 {
+		names := make([]string, 0, len(remaining))
+		for n := range remaining {
+			names = append(names, n)
+		}
+		sort.Strings(names)
+
+		progressed := false
+		for _, n := range names {
+			if remaining[n] && outCount[n] == 0 {
+				tail = append(tail, n)
+				take(n)
+				progressed = true
+			}
+		}
+		for _, n := range names {
+			if remaining[n] && inCount[n] == 0 {
+				head = append(head, n)
+				take(n)
+				progressed = true
+			}
+		}
+		if progressed {
+			continue
+		}
+
+		best := names[0]
+		for _, n := range names[1:] {
+			if outWeight[n]-inWeight[n] > outWeight[best]-inWeight[best] {
+				best = n
+			}
+		}
+		head = append(head, best)
+		take(best)
+	}
+
+	position := make(map[string]int, len(members))
+	for i, n := range head {
+		position[n] = i
+	}
+	for i := len(tail) - 1; i >= 0; i-- {
+		position[tail[i]] = len(head) + (len(tail) - 1 - i)
+	}
+
+	cut := make(map[int]bool)
+	for i, e := range edges {
+		if position[e.From] >= position[e.To] {
+			cut[i] = true
+		}
+	}
+	return cut
+}
+
+// minimizeFeedbackEdges restores cuts, heaviest first, whenever the component
+// stays acyclic without them, so no suggested cut is redundant.
+func minimizeFeedbackEdges(members []string, edges []CycleCut, removed map[int]bool) []CycleCut {
+	order := make([]int, 0, len(removed))
+	for i := range removed {
+		order = append(order, i)
+	}
+	sort.Slice(order, func(a, b int) bool {
+		if edges[order[a]].Weight != edges[order[b]].Weight {
+			return edges[order[a]].Weight > edges[order[b]].Weight
+		}
+		return cutLess(edges[order[a]], edges[order[b]])
+	})
+	for _, i := range order {
+		delete(removed, i)
+		if !isAcyclicWithout(members, edges, removed) {
+			removed[i] = true
+		}
+	}
+
+	cuts := make([]CycleCut, 0, len(removed))
+	for i := range removed {
+		cuts = append(cuts, edges[i])
+	}
+	sortCuts(cuts)
+	return cuts
+}
+
+// isAcyclicWithout runs Kahn's algorithm over the component with the removed
+// edges left out.
+func isAcyclicWithout(members []string, edges []CycleCut, removed map[int]bool) bool {
+	inDegree := make(map[string]int, len(members))
+	out := make(map[string][]string, len(members))
+	for i, e := range edges {
+		if removed[i] {
+			continue
+		}
+		if e.From == e.To {
+			return false
+		}
+		inDegree[e.To]++
+		out[e.From] = append(out[e.From], e.To)
+	}
+
+	queue := make([]string, 0, len(members))
+	for _, m := range members {
+		if inDegree[m] == 0 {
+			queue = append(queue, m)
+		}
+	}
+	visited := 0
+	for len(queue) > 0 {
+		n := queue[0]
+		queue = queue[1:]
+		visited++
+		for _, next := range out[n] {
+			inDegree[next]--
+			if inDegree[next] == 0 {
+				queue = append(queue, next)
+			}
+		}
+	}
+	return visited == len(members)
+}
+
+func sortCuts(cuts []CycleCut) {
+	sort.Slice(cuts, func(i, j int) bool { return cutLess(cuts[i], cuts[j]) })
+}
+
+func cutLess(a, b CycleCut) bool {
+	if a.From != b.From {
+		return a.From < b.From
+	}
+	return a.To < b.To
+}
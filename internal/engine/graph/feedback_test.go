@@ -0,0 +1,119 @@
+package graph
+
+import (
+	"circular/internal/engine/parser"
+	"fmt"
+	"testing"
+)
+
+func TestSuggestCycleCuts_PrefersCheapestEdge(t *testing.T) {
+	g := NewGraph()
+	// a <-> b, where a uses b heavily and b only touches a once.
+	g.AddFile(&parser.File{
+		Path:   "a1.go",
+		Module: "a",
+		Imports: []parser.Import{
+			{Module: "b", UsageCount: 5, Location: parser.Location{File: "a1.go", Line: 3}},
+		},
+	})
+	g.AddFile(&parser.File{
+		Path:   "a2.go",
+		Module: "a",
+		Imports: []parser.Import{
+			{Module: "b", UsageCount: 2, Location: parser.Location{File: "a2.go", Line: 4}},
+		},
+	})
+	g.AddFile(&parser.File{
+		Path:   "b.go",
+		Module: "b",
+		Imports: []parser.Import{
+			{Module: "a", UsageCount: 1, Location: parser.Location{File: "b.go", Line: 7, Column: 2}},
+		},
+	})
+	g.AddFile(&parser.File{Path: "c.go", Module: "c", Imports: []parser.Import{{Module: "a"}}})
+
+	plans := g.SuggestCycleCuts()
+	if len(plans) != 1 {
+		t.Fatalf("expected one cyclic component, got %d", len(plans))
+	}
+	plan := plans[0]
+	if !plan.Exact {
+		t.Fatal("expected small component to be solved exactly")
+	}
+	if len(plan.Modules) != 2 || plan.Modules[0] != "a" || plan.Modules[1] != "b" {
+		t.Fatalf("unexpected component modules: %v", plan.Modules)
+	}
+	if len(plan.Cuts) != 1 {
+		t.Fatalf("expected one cut, got %+v", plan.Cuts)
+	}
+	cut := plan.Cuts[0]
+	if cut.From != "b" || cut.To != "a" {
+		t.Fatalf("expected cut b -> a, got %s -> %s", cut.From, cut.To)
+	}
+	if cut.ImportSites != 1 || cut.SymbolRefs != 1 || cut.Weight != 2 || plan.TotalWeight != 2 {
+		t.Fatalf("unexpected cut weights: %+v (total %d)", cut, plan.TotalWeight)
+	}
+	if cut.File != "b.go" || cut.Line != 7 || cut.Column != 2 {
+		t.Fatalf("unexpected cut location: %+v", cut)
+	}
+}
+
+func TestSuggestCycleCuts_NoCycles(t *testing.T) {
+	g := NewGraph()
+	g.AddFile(&parser.File{Path: "a.go", Module: "a", Imports: []parser.Import{{Module: "b"}}})
+	g.AddFile(&parser.File{Path: "b.go", Module: "b"})
+
+	if plans := g.SuggestCycleCuts(); len(plans) != 0 {
+		t.Fatalf("expected no plans, got %+v", plans)
+	}
+}
+
+func TestSuggestCycleCuts_HeuristicBreaksLargeComponent(t *testing.T) {
+	g := NewGraph()
+	// A dense ring with chords: more edges than the exact search handles.
+	const n = 8
+	for i := 0; i < n; i++ {
+		imports := []parser.Import{
+			{Module: fmt.Sprintf("m%d", (i+1)%n)},
+			{Module: fmt.Sprintf("m%d", (i+3)%n)},
+			{Module: fmt.Sprintf("m%d", (i+5)%n)},
+		}
+		g.AddFile(&parser.File{Path: fmt.Sprintf("m%d.go", i), Module: fmt.Sprintf("m%d", i), Imports: imports})
+	}
+
+	plans := g.SuggestCycleCuts()
+	if len(plans) != 1 {
+		t.Fatalf("expected one component, got %d", len(plans))
+	}
+	plan := plans[0]
+	if plan.Exact {
+		t.Fatal("expected heuristic for large component")
+	}
+
+	edges := make([]CycleCut, 0)
+	removed := make(map[int]bool)
+	for from, targets := range g.GetImports() {
+		for to := range targets {
+			idx := len(edges)
+			edges = append(edges, CycleCut{From: from, To: to})
+			for _, cut := range plan.Cuts {
+				if cut.From == from && cut.To == to {
+					removed[idx] = true
+				}
+			}
+		}
+	}
+	if len(removed) != len(plan.Cuts) {
+		t.Fatalf("cuts do not match graph edges: %+v", plan.Cuts)
+	}
+	if !isAcyclicWithout(plan.Modules, edges, removed) {
+		t.Fatal("expected suggested cuts to break every cycle")
+	}
+	for idx := range removed {
+		delete(removed, idx)
+		if isAcyclicWithout(plan.Modules, edges, removed) {
+			t.Fatalf("cut %s -> %s is redundant", edges[idx].From, edges[idx].To)
+		}
+		removed[idx] = true
+	}
+}
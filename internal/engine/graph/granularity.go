@@ -0,0 +1,152 @@
+package graph
+
+// internal/engine/graph/granularity.go
+
+import (
+	"circular/internal/engine/parser"
+	"circular/internal/shared/util"
+	"fmt"
+	"path/filepath"
+	"strings"
+)
+
+// Granularity selects what a graph node stands for.
+type Granularity string
+
+const (
+	GranularityModule    Granularity = "module"
+	GranularityFile      Granularity = "file"
+	GranularityDirectory Granularity = "directory"
+)
+
+// ParseGranularity accepts "module", "file", or "directory"; empty means
+// module.
+func ParseGranularity(raw string) (Granularity, error) {
+	switch Granularity(strings.ToLower(strings.TrimSpace(raw))) {
+	case "", GranularityModule:
+		return GranularityModule, nil
+	case GranularityFile:
+		return GranularityFile, nil
+	case GranularityDirectory:
+		return GranularityDirectory, nil
+	default:
+		return "", fmt.Errorf("unknown granularity %q (want module, file, or directory)", raw)
+	}
+}
+
+// AtGranularity returns a graph whose nodes are files or directories instead
+// of modules, so cycle detection, metrics, diagrams, and tracing run unchanged
+// on finer import edges. Node names are slash-separated paths relative to
+// root (absolute paths are kept when root is empty or does not contain them).
+//
+// An import of a module with several files is resolved to the files defining
+// the symbols the importer uses through it, from linked symbol edges, imported
+// items, and references qualified by the import binding. When nothing
+// resolves, the import points at every file of the module. Imports of modules
+// without files keep the module name. The receiver is not modified; module
+// granularity returns it as is.
+func (g *Graph) AtGranularity(level Granularity, root string) (*Graph, error) {
+	if level == "" || level == GranularityModule {
+		return g, nil
+	}
+	if level != GranularityFile && level != GranularityDirectory {
+		return nil, fmt.Errorf("unknown granularity %q", level)
+	}
+	sim, err := g.newSimulation()
+	if err != nil {
+		return nil, err
+	}
+
+	node := func(path string) string {
+		name := granularityPath(path, root)
+		if level == GranularityDirectory {
+			name = filepath.ToSlash(filepath.Dir(name))
+		}
+		return name
+	}
+
+	moduleFiles := make(map[string][]string)
+	definedIn := make(map[string]map[string][]string) // module -> name -> paths
+	for _, path := range util.SortedStringKeys(sim.files) {
+		file := sim.files[path]
+		moduleFiles[file.Module] = append(moduleFiles[file.Module], path)
+		if definedIn[file.Module] == nil {
+			definedIn[file.Module] = make(map[string][]string)
+		}
+		for _, def := range file.Definitions {
+			definedIn[file.Module][def.Name] = append(definedIn[file.Module][def.Name], path)
+		}
+	}
+
+	for _, path := range util.SortedStringKeys(sim.files) {
+		file := sim.files[path]
+		self := node(path)
+
+		linked := make(map[string]map[string]bool) // module -> defining paths
+		for _, edge := range sim.edges[path] {
+			defPath, ok := sim.defFile[edge.To]
+			if !ok {
+				continue
+			}
+			if linked[edge.To.Module] == nil {
+				linked[edge.To.Module] = make(map[string]bool)
+			}
+			linked[edge.To.Module][defPath] = true
+		}
+
+		imports := make([]parser.Import, 0, len(file.Imports))
+		for _, imp := range file.Imports {
+			candidates := moduleFiles[imp.Module]
+			var targets []string
+			switch len(candidates) {
+			case 0:
+				targets = []string{imp.Module}
+			case 1:
+				targets = []string{node(candidates[0])}
+			default:
+				resolved := make(map[string]bool, len(linked[imp.Module]))
+				for defPath := range linked[imp.Module] {
+					resolved[defPath] = true
+				}
+				for _, symbol := range importedSymbols(file, imp) {
+					for _, defPath := range definedIn[imp.Module][symbol] {
+						resolved[defPath] = true
+					}
+				}
+				if len(resolved) == 0 {
+					for _, candidate := range candidates {
+						resolved[candidate] = true
+					}
+				}
+				seen := make(map[string]bool, len(resolved))
+				for _, defPath := range util.SortedStringKeys(resolved) {
+					if name := node(defPath); !seen[name] {
+						seen[name] = true
+						targets = append(targets, name)
+					}
+				}
+			}
+			for _, target := range targets {
+				if target == self {
+					continue
+				}
+				projected := imp
+				projected.Module = target
+				imports = append(imports, projected)
+			}
+		}
+		file.Imports = imports
+		file.Module = self
+	}
+	return sim.build(), nil
+}
+
+// granularityPath makes path relative to root when it lies below it.
+func granularityPath(path, root string) string {
+	if root != "" {
+		if rel, err := filepath.Rel(root, path); err == nil && rel != ".." && !strings.HasPrefix(rel, ".."+string(filepath.Separator)) {
+			path = rel
+		}
+	}
+	return filepath.ToSlash(filepath.Clean(path))
+}
@@ -0,0 +1,105 @@
+package graph
+
+import (
+	"circular/internal/engine/parser"
+	"reflect"
+	"testing"
+)
+
+func addGranularityFixture(g *Graph, itemImport bool) {
+	var items []string
+	if itemImport {
+		items = []string{"Helper"}
+	}
+	g.AddFile(&parser.File{
+		Path:        "/repo/pkg/a.py",
+		Language:    "python",
+		Module:      "pkg",
+		Imports:     []parser.Import{{Module: "other", Items: []string{"Run"}, Location: parser.Location{Line: 1}}, {Module: "os"}},
+		Definitions: []parser.Definition{{Name: "Entry"}},
+	})
+	g.AddFile(&parser.File{
+		Path:        "/repo/pkg/b.py",
+		Language:    "python",
+		Module:      "pkg",
+		Definitions: []parser.Definition{{Name: "Helper"}},
+	})
+	g.AddFile(&parser.File{
+		Path:        "/repo/other/c.py",
+		Language:    "python",
+		Module:      "other",
+		Imports:     []parser.Import{{Module: "pkg", Items: items, Location: parser.Location{Line: 2}}},
+		Definitions: []parser.Definition{{Name: "Run"}},
+	})
+}
+
+func TestAtGranularity_FileResolvesImportedSymbols(t *testing.T) {
+	g := NewGraph()
+	addGranularityFixture(g, true)
+	if cycles := g.DetectCycles(); len(cycles) != 1 {
+		t.Fatalf("expected module-level cycle, got %v", cycles)
+	}
+
+	files, err := g.AtGranularity(GranularityFile, "/repo")
+	if err != nil {
+		t.Fatalf("file granularity: %v", err)
+	}
+	if cycles := files.DetectCycles(); len(cycles) != 0 {
+		t.Fatalf("expected no file-level cycle, got %v", cycles)
+	}
+	chain, ok := files.FindImportChain("pkg/a.py", "pkg/b.py")
+	if !ok || !reflect.DeepEqual(chain, []string{"pkg/a.py", "other/c.py", "pkg/b.py"}) {
+		t.Fatalf("unexpected file-level chain: %v", chain)
+	}
+	imports := files.GetImports()
+	if _, ok := imports["pkg/a.py"]["os"]; !ok {
+		t.Fatalf("expected external import to keep its module name, got %v", imports["pkg/a.py"])
+	}
+	if edge := imports["other/c.py"]["pkg/b.py"]; edge == nil || edge.Location.Line != 2 {
+		t.Fatalf("expected located file edge, got %+v", edge)
+	}
+	if _, ok := g.GetModule("pkg"); !ok {
+		t.Fatal("expected receiver to keep module nodes")
+	}
+}
+
+func TestAtGranularity_FileFallsBackToEveryModuleFile(t *testing.T) {
+	g := NewGraph()
+	addGranularityFixture(g, false)
+
+	files, err := g.AtGranularity(GranularityFile, "/repo")
+	if err != nil {
+		t.Fatalf("file granularity: %v", err)
+	}
+	if cycles := files.DetectCycles(); len(cycles) != 1 || !reflect.DeepEqual(cycles[0], []string{"other/c.py", "pkg/a.py"}) {
+		t.Fatalf("expected unresolved import to reach every file, got %v", cycles)
+	}
+}
+
+func TestAtGranularity_DirectoryRollup(t *testing.T) {
+	g := NewGraph()
+	addGranularityFixture(g, true)
+
+	dirs, err := g.AtGranularity(GranularityDirectory, "/repo")
+	if err != nil {
+		t.Fatalf("directory granularity: %v", err)
+	}
+	if dirs.ModuleCount() != 2 {
+		t.Fatalf("expected two directory nodes, got %v", dirs.Modules())
+	}
+	if cycles := dirs.DetectCycles(); len(cycles) != 1 || !reflect.DeepEqual(cycles[0], []string{"other", "pkg"}) {
+		t.Fatalf("expected directory cycle, got %v", cycles)
+	}
+}
+
+func TestParseGranularity(t *testing.T) {
+	for raw, want := range map[string]Granularity{"": GranularityModule, "File": GranularityFile, " directory ": GranularityDirectory} {
+		got, err := ParseGranularity(raw)
+		if err != nil || got != want {
+			t.Fatalf("ParseGranularity(%q) = %q, %v", raw, got, err)
+		}
+	}
+	if _, err := ParseGranularity("package"); err == nil {
+		t.Fatal("expected error for unknown granularity")
+	}
+}
@@ -3,8 +3,8 @@ package graph
 
 import (
 	"circular/internal/engine/parser"
-	"circular/internal/shared/observability"
 	"sort"
+	"strings"
 	"sync"
 )
 
@@ -19,6 +19,7 @@ type Graph struct {
 	fileCache      *LRUCache[string, *parser.File] // path -> file
 	fileToModule   map[string]string               // path -> module name
 	fileToLanguage map[string]string               // path -> language
+	fileToLOC      map[string]int                  // path -> source lines
 	modules        map[string]*Module              // module name -> module info
 
 	loader FileLoader
@@ -30,8 +31,23 @@ type Graph struct {
 	// Symbol tables (for hallucination detection)
 	definitions map[string]map[string]*parser.Definition // module -> symbol -> def
 
+	// Symbol-level edges linked by the resolver
+	symbolEdges  map[string][]SymbolEdge // path -> outgoing symbol edges
+	symbolLinked map[string]bool         // path -> edges are current
+
 	// Invalidation tracking
 	dirty map[string]bool // Files needing re-analysis
+
+	// Paging of cold modules and their adjacency to node storage
+	nodeStorage  NodeStorage
+	projectKey   string
+	nodeBudget   int               // max resident modules before paging out
+	paged        map[string]bool   // module -> node and adjacency live in storage
+	pagedEdges   int               // import edges held by paged modules
+	residentUse  map[string]uint64 // module -> last mutation/fault-in tick
+	useClock     uint64
+	storageErrMu sync.Mutex
+	storageErr   error
 }
 
 type Module struct {
@@ -42,18 +58,42 @@ type Module struct {
 	RootPath      string // For Go: module root, Python: package root
 }
 
+// ImportEdge aggregates every import of To from files in From. ImportedBy
+// and Location point at the first site by file path and line.
 type ImportEdge struct {
-	From       string
-	To         string
-	ImportedBy string // File path
-	Location   parser.Location
+	From        string
+	To          string
+	ImportedBy  string // File path
+	Location    parser.Location
+	Files       []string // every importing file, sorted
+	ImportCount int      // import statements across Files
+	UsageCount  int      // summed parser.Import.UsageCount
+	Symbols     []string // imported items and qualified names referenced through the import, sorted
+}
+
+// Weight is the edge's coupling weight: import statements plus references
+// through them.
+func (e *ImportEdge) Weight() int {
+	if e == nil {
+		return 0
+	}
+	return e.ImportCount + e.UsageCount
 }
 
 type ModuleMetrics struct {
 	Depth           int
-	FanIn           int
-	FanOut          int
+	FanIn           int     // afferent coupling (Ca)
+	FanOut          int     // efferent coupling (Ce)
 	ImportanceScore float64 // (FanIn*2) + (FanOut*1) + (Complexity*0.5) + (IsAPI?10:0)
+	Instability     float64 // Ce / (Ca + Ce)
+	Abstractness    float64 // abstract types / all types
+	Distance        float64 // |A + I - 1|
+
+	// Transitive dependency closure ("import cost"): internal modules pulled in
+	// directly or through other imports, their files, and those files' lines.
+	TransitiveModules int
+	TransitiveFiles   int
+	TransitiveLOC     int
 }
 
 func NewGraph() *Graph {
@@ -65,11 +105,16 @@ func NewGraphWithCapacity(capacity int) *Graph {
 		fileCache:      NewLRUCache[string, *parser.File](capacity),
 		fileToModule:   make(map[string]string),
 		fileToLanguage: make(map[string]string),
+		fileToLOC:      make(map[string]int),
 		modules:        make(map[string]*Module),
 		imports:        make(map[string]map[string]*ImportEdge),
 		importedBy:     make(map[string]map[string]bool),
 		definitions:    make(map[string]map[string]*parser.Definition),
+		symbolEdges:    make(map[string][]SymbolEdge),
+		symbolLinked:   make(map[string]bool),
 		dirty:          make(map[string]bool),
+		paged:          make(map[string]bool),
+		residentUse:    make(map[string]uint64),
 	}
 }
 
@@ -89,19 +134,31 @@ func (g *Graph) PruneCache(percentage int) int {
 	return g.fileCache.Prune(percentage)
 }
 
-func (g *Graph) AddFile(file *parser.File) {
+// AddFile adds or replaces a file's contributions. It fails, leaving the
+// graph unchanged, only when the file's module is paged out and cannot be read
+// back from node storage; adding the file again retries.
+func (g *Graph) AddFile(file *parser.File) error {
 	g.mu.Lock()
 	defer g.mu.Unlock()
 
+	if err := g.faultInLocked(file.Module); err != nil {
+		return err
+	}
+
 	// If this file already exists, remove prior contributions first.
 	// This prevents stale imports/definitions after file edits.
 	if _, exists := g.fileCache.Get(file.Path); exists {
-		g.removeFileLocked(file.Path)
+		if err := g.removeFileLocked(file.Path); err != nil {
+			return err
+		}
 	}
+	delete(g.symbolEdges, file.Path)
+	delete(g.symbolLinked, file.Path)
 
 	g.fileCache.Put(file.Path, cloneFile(file))
 	g.fileToModule[file.Path] = file.Module
 	g.fileToLanguage[file.Path] = file.Language
+	g.fileToLOC[file.Path] = file.LOC
 
 	mod, ok := g.modules[file.Module]
 	if !ok {
@@ -158,38 +215,32 @@ func (g *Graph) AddFile(file *parser.File) {
 	}
 
 	for _, imp := range file.Imports {
-		edge := &ImportEdge{
-			From:       file.Module,
-			To:         imp.Module,
-			ImportedBy: file.Path,
-			Location:   imp.Location,
-		}
-		g.imports[file.Module][imp.Module] = edge
-
-		if g.importedBy[imp.Module] == nil {
-			g.importedBy[imp.Module] = make(map[string]bool)
-		}
-		g.importedBy[imp.Module][file.Module] = true
+		g.addImportSiteLocked(file, imp)
 	}
 
-	observability.GraphNodes.Set(float64(len(g.modules)))
-	edgeCount := 0
-	for _, targets := range g.imports {
-		edgeCount += len(targets)
+	g.touchLocked(file.Module)
+	if err := g.enforceNodeBudgetLocked(); err != nil {
+		g.recordStorageErr(err)
 	}
-	observability.GraphEdges.Set(float64(edgeCount))
+	g.publishGraphSizeLocked()
+	return nil
 }
 
-func (g *Graph) RemoveFile(path string) {
+// RemoveFile drops a file's contributions. Like AddFile, it only fails when the
+// file's module cannot be faulted back in, and leaves the graph unchanged.
+func (g *Graph) RemoveFile(path string) error {
 	g.mu.Lock()
 	defer g.mu.Unlock()
-	g.removeFileLocked(path)
+	return g.removeFileLocked(path)
 }
 
-func (g *Graph) removeFileLocked(path string) {
+func (g *Graph) removeFileLocked(path string) error {
 	moduleName, ok := g.fileToModule[path]
 	if !ok {
-		return
+		return nil
+	}
+	if err := g.faultInLocked(moduleName); err != nil {
+		return err
 	}
 
 	if mod, ok := g.modules[moduleName]; ok {
@@ -202,14 +253,13 @@ func (g *Graph) removeFileLocked(path string) {
 
 		if len(mod.Files) == 0 {
 			for to := range g.imports[moduleName] {
-				if g.importedBy[to] != nil {
-					delete(g.importedBy[to], moduleName)
-				}
+				g.removeImporterLocked(to, moduleName)
 			}
 
 			delete(g.modules, moduleName)
 			delete(g.imports, moduleName)
 			delete(g.definitions, moduleName)
+			delete(g.residentUse, moduleName)
 		} else {
 			mod.Exports = make(map[string]*parser.Definition)
 			g.definitions[moduleName] = make(map[string]*parser.Definition)
@@ -233,26 +283,14 @@ func (g *Graph) removeFileLocked(path string) {
 						}
 					}
 					for _, imp := range f.Imports {
-						edge := &ImportEdge{
-							From:       f.Module,
-							To:         imp.Module,
-							ImportedBy: f.Path,
-							Location:   imp.Location,
-						}
-						g.imports[f.Module][imp.Module] = edge
-						if g.importedBy[imp.Module] == nil {
-							g.importedBy[imp.Module] = make(map[string]bool)
-						}
-						g.importedBy[imp.Module][f.Module] = true
+						g.addImportSiteLocked(f, imp)
 					}
 				}
 			}
 
 			for to := range oldImports {
 				if _, stillImported := g.imports[moduleName][to]; !stillImported {
-					if g.importedBy[to] != nil {
-						delete(g.importedBy[to], moduleName)
-					}
+					g.removeImporterLocked(to, moduleName)
 				}
 			}
 		}
@@ -261,19 +299,18 @@ func (g *Graph) removeFileLocked(path string) {
 	g.fileCache.Evict(path)
 	delete(g.fileToModule, path)
 	delete(g.fileToLanguage, path)
+	delete(g.fileToLOC, path)
+	delete(g.symbolEdges, path)
+	delete(g.symbolLinked, path)
 
-	observability.GraphNodes.Set(float64(len(g.modules)))
-	edgeCount := 0
-	for _, targets := range g.imports {
-		edgeCount += len(targets)
-	}
-	observability.GraphEdges.Set(float64(edgeCount))
+	g.publishGraphSizeLocked()
+	return nil
 }
 
 func (g *Graph) GetModule(name string) (*Module, bool) {
 	g.mu.RLock()
 	defer g.mu.RUnlock()
-	mod, ok := g.modules[name]
+	mod, ok := g.moduleLocked(name)
 	if !ok {
 		return nil, false
 	}
@@ -284,17 +321,22 @@ func (g *Graph) Modules() map[string]*Module {
 	g.mu.RLock()
 	defer g.mu.RUnlock()
 
-	res := make(map[string]*Module, len(g.modules))
+	res := make(map[string]*Module, g.moduleCountLocked())
 	for name, mod := range g.modules {
 		res[name] = cloneModule(mod)
 	}
+	for name := range g.paged {
+		if mod, ok := g.moduleLocked(name); ok {
+			res[name] = mod
+		}
+	}
 	return res
 }
 
 func (g *Graph) ModuleCount() int {
 	g.mu.RLock()
 	defer g.mu.RUnlock()
-	return len(g.modules)
+	return g.moduleCountLocked()
 }
 
 func (g *Graph) GetDefinitions(moduleName string) (map[string]*parser.Definition, bool) {
@@ -311,6 +353,18 @@ func (g *Graph) GetDefinitions(moduleName string) (map[string]*parser.Definition
 	return res, true
 }
 
+// LookupDefinition returns a single definition without cloning the module's
+// whole symbol table.
+func (g *Graph) LookupDefinition(moduleName, symbolName string) (*parser.Definition, bool) {
+	g.mu.RLock()
+	defer g.mu.RUnlock()
+	def, ok := g.definitions[moduleName][symbolName]
+	if !ok {
+		return nil, false
+	}
+	return cloneDefinition(def), true
+}
+
 func (g *Graph) HasDefinitions(moduleName string) bool {
 	g.mu.RLock()
 	defer g.mu.RUnlock()
@@ -348,6 +402,19 @@ func (g *Graph) GetFile(path string) (*parser.File, bool) {
 	return nil, false
 }
 
+// FilePaths returns the sorted path of every file in the graph, including
+// files whose parse has been evicted from the file cache.
+func (g *Graph) FilePaths() []string {
+	g.mu.RLock()
+	defer g.mu.RUnlock()
+	paths := make([]string, 0, len(g.fileToModule))
+	for path := range g.fileToModule {
+		paths = append(paths, path)
+	}
+	sort.Strings(paths)
+	return paths
+}
+
 func (g *Graph) GetAllFiles() []*parser.File {
 	g.mu.RLock()
 	defer g.mu.RUnlock()
@@ -365,8 +432,9 @@ func (g *Graph) GetImports() map[string]map[string]*ImportEdge {
 	g.mu.RLock()
 	defer g.mu.RUnlock()
 
-	res := make(map[string]map[string]*ImportEdge, len(g.imports))
-	for from, targets := range g.imports {
+	imports := g.allImportsLocked()
+	res := make(map[string]map[string]*ImportEdge, len(imports))
+	for from, targets := range imports {
 		res[from] = make(map[string]*ImportEdge, len(targets))
 		for to, edge := range targets {
 			res[from][to] = cloneImportEdge(edge)
@@ -379,17 +447,13 @@ func (g *Graph) ComputeModuleMetrics() map[string]ModuleMetrics {
 	g.mu.RLock()
 	defer g.mu.RUnlock()
 
-	moduleNames := make([]string, 0, len(g.modules))
-	for name := range g.modules {
-		moduleNames = append(moduleNames, name)
-	}
-	sort.Strings(moduleNames)
+	moduleNames := g.moduleNamesLocked()
 
 	adjacency := make(map[string][]string, len(moduleNames))
 	for _, name := range moduleNames {
 		targetSet := make(map[string]bool)
-		for to := range g.imports[name] {
-			if _, ok := g.modules[to]; ok {
+		for to := range g.importsOfLocked(name) {
+			if g.hasModuleLocked(to) {
 				targetSet[to] = true
 			}
 		}
@@ -447,14 +511,17 @@ func (g *Graph) ComputeModuleMetrics() map[string]ModuleMetrics {
 		computeDepth(comp)
 	}
 
+	closure := g.importClosureLocked(moduleNames, adjacency)
+
 	// Compute max complexity score per module for the importance formula.
 	metrics := make(map[string]ModuleMetrics, len(moduleNames))
-	for _, name := range moduleNames {
+	for i, name := range moduleNames {
 		fi := fanIn[name]
 		fo := fanOut[name]
 
 		maxComplexity := 0
-		if defs, ok := g.definitions[name]; ok {
+		defs := g.definitions[name]
+		if len(defs) > 0 {
 			for _, def := range defs {
 				sc := def.ComplexityScore
 				if sc == 0 {
@@ -466,11 +533,20 @@ func (g *Graph) ComputeModuleMetrics() map[string]ModuleMetrics {
 			}
 		}
 
+		instability := CalculateInstability(fi, fo)
+		abstractness := CalculateAbstractness(defs)
+		modules, files, loc := closure.weigh(closure.reach[i])
 		metrics[name] = ModuleMetrics{
-			Depth:           depthByComp[componentOf[name]],
-			FanIn:           fi,
-			FanOut:          fo,
-			ImportanceScore: CalculateImportanceScore(fi, fo, maxComplexity, name),
+			Depth:             depthByComp[componentOf[name]],
+			FanIn:             fi,
+			FanOut:            fo,
+			ImportanceScore:   CalculateImportanceScore(fi, fo, maxComplexity, name),
+			Instability:       instability,
+			Abstractness:      abstractness,
+			Distance:          CalculateMainSequenceDistance(abstractness, instability),
+			TransitiveModules: modules,
+			TransitiveFiles:   files,
+			TransitiveLOC:     loc,
 		}
 	}
 
@@ -564,6 +640,7 @@ func cloneFile(file *parser.File) *parser.File {
 	}
 	c := *file
 	c.Imports = append([]parser.Import(nil), file.Imports...)
+	c.SampleImports = append([]parser.Import(nil), file.SampleImports...)
 	c.Definitions = append([]parser.Definition(nil), file.Definitions...)
 	for i := range c.Definitions {
 		if len(c.Definitions[i].Decorators) == 0 {
@@ -582,9 +659,80 @@ func cloneImportEdge(edge *ImportEdge) *ImportEdge {
 		return nil
 	}
 	c := *edge
+	c.Files = append([]string(nil), edge.Files...)
+	c.Symbols = append([]string(nil), edge.Symbols...)
 	return &c
 }
 
+// addImportSiteLocked folds one import statement into its module-pair edge.
+func (g *Graph) addImportSiteLocked(file *parser.File, imp parser.Import) {
+	edge := g.imports[file.Module][imp.Module]
+	if edge == nil {
+		edge = &ImportEdge{
+			From:       file.Module,
+			To:         imp.Module,
+			ImportedBy: file.Path,
+			Location:   imp.Location,
+		}
+		g.imports[file.Module][imp.Module] = edge
+	} else if file.Path < edge.ImportedBy || (file.Path == edge.ImportedBy && imp.Location.Line < edge.Location.Line) {
+		edge.ImportedBy = file.Path
+		edge.Location = imp.Location
+	}
+	edge.ImportCount++
+	edge.UsageCount += imp.UsageCount
+	edge.Files = insertSortedUnique(edge.Files, file.Path)
+	for _, symbol := range importedSymbols(file, imp) {
+		edge.Symbols = insertSortedUnique(edge.Symbols, symbol)
+	}
+	g.addImporterLocked(imp.Module, file.Module)
+}
+
+// importedSymbols lists the names a file pulls through one import: explicit
+// items, plus the first member of every reference qualified by the import's
+// binding (alias or module base name).
+func importedSymbols(file *parser.File, imp parser.Import) []string {
+	symbols := make([]string, 0, len(imp.Items))
+	for _, item := range imp.Items {
+		if item = strings.TrimSpace(item); item != "" && item != "*" {
+			symbols = append(symbols, item)
+		}
+	}
+
+	binding := strings.TrimSpace(imp.Alias)
+	if binding == "" {
+		binding = parser.ModuleReferenceBase(file.Language, imp.Module)
+	}
+	if binding == "" || binding == "_" || binding == "." {
+		return symbols
+	}
+	prefix := binding + "."
+	for _, ref := range file.References {
+		if !strings.HasPrefix(ref.Name, prefix) {
+			continue
+		}
+		member := strings.TrimPrefix(ref.Name, prefix)
+		if idx := strings.Index(member, "."); idx >= 0 {
+			member = member[:idx]
+		}
+		if member != "" {
+			symbols = append(symbols, member)
+		}
+	}
+	return symbols
+}
+
+func insertSortedUnique(values []string, value string) []string {
+	idx := sort.SearchStrings(values, value)
+	if idx < len(values) && values[idx] == value {
+		return values
+	}
+	values = append(values, "")
+	copy(values[idx+1:], values[idx:])
+	values[idx] = value
+	return values
+}
+
 func stronglyConnectedComponents(nodes []string, adjacency map[string][]string) (map[string]int, [][]string) {
 	index := 0
 	stack := make([]string, 0, len(nodes))
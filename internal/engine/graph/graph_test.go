@@ -4,6 +4,7 @@ package graph
 import (
 	"circular/internal/engine/parser"
 	"errors"
+	"strings"
 	"testing"
 )
 
@@ -124,6 +125,19 @@ func TestGraph_Getters(t *testing.T) {
 	}
 }
 
+func TestGraph_FilePaths_IncludesEvictedFiles(t *testing.T) {
+	g := NewGraphWithCapacity(1)
+	g.AddFile(&parser.File{Path: "b.go", Module: "mod/b"})
+	g.AddFile(&parser.File{Path: "a.go", Module: "mod/a"})
+
+	if got := len(g.GetAllFiles()); got != 1 {
+		t.Fatalf("expected one cached file, got %d", got)
+	}
+	if got := strings.Join(g.FilePaths(), ","); got != "a.go,b.go" {
+		t.Fatalf("expected every graph file path, got %q", got)
+	}
+}
+
 func TestGraph_RemoveFile_Incremental(t *testing.T) {
 	g := NewGraph()
 
@@ -228,6 +242,50 @@ func TestGraph_AddFile_ReplacesExistingContributions(t *testing.T) {
 	}
 }
 
+func TestGraph_AddFile_AggregatesImportSites(t *testing.T) {
+	g := NewGraph()
+	g.AddFile(&parser.File{
+		Path:     "b.go",
+		Language: "go",
+		Module:   "app/b",
+		Imports: []parser.Import{
+			{Module: "app/util", Location: parser.Location{Line: 4}, UsageCount: 2},
+		},
+		References: []parser.Reference{{Name: "util.Join"}, {Name: "util.Split.Sep"}},
+	})
+	g.AddFile(&parser.File{
+		Path:     "a.go",
+		Language: "go",
+		Module:   "app/b",
+		Imports: []parser.Import{
+			{Module: "app/util", Items: []string{"Trim"}, Location: parser.Location{Line: 3}, UsageCount: 1},
+		},
+	})
+
+	edge := g.GetImports()["app/b"]["app/util"]
+	if edge == nil {
+		t.Fatal("expected aggregated edge app/b -> app/util")
+	}
+	if edge.ImportedBy != "a.go" || edge.Location.Line != 3 {
+		t.Fatalf("expected primary site a.go:3, got %s:%d", edge.ImportedBy, edge.Location.Line)
+	}
+	if edge.ImportCount != 2 || edge.UsageCount != 3 || edge.Weight() != 5 {
+		t.Fatalf("unexpected edge counts: %+v", edge)
+	}
+	if strings.Join(edge.Files, ",") != "a.go,b.go" {
+		t.Fatalf("unexpected edge files: %v", edge.Files)
+	}
+	if strings.Join(edge.Symbols, ",") != "Join,Split,Trim" {
+		t.Fatalf("unexpected edge symbols: %v", edge.Symbols)
+	}
+
+	g.RemoveFile("a.go")
+	edge = g.GetImports()["app/b"]["app/util"]
+	if edge == nil || edge.ImportCount != 1 || edge.ImportedBy != "b.go" || strings.Join(edge.Symbols, ",") != "Join,Split" {
+		t.Fatalf("expected edge rebuilt from b.go only, got %+v", edge)
+	}
+}
+
 func TestGraph_ComputeModuleMetrics(t *testing.T) {
 	g := NewGraph()
 
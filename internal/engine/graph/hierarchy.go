@@ -0,0 +1,219 @@
+package graph
+
+// internal/engine/graph/hierarchy.go
+
+import (
+	"circular/internal/engine/parser"
+	"circular/internal/shared/util"
+	"sort"
+	"strings"
+)
+
+// ModuleTreeNode is one path prefix of the module hierarchy, such as
+// "internal" or "internal/engine", with metrics rolled up over every module at
+// or below it.
+type ModuleTreeNode struct {
+	Path         string
+	Depth        int  // number of path segments in Path
+	IsModule     bool // Path is itself a module, not only a prefix
+	ModuleCount  int
+	FileCount    int
+	FanIn        int // prefixes at the same depth importing this subtree
+	FanOut       int // prefixes at the same depth this subtree imports
+	HotspotScore int // summed complexity scores of hotspots in the subtree
+	Violations   int // architecture violations raised by imports in the subtree
+	Children     []*ModuleTreeNode
+}
+
+// PrefixEdge aggregates the module import edges between two prefixes.
+type PrefixEdge struct {
+	From        string
+	To          string
+	EdgeCount   int // module-level edges rolled into this one
+	ImportCount int // import statements across those edges
+}
+
+// moduleSeparator is "/" for path-like module names and "." for dotted names
+// such as Python packages.
+func moduleSeparator(name string) string {
+	if strings.Contains(name, "/") {
+		return "/"
+	}
+	return "."
+}
+
+// ModuleSegments splits a module name into its path segments.
+func ModuleSegments(name string) []string {
+	if name == "" {
+		return nil
+	}
+	return strings.Split(name, moduleSeparator(name))
+}
+
+// ModulePrefix returns the first depth segments of a module name. Names with
+// no more than depth segments, and any name when depth <= 0, are returned
+// unchanged.
+func ModulePrefix(name string, depth int) string {
+	if depth <= 0 {
+		return name
+	}
+	sep := moduleSeparator(name)
+	parts := strings.Split(name, sep)
+	if len(parts) <= depth {
+		return name
+	}
+	return strings.Join(parts[:depth], sep)
+}
+
+// AtDepth returns a graph whose nodes are module prefixes of at most depth
+// segments, so cycle detection, metrics, diagrams, and tracing run unchanged on
+// the rolled-up hierarchy. Imports between modules under the same prefix are
+// dropped; imports of modules without files keep the module name. The
+// receiver is not modified; depth <= 0 returns it as is.
+func (g *Graph) AtDepth(depth int) (*Graph, error) {
+	if depth <= 0 {
+		return g, nil
+	}
+	sim, err := g.newSimulation()
+	if err != nil {
+		return nil, err
+	}
+
+	internal := make(map[string]bool)
+	for _, file := range sim.files {
+		internal[file.Module] = true
+	}
+	for _, path := range util.SortedStringKeys(sim.files) {
+		file := sim.files[path]
+		self := ModulePrefix(file.Module, depth)
+		imports := make([]parser.Import, 0, len(file.Imports))
+		for _, imp := range file.Imports {
+			target := imp.Module
+			if internal[target] {
+				target = ModulePrefix(target, depth)
+			}
+			if target == self {
+				continue
+			}
+			projected := imp
+			projected.Module = target
+			imports = append(imports, projected)
+		}
+		file.Imports = imports
+		file.Module = self
+	}
+	return sim.build(), nil
+}
+
+// PrefixEdges aggregates import edges between internal modules into edges
+// between their prefixes of at most depth segments, sorted by From then To.
+// Edges inside one prefix and edges to modules without files are skipped.
+func (g *Graph) PrefixEdges(depth int) []PrefixEdge {
+	modules := g.Modules()
+	byPair := make(map[[2]string]*PrefixEdge)
+	for from, edges := range g.GetImports() {
+		if _, ok := modules[from]; !ok {
+			continue
+		}
+		for to, edge := range edges {
+			if _, ok := modules[to]; !ok {
+				continue
+			}
+			pair := [2]string{ModulePrefix(from, depth), ModulePrefix(to, depth)}
+			if pair[0] == pair[1] {
+				continue
+			}
+			agg, ok := byPair[pair]
+			if !ok {
+				agg = &PrefixEdge{From: pair[0], To: pair[1]}
+				byPair[pair] = agg
+			}
+			agg.EdgeCount++
+			agg.ImportCount += edge.ImportCount
+		}
+	}
+
+	out := make([]PrefixEdge, 0, len(byPair))
+	for _, edge := range byPair {
+		out = append(out, *edge)
+	}
+	sort.Slice(out, func(i, j int) bool {
+		if out[i].From != out[j].From {
+			return out[i].From < out[j].From
+		}
+		return out[i].To < out[j].To
+	})
+	return out
+}
+
+// ModuleTree builds the prefix hierarchy of internal modules and returns its
+// roots sorted by path. File counts, hotspot scores, and violations (counted
+// against the importing module) sum over each subtree; fan-in and fan-out
+// count the distinct prefixes of the same depth linked to the subtree by
+// PrefixEdges.
+func (g *Graph) ModuleTree(hotspots []ComplexityHotspot, violations []ArchitectureViolation) []*ModuleTreeNode {
+	modules := g.Modules()
+	nodes := make(map[string]*ModuleTreeNode)
+	var roots []*ModuleTreeNode
+	maxDepth := 0
+
+	for _, name := range util.SortedStringKeys(modules) {
+		segments := ModuleSegments(name)
+		if len(segments) > maxDepth {
+			maxDepth = len(segments)
+		}
+		var parent *ModuleTreeNode
+		for depth := 1; depth <= len(segments); depth++ {
+			path := ModulePrefix(name, depth)
+			node, ok := nodes[path]
+			if !ok {
+				node = &ModuleTreeNode{Path: path, Depth: depth}
+				nodes[path] = node
+				if parent == nil {
+					roots = append(roots, node)
+				} else {
+					parent.Children = append(parent.Children, node)
+				}
+			}
+			node.ModuleCount++
+			node.FileCount += len(modules[name].Files)
+			parent = node
+		}
+		parent.IsModule = true
+	}
+
+	rollUp := func(module string, apply func(*ModuleTreeNode)) {
+		for depth := 1; depth <= len(ModuleSegments(module)); depth++ {
+			if node, ok := nodes[ModulePrefix(module, depth)]; ok {
+				apply(node)
+			}
+		}
+	}
+	for _, hotspot := range hotspots {
+		rollUp(hotspot.Module, func(n *ModuleTreeNode) { n.HotspotScore += hotspot.Score })
+	}
+	for _, violation := range violations {
+		rollUp(violation.FromModule, func(n *ModuleTreeNode) { n.Violations++ })
+	}
+
+	for depth := 1; depth <= maxDepth; depth++ {
+		for _, edge := range g.PrefixEdges(depth) {
+			if node, ok := nodes[edge.From]; ok && node.Depth == depth {
+				node.FanOut++
+			}
+			if node, ok := nodes[edge.To]; ok && node.Depth == depth {
+				node.FanIn++
+			}
+		}
+	}
+
+	sortModuleTree(roots)
+	return roots
+}
+
+func sortModuleTree(nodes []*ModuleTreeNode) {
+	sort.Slice(nodes, func(i, j int) bool { return nodes[i].Path < nodes[j].Path })
+	for _, node := range nodes {
+		sortModuleTree(node.Children)
+	}
+}
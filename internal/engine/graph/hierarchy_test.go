@@ -0,0 +1,125 @@
+package graph
+
+import (
+	"circular/internal/engine/parser"
+	"reflect"
+	"testing"
+)
+
+func newHierarchyFixture() *Graph {
+	g := NewGraph()
+	g.AddFile(&parser.File{
+		Path:    "/repo/internal/engine/graph/g.go",
+		Module:  "app/internal/engine/graph",
+		Imports: []parser.Import{{Module: "app/internal/engine/parser"}, {Module: "app/internal/shared"}, {Module: "fmt"}},
+	})
+	g.AddFile(&parser.File{
+		Path:    "/repo/internal/engine/parser/p.go",
+		Module:  "app/internal/engine/parser",
+		Imports: []parser.Import{{Module: "app/internal/shared"}},
+	})
+	g.AddFile(&parser.File{
+		Path:    "/repo/internal/engine/parser/q.go",
+		Module:  "app/internal/engine/parser",
+		Imports: []parser.Import{{Module: "app/internal/shared"}},
+	})
+	g.AddFile(&parser.File{
+		Path:    "/repo/internal/shared/s.go",
+		Module:  "app/internal/shared",
+		Imports: []parser.Import{{Module: "app/internal/engine/graph"}},
+	})
+	return g
+}
+
+func TestModulePrefix(t *testing.T) {
+	cases := []struct {
+		name  string
+		depth int
+		want  string
+	}{
+		{"app/internal/engine", 2, "app/internal"},
+		{"app/internal", 2, "app/internal"},
+		{"app/internal", 0, "app/internal"},
+		{"pkg.sub.mod", 1, "pkg"},
+		{"pkg.sub.mod", 2, "pkg.sub"},
+	}
+	for _, tc := range cases {
+		if got := ModulePrefix(tc.name, tc.depth); got != tc.want {
+			t.Fatalf("ModulePrefix(%q, %d) = %q, want %q", tc.name, tc.depth, got, tc.want)
+		}
+	}
+}
+
+func TestAtDepth_RollsUpModulesAndImports(t *testing.T) {
+	g := newHierarchyFixture()
+
+	rolled, err := g.AtDepth(3)
+	if err != nil {
+		t.Fatalf("at depth: %v", err)
+	}
+	if got := rolled.ModuleCount(); got != 2 {
+		t.Fatalf("expected app/internal/engine and app/internal/shared, got %d modules", got)
+	}
+	imports := rolled.GetImports()
+	edge := imports["app/internal/engine"]["app/internal/shared"]
+	if edge == nil || edge.ImportCount != 3 {
+		t.Fatalf("expected aggregated engine -> shared edge, got %+v", edge)
+	}
+	if _, ok := imports["app/internal/engine"]["app/internal/engine"]; ok {
+		t.Fatal("expected imports inside a prefix to be dropped")
+	}
+	if _, ok := imports["app/internal/engine"]["fmt"]; !ok {
+		t.Fatal("expected external import to keep its module name")
+	}
+	if cycles := rolled.DetectCycles(); len(cycles) != 1 {
+		t.Fatalf("expected engine <-> shared cycle, got %v", cycles)
+	}
+	if same, _ := g.AtDepth(0); same != g {
+		t.Fatal("expected depth 0 to return the receiver")
+	}
+}
+
+func TestModuleTree_RollsUpMetrics(t *testing.T) {
+	g := newHierarchyFixture()
+	hotspots := []ComplexityHotspot{
+		{Module: "app/internal/engine/graph", Score: 10},
+		{Module: "app/internal/engine/parser", Score: 5},
+	}
+	violations := []ArchitectureViolation{{FromModule: "app/internal/shared", ToModule: "app/internal/engine/graph"}}
+
+	roots := g.ModuleTree(hotspots, violations)
+	if len(roots) != 1 || roots[0].Path != "app" {
+		t.Fatalf("expected single app root, got %+v", roots)
+	}
+	internal := roots[0].Children[0]
+	if internal.Path != "app/internal" || internal.FileCount != 4 || internal.ModuleCount != 3 || internal.HotspotScore != 15 || internal.Violations != 1 {
+		t.Fatalf("unexpected internal rollup: %+v", internal)
+	}
+	children := make([]string, 0, len(internal.Children))
+	for _, child := range internal.Children {
+		children = append(children, child.Path)
+	}
+	if !reflect.DeepEqual(children, []string{"app/internal/engine", "app/internal/shared"}) {
+		t.Fatalf("unexpected children: %v", children)
+	}
+
+	engine := internal.Children[0]
+	if engine.IsModule || engine.FileCount != 3 || engine.HotspotScore != 15 || engine.FanIn != 1 || engine.FanOut != 1 {
+		t.Fatalf("unexpected engine rollup: %+v", engine)
+	}
+	graphNode := engine.Children[0]
+	if !graphNode.IsModule || graphNode.Depth != 4 || graphNode.FanIn != 1 || graphNode.FanOut != 2 {
+		t.Fatalf("unexpected graph leaf: %+v", graphNode)
+	}
+}
+
+func TestPrefixEdges_AggregatesModuleEdges(t *testing.T) {
+	edges := newHierarchyFixture().PrefixEdges(3)
+	want := []PrefixEdge{
+		{From: "app/internal/engine", To: "app/internal/shared", EdgeCount: 2, ImportCount: 3},
+		{From: "app/internal/shared", To: "app/internal/engine", EdgeCount: 1, ImportCount: 1},
+	}
+	if !reflect.DeepEqual(edges, want) {
+		t.Fatalf("unexpected prefix edges: %+v", edges)
+	}
+}
@@ -14,6 +14,8 @@ type ImpactReport struct {
 	DirectImporters       []string
 	TransitiveImporters   []string
 	ExternallyUsedSymbols []string
+	// Symbol is set when the target was a module#Symbol key.
+	Symbol *SymbolImpactReport
 }
 
 type ImpactTargetError struct {
@@ -34,7 +36,7 @@ func (g *Graph) AnalyzeImpact(path string) (ImpactReport, error) {
 
 	moduleName, ok := g.fileToModule[path]
 	if !ok {
-		if mod, exists := g.modules[path]; exists {
+		if mod, exists := g.moduleLocked(path); exists {
 			targetPath := ""
 			if len(mod.Files) > 0 {
 				files := append([]string(nil), mod.Files...)
@@ -55,8 +57,9 @@ func (g *Graph) analyzeImpactForModule(targetModule, targetPath string) ImpactRe
 		TargetModule: targetModule,
 	}
 
-	direct := make([]string, 0, len(g.importedBy[targetModule]))
-	for importer := range g.importedBy[targetModule] {
+	importers := g.importersOfLocked(targetModule)
+	direct := make([]string, 0, len(importers))
+	for importer := range importers {
 		direct = append(direct, importer)
 	}
 	sort.Strings(direct)
@@ -77,7 +80,7 @@ func (g *Graph) analyzeImpactForModule(targetModule, targetPath string) ImpactRe
 	for len(queue) > 0 {
 		curr := queue[0]
 		queue = queue[1:]
-		for next := range g.importedBy[curr] {
+		for next := range g.importersOfLocked(curr) {
 			if seen[next] {
 				continue
 			}
@@ -91,7 +94,7 @@ func (g *Graph) analyzeImpactForModule(targetModule, targetPath string) ImpactRe
 	sort.Strings(transitive)
 	report.TransitiveImporters = transitive
 
-	if mod, ok := g.modules[targetModule]; ok {
+	if mod, ok := g.moduleLocked(targetModule); ok {
 		symbols := make([]string, 0, len(mod.Exports))
 		for symbol := range mod.Exports {
 			symbols = append(symbols, symbol)
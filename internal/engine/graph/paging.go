@@ -0,0 +1,404 @@
+package graph
+
+import (
+	"circular/internal/engine/parser"
+	"circular/internal/shared/observability"
+	"fmt"
+	"sort"
+)
+
+// PagingStats describes how many module nodes are held in memory versus in
+// node storage.
+type PagingStats struct {
+	Enabled         bool
+	Budget          int
+	ResidentModules int
+	PagedModules    int
+	LastError       error
+}
+
+// SetNodeStorage lets the graph page module nodes and their imports/importedBy
+// adjacency out to storage once more than budget modules are resident. The
+// least recently updated modules are paged out first; reads go through to
+// storage, importer changes are written through, and mutations of a module
+// fault it back in. A nil or noop storage, or a budget <= 0, disables paging
+// and brings every paged module back into memory.
+func (g *Graph) SetNodeStorage(storage NodeStorage, projectKey string, budget int) error {
+	g.mu.Lock()
+	defer g.mu.Unlock()
+
+	if g.nodeStorage != nil && g.nodeStorage != storage {
+		if err := g.faultInAllLocked(); err != nil {
+			return err
+		}
+	}
+
+	if _, noop := storage.(*NoopNodeStorage); storage == nil || noop || budget <= 0 {
+		if err := g.faultInAllLocked(); err != nil {
+			return err
+		}
+		g.nodeStorage = nil
+		g.nodeBudget = 0
+		return nil
+	}
+
+	g.nodeStorage = storage
+	g.projectKey = projectKey
+	g.nodeBudget = budget
+	for name := range g.modules {
+		if _, ok := g.residentUse[name]; !ok {
+			g.touchLocked(name)
+		}
+	}
+	return g.enforceNodeBudgetLocked()
+}
+
+// PageOutModules writes the given percentage of resident modules to node
+// storage, least recently updated first, and returns how many were paged out.
+// It does nothing when paging is disabled.
+func (g *Graph) PageOutModules(percentage int) (int, error) {
+	g.mu.Lock()
+	defer g.mu.Unlock()
+
+	if g.nodeStorage == nil || percentage <= 0 {
+		return 0, nil
+	}
+	if percentage > 100 {
+		percentage = 100
+	}
+	count := len(g.modules) * percentage / 100
+	if count == 0 && len(g.modules) > 0 {
+		count = 1
+	}
+	paged, err := g.pageOutColdestLocked(count)
+	g.publishGraphSizeLocked()
+	return paged, err
+}
+
+func (g *Graph) PagingStats() PagingStats {
+	g.mu.RLock()
+	stats := PagingStats{
+		Enabled:         g.nodeStorage != nil,
+		Budget:          g.nodeBudget,
+		ResidentModules: len(g.modules),
+		PagedModules:    len(g.paged),
+	}
+	g.mu.RUnlock()
+
+	g.storageErrMu.Lock()
+	stats.LastError = g.storageErr
+	g.storageErrMu.Unlock()
+	return stats
+}
+
+func (g *Graph) recordStorageErr(err error) {
+	if err == nil {
+		return
+	}
+	g.storageErrMu.Lock()
+	g.storageErr = err
+	g.storageErrMu.Unlock()
+}
+
+func (g *Graph) touchLocked(name string) {
+	if g.nodeStorage == nil {
+		return
+	}
+	g.useClock++
+	g.residentUse[name] = g.useClock
+}
+
+// enforceNodeBudgetLocked pages modules out until the resident set is back
+// under budget. It trims to 90% of the budget so that steady growth does not
+// page out one module per added file.
+func (g *Graph) enforceNodeBudgetLocked() error {
+	if g.nodeStorage == nil || len(g.modules) <= g.nodeBudget {
+		return nil
+	}
+	target := g.nodeBudget - g.nodeBudget/10
+	_, err := g.pageOutColdestLocked(len(g.modules) - target)
+	return err
+}
+
+func (g *Graph) pageOutColdestLocked(count int) (int, error) {
+	if count <= 0 {
+		return 0, nil
+	}
+	names := make([]string, 0, len(g.modules))
+	for name := range g.modules {
+		names = append(names, name)
+	}
+	sort.Slice(names, func(i, j int) bool {
+		ui, uj := g.residentUse[names[i]], g.residentUse[names[j]]
+		if ui != uj {
+			return ui < uj
+		}
+		return names[i] < names[j]
+	})
+	if count > len(names) {
+		count = len(names)
+	}
+
+	paged := 0
+	for _, name := range names[:count] {
+		if err := g.pageOutLocked(name); err != nil {
+			g.recordStorageErr(err)
+			return paged, err
+		}
+		paged++
+	}
+	return paged, nil
+}
+
+// pageOutLocked writes a resident module and its adjacency to storage and
+// drops them from memory. On failure the module stays resident.
+func (g *Graph) pageOutLocked(name string) error {
+	mod, ok := g.modules[name]
+	if !ok {
+		return nil
+	}
+
+	edges := make([]*ImportEdge, 0, len(g.imports[name]))
+	for _, edge := range g.imports[name] {
+		edges = append(edges, edge)
+	}
+	sort.Slice(edges, func(i, j int) bool { return edges[i].To < edges[j].To })
+	importers := make([]string, 0, len(g.importedBy[name]))
+	for importer := range g.importedBy[name] {
+		importers = append(importers, importer)
+	}
+	sort.Strings(importers)
+
+	if err := g.nodeStorage.SaveNode(g.projectKey, name, mod); err != nil {
+		return fmt.Errorf("page out module %q: %w", name, err)
+	}
+	if err := g.nodeStorage.SaveAdjacency(g.projectKey, name, edges, importers); err != nil {
+		return fmt.Errorf("page out adjacency for %q: %w", name, err)
+	}
+
+	g.pagedEdges += len(edges)
+	delete(g.modules, name)
+	delete(g.imports, name)
+	delete(g.importedBy, name)
+	delete(g.residentUse, name)
+	g.paged[name] = true
+	return nil
+}
+
+// faultInLocked makes a paged module resident again so it can be mutated.
+// On failure the error is recorded and returned, and the module stays paged so
+// the next mutation retries.
+func (g *Graph) faultInLocked(name string) error {
+	if !g.paged[name] {
+		return nil
+	}
+
+	mod, err := g.nodeStorage.LoadNode(g.projectKey, name)
+	if err == nil && mod == nil {
+		err = fmt.Errorf("node missing from storage")
+	}
+	if err != nil {
+		err = fmt.Errorf("fault in module %q: %w", name, err)
+		g.recordStorageErr(err)
+		return err
+	}
+	edges, importers, err := g.nodeStorage.LoadAdjacency(g.projectKey, name)
+	if err != nil {
+		err = fmt.Errorf("fault in adjacency for %q: %w", name, err)
+		g.recordStorageErr(err)
+		return err
+	}
+	if mod.Exports == nil {
+		mod.Exports = make(map[string]*parser.Definition)
+	}
+
+	delete(g.paged, name)
+	g.modules[name] = mod
+	g.imports[name] = make(map[string]*ImportEdge, len(edges))
+	for _, edge := range edges {
+		g.imports[name][edge.To] = edge
+	}
+	g.pagedEdges -= len(edges)
+	if len(importers) > 0 && g.importedBy[name] == nil {
+		g.importedBy[name] = make(map[string]bool, len(importers))
+	}
+	for _, importer := range importers {
+		g.importedBy[name][importer] = true
+	}
+	g.touchLocked(name)
+
+	// The storage copy is stale from here on; drop it so storage only ever
+	// holds paged modules.
+	if err := g.nodeStorage.DeleteNode(g.projectKey, name); err != nil {
+		g.recordStorageErr(fmt.Errorf("drop paged module %q: %w", name, err))
+	}
+	return nil
+}
+
+func (g *Graph) faultInAllLocked() error {
+	names := make([]string, 0, len(g.paged))
+	for name := range g.paged {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	var firstErr error
+	for _, name := range names {
+		if err := g.faultInLocked(name); err != nil && firstErr == nil {
+			firstErr = err
+		}
+	}
+	return firstErr
+}
+
+// addImporterLocked records from as an importer of to. A paged target keeps
+// its importer list in storage, so the entry is written through there; if that
+// fails it is kept in memory and merged on reads and on the next fault-in.
+func (g *Graph) addImporterLocked(to, from string) {
+	if g.paged[to] && g.updatePagedImportersLocked(to, from, true) == nil {
+		return
+	}
+	if g.importedBy[to] == nil {
+		g.importedBy[to] = make(map[string]bool)
+	}
+	g.importedBy[to][from] = true
+}
+
+func (g *Graph) removeImporterLocked(to, from string) {
+	if g.paged[to] {
+		_ = g.updatePagedImportersLocked(to, from, false)
+	}
+	if g.importedBy[to] != nil {
+		delete(g.importedBy[to], from)
+		if len(g.importedBy[to]) == 0 && g.paged[to] {
+			delete(g.importedBy, to)
+		}
+	}
+}
+
+// updatePagedImportersLocked adds or removes one importer in the stored
+// adjacency of a paged module, leaving its stored imports untouched.
+func (g *Graph) updatePagedImportersLocked(name, importer string, add bool) error {
+	edges, importers, err := g.nodeStorage.LoadAdjacency(g.projectKey, name)
+	if err != nil {
+		err = fmt.Errorf("read paged importers for %q: %w", name, err)
+		g.recordStorageErr(err)
+		return err
+	}
+	updated := make([]string, 0, len(importers)+1)
+	for _, existing := range importers {
+		if existing != importer {
+			updated = append(updated, existing)
+		}
+	}
+	if add {
+		updated = append(updated, importer)
+	}
+	sort.Strings(updated)
+	if err := g.nodeStorage.SaveAdjacency(g.projectKey, name, edges, updated); err != nil {
+		err = fmt.Errorf("update paged importers for %q: %w", name, err)
+		g.recordStorageErr(err)
+		return err
+	}
+	return nil
+}
+
+func (g *Graph) hasModuleLocked(name string) bool {
+	if _, ok := g.modules[name]; ok {
+		return true
+	}
+	return g.paged[name]
+}
+
+// moduleLocked returns a module, reading paged nodes through from storage
+// without making them resident.
+func (g *Graph) moduleLocked(name string) (*Module, bool) {
+	if mod, ok := g.modules[name]; ok {
+		return mod, true
+	}
+	if !g.paged[name] {
+		return nil, false
+	}
+	mod, err := g.nodeStorage.LoadNode(g.projectKey, name)
+	if err != nil {
+		g.recordStorageErr(fmt.Errorf("read paged module %q: %w", name, err))
+		return nil, false
+	}
+	return mod, mod != nil
+}
+
+func (g *Graph) importsOfLocked(name string) map[string]*ImportEdge {
+	if !g.paged[name] {
+		return g.imports[name]
+	}
+	edges, _, err := g.nodeStorage.LoadAdjacency(g.projectKey, name)
+	if err != nil {
+		g.recordStorageErr(fmt.Errorf("read paged imports for %q: %w", name, err))
+		return nil
+	}
+	out := make(map[string]*ImportEdge, len(edges))
+	for _, edge := range edges {
+		out[edge.To] = edge
+	}
+	return out
+}
+
+func (g *Graph) importersOfLocked(name string) map[string]bool {
+	if !g.paged[name] {
+		return g.importedBy[name]
+	}
+	_, importers, err := g.nodeStorage.LoadAdjacency(g.projectKey, name)
+	if err != nil {
+		g.recordStorageErr(fmt.Errorf("read paged importers for %q: %w", name, err))
+	}
+	// Importers that could not be written through to storage stay in memory.
+	out := make(map[string]bool, len(importers)+len(g.importedBy[name]))
+	for _, importer := range importers {
+		out[importer] = true
+	}
+	for importer := range g.importedBy[name] {
+		out[importer] = true
+	}
+	return out
+}
+
+// moduleNamesLocked returns every resident and paged module name, sorted.
+func (g *Graph) moduleNamesLocked() []string {
+	names := make([]string, 0, len(g.modules)+len(g.paged))
+	for name := range g.modules {
+		names = append(names, name)
+	}
+	for name := range g.paged {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
+
+// allImportsLocked returns the full import adjacency. Without paged modules it
+// is the live map; callers must treat the result as read-only.
+func (g *Graph) allImportsLocked() map[string]map[string]*ImportEdge {
+	if len(g.paged) == 0 {
+		return g.imports
+	}
+	out := make(map[string]map[string]*ImportEdge, len(g.imports)+len(g.paged))
+	for from, targets := range g.imports {
+		out[from] = targets
+	}
+	for name := range g.paged {
+		out[name] = g.importsOfLocked(name)
+	}
+	return out
+}
+
+func (g *Graph) moduleCountLocked() int {
+	return len(g.modules) + len(g.paged)
+}
+
+func (g *Graph) publishGraphSizeLocked() {
+	observability.GraphNodes.Set(float64(g.moduleCountLocked()))
+	edgeCount := g.pagedEdges
+	for _, targets := range g.imports {
+		edgeCount += len(targets)
+	}
+	observability.GraphEdges.Set(float64(edgeCount))
+}
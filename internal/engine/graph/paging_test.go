@@ -0,0 +1,295 @@
+package graph
+
+import (
+	"circular/internal/engine/parser"
+	"errors"
+	"reflect"
+	"testing"
+)
+
+// memoryNodeStorage is a map-backed NodeStorage used to exercise paging
+// without a database.
+type memoryNodeStorage struct {
+	nodes     map[string]*Module
+	edges     map[string][]*ImportEdge
+	importers map[string][]string
+}
+
+func newMemoryNodeStorage() *memoryNodeStorage {
+	return &memoryNodeStorage{
+		nodes:     make(map[string]*Module),
+		edges:     make(map[string][]*ImportEdge),
+		importers: make(map[string][]string),
+	}
+}
+
+func (m *memoryNodeStorage) SaveNode(_, moduleName string, mod *Module) error {
+	m.nodes[moduleName] = cloneModule(mod)
+	return nil
+}
+
+func (m *memoryNodeStorage) LoadNode(_, moduleName string) (*Module, error) {
+	mod, ok := m.nodes[moduleName]
+	if !ok {
+		return nil, nil
+	}
+	return cloneModule(mod), nil
+}
+
+func (m *memoryNodeStorage) SaveAdjacency(_, moduleName string, imports []*ImportEdge, importedBy []string) error {
+	m.edges[moduleName] = imports
+	m.importers[moduleName] = importedBy
+	return nil
+}
+
+func (m *memoryNodeStorage) LoadAdjacency(_, moduleName string) ([]*ImportEdge, []string, error) {
+	return m.edges[moduleName], m.importers[moduleName], nil
+}
+
+func (m *memoryNodeStorage) QueryEdges(_, from string) ([]string, error) {
+	out := make([]string, 0, len(m.edges[from]))
+	for _, edge := range m.edges[from] {
+		out = append(out, edge.To)
+	}
+	return out, nil
+}
+
+func (m *memoryNodeStorage) DeleteNode(_, moduleName string) error {
+	delete(m.nodes, moduleName)
+	delete(m.edges, moduleName)
+	delete(m.importers, moduleName)
+	return nil
+}
+
+func (m *memoryNodeStorage) Close() error { return nil }
+
+func seedPagingGraph(g *Graph) {
+	// A -> B -> C -> A, D -> A, E -> D
+	files := []*parser.File{
+		{Path: "a.go", Module: "A", Imports: []parser.Import{{Module: "B"}}, Definitions: []parser.Definition{{Name: "Run", Exported: true}}},
+		{Path: "b.go", Module: "B", Imports: []parser.Import{{Module: "C"}}},
+		{Path: "c.go", Module: "C", Imports: []parser.Import{{Module: "A"}}},
+		{Path: "d.go", Module: "D", Imports: []parser.Import{{Module: "A"}}},
+		{Path: "e.go", Module: "E", Imports: []parser.Import{{Module: "D"}}},
+	}
+	for _, f := range files {
+		g.AddFile(f)
+	}
+}
+
+func TestGraph_PagingMatchesInMemoryGraph(t *testing.T) {
+	plain := NewGraph()
+	seedPagingGraph(plain)
+
+	storage := newMemoryNodeStorage()
+	paged := NewGraph()
+	if err := paged.SetNodeStorage(storage, "test", 2); err != nil {
+		t.Fatalf("SetNodeStorage: %v", err)
+	}
+	seedPagingGraph(paged)
+
+	stats := paged.PagingStats()
+	if !stats.Enabled || stats.ResidentModules > 2 || stats.PagedModules == 0 {
+		t.Fatalf("expected modules to be paged out, got %+v", stats)
+	}
+	if stats.ResidentModules+stats.PagedModules != 5 || paged.ModuleCount() != 5 {
+		t.Fatalf("expected 5 modules in total, got %+v (count=%d)", stats, paged.ModuleCount())
+	}
+	if len(storage.nodes) != stats.PagedModules {
+		t.Fatalf("expected storage to hold exactly the paged modules, got %d nodes", len(storage.nodes))
+	}
+
+	if got, want := paged.DetectCycles(), plain.DetectCycles(); !reflect.DeepEqual(got, want) {
+		t.Fatalf("cycles differ: paged=%v plain=%v", got, want)
+	}
+	if got, want := paged.ComputeModuleMetrics(), plain.ComputeModuleMetrics(); !reflect.DeepEqual(got, want) {
+		t.Fatalf("metrics differ: paged=%v plain=%v", got, want)
+	}
+	if chain, ok := paged.FindImportChain("E", "C"); !ok || len(chain) != 5 {
+		t.Fatalf("unexpected chain %v (ok=%v)", chain, ok)
+	}
+	report, err := paged.AnalyzeImpact("A")
+	if err != nil {
+		t.Fatalf("AnalyzeImpact: %v", err)
+	}
+	if !reflect.DeepEqual(report.DirectImporters, []string{"C", "D"}) {
+		t.Fatalf("unexpected direct importers %v", report.DirectImporters)
+	}
+	if mod, ok := paged.GetModule("A"); !ok || mod.Exports["Run"] == nil {
+		t.Fatalf("expected paged module A to be readable, got %+v (ok=%v)", mod, ok)
+	}
+	if imports := paged.GetImports(); len(imports) != 5 || imports["C"]["A"] == nil {
+		t.Fatalf("expected full import adjacency, got %v", imports)
+	}
+}
+
+func TestGraph_PagingFaultsInOnMutation(t *testing.T) {
+	storage := newMemoryNodeStorage()
+	g := NewGraph()
+	if err := g.SetNodeStorage(storage, "test", 2); err != nil {
+		t.Fatalf("SetNodeStorage: %v", err)
+	}
+	seedPagingGraph(g)
+
+	// Breaking the cycle edits C, which must be faulted back in, and drops C
+	// from A's importers even though A may be paged.
+	g.AddFile(&parser.File{Path: "c.go", Module: "C"})
+	if cycles := g.DetectCycles(); len(cycles) != 0 {
+		t.Fatalf("expected no cycles after edit, got %v", cycles)
+	}
+	report, err := g.AnalyzeImpact("A")
+	if err != nil {
+		t.Fatalf("AnalyzeImpact: %v", err)
+	}
+	if !reflect.DeepEqual(report.DirectImporters, []string{"D"}) {
+		t.Fatalf("unexpected direct importers %v", report.DirectImporters)
+	}
+
+	g.RemoveFile("e.go")
+	if g.ModuleCount() != 4 {
+		t.Fatalf("expected 4 modules after removal, got %d", g.ModuleCount())
+	}
+
+	// Disabling paging brings every module back into memory.
+	if err := g.SetNodeStorage(nil, "", 0); err != nil {
+		t.Fatalf("disable paging: %v", err)
+	}
+	stats := g.PagingStats()
+	if stats.Enabled || stats.PagedModules != 0 || stats.ResidentModules != 4 {
+		t.Fatalf("expected all modules resident, got %+v", stats)
+	}
+	if len(g.importedBy["D"]) != 0 || !g.importedBy["A"]["D"] {
+		t.Fatalf("unexpected importers after fault-in: %v", g.importedBy)
+	}
+}
+
+func TestGraph_PageOutModules(t *testing.T) {
+	g := NewGraph()
+	seedPagingGraph(g)
+	if n, err := g.PageOutModules(50); err != nil || n != 0 {
+		t.Fatalf("expected no paging without storage, got %d (%v)", n, err)
+	}
+
+	if err := g.SetNodeStorage(newMemoryNodeStorage(), "test", 100); err != nil {
+		t.Fatalf("SetNodeStorage: %v", err)
+	}
+	n, err := g.PageOutModules(40)
+	if err != nil {
+		t.Fatalf("PageOutModules: %v", err)
+	}
+	if n != 2 || g.PagingStats().PagedModules != 2 {
+		t.Fatalf("expected 2 modules paged out, got %d (%+v)", n, g.PagingStats())
+	}
+}
+
+// flakyNodeStorage fails node loads while failLoads is set and adjacency
+// saves while failSaves is set.
+type flakyNodeStorage struct {
+	*memoryNodeStorage
+	failLoads bool
+	failSaves bool
+}
+
+func (f *flakyNodeStorage) LoadNode(project, moduleName string) (*Module, error) {
+	if f.failLoads {
+		return nil, errors.New("storage unavailable")
+	}
+	return f.memoryNodeStorage.LoadNode(project, moduleName)
+}
+
+func (f *flakyNodeStorage) SaveAdjacency(project, moduleName string, imports []*ImportEdge, importedBy []string) error {
+	if f.failSaves {
+		return errors.New("storage unavailable")
+	}
+	return f.memoryNodeStorage.SaveAdjacency(project, moduleName, imports, importedBy)
+}
+
+func TestGraph_FaultInFailureKeepsModulePaged(t *testing.T) {
+	storage := &flakyNodeStorage{memoryNodeStorage: newMemoryNodeStorage()}
+	g := NewGraph()
+	if err := g.SetNodeStorage(storage, "test", 100); err != nil {
+		t.Fatalf("SetNodeStorage: %v", err)
+	}
+	seedPagingGraph(g)
+	if n, err := g.PageOutModules(100); err != nil || n != 5 {
+		t.Fatalf("expected every module paged out, got %d (%v)", n, err)
+	}
+
+	storage.failLoads = true
+	if err := g.AddFile(&parser.File{Path: "c.go", Module: "C"}); err == nil {
+		t.Fatal("expected AddFile to fail while storage is unavailable")
+	}
+	if err := g.RemoveFile("e.go"); err == nil {
+		t.Fatal("expected RemoveFile to fail while storage is unavailable")
+	}
+	stats := g.PagingStats()
+	if stats.PagedModules != 5 || stats.ResidentModules != 0 || stats.LastError == nil {
+		t.Fatalf("expected modules to stay paged with the error recorded, got %+v", stats)
+	}
+	if g.ModuleCount() != 5 || len(storage.nodes) != 5 {
+		t.Fatalf("expected 5 modules still in storage, got count=%d stored=%d", g.ModuleCount(), len(storage.nodes))
+	}
+
+	// Once storage recovers the same mutations succeed.
+	storage.failLoads = false
+	if err := g.AddFile(&parser.File{Path: "c.go", Module: "C"}); err != nil {
+		t.Fatalf("retry AddFile: %v", err)
+	}
+	if err := g.RemoveFile("e.go"); err != nil {
+		t.Fatalf("retry RemoveFile: %v", err)
+	}
+	if cycles := g.DetectCycles(); len(cycles) != 0 {
+		t.Fatalf("expected no cycles after retried edit, got %v", cycles)
+	}
+	if g.ModuleCount() != 4 {
+		t.Fatalf("expected 4 modules after retried removal, got %d", g.ModuleCount())
+	}
+}
+
+func TestGraph_ImporterChangesReachPagedModules(t *testing.T) {
+	storage := &flakyNodeStorage{memoryNodeStorage: newMemoryNodeStorage()}
+	g := NewGraph()
+	if err := g.SetNodeStorage(storage, "test", 100); err != nil {
+		t.Fatalf("SetNodeStorage: %v", err)
+	}
+	seedPagingGraph(g)
+	if n, err := g.PageOutModules(100); err != nil || n != 5 {
+		t.Fatalf("expected every module paged out, got %d (%v)", n, err)
+	}
+
+	// F imports the paged A and C drops its import of A; A stays paged and
+	// its stored importers follow both changes.
+	if err := g.AddFile(&parser.File{Path: "f.go", Module: "F", Imports: []parser.Import{{Module: "A"}}}); err != nil {
+		t.Fatalf("AddFile f.go: %v", err)
+	}
+	if err := g.AddFile(&parser.File{Path: "c.go", Module: "C"}); err != nil {
+		t.Fatalf("AddFile c.go: %v", err)
+	}
+	if !g.paged["A"] {
+		t.Fatal("expected A to stay paged while its importers change")
+	}
+	if got := storage.importers["A"]; !reflect.DeepEqual(got, []string{"D", "F"}) {
+		t.Fatalf("unexpected stored importers of A: %v", got)
+	}
+
+	// An importer that cannot be written through is still visible on reads.
+	storage.failSaves = true
+	if err := g.AddFile(&parser.File{Path: "g.go", Module: "G", Imports: []parser.Import{{Module: "A"}}}); err != nil {
+		t.Fatalf("AddFile g.go: %v", err)
+	}
+	storage.failSaves = false
+	report, err := g.AnalyzeImpact("A")
+	if err != nil {
+		t.Fatalf("AnalyzeImpact: %v", err)
+	}
+	if !reflect.DeepEqual(report.DirectImporters, []string{"D", "F", "G"}) {
+		t.Fatalf("unexpected direct importers of paged A: %v", report.DirectImporters)
+	}
+
+	if err := g.SetNodeStorage(nil, "", 0); err != nil {
+		t.Fatalf("disable paging: %v", err)
+	}
+	if want := map[string]bool{"D": true, "F": true, "G": true}; !reflect.DeepEqual(g.importedBy["A"], want) {
+		t.Fatalf("unexpected importers of A after fault-in: %v", g.importedBy["A"])
+	}
+}
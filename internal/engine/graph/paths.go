@@ -0,0 +1,170 @@
+package graph
+
+// internal/engine/graph/paths.go
+
+import (
+	"sort"
+	"strings"
+)
+
+// ImportHop is one import along an ImportPath, located at the first import
+// site of the edge (see ImportEdge).
+type ImportHop struct {
+	From   string
+	To     string
+	File   string
+	Line   int
+	Column int
+}
+
+// ImportPath is a loopless chain of imports between two modules.
+type ImportPath struct {
+	Modules []string
+	Hops    []ImportHop
+}
+
+// Depth is the number of imports along the path.
+func (p ImportPath) Depth() int {
+	return len(p.Hops)
+}
+
+// FindImportPaths returns up to k loopless import paths from `from` to `to`,
+// shortest first, using Yen's algorithm over hop counts. Paths of equal length
+// are ordered by module names. maxDepth > 0 drops paths with more imports, so
+// a large k enumerates every simple path up to that depth.
+func (g *Graph) FindImportPaths(from, to string, k, maxDepth int) []ImportPath {
+	g.mu.RLock()
+	defer g.mu.RUnlock()
+
+	if k <= 0 || !g.hasModuleLocked(from) || !g.hasModuleLocked(to) {
+		return nil
+	}
+	if from == to {
+		return []ImportPath{{Modules: []string{from}, Hops: []ImportHop{}}}
+	}
+
+	neighbors := make(map[string][]string)
+	next := func(name string) []string {
+		if list, ok := neighbors[name]; ok {
+			return list
+		}
+		list := g.getSortedNeighbors(name)
+		neighbors[name] = list
+		return list
+	}
+	withinDepth := func(path []string) bool {
+		return maxDepth <= 0 || len(path)-1 <= maxDepth
+	}
+
+	first, ok := shortestPathAvoiding(from, to, next, nil, nil)
+	if !ok || !withinDepth(first) {
+		return nil
+	}
+	accepted := [][]string{first}
+	seen := map[string]bool{strings.Join(first, "\x00"): true}
+	candidates := make([][]string, 0)
+
+	for len(accepted) < k {
+		last := accepted[len(accepted)-1]
+		for i := 0; i < len(last)-1; i++ {
+			spur := last[i]
+			root := last[:i+1]
+
+			blockedEdges := make(map[[2]string]bool)
+			for _, path := range accepted {
+				if len(path) > i+1 && equalPrefix(path, root) {
+					blockedEdges[[2]string{path[i], path[i+1]}] = true
+				}
+			}
+			blockedNodes := make(map[string]bool, i)
+			for _, node := range root[:i] {
+				blockedNodes[node] = true
+			}
+
+			spurPath, ok := shortestPathAvoiding(spur, to, next, blockedNodes, blockedEdges)
+			if !ok {
+				continue
+			}
+			candidate := append(append([]string(nil), root[:i]...), spurPath...)
+			key := strings.Join(candidate, "\x00")
+			if seen[key] || !withinDepth(candidate) {
+				continue
+			}
+			seen[key] = true
+			candidates = append(candidates, candidate)
+		}
+		if len(candidates) == 0 {
+			break
+		}
+		sort.Slice(candidates, func(i, j int) bool {
+			if len(candidates[i]) != len(candidates[j]) {
+				return len(candidates[i]) < len(candidates[j])
+			}
+			return strings.Join(candidates[i], "\x00") < strings.Join(candidates[j], "\x00")
+		})
+		accepted = append(accepted, candidates[0])
+		candidates = candidates[1:]
+	}
+
+	out := make([]ImportPath, 0, len(accepted))
+	for _, modules := range accepted {
+		out = append(out, g.importPathLocked(modules))
+	}
+	return out
+}
+
+func (g *Graph) importPathLocked(modules []string) ImportPath {
+	path := ImportPath{Modules: modules, Hops: make([]ImportHop, 0, len(modules)-1)}
+	for i := 0; i+1 < len(modules); i++ {
+		hop := ImportHop{From: modules[i], To: modules[i+1]}
+		if edge := g.importsOfLocked(modules[i])[modules[i+1]]; edge != nil {
+			hop.File = edge.ImportedBy
+			hop.Line = edge.Location.Line
+			hop.Column = edge.Location.Column
+		}
+		path.Hops = append(path.Hops, hop)
+	}
+	return path
+}
+
+// shortestPathAvoiding runs a breadth-first search from `from` to `to` that
+// skips blocked modules and import edges.
+func shortestPathAvoiding(from, to string, next func(string) []string, blockedNodes map[string]bool, blockedEdges map[[2]string]bool) ([]string, bool) {
+	prev := map[string]string{from: ""}
+	queue := []string{from}
+	for len(queue) > 0 {
+		curr := queue[0]
+		queue = queue[1:]
+		for _, n := range next(curr) {
+			if _, visited := prev[n]; visited || blockedNodes[n] || blockedEdges[[2]string{curr, n}] {
+				continue
+			}
+			prev[n] = curr
+			if n == to {
+				path := []string{to}
+				for node := curr; node != from; node = prev[node] {
+					path = append(path, node)
+				}
+				path = append(path, from)
+				for i, j := 0, len(path)-1; i < j; i, j = i+1, j-1 {
+					path[i], path[j] = path[j], path[i]
+				}
+				return path, true
+			}
+			queue = append(queue, n)
+		}
+	}
+	return nil, false
+}
+
+func equalPrefix(path, prefix []string) bool {
+	if len(path) < len(prefix) {
+		return false
+	}
+	for i := range prefix {
+		if path[i] != prefix[i] {
+			return false
+		}
+	}
+	return true
+}
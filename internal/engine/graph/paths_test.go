@@ -0,0 +1,46 @@
+package graph
+
+import (
+	"circular/internal/engine/parser"
+	"reflect"
+	"testing"
+)
+
+func TestFindImportPaths(t *testing.T) {
+	g := NewGraph()
+	// a -> d directly, via b, via c, and via b -> c.
+	g.AddFile(&parser.File{Path: "a.go", Module: "a", Imports: []parser.Import{
+		{Module: "b", Location: parser.Location{Line: 3, Column: 2}},
+		{Module: "c", Location: parser.Location{Line: 4, Column: 2}},
+		{Module: "d", Location: parser.Location{Line: 5, Column: 2}},
+	}})
+	g.AddFile(&parser.File{Path: "b.go", Module: "b", Imports: []parser.Import{{Module: "c"}, {Module: "d", Location: parser.Location{Line: 7}}}})
+	g.AddFile(&parser.File{Path: "c.go", Module: "c", Imports: []parser.Import{{Module: "d"}}})
+	g.AddFile(&parser.File{Path: "d.go", Module: "d", Imports: []parser.Import{{Module: "a"}}})
+
+	paths := g.FindImportPaths("a", "d", 10, 0)
+	got := make([][]string, 0, len(paths))
+	for _, path := range paths {
+		got = append(got, path.Modules)
+	}
+	want := [][]string{{"a", "d"}, {"a", "b", "d"}, {"a", "c", "d"}, {"a", "b", "c", "d"}}
+	if !reflect.DeepEqual(got, want) {
+		t.Fatalf("unexpected paths:\n got %v\nwant %v", got, want)
+	}
+	if hop := paths[1].Hops[1]; hop.From != "b" || hop.To != "d" || hop.File != "b.go" || hop.Line != 7 {
+		t.Fatalf("unexpected hop location: %+v", hop)
+	}
+	if paths[3].Depth() != 3 {
+		t.Fatalf("expected depth 3, got %d", paths[3].Depth())
+	}
+
+	if limited := g.FindImportPaths("a", "d", 2, 0); len(limited) != 2 {
+		t.Fatalf("expected k=2 to return 2 paths, got %d", len(limited))
+	}
+	if shallow := g.FindImportPaths("a", "d", 10, 2); len(shallow) != 3 {
+		t.Fatalf("expected 3 paths within depth 2, got %d", len(shallow))
+	}
+	if none := g.FindImportPaths("a", "missing", 3, 0); none != nil {
+		t.Fatalf("expected no paths to unknown module, got %+v", none)
+	}
+}
@@ -0,0 +1,238 @@
+package graph
+
+// internal/engine/graph/reachability.go
+
+import (
+	"path/filepath"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/gobwas/glob"
+)
+
+// ReachabilityOptions selects the entry points EntryModules resolves and
+// AnalyzeReachability starts from.
+type ReachabilityOptions struct {
+	// Patterns are module names, file paths, or globs. Paths match exactly, by
+	// path suffix, or by base name; globs match module names or any trailing
+	// part of a file path, e.g. "cmd/**" or "scripts/*.py".
+	Patterns []string
+	// EntryFiles are graph file paths whose modules are entry points, matched
+	// exactly (e.g. package.json bin targets).
+	EntryFiles []string
+	// GoMain treats modules containing a Go `main` package as entry points.
+	GoMain bool
+	// PythonMain treats modules with a `__main__.py` file or an
+	// `if __name__ == "__main__":` guard as entry points.
+	PythonMain bool
+	// IsTestFile reports test files. Modules made only of test files are never
+	// reported as orphans. Nil falls back to Go and Python test file naming.
+	IsTestFile func(path string) bool
+}
+
+// OrphanModule is a module no entry point reaches through the import graph.
+// LastModified is left for callers with access to version control.
+type OrphanModule struct {
+	Module       string
+	Files        []string
+	LastModified time.Time
+}
+
+// ReachabilityReport is the result of AnalyzeReachability.
+type ReachabilityReport struct {
+	EntryPoints []string
+	Reachable   []string
+	Orphans     []OrphanModule
+}
+
+// EntryModules resolves opts to the sorted names of the modules analyses start
+// from: modules matching Patterns, holding an EntryFiles path, or detected as
+// Go or Python mains. File parses are read through GetFile so files evicted
+// from the cache still count.
+func (g *Graph) EntryModules(opts ReachabilityOptions) []string {
+	patterns := compileEntryPatterns(opts.Patterns)
+	entryFiles := make(map[string]bool, len(opts.EntryFiles))
+	for _, path := range opts.EntryFiles {
+		entryFiles[path] = true
+	}
+
+	entrySet := make(map[string]bool)
+	if len(entryFiles) > 0 || opts.GoMain || opts.PythonMain {
+		for _, path := range g.FilePaths() {
+			file, ok := g.GetFile(path)
+			if !ok {
+				continue
+			}
+			switch {
+			case entryFiles[path]:
+				entrySet[file.Module] = true
+			case opts.GoMain && file.Language == "go" && file.PackageName == "main":
+				entrySet[file.Module] = true
+			case opts.PythonMain && file.Language == "python" && (file.MainGuard || filepath.Base(path) == "__main__.py"):
+				entrySet[file.Module] = true
+			}
+		}
+	}
+
+	g.mu.RLock()
+	for _, name := range g.moduleNamesLocked() {
+		if entrySet[name] {
+			continue
+		}
+		var files []string
+		if mod, ok := g.moduleLocked(name); ok {
+			files = mod.Files
+		}
+		if patterns.matches(name, files) {
+			entrySet[name] = true
+		}
+	}
+	g.mu.RUnlock()
+
+	entries := make([]string, 0, len(entrySet))
+	for name := range entrySet {
+		entries = append(entries, name)
+	}
+	sort.Strings(entries)
+	return entries
+}
+
+// AnalyzeReachability walks the import graph from the entry modules opts
+// resolves to and reports the modules it cannot reach. Modules without files
+// (external imports) and test-only modules are never orphans. When no entry
+// point matches, nothing is reported as an orphan.
+func (g *Graph) AnalyzeReachability(opts ReachabilityOptions) ReachabilityReport {
+	isTestFile := opts.IsTestFile
+	if isTestFile == nil {
+		isTestFile = isDefaultTestFile
+	}
+	entries := g.EntryModules(opts)
+
+	g.mu.RLock()
+	nodes := g.moduleNamesLocked()
+	adjacency := make(map[string][]string, len(nodes))
+	files := make(map[string][]string, len(nodes))
+	for _, name := range nodes {
+		adjacency[name] = g.getSortedNeighbors(name)
+		if mod, ok := g.moduleLocked(name); ok {
+			files[name] = append([]string(nil), mod.Files...)
+		}
+	}
+	g.mu.RUnlock()
+
+	testOnly := make(map[string]bool, len(files))
+	for name, moduleFiles := range files {
+		testOnly[name] = len(moduleFiles) > 0
+		for _, path := range moduleFiles {
+			if !isTestFile(path) {
+				testOnly[name] = false
+				break
+			}
+		}
+	}
+
+	report := ReachabilityReport{
+		EntryPoints: entries,
+		Reachable:   make([]string, 0),
+		Orphans:     make([]OrphanModule, 0),
+	}
+	if len(report.EntryPoints) == 0 {
+		return report
+	}
+
+	reached := make(map[string]bool, len(nodes))
+	queue := append([]string(nil), report.EntryPoints...)
+	for _, entry := range queue {
+		reached[entry] = true
+	}
+	for len(queue) > 0 {
+		current := queue[0]
+		queue = queue[1:]
+		for _, next := range adjacency[current] {
+			if !reached[next] {
+				reached[next] = true
+				queue = append(queue, next)
+			}
+		}
+	}
+
+	for _, name := range nodes {
+		if reached[name] {
+			report.Reachable = append(report.Reachable, name)
+			continue
+		}
+		if len(files[name]) == 0 || testOnly[name] {
+			continue
+		}
+		moduleFiles := files[name]
+		sort.Strings(moduleFiles)
+		report.Orphans = append(report.Orphans, OrphanModule{Module: name, Files: moduleFiles})
+	}
+	return report
+}
+
+type entryPatterns struct {
+	exact []string
+	globs []glob.Glob
+}
+
+func compileEntryPatterns(raw []string) entryPatterns {
+	var out entryPatterns
+	for _, pattern := range raw {
+		pattern = filepath.ToSlash(strings.TrimSpace(pattern))
+		if pattern == "" {
+			continue
+		}
+		if strings.ContainsAny(pattern, "*?[{") {
+			if compiled, err := glob.Compile(pattern, '/'); err == nil {
+				out.globs = append(out.globs, compiled)
+			}
+			continue
+		}
+		out.exact = append(out.exact, filepath.ToSlash(filepath.Clean(pattern)))
+	}
+	return out
+}
+
+// matches reports whether a module name or one of its files is an entry point.
+func (p entryPatterns) matches(module string, files []string) bool {
+	for _, entry := range p.exact {
+		if entry == module {
+			return true
+		}
+	}
+	for _, g := range p.globs {
+		if g.Match(module) {
+			return true
+		}
+	}
+	for _, path := range files {
+		normalized := filepath.ToSlash(filepath.Clean(path))
+		for _, entry := range p.exact {
+			if normalized == entry || strings.HasSuffix(normalized, "/"+entry) || filepath.Base(normalized) == entry {
+				return true
+			}
+		}
+		for _, g := range p.globs {
+			if matchesPathSuffix(g, normalized) {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+// matchesPathSuffix matches g against the whole path and against every part of
+// it that starts after a slash, so relative globs match absolute paths.
+func matchesPathSuffix(g glob.Glob, path string) bool {
+	if g.Match(path) {
+		return true
+	}
+	for i := 0; i < len(path); i++ {
+		if path[i] == '/' && g.Match(path[i+1:]) {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,48 @@
+package graph
+
+import (
+	"circular/internal/engine/parser"
+	"reflect"
+	"testing"
+)
+
+func TestAnalyzeReachability(t *testing.T) {
+	g := NewGraph()
+	g.AddFile(&parser.File{Path: "/repo/cmd/app/main.go", Module: "cmd/app", Language: "go", PackageName: "main", Imports: []parser.Import{{Module: "svc"}, {Module: "fmt"}}})
+	g.AddFile(&parser.File{Path: "/repo/svc/svc.go", Module: "svc", Language: "go"})
+	g.AddFile(&parser.File{Path: "/repo/legacy/old.go", Module: "legacy", Language: "go", Imports: []parser.Import{{Module: "svc"}}})
+	g.AddFile(&parser.File{Path: "/repo/legacy/old_test.go", Module: "legacy", Language: "go"})
+	g.AddFile(&parser.File{Path: "/repo/fixtures/fixtures_test.go", Module: "fixtures", Language: "go"})
+	g.AddFile(&parser.File{Path: "/repo/tools/run.py", Module: "tools.run", Language: "python", MainGuard: true, Imports: []parser.Import{{Module: "tools.helpers"}}})
+	g.AddFile(&parser.File{Path: "/repo/tools/helpers.py", Module: "tools.helpers", Language: "python"})
+	g.AddFile(&parser.File{Path: "/repo/scripts/seed.py", Module: "scripts.seed", Language: "python"})
+
+	report := g.AnalyzeReachability(ReachabilityOptions{GoMain: true, PythonMain: true})
+
+	if !reflect.DeepEqual(report.EntryPoints, []string{"cmd/app", "tools.run"}) {
+		t.Fatalf("unexpected entry points: %v", report.EntryPoints)
+	}
+	if len(report.Orphans) != 2 || report.Orphans[0].Module != "legacy" || report.Orphans[1].Module != "scripts.seed" {
+		t.Fatalf("expected legacy and scripts.seed as orphans, got %+v", report.Orphans)
+	}
+	if !reflect.DeepEqual(report.Orphans[0].Files, []string{"/repo/legacy/old.go", "/repo/legacy/old_test.go"}) {
+		t.Fatalf("unexpected orphan files: %v", report.Orphans[0].Files)
+	}
+
+	report = g.AnalyzeReachability(ReachabilityOptions{GoMain: true, Patterns: []string{"scripts/*.py", "legacy"}})
+	if !reflect.DeepEqual(report.EntryPoints, []string{"cmd/app", "legacy", "scripts.seed"}) {
+		t.Fatalf("unexpected pattern entry points: %v", report.EntryPoints)
+	}
+	if len(report.Orphans) != 2 || report.Orphans[0].Module != "tools.helpers" || report.Orphans[1].Module != "tools.run" {
+		t.Fatalf("expected tools modules as orphans, got %+v", report.Orphans)
+	}
+
+	report = g.AnalyzeReachability(ReachabilityOptions{EntryFiles: []string{"/repo/scripts/seed.py"}})
+	if !reflect.DeepEqual(report.EntryPoints, []string{"scripts.seed"}) {
+		t.Fatalf("unexpected entry file entry points: %v", report.EntryPoints)
+	}
+
+	if report := g.AnalyzeReachability(ReachabilityOptions{}); len(report.EntryPoints) != 0 || len(report.Orphans) != 0 {
+		t.Fatalf("expected no orphans without entry points, got %+v", report)
+	}
+}
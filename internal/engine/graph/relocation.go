@@ -0,0 +1,366 @@
+package graph
+
+import (
+	"circular/internal/engine/parser"
+	"fmt"
+	"sort"
+	"strings"
+)
+
+// Relocation strategies, tried in this order.
+const (
+	RelocateToImporter = "importing_module"
+	RelocateToShared   = "shared_module"
+)
+
+// ModuleEdge is a module-level import added or removed by a simulated change.
+type ModuleEdge struct {
+	From string
+	To   string
+}
+
+func (e ModuleEdge) String() string {
+	return e.From + " -> " + e.To
+}
+
+// SymbolMove relocates one definition out of the back-edge target module.
+// ReferencedBy lists the symbols that pull it along: symbols of the importing
+// module for directly referenced definitions, or already-moved symbols for
+// definitions that move with them.
+type SymbolMove struct {
+	Symbol       SymbolKey
+	Kind         parser.DefinitionKind
+	File         string
+	Line         int
+	ReferencedBy []SymbolKey
+	Destination  string
+}
+
+// RelocationPlan describes how to dissolve a cycle by moving the definitions a
+// back-edge depends on. The plan is checked by re-running cycle detection on a
+// simulated copy of the graph; Resolved is true only when the back-edge import
+// disappears and no import added by the moves lies on a cycle.
+type RelocationPlan struct {
+	Cycle           []string
+	BackEdge        CycleCut
+	Strategy        string
+	Destination     string
+	Moves           []SymbolMove
+	RemovedImports  []ModuleEdge
+	AddedImports    []ModuleEdge
+	SimulatedCycles [][]string
+	Resolved        bool
+	Reason          string
+}
+
+// PlanCycleRelocation proposes symbol moves that break the given cycle. Each
+// cycle edge is tried as the back-edge, cheapest first by cut weight; for each
+// one the referenced definitions are moved into the importing module or, if
+// that still leaves a cycle, into a new shared module. The first validated plan
+// is returned; otherwise the attempt for the cheapest back-edge is returned
+// with Resolved=false and a Reason. Symbol edges must already be linked.
+func (g *Graph) PlanCycleRelocation(cycle []string) (RelocationPlan, error) {
+	g.mu.RLock()
+	defer g.mu.RUnlock()
+
+	cycle = normalizeCycle(cycle)
+	if len(cycle) < 2 {
+		return RelocationPlan{}, fmt.Errorf("cycle must name at least two modules")
+	}
+	candidates := make([]CycleCut, 0, len(cycle))
+	for i, from := range cycle {
+		to := cycle[(i+1)%len(cycle)]
+		if !g.hasModuleLocked(from) {
+			return RelocationPlan{}, fmt.Errorf("module not found: %s", from)
+		}
+		if _, ok := g.importsOfLocked(from)[to]; !ok {
+			return RelocationPlan{}, fmt.Errorf("cycle edge %s -> %s not found in graph", from, to)
+		}
+		candidates = append(candidates, g.weighCutLocked(from, to))
+	}
+	sort.SliceStable(candidates, func(i, j int) bool {
+		if candidates[i].Weight != candidates[j].Weight {
+			return candidates[i].Weight < candidates[j].Weight
+		}
+		return cutLess(candidates[i], candidates[j])
+	})
+
+	var first *RelocationPlan
+	for _, backEdge := range candidates {
+		plan := g.planRelocationLocked(cycle, backEdge)
+		if plan.Resolved {
+			return plan, nil
+		}
+		if first == nil {
+			first = &plan
+		}
+	}
+	return *first, nil
+}
+
+func (g *Graph) planRelocationLocked(cycle []string, backEdge CycleCut) RelocationPlan {
+	plan := RelocationPlan{
+		Cycle:    append([]string(nil), cycle...),
+		BackEdge: backEdge,
+	}
+
+	edges := g.liveSymbolEdgesLocked()
+	moves := g.collectMovesLocked(backEdge.From, backEdge.To, edges)
+	if len(moves) == 0 {
+		plan.Reason = fmt.Sprintf("no linked symbol references from %s to %s", backEdge.From, backEdge.To)
+		return plan
+	}
+
+	destinations := []struct {
+		strategy string
+		module   string
+	}{
+		{RelocateToImporter, backEdge.From},
+		{RelocateToShared, g.sharedModuleNameLocked(backEdge.From, backEdge.To)},
+	}
+	for _, dest := range destinations {
+		attempt := plan
+		attempt.Strategy = dest.strategy
+		attempt.Destination = dest.module
+		attempt.Moves = make([]SymbolMove, 0, len(moves))
+		for _, move := range moves {
+			move.Destination = dest.module
+			attempt.Moves = append(attempt.Moves, move)
+		}
+
+		sim := g.cloneTopologyLocked()
+		attempt.RemovedImports, attempt.AddedImports = applyMovesToSimulation(sim, backEdge, attempt.Moves, edges)
+		attempt.SimulatedCycles = sim.DetectCycles()
+
+		removed := false
+		for _, edge := range attempt.RemovedImports {
+			if edge.From == backEdge.From && edge.To == backEdge.To {
+				removed = true
+			}
+		}
+		switch {
+		case !removed:
+			attempt.Reason = fmt.Sprintf("%s still references unmoved symbols of %s", backEdge.From, backEdge.To)
+		case introducesNewCycle(sim, attempt.AddedImports):
+			attempt.Reason = fmt.Sprintf("moving to %s introduces a new cycle", dest.module)
+		default:
+			attempt.Resolved = true
+			attempt.Reason = ""
+			return attempt
+		}
+		plan = attempt
+	}
+	return plan
+}
+
+// collectMovesLocked returns the definitions of to referenced from from, plus
+// every definition of to they depend on, so nothing moved keeps importing to.
+func (g *Graph) collectMovesLocked(from, to string, edges []SymbolEdge) []SymbolMove {
+	referencedBy := make(map[SymbolKey]map[SymbolKey]bool)
+	queue := make([]SymbolKey, 0)
+	require := func(target, source SymbolKey) {
+		if referencedBy[target] == nil {
+			referencedBy[target] = make(map[SymbolKey]bool)
+			queue = append(queue, target)
+		}
+		referencedBy[target][source] = true
+	}
+	for _, edge := range edges {
+		if edge.From.Module == from && edge.To.Module == to {
+			require(edge.To, edge.From)
+		}
+	}
+	for len(queue) > 0 {
+		key := queue[0]
+		queue = queue[1:]
+		for _, edge := range edges {
+			if edge.From == key && edge.To.Module == to && edge.To != key {
+				require(edge.To, key)
+			}
+		}
+	}
+
+	moves := make([]SymbolMove, 0, len(referencedBy))
+	for key, sources := range referencedBy {
+		move := SymbolMove{Symbol: key}
+		if def, ok := g.lookupSymbolLocked(key); ok {
+			move.Kind = def.Kind
+			move.File = def.Location.File
+			move.Line = def.Location.Line
+		}
+		for source := range sources {
+			move.ReferencedBy = append(move.ReferencedBy, source)
+		}
+		sort.Slice(move.ReferencedBy, func(i, j int) bool {
+			return move.ReferencedBy[i].String() < move.ReferencedBy[j].String()
+		})
+		moves = append(moves, move)
+	}
+	sort.Slice(moves, func(i, j int) bool { return moves[i].Symbol.Name < moves[j].Symbol.Name })
+	return moves
+}
+
+// applyMovesToSimulation rewires the simulated module graph as if the moves
+// were made: the back-edge import goes away when nothing unmoved is still
+// referenced through it, the destination imports whatever the moved symbols
+// use, and every remaining user of a moved symbol imports the destination.
+func applyMovesToSimulation(sim *Graph, backEdge CycleCut, moves []SymbolMove, edges []SymbolEdge) (removed, added []ModuleEdge) {
+	if len(moves) == 0 {
+		return nil, nil
+	}
+	dest := moves[0].Destination
+	moved := make(map[SymbolKey]bool, len(moves))
+	for _, move := range moves {
+		moved[move.Symbol] = true
+	}
+	if !sim.hasModuleLocked(dest) {
+		sim.modules[dest] = &Module{Name: dest, Exports: make(map[string]*parser.Definition)}
+	}
+
+	stillUsed := false
+	for _, edge := range edges {
+		if edge.From.Module == backEdge.From && edge.To.Module == backEdge.To && !moved[edge.To] {
+			stillUsed = true
+			break
+		}
+	}
+	if !stillUsed && sim.removeSimulatedImport(backEdge.From, backEdge.To) {
+		removed = append(removed, ModuleEdge{From: backEdge.From, To: backEdge.To})
+	}
+
+	seen := make(map[ModuleEdge]bool)
+	addImport := func(from, to string) {
+		edge := ModuleEdge{From: from, To: to}
+		if from == to || seen[edge] {
+			return
+		}
+		seen[edge] = true
+		if sim.addSimulatedImport(from, to) {
+			added = append(added, edge)
+		}
+	}
+	for _, edge := range edges {
+		switch {
+		case moved[edge.From] && !moved[edge.To]:
+			addImport(dest, edge.To.Module)
+		case !moved[edge.From] && moved[edge.To]:
+			addImport(edge.From.Module, dest)
+		}
+	}
+
+	sort.Slice(added, func(i, j int) bool { return added[i].String() < added[j].String() })
+	return removed, added
+}
+
+// introducesNewCycle reports whether any added import closes a cycle, i.e.
+// its importer is reachable again from the imported module. Cycles through
+// added imports cannot have existed before the simulated change.
+func introducesNewCycle(sim *Graph, added []ModuleEdge) bool {
+	for _, edge := range added {
+		if _, ok := sim.findImportChainLocked(edge.To, edge.From); ok {
+			return true
+		}
+	}
+	return false
+}
+
+// cloneTopologyLocked copies modules and import adjacency into a detached
+// graph for simulation. Files, definitions, and storage are not copied.
+func (g *Graph) cloneTopologyLocked() *Graph {
+	sim := NewGraphWithCapacity(1)
+	for _, name := range g.moduleNamesLocked() {
+		if mod, ok := g.moduleLocked(name); ok {
+			sim.modules[name] = cloneModule(mod)
+		}
+		targets := g.importsOfLocked(name)
+		sim.imports[name] = make(map[string]*ImportEdge, len(targets))
+		for to, edge := range targets {
+			sim.imports[name][to] = cloneImportEdge(edge)
+			if sim.importedBy[to] == nil {
+				sim.importedBy[to] = make(map[string]bool)
+			}
+			sim.importedBy[to][name] = true
+		}
+	}
+	return sim
+}
+
+func (g *Graph) addSimulatedImport(from, to string) bool {
+	if g.imports[from] == nil {
+		g.imports[from] = make(map[string]*ImportEdge)
+	}
+	if _, ok := g.imports[from][to]; ok {
+		return false
+	}
+	g.imports[from][to] = &ImportEdge{From: from, To: to}
+	if g.importedBy[to] == nil {
+		g.importedBy[to] = make(map[string]bool)
+	}
+	g.importedBy[to][from] = true
+	return true
+}
+
+func (g *Graph) removeSimulatedImport(from, to string) bool {
+	if _, ok := g.imports[from][to]; !ok {
+		return false
+	}
+	delete(g.imports[from], to)
+	if g.importedBy[to] != nil {
+		delete(g.importedBy[to], from)
+	}
+	return true
+}
+
+// liveSymbolEdgesLocked returns symbol edges whose target still exists.
+func (g *Graph) liveSymbolEdgesLocked() []SymbolEdge {
+	out := make([]SymbolEdge, 0)
+	for _, edges := range g.symbolEdges {
+		for _, edge := range edges {
+			if g.hasSymbolLocked(edge.To) {
+				out = append(out, edge)
+			}
+		}
+	}
+	sortSymbolEdges(out)
+	return out
+}
+
+// sharedModuleNameLocked names a new module next to the common parent of a and
+// b, for example "app/shared" for "app/a" and "app/b".
+func (g *Graph) sharedModuleNameLocked(a, b string) string {
+	base := "shared"
+	for _, sep := range []string{"/", "::", "."} {
+		if !strings.Contains(a, sep) || !strings.Contains(b, sep) {
+			continue
+		}
+		left, right := strings.Split(a, sep), strings.Split(b, sep)
+		common := make([]string, 0)
+		for i := 0; i < len(left)-1 && i < len(right)-1 && left[i] == right[i]; i++ {
+			common = append(common, left[i])
+		}
+		if len(common) > 0 {
+			base = strings.Join(append(common, "shared"), sep)
+		}
+		break
+	}
+
+	name := base
+	for i := 2; g.hasModuleLocked(name); i++ {
+		name = fmt.Sprintf("%s%d", base, i)
+	}
+	return name
+}
+
+// normalizeCycle drops a repeated closing module ("a, b, a" becomes "a, b").
+func normalizeCycle(cycle []string) []string {
+	out := make([]string, 0, len(cycle))
+	for _, module := range cycle {
+		if module = strings.TrimSpace(module); module != "" {
+			out = append(out, module)
+		}
+	}
+	if len(out) > 1 && out[0] == out[len(out)-1] {
+		out = out[:len(out)-1]
+	}
+	return out
+}
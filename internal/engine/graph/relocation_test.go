@@ -0,0 +1,131 @@
+package graph
+
+import (
+	"circular/internal/engine/parser"
+	"testing"
+)
+
+func addRelocationFixture(g *Graph) {
+	g.AddFile(&parser.File{
+		Path:    "app/a/a.go",
+		Module:  "app/a",
+		Imports: []parser.Import{{Module: "app/b"}, {Module: "app/c"}},
+		Definitions: []parser.Definition{
+			{Name: "Run", Kind: parser.KindFunction, Location: parser.Location{File: "app/a/a.go", Line: 3}, LOC: 5},
+			{Name: "Helper", Kind: parser.KindFunction, Location: parser.Location{File: "app/a/a.go", Line: 10}, LOC: 3},
+		},
+	})
+	g.AddFile(&parser.File{
+		Path:    "app/b/b.go",
+		Module:  "app/b",
+		Imports: []parser.Import{{Module: "app/a"}},
+		Definitions: []parser.Definition{
+			{Name: "Serve", Kind: parser.KindFunction, Location: parser.Location{File: "app/b/b.go", Line: 3}, LOC: 5},
+			{Name: "Format", Kind: parser.KindFunction, Location: parser.Location{File: "app/b/b.go", Line: 10}, LOC: 3},
+			{Name: "pad", Kind: parser.KindFunction, Location: parser.Location{File: "app/b/b.go", Line: 15}, LOC: 3},
+		},
+	})
+	g.AddFile(&parser.File{
+		Path:   "app/c/c.go",
+		Module: "app/c",
+		Definitions: []parser.Definition{
+			{Name: "Log", Kind: parser.KindFunction, Location: parser.Location{File: "app/c/c.go", Line: 3}, LOC: 3},
+		},
+	})
+
+	g.SetSymbolEdges("app/a/a.go", []SymbolEdge{
+		{From: SymbolKey{Module: "app/a", Name: "Run"}, To: SymbolKey{Module: "app/b", Name: "Format"}, File: "app/a/a.go"},
+	})
+	g.SetSymbolEdges("app/b/b.go", []SymbolEdge{
+		{From: SymbolKey{Module: "app/b", Name: "Serve"}, To: SymbolKey{Module: "app/a", Name: "Helper"}, File: "app/b/b.go"},
+		{From: SymbolKey{Module: "app/b", Name: "Serve"}, To: SymbolKey{Module: "app/a", Name: "Run"}, File: "app/b/b.go"},
+		{From: SymbolKey{Module: "app/b", Name: "Format"}, To: SymbolKey{Module: "app/b", Name: "pad"}, File: "app/b/b.go"},
+	})
+}
+
+func TestPlanCycleRelocation_MovesIntoImportingModule(t *testing.T) {
+	g := NewGraph()
+	addRelocationFixture(g)
+
+	plan, err := g.PlanCycleRelocation([]string{"app/a", "app/b", "app/a"})
+	if err != nil {
+		t.Fatalf("plan relocation: %v", err)
+	}
+	if !plan.Resolved {
+		t.Fatalf("expected resolved plan, got reason %q", plan.Reason)
+	}
+	if plan.BackEdge.From != "app/a" || plan.BackEdge.To != "app/b" {
+		t.Fatalf("unexpected back-edge: %s -> %s", plan.BackEdge.From, plan.BackEdge.To)
+	}
+	if plan.Strategy != RelocateToImporter || plan.Destination != "app/a" {
+		t.Fatalf("unexpected strategy %q -> %q", plan.Strategy, plan.Destination)
+	}
+	if len(plan.Moves) != 2 || plan.Moves[0].Symbol.Name != "Format" || plan.Moves[1].Symbol.Name != "pad" {
+		t.Fatalf("expected Format and its dependency pad to move, got %+v", plan.Moves)
+	}
+	if plan.Moves[0].File != "app/b/b.go" || plan.Moves[0].Line != 10 {
+		t.Fatalf("expected definition location on move, got %+v", plan.Moves[0])
+	}
+	if len(plan.Moves[1].ReferencedBy) != 1 || plan.Moves[1].ReferencedBy[0].Name != "Format" {
+		t.Fatalf("expected pad to be pulled in by Format, got %+v", plan.Moves[1].ReferencedBy)
+	}
+	if len(plan.RemovedImports) != 1 || plan.RemovedImports[0] != (ModuleEdge{From: "app/a", To: "app/b"}) {
+		t.Fatalf("unexpected removed imports: %+v", plan.RemovedImports)
+	}
+	if len(plan.SimulatedCycles) != 0 {
+		t.Fatalf("expected no simulated cycles, got %v", plan.SimulatedCycles)
+	}
+
+	// The live graph is untouched by the simulation.
+	if len(g.DetectCycles()) != 1 {
+		t.Fatal("expected original cycle to remain in the live graph")
+	}
+}
+
+func TestPlanCycleRelocation_FallsBackToSharedModule(t *testing.T) {
+	g := NewGraph()
+	addRelocationFixture(g)
+	// c also uses b.Format; moving it into a would make c import a while a
+	// imports c, so the plan has to extract a shared module instead.
+	g.AddFile(&parser.File{
+		Path:    "app/c/c.go",
+		Module:  "app/c",
+		Imports: []parser.Import{{Module: "app/b"}},
+		Definitions: []parser.Definition{
+			{Name: "Log", Kind: parser.KindFunction, Location: parser.Location{File: "app/c/c.go", Line: 3}, LOC: 3},
+		},
+	})
+	g.SetSymbolEdges("app/c/c.go", []SymbolEdge{
+		{From: SymbolKey{Module: "app/c", Name: "Log"}, To: SymbolKey{Module: "app/b", Name: "Format"}, File: "app/c/c.go"},
+	})
+
+	plan, err := g.PlanCycleRelocation([]string{"app/a", "app/b"})
+	if err != nil {
+		t.Fatalf("plan relocation: %v", err)
+	}
+	if !plan.Resolved {
+		t.Fatalf("expected resolved plan, got reason %q", plan.Reason)
+	}
+	if plan.Strategy != RelocateToShared || plan.Destination != "app/shared" {
+		t.Fatalf("expected shared module fallback, got %q -> %q", plan.Strategy, plan.Destination)
+	}
+	want := map[ModuleEdge]bool{
+		{From: "app/a", To: "app/shared"}: true,
+		{From: "app/c", To: "app/shared"}: true,
+	}
+	for _, edge := range plan.AddedImports {
+		delete(want, edge)
+	}
+	if len(want) != 0 {
+		t.Fatalf("missing added imports %v in %+v", want, plan.AddedImports)
+	}
+}
+
+func TestPlanCycleRelocation_RejectsUnknownEdge(t *testing.T) {
+	g := NewGraph()
+	addRelocationFixture(g)
+
+	if _, err := g.PlanCycleRelocation([]string{"app/a", "app/c"}); err == nil {
+		t.Fatal("expected error for cycle edge missing from graph")
+	}
+}
@@ -0,0 +1,47 @@
+package graph
+
+// internal/engine/graph/samples.go
+
+// StaleSampleImport is an import in a Markdown code sample naming a module
+// the graph does not have, below a path that is internal to the project: a
+// sample still importing a package that was renamed or removed.
+type StaleSampleImport struct {
+	File   string
+	Module string // imported module
+	Line   int
+	Column int
+}
+
+// FindStaleSampleImports checks the sample imports of documentation files
+// against the graph's modules. Sample imports never add edges, so this is the
+// only place they are read. An import is stale when its parent path is an internal
+// module or a prefix of one, but the import itself is neither a module, a
+// prefix of one, nor a path below one. Imports of other projects and the
+// standard library are never stale.
+func (g *Graph) FindStaleSampleImports() []StaleSampleImport {
+	internal := g.internalPrefixes()
+	out := make([]StaleSampleImport, 0)
+	for _, path := range g.FilePaths() {
+		file, ok := g.GetFile(path)
+		if !ok || len(file.SampleImports) == 0 {
+			continue
+		}
+		for _, imp := range file.SampleImports {
+			segments := ModuleSegments(imp.Module)
+			if len(segments) < 2 || internal.covers(imp.Module) {
+				continue
+			}
+			parent := ModulePrefix(imp.Module, len(segments)-1)
+			if !internal.modules[parent] && !internal.prefixes[parent] {
+				continue
+			}
+			out = append(out, StaleSampleImport{
+				File:   path,
+				Module: imp.Module,
+				Line:   imp.Location.Line,
+				Column: imp.Location.Column,
+			})
+		}
+	}
+	return out
+}
@@ -0,0 +1,67 @@
+package graph
+
+import (
+	"circular/internal/engine/parser"
+	"reflect"
+	"testing"
+)
+
+func TestFindStaleSampleImports(t *testing.T) {
+	g := NewGraph()
+	g.AddFile(&parser.File{Path: "app/store/store.go", Language: "go", Module: "example.com/app/store"})
+	g.AddFile(&parser.File{Path: "app/auth/auth.py", Language: "python", Module: "app.auth"})
+	g.AddFile(&parser.File{
+		Path:     "docs/guide.md",
+		Language: "markdown",
+		SampleImports: []parser.Import{
+			{Module: "example.com/app/store", Location: parser.Location{Line: 3, Column: 8}},
+			{Module: "example.com/app/store/sub", Location: parser.Location{Line: 4, Column: 8}},
+			{Module: "example.com/app/cache", Location: parser.Location{Line: 5, Column: 8}},
+			{Module: "app.gone", Location: parser.Location{Line: 9, Column: 6}},
+			{Module: "github.com/spf13/cobra", Location: parser.Location{Line: 6, Column: 8}},
+			{Module: "os", Location: parser.Location{Line: 10, Column: 8}},
+		},
+	})
+	// Imports of code files are never checked.
+	g.AddFile(&parser.File{
+		Path:     "app/cmd/main.go",
+		Language: "go",
+		Module:   "example.com/app/cmd",
+		Imports:  []parser.Import{{Module: "example.com/app/missing", Location: parser.Location{Line: 3, Column: 8}}},
+	})
+
+	want := []StaleSampleImport{
+		{File: "docs/guide.md", Module: "example.com/app/cache", Line: 5, Column: 8},
+		{File: "docs/guide.md", Module: "app.gone", Line: 9, Column: 6},
+	}
+	if got := g.FindStaleSampleImports(); !reflect.DeepEqual(got, want) {
+		t.Fatalf("unexpected stale sample imports\n got: %+v\nwant: %+v", got, want)
+	}
+}
+
+func TestSampleImports_DoNotAddImportEdges(t *testing.T) {
+	build := func(samples []parser.Import) *Graph {
+		g := NewGraph()
+		g.AddFile(&parser.File{Path: "app/store/store.go", Language: "go", Module: "example.com/app/store"})
+		g.AddFile(&parser.File{Path: "docs/guide.md", Language: "markdown", SampleImports: samples})
+		return g
+	}
+	plain := build(nil)
+	sampled := build([]parser.Import{
+		{Module: "example.com/app/store", Location: parser.Location{Line: 3, Column: 8}},
+		{Module: "example.com/app/cache", Location: parser.Location{Line: 4, Column: 8}},
+	})
+
+	if got, want := sampled.ModuleCount(), plain.ModuleCount(); got != want {
+		t.Fatalf("expected sample imports to leave the module count at %d, got %d", want, got)
+	}
+	if fanIn := sampled.ComputeModuleMetrics()["example.com/app/store"].FanIn; fanIn != 0 {
+		t.Fatalf("expected sample imports to leave store's fan-in at 0, got %d", fanIn)
+	}
+	if imports := sampled.GetImports(); !reflect.DeepEqual(imports, plain.GetImports()) {
+		t.Fatalf("expected no import edges from sample imports, got %v", imports)
+	}
+	if stale := sampled.FindStaleSampleImports(); len(stale) != 1 || stale[0].Module != "example.com/app/cache" {
+		t.Fatalf("expected sample imports to still be checked, got %+v", stale)
+	}
+}
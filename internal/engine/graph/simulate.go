@@ -0,0 +1,336 @@
+package graph
+
+import (
+	"circular/internal/engine/parser"
+	"circular/internal/shared/util"
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/gobwas/glob"
+)
+
+// Simulation operation kinds.
+const (
+	SimulateMoveFile     = "move_file"
+	SimulateDeleteEdge   = "delete_edge"
+	SimulateMergeModules = "merge_modules"
+	SimulateSplitModule  = "split_module"
+)
+
+// SimulationOp is one hypothetical refactoring step:
+//   - move_file: File moves to module To
+//   - delete_edge: module From stops importing module To
+//   - merge_modules: module From is folded into module To
+//   - split_module: files of Module matching Glob move to module To
+type SimulationOp struct {
+	Kind   string
+	File   string
+	Module string
+	Glob   string
+	From   string
+	To     string
+}
+
+func (op SimulationOp) String() string {
+	switch op.Kind {
+	case SimulateMoveFile:
+		return fmt.Sprintf("move_file %s -> %s", op.File, op.To)
+	case SimulateDeleteEdge:
+		return fmt.Sprintf("delete_edge %s -> %s", op.From, op.To)
+	case SimulateMergeModules:
+		return fmt.Sprintf("merge_modules %s -> %s", op.From, op.To)
+	case SimulateSplitModule:
+		return fmt.Sprintf("split_module %s[%s] -> %s", op.Module, op.Glob, op.To)
+	default:
+		return op.Kind
+	}
+}
+
+// Validate checks that op names a known kind and carries the fields it needs.
+func (op SimulationOp) Validate() error {
+	switch op.Kind {
+	case SimulateMoveFile:
+		if strings.TrimSpace(op.File) == "" || strings.TrimSpace(op.To) == "" {
+			return fmt.Errorf("%s requires file and to", op.Kind)
+		}
+	case SimulateDeleteEdge, SimulateMergeModules:
+		if strings.TrimSpace(op.From) == "" || strings.TrimSpace(op.To) == "" {
+			return fmt.Errorf("%s requires from and to", op.Kind)
+		}
+		if op.From == op.To {
+			return fmt.Errorf("%s requires two different modules", op.Kind)
+		}
+	case SimulateSplitModule:
+		if strings.TrimSpace(op.Module) == "" || strings.TrimSpace(op.Glob) == "" || strings.TrimSpace(op.To) == "" {
+			return fmt.Errorf("%s requires module, glob, and to", op.Kind)
+		}
+		if op.Module == op.To {
+			return fmt.Errorf("%s requires a new target module", op.Kind)
+		}
+	default:
+		return fmt.Errorf("unknown operation %q", op.Kind)
+	}
+	return nil
+}
+
+// simulation holds detached file copies that operations rewrite before the
+// simulated graph is built from them.
+type simulation struct {
+	files   map[string]*parser.File // path -> file copy
+	edges   map[string][]SymbolEdge // path -> outgoing symbol edges
+	defFile map[SymbolKey]string    // original definition -> defining path
+}
+
+// Simulate applies ops, in order, to a copy of the graph and returns the copy.
+// The receiver is not modified. When symbol edges are linked, importers of a
+// moved file are retargeted to the module that now holds the definitions they
+// use; otherwise their imports are left pointing at the original module.
+func (g *Graph) Simulate(ops []SimulationOp) (*Graph, error) {
+	sim, err := g.newSimulation()
+	if err != nil {
+		return nil, err
+	}
+	for i, op := range ops {
+		if err := sim.apply(op); err != nil {
+			return nil, fmt.Errorf("operation %d (%s): %w", i+1, op.Kind, err)
+		}
+	}
+	return sim.build(), nil
+}
+
+func (g *Graph) newSimulation() (*simulation, error) {
+	g.mu.RLock()
+	defer g.mu.RUnlock()
+
+	sim := &simulation{
+		files:   make(map[string]*parser.File, len(g.fileToModule)),
+		edges:   make(map[string][]SymbolEdge, len(g.symbolEdges)),
+		defFile: make(map[SymbolKey]string),
+	}
+	for path := range g.fileToModule {
+		file, ok := g.fileCache.Peek(path)
+		if !ok && g.loader != nil {
+			if loaded, err := g.loader.LoadFile(path); err == nil && loaded != nil {
+				file, ok = loaded, true
+			}
+		}
+		if !ok {
+			return nil, fmt.Errorf("file %s is not cached and cannot be reloaded", path)
+		}
+		copied := cloneFile(file)
+		sim.files[path] = copied
+		for _, def := range copied.Definitions {
+			sim.defFile[SymbolKey{Module: copied.Module, Name: def.Name}] = path
+		}
+	}
+	for path, edges := range g.symbolEdges {
+		sim.edges[path] = append([]SymbolEdge(nil), edges...)
+	}
+	return sim, nil
+}
+
+func (s *simulation) apply(op SimulationOp) error {
+	if err := op.Validate(); err != nil {
+		return err
+	}
+	switch op.Kind {
+	case SimulateMoveFile:
+		path, err := s.resolvePath(op.File)
+		if err != nil {
+			return err
+		}
+		s.moveFiles([]string{path}, op.To)
+	case SimulateDeleteEdge:
+		return s.deleteEdge(op.From, op.To)
+	case SimulateMergeModules:
+		paths := s.filesOf(op.From)
+		if len(paths) == 0 {
+			return fmt.Errorf("module not found: %s", op.From)
+		}
+		s.moveFiles(paths, op.To)
+		// Imports that could not be retargeted by symbol still name the
+		// merged module; it no longer exists, so point them at the target.
+		for _, file := range s.files {
+			for i := range file.Imports {
+				if file.Imports[i].Module == op.From {
+					file.Imports[i].Module = op.To
+				}
+			}
+		}
+		s.dropSelfImports()
+	case SimulateSplitModule:
+		paths, err := s.matchGlob(op.Module, op.Glob)
+		if err != nil {
+			return err
+		}
+		s.moveFiles(paths, op.To)
+	}
+	return nil
+}
+
+// moveFiles reassigns files to a module and retargets every import that
+// reached their definitions through the old module.
+func (s *simulation) moveFiles(paths []string, to string) {
+	before := make(map[string]string, len(s.files))
+	for path, file := range s.files {
+		before[path] = file.Module
+	}
+	for _, path := range paths {
+		s.files[path].Module = to
+	}
+	s.retargetImports(before)
+	s.dropSelfImports()
+}
+
+// retargetImports rewrites each import to the modules that now define the
+// symbols the importing file used through it. Imports with no linked symbol
+// use are left alone.
+func (s *simulation) retargetImports(before map[string]string) {
+	for path, file := range s.files {
+		targets := make(map[string]map[string]bool) // old module -> new modules
+		for _, edge := range s.edges[path] {
+			defPath, ok := s.defFile[edge.To]
+			if !ok {
+				continue
+			}
+			oldModule := before[defPath]
+			if targets[oldModule] == nil {
+				targets[oldModule] = make(map[string]bool)
+			}
+			targets[oldModule][s.files[defPath].Module] = true
+		}
+
+		imports := make([]parser.Import, 0, len(file.Imports))
+		for _, imp := range file.Imports {
+			modules := util.SortedStringKeys(targets[imp.Module])
+			if len(modules) == 0 {
+				imports = append(imports, imp)
+				continue
+			}
+			for _, module := range modules {
+				retargeted := imp
+				retargeted.Module = module
+				imports = append(imports, retargeted)
+			}
+		}
+		file.Imports = imports
+	}
+}
+
+func (s *simulation) dropSelfImports() {
+	for _, file := range s.files {
+		imports := file.Imports[:0]
+		for _, imp := range file.Imports {
+			if imp.Module != file.Module {
+				imports = append(imports, imp)
+			}
+		}
+		file.Imports = imports
+	}
+}
+
+func (s *simulation) deleteEdge(from, to string) error {
+	removed := false
+	for _, file := range s.files {
+		if file.Module != from {
+			continue
+		}
+		imports := file.Imports[:0]
+		for _, imp := range file.Imports {
+			if imp.Module == to {
+				removed = true
+				continue
+			}
+			imports = append(imports, imp)
+		}
+		file.Imports = imports
+	}
+	if !removed {
+		return fmt.Errorf("import edge %s -> %s not found", from, to)
+	}
+	return nil
+}
+
+// resolvePath accepts a graph path or a unique path suffix such as a
+// project-relative path.
+func (s *simulation) resolvePath(raw string) (string, error) {
+	raw = util.NormalizePatternPath(strings.TrimSpace(raw))
+	if raw == "" {
+		return "", fmt.Errorf("file is required")
+	}
+	if _, ok := s.files[raw]; ok {
+		return raw, nil
+	}
+	matches := make([]string, 0, 1)
+	for path := range s.files {
+		if strings.HasSuffix(util.NormalizePatternPath(path), "/"+strings.TrimPrefix(raw, "./")) {
+			matches = append(matches, path)
+		}
+	}
+	switch len(matches) {
+	case 0:
+		return "", fmt.Errorf("file not found: %s", raw)
+	case 1:
+		return matches[0], nil
+	default:
+		sort.Strings(matches)
+		return "", fmt.Errorf("file %s is ambiguous: %s", raw, strings.Join(matches, ", "))
+	}
+}
+
+// matchGlob returns files of module matching pattern. Relative patterns match
+// anywhere below the project, as if prefixed with "**/".
+func (s *simulation) matchGlob(module, pattern string) ([]string, error) {
+	pattern = util.NormalizePatternPath(strings.TrimSpace(pattern))
+	if pattern == "" {
+		return nil, fmt.Errorf("glob is required")
+	}
+	matchers := make([]glob.Glob, 0, 2)
+	for _, candidate := range []string{pattern, "**/" + strings.TrimPrefix(pattern, "./")} {
+		compiled, err := glob.Compile(candidate, '/')
+		if err != nil {
+			return nil, fmt.Errorf("invalid glob %q: %w", pattern, err)
+		}
+		matchers = append(matchers, compiled)
+	}
+
+	paths := make([]string, 0)
+	for _, path := range s.filesOf(module) {
+		normalized := util.NormalizePatternPath(path)
+		for _, m := range matchers {
+			if m.Match(normalized) {
+				paths = append(paths, path)
+				break
+			}
+		}
+	}
+	if len(paths) == 0 {
+		return nil, fmt.Errorf("no files of module %s match %s", module, pattern)
+	}
+	return paths, nil
+}
+
+func (s *simulation) filesOf(module string) []string {
+	paths := make([]string, 0)
+	for path, file := range s.files {
+		if file.Module == module {
+			paths = append(paths, path)
+		}
+	}
+	sort.Strings(paths)
+	return paths
+}
+
+func (s *simulation) build() *Graph {
+	out := NewGraphWithCapacity(len(s.files) + 1)
+	paths := make([]string, 0, len(s.files))
+	for path := range s.files {
+		paths = append(paths, path)
+	}
+	sort.Strings(paths)
+	for _, path := range paths {
+		out.AddFile(s.files[path])
+	}
+	return out
+}
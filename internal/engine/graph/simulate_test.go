@@ -0,0 +1,95 @@
+package graph
+
+import (
+	"circular/internal/engine/parser"
+	"testing"
+)
+
+func TestSimulate_MoveFileRetargetsImporters(t *testing.T) {
+	g := NewGraph()
+	addRelocationFixture(g)
+	// Move b's Format into its own file so it can be relocated on its own.
+	g.AddFile(&parser.File{
+		Path:    "app/b/b.go",
+		Module:  "app/b",
+		Imports: []parser.Import{{Module: "app/a"}},
+		Definitions: []parser.Definition{
+			{Name: "Serve", Kind: parser.KindFunction, Location: parser.Location{File: "app/b/b.go", Line: 3}},
+		},
+	})
+	g.AddFile(&parser.File{
+		Path:   "app/b/format.go",
+		Module: "app/b",
+		Definitions: []parser.Definition{
+			{Name: "Format", Kind: parser.KindFunction, Location: parser.Location{File: "app/b/format.go", Line: 3}},
+		},
+	})
+	g.SetSymbolEdges("app/b/b.go", []SymbolEdge{
+		{From: SymbolKey{Module: "app/b", Name: "Serve"}, To: SymbolKey{Module: "app/a", Name: "Run"}, File: "app/b/b.go"},
+	})
+
+	sim, err := g.Simulate([]SimulationOp{{Kind: SimulateMoveFile, File: "b/format.go", To: "app/fmt"}})
+	if err != nil {
+		t.Fatalf("simulate: %v", err)
+	}
+	if cycles := sim.DetectCycles(); len(cycles) != 0 {
+		t.Fatalf("expected move to break the cycle, got %v", cycles)
+	}
+	imports := sim.GetImports()
+	if _, ok := imports["app/a"]["app/fmt"]; !ok {
+		t.Fatalf("expected app/a to import app/fmt, got %v", imports["app/a"])
+	}
+	if _, ok := imports["app/a"]["app/b"]; ok {
+		t.Fatal("expected app/a -> app/b import to be retargeted")
+	}
+	if len(g.DetectCycles()) != 1 {
+		t.Fatal("expected the live graph to keep its cycle")
+	}
+}
+
+func TestSimulate_MergeAndDeleteEdge(t *testing.T) {
+	g := NewGraph()
+	addRelocationFixture(g)
+
+	merged, err := g.Simulate([]SimulationOp{{Kind: SimulateMergeModules, From: "app/b", To: "app/a"}})
+	if err != nil {
+		t.Fatalf("simulate merge: %v", err)
+	}
+	if merged.ModuleCount() != 2 || len(merged.DetectCycles()) != 0 {
+		t.Fatalf("expected merge to leave app/a and app/c without cycles, got %d modules", merged.ModuleCount())
+	}
+
+	cut, err := g.Simulate([]SimulationOp{{Kind: SimulateDeleteEdge, From: "app/b", To: "app/a"}})
+	if err != nil {
+		t.Fatalf("simulate delete: %v", err)
+	}
+	if len(cut.DetectCycles()) != 0 {
+		t.Fatal("expected deleted edge to break the cycle")
+	}
+
+	if _, err := g.Simulate([]SimulationOp{{Kind: SimulateDeleteEdge, From: "app/c", To: "app/a"}}); err == nil {
+		t.Fatal("expected error for missing import edge")
+	}
+}
+
+func TestSimulate_SplitModuleByGlob(t *testing.T) {
+	g := NewGraph()
+	g.AddFile(&parser.File{Path: "/repo/core/a.go", Module: "core", Imports: []parser.Import{{Module: "util"}}})
+	g.AddFile(&parser.File{Path: "/repo/core/a_http.go", Module: "core"})
+	g.AddFile(&parser.File{Path: "/repo/util/u.go", Module: "util"})
+
+	sim, err := g.Simulate([]SimulationOp{{Kind: SimulateSplitModule, Module: "core", Glob: "core/*_http.go", To: "core/http"}})
+	if err != nil {
+		t.Fatalf("simulate split: %v", err)
+	}
+	if _, ok := sim.GetModule("core/http"); !ok {
+		t.Fatal("expected split-off module core/http")
+	}
+	if mod, _ := sim.GetModule("core"); mod == nil || len(mod.Files) != 1 {
+		t.Fatalf("expected core to keep one file, got %+v", mod)
+	}
+
+	if _, err := g.Simulate([]SimulationOp{{Kind: SimulateSplitModule, Module: "core", Glob: "*.py", To: "x"}}); err == nil {
+		t.Fatal("expected error when glob matches nothing")
+	}
+}
@@ -7,8 +7,8 @@ package graph
 // adapters (e.g. SQLite, in-memory noop) to be swapped without modifying the
 // graph package.
 //
-// A concrete SQLite adapter will live in internal/data/history/graph_storage.go
-// and implement this interface. The Graph package only depends on this interface.
+// The SQLite adapter lives in internal/data/history/graph_storage.go and
+// implements this interface. The Graph package only depends on this interface.
 type NodeStorage interface {
 	// SaveNode persists the module node for the given project and module name.
 	// If a node already exists it is overwritten (upsert semantics).
@@ -18,10 +18,22 @@ type NodeStorage interface {
 	// Returns (nil, nil) if the node does not exist.
 	LoadNode(projectKey, moduleName string) (*Module, error)
 
+	// SaveAdjacency replaces the outgoing import edges and the importer set
+	// recorded for the given module.
+	SaveAdjacency(projectKey, moduleName string, imports []*ImportEdge, importedBy []string) error
+
+	// LoadAdjacency retrieves the outgoing import edges and the importer set
+	// recorded for the given module. Both are empty if nothing was saved.
+	LoadAdjacency(projectKey, moduleName string) ([]*ImportEdge, []string, error)
+
 	// QueryEdges returns the list of module names that the given module imports.
 	// Returns an empty slice if no edges exist.
 	QueryEdges(projectKey, from string) ([]string, error)
 
+	// DeleteNode removes the module node together with its adjacency.
+	// Deleting a node that does not exist is not an error.
+	DeleteNode(projectKey, moduleName string) error
+
 	// Close releases any underlying resources (e.g. database connections).
 	// After Close, behaviour of other methods is undefined.
 	Close() error
@@ -29,7 +41,8 @@ type NodeStorage interface {
 
 // NoopNodeStorage satisfies NodeStorage with in-memory no-ops.
 // It is the default when no persistent backend is configured, allowing the
-// graph to operate purely in memory without any disk I/O.
+// graph to operate purely in memory without any disk I/O. Graph never pages
+// modules out to a NoopNodeStorage because nothing could be read back.
 type NoopNodeStorage struct{}
 
 var _ NodeStorage = (*NoopNodeStorage)(nil)
@@ -40,8 +53,21 @@ func (n *NoopNodeStorage) SaveNode(_, _ string, _ *Module) error { return nil }
 // LoadNode always reports that the node does not exist.
 func (n *NoopNodeStorage) LoadNode(_, _ string) (*Module, error) { return nil, nil }
 
+// SaveAdjacency is a no-op.
+func (n *NoopNodeStorage) SaveAdjacency(_, _ string, _ []*ImportEdge, _ []string) error {
+	return nil
+}
+
+// LoadAdjacency always returns empty adjacency.
+func (n *NoopNodeStorage) LoadAdjacency(_, _ string) ([]*ImportEdge, []string, error) {
+	return nil, nil, nil
+}
+
 // QueryEdges always returns an empty edge list.
 func (n *NoopNodeStorage) QueryEdges(_, _ string) ([]string, error) { return nil, nil }
 
+// DeleteNode is a no-op.
+func (n *NoopNodeStorage) DeleteNode(_, _ string) error { return nil }
+
 // Close is a no-op.
 func (n *NoopNodeStorage) Close() error { return nil }
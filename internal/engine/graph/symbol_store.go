@@ -358,12 +358,38 @@ func (s *SQLiteSymbolStore) LoadFile(path string) (*parser.File, error) {
 	return &file, nil
 }
 
+// LoadFileHashes returns the content hash recorded with each stored file blob,
+// keyed by path. Blobs written without a hash, or parsed under a different
+// fingerprint (extractor version and parse-affecting config), are omitted.
+func (s *SQLiteSymbolStore) LoadFileHashes(fingerprint string) (map[string]string, error) {
+	if s == nil || s.db == nil {
+		return nil, fmt.Errorf("store not initialized")
+	}
+	rows, err := s.db.Query(`SELECT file_path, content_hash FROM file_blobs WHERE project_key = ? AND content_hash != '' AND parse_fingerprint = ?`, s.projectKey, fingerprint)
+	if err != nil {
+		return nil, fmt.Errorf("load file hashes: %w", err)
+	}
+	defer rows.Close()
+	hashes := make(map[string]string)
+	for rows.Next() {
+		var path, hash string
+		if err := rows.Scan(&path, &hash); err != nil {
+			return nil, fmt.Errorf("scan file hash: %w", err)
+		}
+		hashes[path] = hash
+	}
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("iterate file hashes: %w", err)
+	}
+	return hashes, nil
+}
+
 func upsertFileBlob(tx *sql.Tx, projectKey string, file *parser.File) error {
 	blob, err := json.Marshal(file)
 	if err != nil {
 		return fmt.Errorf("marshal file blob: %w", err)
 	}
-	_, err = tx.Exec(`INSERT OR REPLACE INTO file_blobs (project_key, file_path, blob) VALUES (?, ?, ?)`, projectKey, file.Path, blob)
+	_, err = tx.Exec(`INSERT OR REPLACE INTO file_blobs (project_key, file_path, blob, content_hash, parse_fingerprint) VALUES (?, ?, ?, ?, ?)`, projectKey, file.Path, blob, file.ContentHash, file.ParseFingerprint)
 	if err != nil {
 		return fmt.Errorf("upsert file blob: %w", err)
 	}
@@ -533,6 +559,7 @@ func (s *SQLiteSymbolStore) lookupRowsStmt(stmt *sql.Stmt, args ...any) []Symbol
 			continue
 		}
 		rec.Kind = parser.DefinitionKind(kind)
+		rec.Line = line
 		if decoratorsJSON != "" {
 			var decorators []string
 			if err := json.Unmarshal([]byte(decoratorsJSON), &decorators); err == nil {
@@ -544,7 +571,7 @@ func (s *SQLiteSymbolStore) lookupRowsStmt(stmt *sql.Stmt, args ...any) []Symbol
 	return out
 }
 
-// migrateSymbolSchema creates or migrates the symbols table to schema v7.
+// migrateSymbolSchema creates or migrates the symbols table to schema v9.
 func migrateSymbolSchema(db *sql.DB) error {
 	var version int
 	_ = db.QueryRow(`PRAGMA user_version`).Scan(&version)
@@ -586,13 +613,15 @@ CREATE TABLE file_blobs (
   project_key TEXT NOT NULL,
   file_path TEXT NOT NULL,
   blob BLOB NOT NULL,
+  content_hash TEXT NOT NULL DEFAULT '',
+  parse_fingerprint TEXT NOT NULL DEFAULT '',
   PRIMARY KEY (project_key, file_path)
 );
 
-PRAGMA user_version = 7;
+PRAGMA user_version = 9;
 `)
 		if err != nil {
-			return fmt.Errorf("create v7 schema: %w", err)
+			return fmt.Errorf("create v9 schema: %w", err)
 		}
 		return ensureOverlaySchema(db)
 	}
@@ -633,6 +662,32 @@ PRAGMA user_version = 6;
 				return fmt.Errorf("schema v7 migration: %w", err)
 			}
 		}
+		version = 7
+	}
+
+	if version < 8 {
+		stmts := []string{
+			`ALTER TABLE file_blobs ADD COLUMN content_hash TEXT NOT NULL DEFAULT '';`,
+			`PRAGMA user_version = 8;`,
+		}
+		for _, stmt := range stmts {
+			if _, err := db.Exec(stmt); err != nil {
+				return fmt.Errorf("schema v8 migration: %w", err)
+			}
+		}
+		version = 8
+	}
+
+	if version < 9 {
+		stmts := []string{
+			`ALTER TABLE file_blobs ADD COLUMN parse_fingerprint TEXT NOT NULL DEFAULT '';`,
+			`PRAGMA user_version = 9;`,
+		}
+		for _, stmt := range stmts {
+			if _, err := db.Exec(stmt); err != nil {
+				return fmt.Errorf("schema v9 migration: %w", err)
+			}
+		}
 	}
 
 	return ensureOverlaySchema(db)
@@ -222,3 +222,36 @@ func TestSQLiteSymbolStore_PruneToPaths_RemovesFileBlobs(t *testing.T) {
 		t.Fatalf("expected 1 file blob after prune, got %d", count)
 	}
 }
+
+func TestSQLiteSymbolStore_LoadFileHashes(t *testing.T) {
+	store, err := OpenSQLiteSymbolStore(filepath.Join(t.TempDir(), "symbols.db"), "proj-a")
+	if err != nil {
+		t.Fatalf("open sqlite symbol store: %v", err)
+	}
+	defer store.Close()
+
+	if err := store.UpsertFile(&parser.File{Path: "hashed.go", Language: "go", Module: "mod/hashed", ContentHash: "abc123", ParseFingerprint: "fp1"}); err != nil {
+		t.Fatalf("upsert hashed.go: %v", err)
+	}
+	if err := store.UpsertFile(&parser.File{Path: "plain.go", Language: "go", Module: "mod/plain", ParseFingerprint: "fp1"}); err != nil {
+		t.Fatalf("upsert plain.go: %v", err)
+	}
+	if err := store.UpsertFile(&parser.File{Path: "old.go", Language: "go", Module: "mod/old", ContentHash: "def456", ParseFingerprint: "fp0"}); err != nil {
+		t.Fatalf("upsert old.go: %v", err)
+	}
+
+	hashes, err := store.LoadFileHashes("fp1")
+	if err != nil {
+		t.Fatalf("load file hashes: %v", err)
+	}
+	if len(hashes) != 1 || hashes["hashed.go"] != "abc123" {
+		t.Fatalf("unexpected file hashes: %+v", hashes)
+	}
+	if hashes, err := store.LoadFileHashes("fp2"); err != nil || len(hashes) != 0 {
+		t.Fatalf("expected no hashes under another fingerprint, got %+v (err=%v)", hashes, err)
+	}
+	loaded, err := store.LoadFile("hashed.go")
+	if err != nil || loaded == nil || loaded.ContentHash != "abc123" {
+		t.Fatalf("expected blob to round-trip content hash, got %+v (err=%v)", loaded, err)
+	}
+}
@@ -12,6 +12,7 @@ type SymbolRecord struct {
 	Module     string
 	Language   string
 	File       string
+	Line       int
 	Kind       parser.DefinitionKind
 	Exported   bool
 	Visibility string
@@ -59,6 +60,7 @@ func (g *Graph) BuildUniversalSymbolTable() *UniversalSymbolTable {
 				Module:     moduleName,
 				Language:   g.fileToLanguage[def.Location.File],
 				File:       def.Location.File,
+				Line:       def.Location.Line,
 				Kind:       def.Kind,
 				Exported:   def.Exported,
 				Visibility: def.Visibility,
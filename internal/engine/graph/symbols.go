@@ -0,0 +1,387 @@
+package graph
+
+import (
+	"circular/internal/engine/parser"
+	"fmt"
+	"sort"
+	"strings"
+)
+
+// SymbolSeparator joins a module name and a definition name in symbol keys
+// (for example "internal/engine/graph#Graph").
+const SymbolSeparator = "#"
+
+// SymbolKey identifies a definition inside a module. An empty Name denotes
+// module-level code that is not enclosed by any function or method.
+type SymbolKey struct {
+	Module string
+	Name   string
+}
+
+func (k SymbolKey) String() string {
+	if k.Name == "" {
+		return k.Module
+	}
+	return k.Module + SymbolSeparator + k.Name
+}
+
+// ParseSymbolKey splits "module#Symbol" into a SymbolKey. It reports false when
+// the input does not carry both parts.
+func ParseSymbolKey(raw string) (SymbolKey, bool) {
+	raw = strings.TrimSpace(raw)
+	idx := strings.LastIndex(raw, SymbolSeparator)
+	if idx <= 0 || idx == len(raw)-1 {
+		return SymbolKey{}, false
+	}
+	return SymbolKey{
+		Module: strings.TrimSpace(raw[:idx]),
+		Name:   strings.TrimSpace(raw[idx+1:]),
+	}, true
+}
+
+// IsSymbolKey reports whether raw uses the module#Symbol form.
+func IsSymbolKey(raw string) bool {
+	_, ok := ParseSymbolKey(raw)
+	return ok
+}
+
+// SymbolEdge links a resolved reference to the definition it targets.
+type SymbolEdge struct {
+	From     SymbolKey
+	To       SymbolKey
+	File     string
+	Location parser.Location
+}
+
+func (e SymbolEdge) String() string {
+	return fmt.Sprintf("%s -> %s (%s:%d)", e.From, e.To, e.File, e.Location.Line)
+}
+
+type SymbolImpactReport struct {
+	Target               SymbolKey
+	TargetPath           string
+	DirectDependents     []string
+	TransitiveDependents []string
+	AffectedModules      []string
+	CallSites            []SymbolEdge
+}
+
+// SetSymbolEdges replaces the outgoing symbol edges recorded for path. Calls for
+// files that are not part of the graph are ignored.
+func (g *Graph) SetSymbolEdges(path string, edges []SymbolEdge) {
+	g.mu.Lock()
+	defer g.mu.Unlock()
+
+	if _, ok := g.fileToModule[path]; !ok {
+		return
+	}
+	g.symbolLinked[path] = true
+	if len(edges) == 0 {
+		delete(g.symbolEdges, path)
+		return
+	}
+	g.symbolEdges[path] = append([]SymbolEdge(nil), edges...)
+}
+
+// InvalidateSymbolEdges marks the given files for relinking without dropping
+// their current edges.
+func (g *Graph) InvalidateSymbolEdges(paths ...string) {
+	g.mu.Lock()
+	defer g.mu.Unlock()
+	for _, path := range paths {
+		delete(g.symbolLinked, path)
+	}
+}
+
+// PendingSymbolFiles returns files whose references have not been linked since
+// they were last added or invalidated.
+func (g *Graph) PendingSymbolFiles() []string {
+	g.mu.RLock()
+	defer g.mu.RUnlock()
+
+	pending := make([]string, 0)
+	for path := range g.fileToModule {
+		if !g.symbolLinked[path] {
+			pending = append(pending, path)
+		}
+	}
+	sort.Strings(pending)
+	return pending
+}
+
+// SymbolEdges returns every symbol edge whose target definition still exists.
+func (g *Graph) SymbolEdges() []SymbolEdge {
+	g.mu.RLock()
+	defer g.mu.RUnlock()
+
+	out := make([]SymbolEdge, 0)
+	for _, edges := range g.symbolEdges {
+		for _, edge := range edges {
+			if g.hasSymbolLocked(edge.To) {
+				out = append(out, edge)
+			}
+		}
+	}
+	sortSymbolEdges(out)
+	return out
+}
+
+func (g *Graph) HasSymbol(key SymbolKey) bool {
+	g.mu.RLock()
+	defer g.mu.RUnlock()
+	return g.hasSymbolLocked(key)
+}
+
+// SymbolDependencies returns the edges leaving the given symbol.
+func (g *Graph) SymbolDependencies(key SymbolKey) []SymbolEdge {
+	g.mu.RLock()
+	defer g.mu.RUnlock()
+
+	out := make([]SymbolEdge, 0)
+	for _, edges := range g.symbolEdges {
+		for _, edge := range edges {
+			if edge.From == key && g.hasSymbolLocked(edge.To) {
+				out = append(out, edge)
+			}
+		}
+	}
+	sortSymbolEdges(out)
+	return out
+}
+
+// SymbolDependents returns the edges pointing at the given symbol.
+func (g *Graph) SymbolDependents(key SymbolKey) []SymbolEdge {
+	g.mu.RLock()
+	defer g.mu.RUnlock()
+
+	out := make([]SymbolEdge, 0)
+	if !g.hasSymbolLocked(key) {
+		return out
+	}
+	for _, edges := range g.symbolEdges {
+		for _, edge := range edges {
+			if edge.To == key {
+				out = append(out, edge)
+			}
+		}
+	}
+	sortSymbolEdges(out)
+	return out
+}
+
+// FindSymbolChain returns the shortest chain of symbol references from one
+// definition to another.
+func (g *Graph) FindSymbolChain(from, to SymbolKey) ([]SymbolKey, bool) {
+	g.mu.RLock()
+	defer g.mu.RUnlock()
+
+	if !g.hasSymbolLocked(from) || !g.hasSymbolLocked(to) {
+		return nil, false
+	}
+	if from == to {
+		return []SymbolKey{from}, true
+	}
+
+	adjacency := g.symbolAdjacencyLocked(false)
+	queue := []SymbolKey{from}
+	visited := map[SymbolKey]bool{from: true}
+	prev := make(map[SymbolKey]SymbolKey)
+
+	for len(queue) > 0 {
+		curr := queue[0]
+		queue = queue[1:]
+
+		for _, next := range adjacency[curr] {
+			if visited[next] {
+				continue
+			}
+			visited[next] = true
+			prev[next] = curr
+
+			if next == to {
+				path := []SymbolKey{to}
+				for node := to; node != from; {
+					node = prev[node]
+					path = append(path, node)
+				}
+				for i, j := 0, len(path)-1; i < j; i, j = i+1, j-1 {
+					path[i], path[j] = path[j], path[i]
+				}
+				return path, true
+			}
+			queue = append(queue, next)
+		}
+	}
+
+	return nil, false
+}
+
+// AnalyzeSymbolImpact lists the definitions that directly or transitively
+// reference the target symbol.
+func (g *Graph) AnalyzeSymbolImpact(key SymbolKey) (SymbolImpactReport, error) {
+	g.mu.RLock()
+	defer g.mu.RUnlock()
+
+	def, ok := g.lookupSymbolLocked(key)
+	if !ok {
+		return SymbolImpactReport{}, &ImpactTargetError{Target: key.String()}
+	}
+
+	report := SymbolImpactReport{
+		Target:     key,
+		TargetPath: def.Location.File,
+	}
+
+	reverse := g.symbolAdjacencyLocked(true)
+	callSites := make([]SymbolEdge, 0)
+	for _, edges := range g.symbolEdges {
+		for _, edge := range edges {
+			if edge.To == key {
+				callSites = append(callSites, edge)
+			}
+		}
+	}
+	sortSymbolEdges(callSites)
+	report.CallSites = callSites
+
+	direct := make([]string, 0, len(reverse[key]))
+	directSet := make(map[SymbolKey]bool, len(reverse[key]))
+	for _, dep := range reverse[key] {
+		directSet[dep] = true
+		direct = append(direct, dep.String())
+	}
+	report.DirectDependents = direct
+
+	seen := map[SymbolKey]bool{key: true}
+	queue := append([]SymbolKey(nil), reverse[key]...)
+	for _, dep := range queue {
+		seen[dep] = true
+	}
+	modules := make(map[string]bool)
+	for _, edge := range callSites {
+		if edge.From.Module != key.Module {
+			modules[edge.From.Module] = true
+		}
+	}
+	transitive := make([]string, 0)
+	for len(queue) > 0 {
+		curr := queue[0]
+		queue = queue[1:]
+		if curr.Module != key.Module {
+			modules[curr.Module] = true
+		}
+		if !directSet[curr] {
+			transitive = append(transitive, curr.String())
+		}
+		for _, next := range reverse[curr] {
+			if seen[next] {
+				continue
+			}
+			seen[next] = true
+			queue = append(queue, next)
+		}
+	}
+	sort.Strings(transitive)
+	report.TransitiveDependents = transitive
+
+	affected := make([]string, 0, len(modules))
+	for mod := range modules {
+		affected = append(affected, mod)
+	}
+	sort.Strings(affected)
+	report.AffectedModules = affected
+
+	return report, nil
+}
+
+// EnclosingDefinition returns the innermost function or method in file whose
+// line span contains line. It reports false for module-level code.
+func EnclosingDefinition(file *parser.File, line int) (parser.Definition, bool) {
+	if file == nil {
+		return parser.Definition{}, false
+	}
+	best := -1
+	for i, def := range file.Definitions {
+		if def.Kind != parser.KindFunction && def.Kind != parser.KindMethod {
+			continue
+		}
+		start := def.Location.Line
+		end := start + def.LOC - 1
+		if def.LOC <= 0 {
+			end = start
+		}
+		if line < start || line > end {
+			continue
+		}
+		if best < 0 || start >= file.Definitions[best].Location.Line {
+			best = i
+		}
+	}
+	if best < 0 {
+		return parser.Definition{}, false
+	}
+	return file.Definitions[best], true
+}
+
+func (g *Graph) hasSymbolLocked(key SymbolKey) bool {
+	_, ok := g.lookupSymbolLocked(key)
+	return ok
+}
+
+func (g *Graph) lookupSymbolLocked(key SymbolKey) (*parser.Definition, bool) {
+	defs, ok := g.definitions[key.Module]
+	if !ok {
+		return nil, false
+	}
+	def, ok := defs[key.Name]
+	return def, ok
+}
+
+// symbolAdjacencyLocked builds a sorted, de-duplicated adjacency list over
+// definitions. Module-level sources are skipped because they are not symbols.
+func (g *Graph) symbolAdjacencyLocked(reverse bool) map[SymbolKey][]SymbolKey {
+	sets := make(map[SymbolKey]map[SymbolKey]bool)
+	for _, edges := range g.symbolEdges {
+		for _, edge := range edges {
+			if edge.From.Name == "" || edge.From == edge.To || !g.hasSymbolLocked(edge.To) {
+				continue
+			}
+			from, to := edge.From, edge.To
+			if reverse {
+				from, to = to, from
+			}
+			if sets[from] == nil {
+				sets[from] = make(map[SymbolKey]bool)
+			}
+			sets[from][to] = true
+		}
+	}
+
+	adjacency := make(map[SymbolKey][]SymbolKey, len(sets))
+	for from, targets := range sets {
+		list := make([]SymbolKey, 0, len(targets))
+		for to := range targets {
+			list = append(list, to)
+		}
+		sort.Slice(list, func(i, j int) bool {
+			return list[i].String() < list[j].String()
+		})
+		adjacency[from] = list
+	}
+	return adjacency
+}
+
+func sortSymbolEdges(edges []SymbolEdge) {
+	sort.Slice(edges, func(i, j int) bool {
+		if edges[i].File != edges[j].File {
+			return edges[i].File < edges[j].File
+		}
+		if edges[i].Location.Line != edges[j].Location.Line {
+			return edges[i].Location.Line < edges[j].Location.Line
+		}
+		if edges[i].Location.Column != edges[j].Location.Column {
+			return edges[i].Location.Column < edges[j].Location.Column
+		}
+		return edges[i].To.String() < edges[j].To.String()
+	})
+}
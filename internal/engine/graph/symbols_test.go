@@ -0,0 +1,158 @@
+package graph
+
+import (
+	"circular/internal/engine/parser"
+	"errors"
+	"testing"
+)
+
+func seedSymbolGraph() *Graph {
+	g := NewGraph()
+	g.AddFile(&parser.File{
+		Path:   "a.go",
+		Module: "app/a",
+		Definitions: []parser.Definition{
+			{Name: "Handler", Kind: parser.KindFunction, Exported: true, Location: parser.Location{File: "a.go", Line: 10}, LOC: 5},
+		},
+	})
+	g.AddFile(&parser.File{
+		Path:   "b.go",
+		Module: "app/b",
+		Definitions: []parser.Definition{
+			{Name: "Service", Kind: parser.KindFunction, Exported: true, Location: parser.Location{File: "b.go", Line: 3}, LOC: 4},
+		},
+	})
+	g.AddFile(&parser.File{
+		Path:   "c.go",
+		Module: "app/c",
+		Definitions: []parser.Definition{
+			{Name: "Store", Kind: parser.KindType, Exported: true, Location: parser.Location{File: "c.go", Line: 1}},
+		},
+	})
+
+	g.SetSymbolEdges("a.go", []SymbolEdge{{
+		From:     SymbolKey{Module: "app/a", Name: "Handler"},
+		To:       SymbolKey{Module: "app/b", Name: "Service"},
+		File:     "a.go",
+		Location: parser.Location{File: "a.go", Line: 12},
+	}})
+	g.SetSymbolEdges("b.go", []SymbolEdge{{
+		From:     SymbolKey{Module: "app/b", Name: "Service"},
+		To:       SymbolKey{Module: "app/c", Name: "Store"},
+		File:     "b.go",
+		Location: parser.Location{File: "b.go", Line: 5},
+	}})
+	g.SetSymbolEdges("c.go", nil)
+	return g
+}
+
+func TestParseSymbolKey(t *testing.T) {
+	key, ok := ParseSymbolKey("internal/engine/graph#Graph")
+	if !ok || key.Module != "internal/engine/graph" || key.Name != "Graph" {
+		t.Fatalf("unexpected key %+v (ok=%v)", key, ok)
+	}
+	if key.String() != "internal/engine/graph#Graph" {
+		t.Fatalf("unexpected round trip %q", key.String())
+	}
+	for _, raw := range []string{"app/a", "#Graph", "app/a#", ""} {
+		if _, ok := ParseSymbolKey(raw); ok {
+			t.Fatalf("expected %q to be rejected", raw)
+		}
+	}
+}
+
+func TestGraph_FindSymbolChain(t *testing.T) {
+	g := seedSymbolGraph()
+
+	chain, ok := g.FindSymbolChain(SymbolKey{Module: "app/a", Name: "Handler"}, SymbolKey{Module: "app/c", Name: "Store"})
+	if !ok {
+		t.Fatal("expected symbol chain")
+	}
+	if len(chain) != 3 || chain[1].String() != "app/b#Service" {
+		t.Fatalf("unexpected chain %v", chain)
+	}
+
+	if _, ok := g.FindSymbolChain(SymbolKey{Module: "app/c", Name: "Store"}, SymbolKey{Module: "app/a", Name: "Handler"}); ok {
+		t.Fatal("did not expect reverse chain")
+	}
+}
+
+func TestGraph_AnalyzeSymbolImpact(t *testing.T) {
+	g := seedSymbolGraph()
+
+	report, err := g.AnalyzeSymbolImpact(SymbolKey{Module: "app/c", Name: "Store"})
+	if err != nil {
+		t.Fatalf("AnalyzeSymbolImpact: %v", err)
+	}
+	if len(report.DirectDependents) != 1 || report.DirectDependents[0] != "app/b#Service" {
+		t.Fatalf("unexpected direct dependents %v", report.DirectDependents)
+	}
+	if len(report.TransitiveDependents) != 1 || report.TransitiveDependents[0] != "app/a#Handler" {
+		t.Fatalf("unexpected transitive dependents %v", report.TransitiveDependents)
+	}
+	if len(report.AffectedModules) != 2 {
+		t.Fatalf("expected two affected modules, got %v", report.AffectedModules)
+	}
+	if len(report.CallSites) != 1 || report.CallSites[0].Location.Line != 5 {
+		t.Fatalf("unexpected call sites %+v", report.CallSites)
+	}
+
+	_, err = g.AnalyzeSymbolImpact(SymbolKey{Module: "app/c", Name: "Missing"})
+	if !errors.Is(err, ErrImpactTargetNotFound) {
+		t.Fatalf("expected ErrImpactTargetNotFound, got %v", err)
+	}
+}
+
+func TestGraph_SymbolEdgesFollowFileLifecycle(t *testing.T) {
+	g := seedSymbolGraph()
+
+	if pending := g.PendingSymbolFiles(); len(pending) != 0 {
+		t.Fatalf("expected no pending files, got %v", pending)
+	}
+
+	// Re-adding a file drops its outgoing edges until it is relinked.
+	g.AddFile(&parser.File{
+		Path:   "a.go",
+		Module: "app/a",
+		Definitions: []parser.Definition{
+			{Name: "Handler", Kind: parser.KindFunction, Exported: true, Location: parser.Location{File: "a.go", Line: 10}, LOC: 5},
+		},
+	})
+	if deps := g.SymbolDependents(SymbolKey{Module: "app/b", Name: "Service"}); len(deps) != 0 {
+		t.Fatalf("expected stale edges to be dropped, got %+v", deps)
+	}
+	if pending := g.PendingSymbolFiles(); len(pending) != 1 || pending[0] != "a.go" {
+		t.Fatalf("expected a.go to be pending, got %v", pending)
+	}
+
+	// Removing the target file hides edges that point at its definitions.
+	g.RemoveFile("c.go")
+	if edges := g.SymbolEdges(); len(edges) != 0 {
+		t.Fatalf("expected edges to removed definitions to be hidden, got %+v", edges)
+	}
+
+	g.InvalidateSymbolEdges("b.go")
+	if pending := g.PendingSymbolFiles(); len(pending) != 2 {
+		t.Fatalf("expected two pending files, got %v", pending)
+	}
+}
+
+func TestEnclosingDefinition(t *testing.T) {
+	file := &parser.File{
+		Definitions: []parser.Definition{
+			{Name: "Outer", Kind: parser.KindFunction, Location: parser.Location{Line: 1}, LOC: 20},
+			{Name: "Inner", Kind: parser.KindMethod, Location: parser.Location{Line: 5}, LOC: 3},
+			{Name: "Config", Kind: parser.KindType, Location: parser.Location{Line: 6}},
+		},
+	}
+
+	if def, ok := EnclosingDefinition(file, 6); !ok || def.Name != "Inner" {
+		t.Fatalf("expected Inner, got %+v (ok=%v)", def, ok)
+	}
+	if def, ok := EnclosingDefinition(file, 15); !ok || def.Name != "Outer" {
+		t.Fatalf("expected Outer, got %+v (ok=%v)", def, ok)
+	}
+	if _, ok := EnclosingDefinition(file, 30); ok {
+		t.Fatal("expected module-level line to have no enclosing definition")
+	}
+}
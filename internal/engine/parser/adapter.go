@@ -13,6 +13,14 @@ func (a *Adapter) ParseFile(path string, content []byte) (*File, error) {
 	return a.parser.ParseFile(path, content)
 }
 
+func (a *Adapter) ReparseFile(path string, content []byte) (*File, error) {
+	return a.parser.ReparseFile(path, content)
+}
+
+func (a *Adapter) ForgetFile(path string) {
+	a.parser.ForgetFile(path)
+}
+
 func (a *Adapter) GetLanguage(path string) string {
 	return a.parser.GetLanguage(path)
 }
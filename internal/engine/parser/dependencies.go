@@ -0,0 +1,385 @@
+package parser
+
+// internal/engine/parser/dependencies.go
+
+import (
+	"encoding/json"
+	"encoding/xml"
+	"fmt"
+	"path/filepath"
+	"sort"
+	"strings"
+
+	"github.com/BurntSushi/toml"
+)
+
+// Package ecosystems of third-party dependencies.
+const (
+	EcosystemGo    = "go"
+	EcosystemNPM   = "npm"
+	EcosystemPyPI  = "pypi"
+	EcosystemMaven = "maven"
+	EcosystemCargo = "cargo"
+)
+
+// DeclaredDependency is a third-party package named by a dependency manifest.
+type DeclaredDependency struct {
+	Name     string // module path, package, distribution, groupId:artifactId, or crate
+	Version  string // version or constraint as written; empty when unpinned
+	Line     int
+	Dev      bool // development, test, or build-only scope
+	Indirect bool // go.mod requirement marked `// indirect`
+}
+
+// DependencyManifest is a parsed package manifest such as go.mod or
+// package.json.
+type DependencyManifest struct {
+	Path         string
+	Ecosystem    string
+	Module       string // the project's own module or package name, if declared
+	Lockfile     bool   // lists resolved modules (go.sum) rather than declarations
+	Dependencies []DeclaredDependency
+}
+
+var dependencyManifestEcosystems = map[string]string{
+	"go.mod":         EcosystemGo,
+	"go.sum":         EcosystemGo,
+	"package.json":   EcosystemNPM,
+	"pyproject.toml": EcosystemPyPI,
+	"pom.xml":        EcosystemMaven,
+	"Cargo.toml":     EcosystemCargo,
+}
+
+// DependencyManifestEcosystem returns the ecosystem of a manifest path, or ""
+// when the base name is not a supported manifest. requirements*.txt files
+// count as PyPI manifests.
+func DependencyManifestEcosystem(path string) string {
+	base := filepath.Base(path)
+	if ecosystem, ok := dependencyManifestEcosystems[base]; ok {
+		return ecosystem
+	}
+	if strings.HasPrefix(base, "requirements") && strings.HasSuffix(base, ".txt") {
+		return EcosystemPyPI
+	}
+	return ""
+}
+
+// ParseDependencyManifest parses the declared dependencies of go.mod, go.sum,
+// package.json, requirements*.txt, pyproject.toml, pom.xml, or Cargo.toml.
+// Dependencies are sorted by name.
+func ParseDependencyManifest(path string, source []byte) (DependencyManifest, error) {
+	manifest := DependencyManifest{Path: path, Ecosystem: DependencyManifestEcosystem(path)}
+	var err error
+	switch base := filepath.Base(path); {
+	case base == "go.mod":
+		manifest.Module, manifest.Dependencies = parseGoMod(source)
+	case base == "go.sum":
+		manifest.Lockfile = true
+		manifest.Dependencies = parseGoSum(source)
+	case base == "package.json":
+		manifest.Module, manifest.Dependencies, err = parsePackageJSON(source)
+	case base == "pyproject.toml":
+		manifest.Module, manifest.Dependencies, err = parsePyProject(source)
+	case base == "pom.xml":
+		manifest.Module, manifest.Dependencies, err = parsePOM(source)
+	case base == "Cargo.toml":
+		manifest.Module, manifest.Dependencies, err = parseCargoToml(source)
+	case manifest.Ecosystem == EcosystemPyPI:
+		manifest.Dependencies = parseRequirements(source)
+	default:
+		return manifest, fmt.Errorf("unsupported dependency manifest: %s", path)
+	}
+	if err != nil {
+		return manifest, fmt.Errorf("parse %s: %w", path, err)
+	}
+	sort.SliceStable(manifest.Dependencies, func(i, j int) bool {
+		return manifest.Dependencies[i].Name < manifest.Dependencies[j].Name
+	})
+	return manifest, nil
+}
+
+// parseGoMod reads the module path and requirements of a go.mod file, in both
+// the single-line and the parenthesised `require` forms.
+func parseGoMod(source []byte) (string, []DeclaredDependency) {
+	module := ""
+	deps := make([]DeclaredDependency, 0)
+	inRequire := false
+	for i, line := range strings.Split(string(source), "\n") {
+		trimmed, comment, _ := strings.Cut(line, "//")
+		trimmed = strings.TrimSpace(trimmed)
+		if trimmed == "" {
+			continue
+		}
+		if inRequire {
+			if trimmed == ")" {
+				inRequire = false
+				continue
+			}
+		} else {
+			switch {
+			case strings.HasPrefix(trimmed, "module "):
+				module = strings.Trim(strings.TrimSpace(strings.TrimPrefix(trimmed, "module ")), `"`)
+				continue
+			case trimmed == "require (" || trimmed == "require(":
+				inRequire = true
+				continue
+			case strings.HasPrefix(trimmed, "require "):
+				trimmed = strings.TrimSpace(strings.TrimPrefix(trimmed, "require "))
+			default:
+				continue
+			}
+		}
+		fields := strings.Fields(trimmed)
+		if len(fields) == 0 {
+			continue
+		}
+		dep := DeclaredDependency{
+			Name:     strings.Trim(fields[0], `"`),
+			Line:     i + 1,
+			Indirect: strings.TrimSpace(comment) == "indirect",
+		}
+		if len(fields) > 1 {
+			dep.Version = fields[1]
+		}
+		deps = append(deps, dep)
+	}
+	return module, deps
+}
+
+// parseGoSum lists each module of a go.sum file once, at its first version.
+func parseGoSum(source []byte) []DeclaredDependency {
+	seen := make(map[string]bool)
+	deps := make([]DeclaredDependency, 0)
+	for i, line := range strings.Split(string(source), "\n") {
+		fields := strings.Fields(line)
+		if len(fields) < 2 || seen[fields[0]] {
+			continue
+		}
+		seen[fields[0]] = true
+		deps = append(deps, DeclaredDependency{
+			Name:    fields[0],
+			Version: strings.TrimSuffix(fields[1], "/go.mod"),
+			Line:    i + 1,
+		})
+	}
+	return deps
+}
+
+func parsePackageJSON(source []byte) (string, []DeclaredDependency, error) {
+	var manifest struct {
+		Name                 string            `json:"name"`
+		Dependencies         map[string]string `json:"dependencies"`
+		DevDependencies      map[string]string `json:"devDependencies"`
+		PeerDependencies     map[string]string `json:"peerDependencies"`
+		OptionalDependencies map[string]string `json:"optionalDependencies"`
+	}
+	if err := json.Unmarshal(source, &manifest); err != nil {
+		return "", nil, err
+	}
+	deps := make([]DeclaredDependency, 0)
+	seen := make(map[string]bool)
+	add := func(group map[string]string, dev bool) {
+		for name, version := range group {
+			if seen[name] {
+				continue
+			}
+			seen[name] = true
+			deps = append(deps, DeclaredDependency{Name: name, Version: version, Dev: dev})
+		}
+	}
+	add(manifest.Dependencies, false)
+	add(manifest.PeerDependencies, false)
+	add(manifest.OptionalDependencies, false)
+	add(manifest.DevDependencies, true)
+	return manifest.Name, deps, nil
+}
+
+// parseRequirements reads a pip requirements file, skipping options such as
+// `-r other.txt` and `-e .`.
+func parseRequirements(source []byte) []DeclaredDependency {
+	deps := make([]DeclaredDependency, 0)
+	for i, line := range strings.Split(string(source), "\n") {
+		line, _, _ = strings.Cut(line, "#")
+		line = strings.TrimSpace(line)
+		if line == "" || strings.HasPrefix(line, "-") {
+			continue
+		}
+		if dep, ok := parsePEP508(line); ok {
+			dep.Line = i + 1
+			deps = append(deps, dep)
+		}
+	}
+	return deps
+}
+
+// parsePEP508 splits a requirement such as `requests[socks]>=2.0; python_version>"3"`
+// into its distribution name and version constraint.
+func parsePEP508(requirement string) (DeclaredDependency, bool) {
+	requirement, _, _ = strings.Cut(requirement, ";")
+	requirement = strings.TrimSpace(requirement)
+	end := strings.IndexAny(requirement, "<>=!~[ (@")
+	if end < 0 {
+		end = len(requirement)
+	}
+	name := strings.TrimSpace(requirement[:end])
+	if name == "" {
+		return DeclaredDependency{}, false
+	}
+	rest := requirement[end:]
+	if strings.HasPrefix(rest, "[") {
+		if close := strings.Index(rest, "]"); close >= 0 {
+			rest = rest[close+1:]
+		}
+	}
+	version := strings.Trim(strings.TrimSpace(rest), "()")
+	return DeclaredDependency{Name: name, Version: strings.TrimSpace(version)}, true
+}
+
+func parsePyProject(source []byte) (string, []DeclaredDependency, error) {
+	var manifest struct {
+		Project struct {
+			Name                 string              `toml:"name"`
+			Dependencies         []string            `toml:"dependencies"`
+			OptionalDependencies map[string][]string `toml:"optional-dependencies"`
+		} `toml:"project"`
+		Tool struct {
+			Poetry struct {
+				Name            string                    `toml:"name"`
+				Dependencies    map[string]toml.Primitive `toml:"dependencies"`
+				DevDependencies map[string]toml.Primitive `toml:"dev-dependencies"`
+				Group           map[string]struct {
+					Dependencies map[string]toml.Primitive `toml:"dependencies"`
+				} `toml:"group"`
+			} `toml:"poetry"`
+		} `toml:"tool"`
+	}
+	meta, err := toml.Decode(string(source), &manifest)
+	if err != nil {
+		return "", nil, err
+	}
+
+	deps := make([]DeclaredDependency, 0)
+	seen := make(map[string]bool)
+	add := func(dep DeclaredDependency) {
+		if dep.Name == "" || seen[dep.Name] {
+			return
+		}
+		seen[dep.Name] = true
+		deps = append(deps, dep)
+	}
+	for _, requirement := range manifest.Project.Dependencies {
+		if dep, ok := parsePEP508(requirement); ok {
+			add(dep)
+		}
+	}
+	for _, group := range manifest.Project.OptionalDependencies {
+		for _, requirement := range group {
+			if dep, ok := parsePEP508(requirement); ok {
+				add(dep)
+			}
+		}
+	}
+	poetry := manifest.Tool.Poetry
+	addPoetry := func(group map[string]toml.Primitive, dev bool) {
+		for name, value := range group {
+			if strings.EqualFold(name, "python") {
+				continue
+			}
+			add(DeclaredDependency{Name: name, Version: tomlVersion(meta, value), Dev: dev})
+		}
+	}
+	addPoetry(poetry.Dependencies, false)
+	addPoetry(poetry.DevDependencies, true)
+	for _, group := range poetry.Group {
+		addPoetry(group.Dependencies, true)
+	}
+
+	name := manifest.Project.Name
+	if name == "" {
+		name = poetry.Name
+	}
+	return name, deps, nil
+}
+
+func parsePOM(source []byte) (string, []DeclaredDependency, error) {
+	type coordinates struct {
+		GroupID    string `xml:"groupId"`
+		ArtifactID string `xml:"artifactId"`
+		Version    string `xml:"version"`
+		Scope      string `xml:"scope"`
+	}
+	var manifest struct {
+		coordinates
+		Parent       coordinates   `xml:"parent"`
+		Dependencies []coordinates `xml:"dependencies>dependency"`
+	}
+	if err := xml.Unmarshal(source, &manifest); err != nil {
+		return "", nil, err
+	}
+	deps := make([]DeclaredDependency, 0, len(manifest.Dependencies))
+	for _, dep := range manifest.Dependencies {
+		if dep.GroupID == "" || dep.ArtifactID == "" {
+			continue
+		}
+		deps = append(deps, DeclaredDependency{
+			Name:    strings.TrimSpace(dep.GroupID) + ":" + strings.TrimSpace(dep.ArtifactID),
+			Version: strings.TrimSpace(dep.Version),
+			Dev:     strings.TrimSpace(dep.Scope) == "test",
+		})
+	}
+	group := manifest.GroupID
+	if group == "" {
+		group = manifest.Parent.GroupID
+	}
+	name := ""
+	if group != "" && manifest.ArtifactID != "" {
+		name = strings.TrimSpace(group) + ":" + strings.TrimSpace(manifest.ArtifactID)
+	}
+	return name, deps, nil
+}
+
+func parseCargoToml(source []byte) (string, []DeclaredDependency, error) {
+	var manifest struct {
+		Package struct {
+			Name string `toml:"name"`
+		} `toml:"package"`
+		Dependencies      map[string]toml.Primitive `toml:"dependencies"`
+		DevDependencies   map[string]toml.Primitive `toml:"dev-dependencies"`
+		BuildDependencies map[string]toml.Primitive `toml:"build-dependencies"`
+	}
+	meta, err := toml.Decode(string(source), &manifest)
+	if err != nil {
+		return "", nil, err
+	}
+	deps := make([]DeclaredDependency, 0)
+	seen := make(map[string]bool)
+	add := func(group map[string]toml.Primitive, dev bool) {
+		for name, value := range group {
+			if seen[name] {
+				continue
+			}
+			seen[name] = true
+			deps = append(deps, DeclaredDependency{Name: name, Version: tomlVersion(meta, value), Dev: dev})
+		}
+	}
+	add(manifest.Dependencies, false)
+	add(manifest.DevDependencies, true)
+	add(manifest.BuildDependencies, true)
+	return manifest.Package.Name, deps, nil
+}
+
+// tomlVersion reads a dependency given either as a version string or as a
+// table with a `version` key.
+func tomlVersion(meta toml.MetaData, value toml.Primitive) string {
+	var version string
+	if err := meta.PrimitiveDecode(value, &version); err == nil {
+		return version
+	}
+	var table struct {
+		Version string `toml:"version"`
+	}
+	if err := meta.PrimitiveDecode(value, &table); err == nil {
+		return table.Version
+	}
+	return ""
+}
@@ -0,0 +1,164 @@
+package parser
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestParseDependencyManifest_GoMod(t *testing.T) {
+	source := `module example.com/app
+
+go 1.24
+
+require github.com/pkg/errors v0.9.1
+
+require (
+	github.com/spf13/cobra v1.8.0
+	golang.org/x/sys v0.20.0 // indirect
+)
+`
+	manifest, err := ParseDependencyManifest("/repo/go.mod", []byte(source))
+	if err != nil {
+		t.Fatalf("parse go.mod: %v", err)
+	}
+	if manifest.Ecosystem != EcosystemGo || manifest.Module != "example.com/app" || manifest.Lockfile {
+		t.Fatalf("unexpected manifest header: %+v", manifest)
+	}
+	want := []DeclaredDependency{
+		{Name: "github.com/pkg/errors", Version: "v0.9.1", Line: 5},
+		{Name: "github.com/spf13/cobra", Version: "v1.8.0", Line: 8},
+		{Name: "golang.org/x/sys", Version: "v0.20.0", Line: 9, Indirect: true},
+	}
+	if !reflect.DeepEqual(manifest.Dependencies, want) {
+		t.Fatalf("unexpected requirements: %+v", manifest.Dependencies)
+	}
+
+	file, err := (&goModProfileExtractor{}).ExtractRaw([]byte(source), "/repo/go.mod")
+	if err != nil {
+		t.Fatalf("extract go.mod: %v", err)
+	}
+	if len(file.Imports) != 3 || file.Imports[2].Module != "golang.org/x/sys" {
+		t.Fatalf("expected block requirements as imports, got %+v", file.Imports)
+	}
+}
+
+func TestParseDependencyManifest_GoSum(t *testing.T) {
+	source := "github.com/pkg/errors v0.9.1 h1:abc\ngithub.com/pkg/errors v0.9.1/go.mod h1:def\ngolang.org/x/sys v0.20.0/go.mod h1:ghi\n"
+	manifest, err := ParseDependencyManifest("go.sum", []byte(source))
+	if err != nil {
+		t.Fatalf("parse go.sum: %v", err)
+	}
+	if !manifest.Lockfile || len(manifest.Dependencies) != 2 || manifest.Dependencies[1].Version != "v0.20.0" {
+		t.Fatalf("unexpected go.sum modules: %+v", manifest)
+	}
+}
+
+func TestParseDependencyManifest_PackageJSON(t *testing.T) {
+	source := `{"name": "web", "dependencies": {"react": "^18.2.0", "@scope/ui": "1.0.0"}, "devDependencies": {"vitest": "^1.0.0", "react": "^18.2.0"}}`
+	manifest, err := ParseDependencyManifest("package.json", []byte(source))
+	if err != nil {
+		t.Fatalf("parse package.json: %v", err)
+	}
+	want := []DeclaredDependency{
+		{Name: "@scope/ui", Version: "1.0.0"},
+		{Name: "react", Version: "^18.2.0"},
+		{Name: "vitest", Version: "^1.0.0", Dev: true},
+	}
+	if manifest.Module != "web" || !reflect.DeepEqual(manifest.Dependencies, want) {
+		t.Fatalf("unexpected package.json dependencies: %+v", manifest)
+	}
+}
+
+func TestParseDependencyManifest_Python(t *testing.T) {
+	requirements := "# pinned\nrequests[socks]>=2.31 ; python_version > \"3.8\"\n-r base.txt\nPyYAML==6.0\n\n"
+	manifest, err := ParseDependencyManifest("requirements-dev.txt", []byte(requirements))
+	if err != nil {
+		t.Fatalf("parse requirements: %v", err)
+	}
+	want := []DeclaredDependency{
+		{Name: "PyYAML", Version: "==6.0", Line: 4},
+		{Name: "requests", Version: ">=2.31", Line: 2},
+	}
+	if manifest.Ecosystem != EcosystemPyPI || !reflect.DeepEqual(manifest.Dependencies, want) {
+		t.Fatalf("unexpected requirements: %+v", manifest)
+	}
+
+	pyproject := `
+[project]
+name = "svc"
+dependencies = ["httpx (>=0.27)", "pydantic"]
+
+[tool.poetry.group.test.dependencies]
+pytest = { version = "^8.0" }
+`
+	manifest, err = ParseDependencyManifest("pyproject.toml", []byte(pyproject))
+	if err != nil {
+		t.Fatalf("parse pyproject: %v", err)
+	}
+	want = []DeclaredDependency{
+		{Name: "httpx", Version: ">=0.27"},
+		{Name: "pydantic"},
+		{Name: "pytest", Version: "^8.0", Dev: true},
+	}
+	if manifest.Module != "svc" || !reflect.DeepEqual(manifest.Dependencies, want) {
+		t.Fatalf("unexpected pyproject dependencies: %+v", manifest)
+	}
+}
+
+func TestParseDependencyManifest_MavenAndCargo(t *testing.T) {
+	pom := `<project>
+  <groupId>com.acme</groupId>
+  <artifactId>shop</artifactId>
+  <dependencies>
+    <dependency><groupId>com.google.guava</groupId><artifactId>guava</artifactId><version>33.0</version></dependency>
+    <dependency><groupId>junit</groupId><artifactId>junit</artifactId><scope>test</scope></dependency>
+  </dependencies>
+</project>`
+	manifest, err := ParseDependencyManifest("pom.xml", []byte(pom))
+	if err != nil {
+		t.Fatalf("parse pom.xml: %v", err)
+	}
+	want := []DeclaredDependency{
+		{Name: "com.google.guava:guava", Version: "33.0"},
+		{Name: "junit:junit", Dev: true},
+	}
+	if manifest.Module != "com.acme:shop" || !reflect.DeepEqual(manifest.Dependencies, want) {
+		t.Fatalf("unexpected pom dependencies: %+v", manifest)
+	}
+
+	cargo := `
+[package]
+name = "cli-tool"
+
+[dependencies]
+serde = { version = "1.0", features = ["derive"] }
+tokio = "1"
+
+[dev-dependencies]
+proptest = "1.4"
+`
+	manifest, err = ParseDependencyManifest("Cargo.toml", []byte(cargo))
+	if err != nil {
+		t.Fatalf("parse Cargo.toml: %v", err)
+	}
+	want = []DeclaredDependency{
+		{Name: "proptest", Version: "1.4", Dev: true},
+		{Name: "serde", Version: "1.0"},
+		{Name: "tokio", Version: "1"},
+	}
+	if manifest.Module != "cli-tool" || !reflect.DeepEqual(manifest.Dependencies, want) {
+		t.Fatalf("unexpected Cargo dependencies: %+v", manifest)
+	}
+}
+
+func TestParseDependencyManifest_Unsupported(t *testing.T) {
+	if DependencyManifestEcosystem("setup.cfg") != "" {
+		t.Fatal("expected setup.cfg to be unsupported")
+	}
+	if _, err := ParseDependencyManifest("setup.cfg", nil); err == nil {
+		t.Fatal("expected error for unsupported manifest")
+	}
+	if _, err := ParseDependencyManifest("package.json", []byte("{")); err == nil {
+		t.Fatal("expected error for invalid package.json")
+	}
+}
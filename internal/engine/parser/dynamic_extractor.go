@@ -10,6 +10,9 @@ import (
 // DynamicExtractor uses configuration to extract symbols from an AST.
 type DynamicExtractor struct {
 	Config registry.DynamicExtractorConfig
+
+	language string
+	queries  []*sitter.Query // compiled Config.Queries; see CompileDynamicExtractor
 }
 
 func NewDynamicExtractor(cfg registry.DynamicExtractorConfig) *DynamicExtractor {
@@ -19,8 +22,13 @@ func NewDynamicExtractor(cfg registry.DynamicExtractorConfig) *DynamicExtractor
 func (e *DynamicExtractor) Extract(root *sitter.Node, source []byte, filePath string) (*File, error) {
 	file := &File{
 		Path:     filePath,
+		Language: e.language,
 		ParsedAt: time.Now(),
 	}
+	if len(e.queries) > 0 {
+		e.extractWithQueries(root, source, file)
+		return file, nil
+	}
 
 	ctx := &ExtractionContext{Source: source, File: file}
 	
@@ -0,0 +1,282 @@
+package parser
+
+import (
+	"circular/internal/core/errors"
+	"fmt"
+	"os"
+	"sort"
+	"strings"
+
+	sitter "github.com/tree-sitter/go-tree-sitter"
+)
+
+// Capture names understood by query-driven dynamic extractors. Definition
+// captures are "definition.<kind>" for the definition node, plus
+// "definition.<kind>.name" and the optional "definition.<kind>.visibility",
+// where <kind> is a DefinitionKind name such as "function" or "class".
+// Captures starting with "_" are free for use in predicates.
+const (
+	captureNamespace     = "namespace"
+	captureImport        = "import"
+	captureImportPath    = "import.path"
+	captureImportAlias   = "import.alias"
+	captureImportItem    = "import.item"
+	captureReferenceCall = "reference.call"
+	captureReferenceType = "reference.type"
+	captureDefinition    = "definition."
+)
+
+var queryDefinitionKinds = map[string]DefinitionKind{
+	KindFunction.String():  KindFunction,
+	KindClass.String():     KindClass,
+	KindMethod.String():    KindMethod,
+	KindVariable.String():  KindVariable,
+	KindConstant.String():  KindConstant,
+	KindType.String():      KindType,
+	KindInterface.String(): KindInterface,
+}
+
+// CompileDynamicExtractor builds the extractor for a dynamic grammar. When cfg
+// lists query files they are compiled against grammar and drive extraction;
+// otherwise the extractor walks the configured node kinds.
+func CompileDynamicExtractor(language string, cfg DynamicExtractorConfig, grammar *sitter.Language) (*DynamicExtractor, error) {
+	e := NewDynamicExtractor(cfg)
+	e.language = language
+	for _, path := range cfg.Queries {
+		source, err := os.ReadFile(path)
+		if err != nil {
+			return nil, errors.Wrap(err, errors.CodeNotFound, fmt.Sprintf("read query file %s", path))
+		}
+		query, qerr := sitter.NewQuery(grammar, string(source))
+		if qerr != nil {
+			return nil, errors.New(errors.CodeValidationError, fmt.Sprintf("query file %s:%d:%d: %s", path, qerr.Row+1, qerr.Column+1, qerr.Message))
+		}
+		if err := validateQueryCaptures(query.CaptureNames()); err != nil {
+			query.Close()
+			return nil, errors.Wrap(err, errors.CodeValidationError, fmt.Sprintf("query file %s", path))
+		}
+		e.queries = append(e.queries, query)
+	}
+	return e, nil
+}
+
+// validateQueryCaptures rejects capture names outside the extractor
+// vocabulary, which are most likely typos.
+func validateQueryCaptures(names []string) error {
+	for _, name := range names {
+		if strings.HasPrefix(name, "_") {
+			continue
+		}
+		switch name {
+		case captureNamespace, captureImport, captureImportPath, captureImportAlias, captureImportItem,
+			captureReferenceCall, captureReferenceType:
+			continue
+		}
+		if kind, part, ok := splitDefinitionCapture(name); ok {
+			if _, known := queryDefinitionKinds[kind]; known && (part == "" || part == "name" || part == "visibility") {
+				continue
+			}
+		}
+		return fmt.Errorf("unknown capture @%s", name)
+	}
+	return nil
+}
+
+// splitDefinitionCapture splits "definition.<kind>[.<part>]".
+func splitDefinitionCapture(name string) (kind, part string, ok bool) {
+	rest, found := strings.CutPrefix(name, captureDefinition)
+	if !found {
+		return "", "", false
+	}
+	kind, part, _ = strings.Cut(rest, ".")
+	return kind, part, true
+}
+
+// queryDefinition is a definition with the byte span of its node, used to
+// derive scopes and reference callers.
+type queryDefinition struct {
+	def        Definition
+	start, end uint
+	hasNode    bool // span is the definition node's, not just its name's
+}
+
+type queryReference struct {
+	ref   Reference
+	tag   UsageTag
+	start uint
+}
+
+func (e *DynamicExtractor) extractWithQueries(root *sitter.Node, source []byte, file *File) {
+	var defs []queryDefinition
+	var refs []queryReference
+
+	cursor := sitter.NewQueryCursor()
+	defer cursor.Close()
+	for _, query := range e.queries {
+		names := query.CaptureNames()
+		matches := cursor.Matches(query, root, source)
+		for match := matches.Next(); match != nil; match = matches.Next() {
+			var imp *Import
+			var importNode *sitter.Node
+			var def *queryDefinition
+			for _, capture := range match.Captures {
+				node := capture.Node
+				name := names[capture.Index]
+				text := nodeText(&node, source)
+				loc := queryLocation(file.Path, &node)
+				switch name {
+				case captureNamespace:
+					file.PackageName = text
+				case captureImport:
+					importNode = &node
+					if imp == nil {
+						imp = &Import{}
+					}
+				case captureImportPath:
+					if imp == nil {
+						imp = &Import{}
+					}
+					imp.Module = strings.Trim(text, "\"'`")
+					imp.IsRelative = strings.HasPrefix(imp.Module, ".")
+					imp.Location = loc
+				case captureImportAlias:
+					if imp == nil {
+						imp = &Import{}
+					}
+					imp.Alias = text
+				case captureImportItem:
+					if imp == nil {
+						imp = &Import{}
+					}
+					imp.Items = append(imp.Items, text)
+				case captureReferenceCall, captureReferenceType:
+					tag := TagRefCall
+					if name == captureReferenceType {
+						tag = TagRefType
+					}
+					refs = append(refs, queryReference{
+						ref:   Reference{Name: text, Location: loc},
+						tag:   tag,
+						start: node.StartByte(),
+					})
+				default:
+					kind, part, ok := splitDefinitionCapture(name)
+					if !ok {
+						continue
+					}
+					if def == nil {
+						def = &queryDefinition{}
+					}
+					switch part {
+					case "":
+						def.def.Kind = queryDefinitionKinds[kind]
+						def.def.Signature = firstLine(text)
+						def.def.Location = loc
+						def.start, def.end = node.StartByte(), node.EndByte()
+						def.hasNode = true
+					case "name":
+						def.def.Kind = queryDefinitionKinds[kind]
+						def.def.Name = text
+						def.def.FullName = text
+						if !def.hasNode {
+							def.def.Location = loc
+							def.start, def.end = node.StartByte(), node.EndByte()
+						}
+					case "visibility":
+						def.def.Visibility = queryVisibility(text)
+					}
+				}
+			}
+			if imp != nil && imp.Module != "" {
+				imp.RawImport = imp.Module
+				if importNode != nil {
+					imp.RawImport = nodeText(importNode, source)
+					imp.Location = queryLocation(file.Path, importNode)
+				}
+				file.Imports = append(file.Imports, *imp)
+			}
+			if def != nil && def.def.Name != "" {
+				def.def.Exported = def.def.Visibility == "public"
+				defs = append(defs, *def)
+			}
+		}
+	}
+
+	for i := range defs {
+		defs[i].def.Scope = strings.Join(enclosingDefinitionNames(defs, defs[i].start, defs[i].end, i), "->")
+		file.Definitions = append(file.Definitions, defs[i].def)
+	}
+	for _, r := range refs {
+		enclosing := enclosingDefinitionNames(defs, r.start, r.start+1, -1)
+		r.ref.Context = string(r.tag) + "|" + strings.Join(enclosing, "->")
+		r.ref.Caller = innermostCaller(defs, r.start)
+		file.References = append(file.References, r.ref)
+	}
+}
+
+// enclosingDefinitionNames returns the names of the definitions other than
+// defs[self] whose spans contain [start, end), outermost first.
+func enclosingDefinitionNames(defs []queryDefinition, start, end uint, self int) []string {
+	var enclosing []queryDefinition
+	for i, d := range defs {
+		if i == self || d.start > start || d.end < end || (d.start == start && d.end == end) {
+			continue
+		}
+		enclosing = append(enclosing, d)
+	}
+	sort.SliceStable(enclosing, func(i, j int) bool {
+		return enclosing[i].end-enclosing[i].start > enclosing[j].end-enclosing[j].start
+	})
+	names := make([]string, 0, len(enclosing))
+	for _, d := range enclosing {
+		names = append(names, d.def.Name)
+	}
+	return names
+}
+
+// innermostCaller names the smallest function or method definition whose
+// span contains offset.
+func innermostCaller(defs []queryDefinition, offset uint) string {
+	caller := ""
+	best := ^uint(0)
+	for _, d := range defs {
+		if d.def.Kind != KindFunction && d.def.Kind != KindMethod {
+			continue
+		}
+		if d.start <= offset && offset < d.end && d.end-d.start < best {
+			caller, best = d.def.Name, d.end-d.start
+		}
+	}
+	return caller
+}
+
+// queryVisibility normalises a captured visibility modifier to public,
+// private, or internal.
+func queryVisibility(text string) string {
+	for _, word := range strings.Fields(text) {
+		switch word {
+		case "public", "pub", "export", "open":
+			return "public"
+		case "private", "protected", "fileprivate":
+			return "private"
+		case "internal", "package":
+			return "internal"
+		}
+	}
+	return ""
+}
+
+func queryLocation(path string, node *sitter.Node) Location {
+	return Location{
+		File:   path,
+		Line:   int(node.StartPosition().Row) + 1,
+		Column: int(node.StartPosition().Column) + 1,
+	}
+}
+
+func firstLine(text string) string {
+	if idx := strings.IndexByte(text, '\n'); idx >= 0 {
+		return strings.TrimSpace(text[:idx])
+	}
+	return text
+}
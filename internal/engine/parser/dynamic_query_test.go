@@ -0,0 +1,132 @@
+package parser
+
+import (
+	"os"
+	"path/filepath"
+	"reflect"
+	"strings"
+	"testing"
+
+	sitter "github.com/tree-sitter/go-tree-sitter"
+	tree_sitter_java "github.com/tree-sitter/tree-sitter-java/bindings/go"
+)
+
+const javaProfileQuery = `
+(package_declaration (scoped_identifier) @namespace)
+(import_declaration (scoped_identifier) @import.path) @import
+(class_declaration (modifiers)? @definition.class.visibility name: (identifier) @definition.class.name) @definition.class
+(method_declaration (modifiers)? @definition.method.visibility name: (identifier) @definition.method.name) @definition.method
+(method_invocation name: (identifier) @reference.call)
+`
+
+func writeQueryFile(t *testing.T, query string) string {
+	t.Helper()
+	path := filepath.Join(t.TempDir(), "profile.scm")
+	if err := os.WriteFile(path, []byte(query), 0o644); err != nil {
+		t.Fatal(err)
+	}
+	return path
+}
+
+func TestDynamicExtractor_Queries(t *testing.T) {
+	grammar := sitter.NewLanguage(tree_sitter_java.Language())
+	extractor, err := CompileDynamicExtractor("java", DynamicExtractorConfig{
+		Queries: []string{writeQueryFile(t, javaProfileQuery)},
+	}, grammar)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	source := []byte(`package com.acme.svc;
+import java.util.List;
+import static java.lang.Math.max;
+public class Service {
+    private int helper() { return max(1, 2); }
+    public void run() { helper(); List.of(); }
+}
+`)
+	parser := sitter.NewParser()
+	defer parser.Close()
+	if err := parser.SetLanguage(grammar); err != nil {
+		t.Fatal(err)
+	}
+	tree := parser.Parse(source, nil)
+	defer tree.Close()
+
+	file, err := extractor.Extract(tree.RootNode(), source, "Service.java")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if file.Language != "java" || file.PackageName != "com.acme.svc" {
+		t.Fatalf("unexpected language/package %q/%q", file.Language, file.PackageName)
+	}
+
+	if len(file.Imports) != 2 {
+		t.Fatalf("expected 2 imports, got %+v", file.Imports)
+	}
+	if got := file.Imports[0]; got.Module != "java.util.List" || got.RawImport != "import java.util.List;" || got.Location.Line != 2 {
+		t.Fatalf("unexpected first import %+v", got)
+	}
+	if got := file.Imports[1].Module; got != "java.lang.Math.max" {
+		t.Fatalf("unexpected static import %q", got)
+	}
+
+	type def struct {
+		Name, Visibility, Scope string
+		Kind                    DefinitionKind
+		Exported                bool
+		Line                    int
+	}
+	var defs []def
+	for _, d := range file.Definitions {
+		defs = append(defs, def{d.Name, d.Visibility, d.Scope, d.Kind, d.Exported, d.Location.Line})
+	}
+	wantDefs := []def{
+		{"Service", "public", "", KindClass, true, 4},
+		{"helper", "private", "Service", KindMethod, false, 5},
+		{"run", "public", "Service", KindMethod, true, 6},
+	}
+	if !reflect.DeepEqual(defs, wantDefs) {
+		t.Fatalf("unexpected definitions\n got: %+v\nwant: %+v", defs, wantDefs)
+	}
+
+	var refs []string
+	for _, r := range file.References {
+		refs = append(refs, r.Name+"@"+r.Caller+"|"+r.Context)
+	}
+	wantRefs := []string{
+		"max@helper|REF_CALL|Service->helper",
+		"helper@run|REF_CALL|Service->run",
+		"of@run|REF_CALL|Service->run",
+	}
+	if !reflect.DeepEqual(refs, wantRefs) {
+		t.Fatalf("unexpected references\n got: %v\nwant: %v", refs, wantRefs)
+	}
+}
+
+func TestCompileDynamicExtractor_RejectsBadQueries(t *testing.T) {
+	grammar := sitter.NewLanguage(tree_sitter_java.Language())
+	cases := map[string]string{
+		"syntax":         "(class_declaration name: (identifier) @definition.class.name",
+		"unknown node":   "(no_such_node) @namespace",
+		"unknown name":   "(class_declaration name: (identifier) @definition.klass.name)",
+		"unknown import": "(import_declaration (scoped_identifier) @import.module)",
+	}
+	for name, query := range cases {
+		t.Run(name, func(t *testing.T) {
+			_, err := CompileDynamicExtractor("java", DynamicExtractorConfig{
+				Queries: []string{writeQueryFile(t, query)},
+			}, grammar)
+			if err == nil || !strings.Contains(err.Error(), "profile.scm") {
+				t.Fatalf("expected an error naming the query file, got %v", err)
+			}
+		})
+	}
+
+	// A query with only helper captures is accepted.
+	if _, err := CompileDynamicExtractor("java", DynamicExtractorConfig{
+		Queries: []string{writeQueryFile(t, `((identifier) @_id (#eq? @_id "x"))`)},
+	}, grammar); err != nil {
+		t.Fatalf("expected helper captures to be accepted, got %v", err)
+	}
+}
@@ -107,6 +107,44 @@ func ModuleReferenceBase(language, module string) string {
 	return module
 }
 
+// CountImportUsage sets Used and UsageCount on every import of file by counting
+// references to the import's binding: its alias (or module base name), or each
+// imported item, either bare or as a qualifier prefix.
+func CountImportUsage(file *File) {
+	if file == nil || len(file.Imports) == 0 {
+		return
+	}
+	refHits := make(map[string]int, len(file.References))
+	for _, ref := range file.References {
+		refHits[ref.Name]++
+	}
+
+	for i := range file.Imports {
+		imp := &file.Imports[i]
+		names := make([]string, 0, len(imp.Items)+1)
+		if imp.Alias != "" && imp.Alias != "_" && imp.Alias != "." {
+			names = append(names, imp.Alias)
+		} else if imp.Alias == "" {
+			if base := ModuleReferenceBase(file.Language, imp.Module); base != "" {
+				names = append(names, base)
+			}
+		}
+		names = append(names, imp.Items...)
+
+		count := 0
+		for ref, hits := range refHits {
+			for _, name := range names {
+				if name != "" && (ref == name || strings.HasPrefix(ref, name+".")) {
+					count += hits
+					break
+				}
+			}
+		}
+		imp.UsageCount = count
+		imp.Used = count > 0
+	}
+}
+
 func callReferenceContext(language, name string) string {
 	name = strings.TrimSpace(name)
 	if name == "" {
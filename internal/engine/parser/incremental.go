@@ -0,0 +1,255 @@
+// # internal/engine/parser/incremental.go
+package parser
+
+import (
+	"bytes"
+	"circular/internal/core/errors"
+	"container/list"
+	"sync"
+
+	sitter "github.com/tree-sitter/go-tree-sitter"
+)
+
+// DefaultTreeCacheSize bounds the syntax trees ReparseFile keeps between
+// parses. Trees of large files are the dominant cost, so only recently
+// reparsed files are retained.
+const DefaultTreeCacheSize = 64
+
+// SourceEdit describes how a file changed since its previous parse: the single
+// edited span passed to Tree.Edit, and the ranges of the new tree whose syntax
+// differs from the old one.
+type SourceEdit struct {
+	Input   sitter.InputEdit
+	Changed []sitter.Range
+}
+
+// IncrementalExtractor is an Extractor that can rebuild a File from the
+// previous extraction of the same source, re-walking only the syntax an edit
+// touched.
+type IncrementalExtractor interface {
+	Extractor
+	ExtractIncremental(root *sitter.Node, source []byte, filePath string, previous *File, edit SourceEdit) (*File, error)
+}
+
+// ReparseFile parses content like ParseFile, reusing the syntax tree kept
+// from the previous ReparseFile call for path: the old tree is edited to match
+// content and reparsed incrementally, and extractors implementing
+// IncrementalExtractor only re-walk the changed syntax. The new tree is kept
+// for the next call. Without a kept tree it falls back to a full parse.
+func (p *Parser) ReparseFile(path string, content []byte) (*File, error) {
+	lang, extractor, grammar, err := p.resolveExtractor(path)
+	if err != nil {
+		return nil, err
+	}
+	if grammar == nil {
+		p.trees.forget(path)
+		return p.ParseFile(path, content)
+	}
+
+	prev := p.trees.take(path)
+	if prev != nil && prev.language != lang {
+		prev.close()
+		prev = nil
+	}
+	if prev != nil && bytes.Equal(prev.content, content) {
+		res := cloneFile(prev.file)
+		p.applyInjections(lang, prev.tree.RootNode(), content, res)
+		p.trees.put(path, prev)
+		return res, nil
+	}
+
+	pool := p.parserPool(lang, grammar)
+	parser := pool.Get()
+	defer pool.Put(parser)
+
+	var oldTree *sitter.Tree
+	var input sitter.InputEdit
+	if prev != nil {
+		input = computeInputEdit(prev.content, content)
+		oldTree = prev.tree
+		oldTree.Edit(&input)
+	}
+	tree := parser.Parse(content, oldTree)
+	if tree == nil {
+		prev.close()
+		return nil, errors.New(errors.CodeInternal, "parse failed")
+	}
+
+	root := tree.RootNode()
+	var res *File
+	if incremental, ok := extractor.(IncrementalExtractor); ok && prev != nil {
+		edit := SourceEdit{Input: input, Changed: oldTree.ChangedRanges(tree)}
+		res, err = incremental.ExtractIncremental(root, content, path, prev.file, edit)
+	} else {
+		res, err = extractor.Extract(root, content, path)
+	}
+	prev.close()
+	if err != nil {
+		tree.Close()
+		return nil, errors.Wrap(err, errors.CodeInternal, "extraction failed")
+	}
+
+	p.trees.put(path, &cachedTree{
+		tree:     tree,
+		content:  content,
+		language: lang,
+		file:     cloneFile(res),
+	})
+	// The kept extraction stays host-only so incremental extraction does not
+	// copy injected symbols.
+	p.applyInjections(lang, root, content, res)
+	return res, nil
+}
+
+// ForgetFile drops the syntax tree kept for path, e.g. after it was deleted.
+func (p *Parser) ForgetFile(path string) {
+	p.trees.forget(path)
+}
+
+// computeInputEdit describes the change from prev to curr as the one span
+// between their common prefix and common suffix.
+func computeInputEdit(prev, curr []byte) sitter.InputEdit {
+	prefix := 0
+	for prefix < len(prev) && prefix < len(curr) && prev[prefix] == curr[prefix] {
+		prefix++
+	}
+	suffix := 0
+	for suffix < len(prev)-prefix && suffix < len(curr)-prefix && prev[len(prev)-1-suffix] == curr[len(curr)-1-suffix] {
+		suffix++
+	}
+	oldEnd := len(prev) - suffix
+	newEnd := len(curr) - suffix
+	return sitter.InputEdit{
+		StartByte:      uint(prefix),
+		OldEndByte:     uint(oldEnd),
+		NewEndByte:     uint(newEnd),
+		StartPosition:  pointAt(prev, prefix),
+		OldEndPosition: pointAt(prev, oldEnd),
+		NewEndPosition: pointAt(curr, newEnd),
+	}
+}
+
+// pointAt converts a byte offset into a tree-sitter row/byte-column point.
+func pointAt(source []byte, offset int) sitter.Point {
+	head := source[:offset]
+	row := bytes.Count(head, []byte("\n"))
+	column := offset - (bytes.LastIndexByte(head, '\n') + 1)
+	return sitter.Point{Row: uint(row), Column: uint(column)}
+}
+
+// cloneFile copies the slices of f an extraction result is mutated through,
+// so a kept File is not changed by callers of the returned one.
+func cloneFile(f *File) *File {
+	if f == nil {
+		return nil
+	}
+	out := *f
+	out.Imports = cloneImports(f.Imports)
+	out.SampleImports = cloneImports(f.SampleImports)
+	out.Definitions = append([]Definition(nil), f.Definitions...)
+	for i := range out.Definitions {
+		if len(out.Definitions[i].Decorators) == 0 {
+			continue
+		}
+		out.Definitions[i].Decorators = append([]string(nil), out.Definitions[i].Decorators...)
+	}
+	out.References = append([]Reference(nil), f.References...)
+	out.Secrets = append([]Secret(nil), f.Secrets...)
+	out.LocalSymbols = append([]string(nil), f.LocalSymbols...)
+	return &out
+}
+
+func cloneImports(imports []Import) []Import {
+	out := append([]Import(nil), imports...)
+	for i := range out {
+		if len(out[i].Items) == 0 {
+			continue
+		}
+		out[i].Items = append([]string(nil), out[i].Items...)
+	}
+	return out
+}
+
+type cachedTree struct {
+	tree     *sitter.Tree
+	content  []byte
+	language string
+	file     *File // extraction result for content, without injections
+}
+
+func (c *cachedTree) close() {
+	if c != nil {
+		c.tree.Close()
+	}
+}
+
+// treeCache is a capacity-bounded LRU of parsed trees keyed by path. Entries
+// are taken out while a reparse uses them, so a tree is never shared between
+// concurrent parses of the same path.
+type treeCache struct {
+	mu       sync.Mutex
+	capacity int
+	items    map[string]*list.Element
+	order    *list.List // front = most-recently used
+}
+
+type treeCacheEntry struct {
+	path  string
+	value *cachedTree
+}
+
+func newTreeCache(capacity int) *treeCache {
+	if capacity <= 0 {
+		capacity = 1
+	}
+	return &treeCache{
+		capacity: capacity,
+		items:    make(map[string]*list.Element, capacity),
+		order:    list.New(),
+	}
+}
+
+// take removes and returns the tree kept for path, or nil.
+func (c *treeCache) take(path string) *cachedTree {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	el, ok := c.items[path]
+	if !ok {
+		return nil
+	}
+	c.order.Remove(el)
+	delete(c.items, path)
+	return el.Value.(*treeCacheEntry).value
+}
+
+// put stores value for path, closing any tree it replaces or evicts.
+func (c *treeCache) put(path string, value *cachedTree) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	if el, ok := c.items[path]; ok {
+		entry := el.Value.(*treeCacheEntry)
+		entry.value.close()
+		entry.value = value
+		c.order.MoveToFront(el)
+		return
+	}
+	if c.order.Len() >= c.capacity {
+		if back := c.order.Back(); back != nil {
+			entry := back.Value.(*treeCacheEntry)
+			entry.value.close()
+			c.order.Remove(back)
+			delete(c.items, entry.path)
+		}
+	}
+	c.items[path] = c.order.PushFront(&treeCacheEntry{path: path, value: value})
+}
+
+func (c *treeCache) forget(path string) {
+	c.take(path).close()
+}
+
+func (c *treeCache) len() int {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	return c.order.Len()
+}
@@ -0,0 +1,131 @@
+package parser
+
+import (
+	"reflect"
+	"testing"
+	"time"
+
+	sitter "github.com/tree-sitter/go-tree-sitter"
+)
+
+func TestReparseFile_MatchesFullParse(t *testing.T) {
+	cases := []struct {
+		path     string
+		versions []string
+	}{
+		{
+			path: "svc/service.go",
+			versions: []string{
+				"package svc\n\nimport \"fmt\"\n\nfunc A() {\n\tfmt.Println(\"a\")\n}\n\nfunc B() {\n\tA()\n}\n\nfunc C() { B() }\n",
+				// edit inside A without changing line counts
+				"package svc\n\nimport \"fmt\"\n\nfunc A() {\n\tfmt.Printf(\"a\")\n}\n\nfunc B() {\n\tA()\n}\n\nfunc C() { B() }\n",
+				// insert a function between A and B, shifting B and C
+				"package svc\n\nimport \"fmt\"\n\nfunc A() {\n\tfmt.Printf(\"a\")\n}\n\nfunc New() {\n\tA()\n\tfmt.Sprint()\n}\n\nfunc B() {\n\tA()\n}\n\nfunc C() { B() }\n",
+				// add an import and delete C
+				"package svc\n\nimport (\n\t\"fmt\"\n\t\"os\"\n)\n\nfunc A() {\n\tfmt.Printf(\"a\")\n}\n\nfunc New() {\n\tA()\n\tos.Exit(1)\n}\n\nfunc B() {\n\tA()\n}\n",
+				// syntax error in B
+				"package svc\n\nimport (\n\t\"fmt\"\n\t\"os\"\n)\n\nfunc A() {\n\tfmt.Printf(\"a\")\n}\n\nfunc New() {\n\tA()\n\tos.Exit(1)\n}\n\nfunc B() {\n\tA(\n}\n",
+			},
+		},
+		{
+			path: "pkg/mod.py",
+			versions: []string{
+				"import os\n\ndef a():\n    return os.getcwd()\n\nclass B:\n    def run(self):\n        a()\n",
+				"import os\nimport sys\n\ndef a():\n    return os.getcwd()\n\nclass B:\n    def run(self):\n        a()\n",
+				"import os\nimport sys\n\ndef a():\n    return sys.argv\n\nclass B:\n    def run(self):\n        a()\n\n    def stop(self):\n        pass\n",
+			},
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.path, func(t *testing.T) {
+			incremental := newDefaultParser(t)
+			full := newDefaultParser(t)
+			for i, version := range tc.versions {
+				got, err := incremental.ReparseFile(tc.path, []byte(version))
+				if err != nil {
+					t.Fatalf("version %d: reparse: %v", i, err)
+				}
+				want, err := full.ParseFile(tc.path, []byte(version))
+				if err != nil {
+					t.Fatalf("version %d: parse: %v", i, err)
+				}
+				got.ParsedAt, want.ParsedAt = time.Time{}, time.Time{}
+				if !reflect.DeepEqual(got, want) {
+					t.Fatalf("version %d: incremental result differs from full parse\n got: %+v\nwant: %+v", i, got, want)
+				}
+			}
+		})
+	}
+}
+
+func TestReparseFile_KeepsBoundedTrees(t *testing.T) {
+	p := newDefaultParser(t)
+	p.trees = newTreeCache(2)
+
+	for _, path := range []string{"a.go", "b.go", "c.go"} {
+		if _, err := p.ReparseFile(path, []byte("package x\n")); err != nil {
+			t.Fatal(err)
+		}
+	}
+	if got := p.trees.len(); got != 2 {
+		t.Fatalf("expected 2 kept trees, got %d", got)
+	}
+	if p.trees.take("a.go") != nil {
+		t.Fatal("expected least recently parsed tree to be evicted")
+	}
+
+	p.ForgetFile("b.go")
+	if got := p.trees.len(); got != 1 {
+		t.Fatalf("expected forgotten tree to be dropped, got %d kept", got)
+	}
+
+	// The returned File must not alias the kept extraction.
+	first, err := p.ReparseFile("c.go", []byte("package x\n\nfunc F() {}\n"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	first.Definitions[0].FullName = "mutated"
+	again, err := p.ReparseFile("c.go", []byte("package x\n\nfunc F() {}\n"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if again.Definitions[0].FullName != "F" {
+		t.Fatalf("expected kept extraction to be unaffected, got %q", again.Definitions[0].FullName)
+	}
+}
+
+func TestCloneFile_CopiesNestedSlices(t *testing.T) {
+	original := &File{
+		Path:        "svc/api.py",
+		Imports:     []Import{{Module: "os", Items: []string{"path", "sep"}}},
+		Definitions: []Definition{{Name: "handler", Decorators: []string{"app.route"}}},
+	}
+	clone := cloneFile(original)
+	clone.Imports[0].Items[0] = "mutated"
+	clone.Definitions[0].Decorators[0] = "mutated"
+
+	if got := original.Imports[0].Items[0]; got != "path" {
+		t.Fatalf("expected original import items to be unaffected, got %q", got)
+	}
+	if got := original.Definitions[0].Decorators[0]; got != "app.route" {
+		t.Fatalf("expected original decorators to be unaffected, got %q", got)
+	}
+}
+
+func TestComputeInputEdit(t *testing.T) {
+	prev := []byte("ab\ncd\nef\n")
+	curr := []byte("ab\ncXYd\nef\n")
+	got := computeInputEdit(prev, curr)
+	want := sitter.InputEdit{
+		StartByte:      4,
+		OldEndByte:     4,
+		NewEndByte:     6,
+		StartPosition:  sitter.Point{Row: 1, Column: 1},
+		OldEndPosition: sitter.Point{Row: 1, Column: 1},
+		NewEndPosition: sitter.Point{Row: 1, Column: 3},
+	}
+	if got != want {
+		t.Fatalf("expected %+v, got %+v", want, got)
+	}
+}
@@ -0,0 +1,300 @@
+// # internal/engine/parser/injection.go
+package parser
+
+import (
+	"bytes"
+	"sort"
+	"strings"
+
+	sitter "github.com/tree-sitter/go-tree-sitter"
+)
+
+// Injection is a byte range of a host file holding source in another
+// language, such as a <script> element in HTML or a fenced code block in
+// Markdown.
+type Injection struct {
+	Language  string
+	StartByte uint
+	EndByte   uint
+	Start     sitter.Point // position of StartByte in the host file
+	// ImportsOnly keeps only the injected imports, as SampleImports of the
+	// host. Documentation samples should not add definitions, references, or
+	// import edges to the host file.
+	ImportsOnly bool
+}
+
+// injectionFinders locate embedded sources by host language. root is nil for
+// hosts handled by a RawExtractor.
+var injectionFinders = map[string]func(root *sitter.Node, source []byte) []Injection{
+	"html":     htmlInjections,
+	"markdown": markdownInjections,
+}
+
+// applyInjections parses the embedded sources of a host file with their own
+// grammar and extractor and merges the results into file, shifting locations
+// back to host coordinates. Injected languages that are not enabled are
+// skipped.
+func (p *Parser) applyInjections(lang string, root *sitter.Node, content []byte, file *File) {
+	find, ok := injectionFinders[lang]
+	if !ok {
+		return
+	}
+	for _, injection := range find(root, content) {
+		injected := p.parseInjection(file.Path, content, injection)
+		if injected == nil {
+			continue
+		}
+		mergeInjection(file, injected, injection)
+	}
+}
+
+func (p *Parser) parseInjection(path string, content []byte, injection Injection) *File {
+	extractor := p.extractors[injection.Language]
+	grammar := p.loader.languages[injection.Language]
+	ext := p.languageExtension(injection.Language)
+	if extractor == nil || grammar == nil || ext == "" || injection.EndByte > uint(len(content)) {
+		return nil
+	}
+	source := content[injection.StartByte:injection.EndByte]
+
+	pool := p.parserPool(injection.Language, grammar)
+	parser := pool.Get()
+	defer pool.Put(parser)
+	tree := parser.Parse(source, nil)
+	if tree == nil {
+		return nil
+	}
+	defer tree.Close()
+
+	// Extractors derive language conventions from the file extension.
+	res, err := extractor.Extract(tree.RootNode(), source, path+ext)
+	if err != nil {
+		return nil
+	}
+	return res
+}
+
+// languageExtension returns the first enabled extension routed to lang.
+func (p *Parser) languageExtension(lang string) string {
+	exts := make([]string, 0, 1)
+	for ext, routed := range p.extensions {
+		if routed == lang {
+			exts = append(exts, ext)
+		}
+	}
+	if len(exts) == 0 {
+		return ""
+	}
+	sort.Strings(exts)
+	return exts[0]
+}
+
+func mergeInjection(file, injected *File, injection Injection) {
+	shift := func(loc Location) Location {
+		if loc.Line == 1 {
+			loc.Column += int(injection.Start.Column)
+		}
+		loc.Line += int(injection.Start.Row)
+		loc.File = file.Path
+		return loc
+	}
+	if injection.ImportsOnly {
+		for _, imp := range injected.Imports {
+			imp.Location = shift(imp.Location)
+			file.SampleImports = append(file.SampleImports, imp)
+		}
+		return
+	}
+	for _, imp := range injected.Imports {
+		imp.Location = shift(imp.Location)
+		file.Imports = append(file.Imports, imp)
+	}
+	for _, def := range injected.Definitions {
+		def.Location = shift(def.Location)
+		file.Definitions = append(file.Definitions, def)
+	}
+	for _, ref := range injected.References {
+		ref.Location = shift(ref.Location)
+		file.References = append(file.References, ref)
+	}
+	file.LocalSymbols = append(file.LocalSymbols, injected.LocalSymbols...)
+}
+
+// htmlInjections returns the JavaScript of inline <script> elements and the
+// CSS of <style> elements. Scripts with a non-JavaScript type, such as JSON or
+// templates, are skipped.
+func htmlInjections(root *sitter.Node, source []byte) []Injection {
+	var out []Injection
+	var walk func(node *sitter.Node)
+	walk = func(node *sitter.Node) {
+		if node == nil {
+			return
+		}
+		language := ""
+		switch node.Kind() {
+		case "script_element":
+			if isJavaScriptType(htmlAttribute(node, source, "type")) {
+				language = "javascript"
+			}
+		case "style_element":
+			language = "css"
+		}
+		if language != "" {
+			for i := uint(0); i < node.NamedChildCount(); i++ {
+				child := node.NamedChild(i)
+				if child != nil && child.Kind() == "raw_text" {
+					out = append(out, Injection{
+						Language:  language,
+						StartByte: child.StartByte(),
+						EndByte:   child.EndByte(),
+						Start:     child.StartPosition(),
+					})
+				}
+			}
+			return
+		}
+		for i := uint(0); i < node.NamedChildCount(); i++ {
+			walk(node.NamedChild(i))
+		}
+	}
+	walk(root)
+	return out
+}
+
+// htmlAttribute returns the unquoted value of the named attribute on an
+// element's start tag.
+func htmlAttribute(element *sitter.Node, source []byte, name string) string {
+	for i := uint(0); i < element.NamedChildCount(); i++ {
+		tag := element.NamedChild(i)
+		if tag == nil || tag.Kind() != "start_tag" {
+			continue
+		}
+		for j := uint(0); j < tag.NamedChildCount(); j++ {
+			attr := tag.NamedChild(j)
+			if attr == nil || attr.Kind() != "attribute" || !strings.EqualFold(childTextOfKind(attr, source, "attribute_name"), name) {
+				continue
+			}
+			for k := uint(0); k < attr.NamedChildCount(); k++ {
+				value := attr.NamedChild(k)
+				switch value.Kind() {
+				case "attribute_value":
+					return nodeText(value, source)
+				case "quoted_attribute_value":
+					return strings.Trim(nodeText(value, source), "\"'")
+				}
+			}
+			return ""
+		}
+	}
+	return ""
+}
+
+func isJavaScriptType(typ string) bool {
+	switch strings.ToLower(strings.TrimSpace(typ)) {
+	case "", "module", "text/javascript", "application/javascript", "text/ecmascript", "application/ecmascript":
+		return true
+	default:
+		return false
+	}
+}
+
+// fenceLanguages maps Markdown code-fence info strings to language IDs.
+var fenceLanguages = map[string]string{
+	"go":         "go",
+	"golang":     "go",
+	"py":         "python",
+	"python":     "python",
+	"python3":    "python",
+	"js":         "javascript",
+	"javascript": "javascript",
+	"jsx":        "javascript",
+	"mjs":        "javascript",
+	"cjs":        "javascript",
+	"ts":         "typescript",
+	"typescript": "typescript",
+	"tsx":        "tsx",
+	"java":       "java",
+	"rs":         "rust",
+	"rust":       "rust",
+	"css":        "css",
+}
+
+// markdownInjections returns the bodies of fenced code blocks whose info
+// string names a known language. Only their imports are kept, as sample
+// imports. An unclosed
+// fence runs to the end of the document.
+func markdownInjections(_ *sitter.Node, source []byte) []Injection {
+	var out []Injection
+	var open *Injection
+	marker := ""
+	row := uint(0)
+	for offset := 0; offset < len(source); row++ {
+		lineEnd := len(source)
+		next := len(source)
+		if idx := bytes.IndexByte(source[offset:], '\n'); idx >= 0 {
+			lineEnd = offset + idx
+			next = lineEnd + 1
+		}
+		line := string(source[offset:lineEnd])
+		if open == nil {
+			if fence, info, ok := openingFence(line); ok {
+				marker = fence
+				open = &Injection{
+					Language:    fenceLanguages[info],
+					StartByte:   uint(next),
+					Start:       sitter.Point{Row: row + 1},
+					ImportsOnly: true,
+				}
+			}
+		} else if isClosingFence(line, marker) {
+			open.EndByte = uint(offset)
+			if open.Language != "" && open.EndByte > open.StartByte {
+				out = append(out, *open)
+			}
+			open = nil
+		}
+		offset = next
+	}
+	if open != nil && open.Language != "" && uint(len(source)) > open.StartByte {
+		open.EndByte = uint(len(source))
+		out = append(out, *open)
+	}
+	return out
+}
+
+// openingFence parses a CommonMark fence opener: up to three spaces of
+// indentation, then at least three backticks or tildes and an optional info
+// string, whose first word is returned lower-cased.
+func openingFence(line string) (string, string, bool) {
+	trimmed := strings.TrimLeft(line, " ")
+	if len(line)-len(trimmed) > 3 || len(trimmed) < 3 || (trimmed[0] != '`' && trimmed[0] != '~') {
+		return "", "", false
+	}
+	n := 0
+	for n < len(trimmed) && trimmed[n] == trimmed[0] {
+		n++
+	}
+	if n < 3 {
+		return "", "", false
+	}
+	info := strings.TrimSpace(trimmed[n:])
+	if trimmed[0] == '`' && strings.Contains(info, "`") {
+		return "", "", false
+	}
+	if fields := strings.Fields(info); len(fields) > 0 {
+		info = strings.ToLower(strings.Trim(fields[0], "{}."))
+	}
+	return trimmed[:n], info, true
+}
+
+func isClosingFence(line, marker string) bool {
+	trimmed := strings.TrimLeft(line, " ")
+	if len(line)-len(trimmed) > 3 {
+		return false
+	}
+	n := 0
+	for n < len(trimmed) && trimmed[n] == marker[0] {
+		n++
+	}
+	return n >= len(marker) && strings.TrimSpace(trimmed[n:]) == ""
+}
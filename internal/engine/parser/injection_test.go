@@ -0,0 +1,166 @@
+package parser
+
+import (
+	"reflect"
+	"testing"
+	"time"
+)
+
+func newInjectionParser(t *testing.T) *Parser {
+	t.Helper()
+	trueVal := true
+	registry, err := BuildLanguageRegistry(map[string]LanguageOverride{
+		"html":       {Enabled: &trueVal},
+		"javascript": {Enabled: &trueVal},
+		"css":        {Enabled: &trueVal},
+		"markdown":   {Enabled: &trueVal},
+	}, nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	loader, err := NewGrammarLoaderWithRegistry("./grammars", registry, false)
+	if err != nil {
+		t.Fatal(err)
+	}
+	p := NewParser(loader)
+	if err := p.RegisterDefaultExtractors(); err != nil {
+		t.Fatal(err)
+	}
+	return p
+}
+
+const injectionHTML = `<html>
+<head>
+<style>
+  .hero { background: url("hero.png"); }
+</style>
+<script type="application/ld+json">{"name": "skip()"}</script>
+</head>
+<body>
+<script>function boot() { render(); }
+  boot();
+</script>
+</body>
+</html>
+`
+
+func TestParseFile_HTMLInjections(t *testing.T) {
+	p := newInjectionParser(t)
+	file, err := p.ParseFile("web/index.html", []byte(injectionHTML))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if file.Language != "html" {
+		t.Fatalf("expected host language html, got %q", file.Language)
+	}
+
+	if len(file.Definitions) != 1 {
+		t.Fatalf("expected the script's function only, got %+v", file.Definitions)
+	}
+	if got := file.Definitions[0]; got.Name != "boot" || got.Location != (Location{File: "web/index.html", Line: 9, Column: 9}) {
+		t.Fatalf("unexpected definition %+v", got)
+	}
+
+	refs := make(map[string]Location)
+	for _, ref := range file.References {
+		refs[ref.Name] = ref.Location
+	}
+	if _, ok := refs["skip"]; ok {
+		t.Fatal("expected JSON script to be skipped")
+	}
+	want := map[string]Location{
+		"render":   {File: "web/index.html", Line: 9, Column: 27},
+		"boot":     {File: "web/index.html", Line: 10, Column: 3},
+		"hero.png": {File: "web/index.html", Line: 4, Column: 28},
+	}
+	for name, loc := range want {
+		if refs[name] != loc {
+			t.Fatalf("expected %s at %+v, got %+v (all: %+v)", name, loc, refs[name], file.References)
+		}
+	}
+}
+
+func TestParseFile_HTMLInjectionsNeedEnabledLanguages(t *testing.T) {
+	trueVal := true
+	registry, err := BuildLanguageRegistry(map[string]LanguageOverride{"html": {Enabled: &trueVal}}, nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	loader, err := NewGrammarLoaderWithRegistry("./grammars", registry, false)
+	if err != nil {
+		t.Fatal(err)
+	}
+	p := NewParser(loader)
+	if err := p.RegisterDefaultExtractors(); err != nil {
+		t.Fatal(err)
+	}
+	file, err := p.ParseFile("index.html", []byte(injectionHTML))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(file.Definitions) != 0 || len(file.References) != 0 {
+		t.Fatalf("expected no injected symbols without javascript/css, got %+v / %+v", file.Definitions, file.References)
+	}
+}
+
+func TestParseFile_MarkdownFenceImports(t *testing.T) {
+	p := newDefaultParser(t)
+	md := newInjectionParser(t)
+	source := "# Usage\n\n```go\npackage main\n\nimport \"example.com/app/store\"\n\nfunc main() { store.Open() }\n```\n\n" +
+		"~~~python title=\"sample\"\nfrom app.auth import login\nlogin()\n~~~\n\n```sh\nimport nothing\n```\n\n" +
+		"````\n```go\nimport \"ignored\"\n```\n````\n\n```py\nimport os\n"
+
+	if _, err := p.ParseFile("README.md", []byte(source)); err == nil {
+		t.Fatal("expected markdown to be disabled by default")
+	}
+	file, err := md.ParseFile("docs/README.md", []byte(source))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if file.Language != "markdown" {
+		t.Fatalf("expected markdown host, got %q", file.Language)
+	}
+	if len(file.Definitions) != 0 || len(file.References) != 0 || len(file.Imports) != 0 {
+		t.Fatalf("expected code samples to contribute sample imports only, got %+v / %+v / %+v", file.Definitions, file.References, file.Imports)
+	}
+
+	type imp struct {
+		Module string
+		Line   int
+	}
+	var got []imp
+	for _, i := range file.SampleImports {
+		if i.Location.File != "docs/README.md" {
+			t.Fatalf("expected import located in the host file, got %+v", i.Location)
+		}
+		got = append(got, imp{i.Module, i.Location.Line})
+	}
+	want := []imp{{"example.com/app/store", 6}, {"app.auth", 12}, {"os", 27}}
+	if !reflect.DeepEqual(got, want) {
+		t.Fatalf("unexpected sample imports\n got: %+v\nwant: %+v", got, want)
+	}
+}
+
+func TestReparseFile_HTMLInjectionsMatchFullParse(t *testing.T) {
+	incremental := newInjectionParser(t)
+	full := newInjectionParser(t)
+	versions := []string{
+		injectionHTML,
+		injectionHTML,
+		"<html>\n<body>\n<p>intro</p>\n" + injectionHTML[len("<html>\n"):],
+	}
+	for i, version := range versions {
+		got, err := incremental.ReparseFile("index.html", []byte(version))
+		if err != nil {
+			t.Fatalf("version %d: reparse: %v", i, err)
+		}
+		want, err := full.ParseFile("index.html", []byte(version))
+		if err != nil {
+			t.Fatalf("version %d: parse: %v", i, err)
+		}
+		got.ParsedAt, want.ParsedAt = time.Time{}, time.Time{}
+		if !reflect.DeepEqual(got, want) {
+			t.Fatalf("version %d: reparse differs from full parse\n got: %+v\nwant: %+v", i, got, want)
+		}
+	}
+}
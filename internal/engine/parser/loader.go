@@ -90,7 +90,7 @@ func NewGrammarLoaderWithRegistry(grammarsPath string, registry map[string]Langu
 			gl.languages["css"] = sitter.NewLanguage(tree_sitter_css.Language())
 		case "go":
 			gl.languages["go"] = sitter.NewLanguage(tree_sitter_go.Language())
-		case "gomod", "gosum":
+		case "gomod", "gosum", "markdown":
 			// Parsed by raw-text extractors; no runtime tree-sitter binding required.
 			continue
 		case "html":
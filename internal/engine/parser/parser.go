@@ -19,6 +19,8 @@ type Parser struct {
 	extensions     map[string]string
 	filenames      map[string]string
 	testFileSuffix []string
+	pools          map[string]*ParserPool // language -> pooled tree-sitter parsers
+	trees          *treeCache
 }
 
 type Extractor interface {
@@ -35,6 +37,11 @@ func NewParser(loader *GrammarLoader) *Parser {
 		extractors: make(map[string]Extractor),
 		extensions: make(map[string]string),
 		filenames:  make(map[string]string),
+		pools:      make(map[string]*ParserPool, len(loader.languages)),
+		trees:      newTreeCache(DefaultTreeCacheSize),
+	}
+	for lang, grammar := range loader.languages {
+		p.pools[lang] = NewParserPool(grammar)
 	}
 	for lang, spec := range loader.LanguageRegistry() {
 		if !spec.Enabled {
@@ -64,7 +71,11 @@ func (p *Parser) RegisterDefaultExtractors() error {
 		extractor, ok := DefaultExtractorForLanguage(lang)
 		if !ok {
 			if spec.IsDynamic && spec.DynamicConfig != nil {
-				p.RegisterExtractor(lang, NewDynamicExtractor(*spec.DynamicConfig))
+				dynamic, err := CompileDynamicExtractor(lang, *spec.DynamicConfig, p.loader.languages[lang])
+				if err != nil {
+					return errors.Wrap(err, errors.CodeValidationError, fmt.Sprintf("dynamic grammar %s", lang))
+				}
+				p.RegisterExtractor(lang, dynamic)
 				continue
 			}
 			return errors.New(errors.CodeNotSupported, fmt.Sprintf("no default extractor for enabled language: %s", lang))
@@ -75,27 +86,25 @@ func (p *Parser) RegisterDefaultExtractors() error {
 }
 
 func (p *Parser) ParseFile(path string, content []byte) (*File, error) {
-	lang := p.detectLanguage(path)
-	if lang == "" {
-		return nil, errors.New(errors.CodeNotSupported, "unsupported language")
-	}
-
-	extractor := p.extractors[lang]
-	if extractor == nil {
-		return nil, errors.New(errors.CodeNotSupported, fmt.Sprintf("no extractor for: %s", lang))
+	lang, extractor, grammar, err := p.resolveExtractor(path)
+	if err != nil {
+		return nil, err
 	}
-
-	grammar := p.loader.languages[lang]
 	if grammar == nil {
 		if rawExtractor, ok := extractor.(RawExtractor); ok {
-			return rawExtractor.ExtractRaw(content, path)
+			res, err := rawExtractor.ExtractRaw(content, path)
+			if err != nil {
+				return nil, err
+			}
+			p.applyInjections(lang, nil, content, res)
+			return res, nil
 		}
 		return nil, errors.New(errors.CodeInternal, fmt.Sprintf("grammar not loaded: %s", lang))
 	}
 
-	parser := sitter.NewParser()
-	defer parser.Close()
-	parser.SetLanguage(grammar)
+	pool := p.parserPool(lang, grammar)
+	parser := pool.Get()
+	defer pool.Put(parser)
 
 	tree := parser.Parse(content, nil)
 	if tree == nil {
@@ -108,9 +117,35 @@ func (p *Parser) ParseFile(path string, content []byte) (*File, error) {
 	if err != nil {
 		return nil, errors.Wrap(err, errors.CodeInternal, "extraction failed")
 	}
+	p.applyInjections(lang, root, content, res)
 	return res, nil
 }
 
+// parserPool returns the pool of parsers for lang. Grammars are loaded before
+// the Parser is built, so the pool normally exists; a fresh one is used
+// otherwise.
+func (p *Parser) parserPool(lang string, grammar *sitter.Language) *ParserPool {
+	if pool, ok := p.pools[lang]; ok {
+		return pool
+	}
+	return NewParserPool(grammar)
+}
+
+// resolveExtractor returns the language, extractor, and grammar for path. The
+// grammar is nil for languages handled by a RawExtractor.
+func (p *Parser) resolveExtractor(path string) (string, Extractor, *sitter.Language, error) {
+	lang := p.detectLanguage(path)
+	if lang == "" {
+		return "", nil, nil, errors.New(errors.CodeNotSupported, "unsupported language")
+	}
+
+	extractor := p.extractors[lang]
+	if extractor == nil {
+		return "", nil, nil, errors.New(errors.CodeNotSupported, fmt.Sprintf("no extractor for: %s", lang))
+	}
+	return lang, extractor, p.loader.languages[lang], nil
+}
+
 func (p *Parser) detectLanguage(path string) string {
 	base := strings.ToLower(filepath.Base(path))
 	if lang, ok := p.filenames[base]; ok {
@@ -2,6 +2,7 @@
 package parser
 
 import (
+	"strings"
 	"testing"
 )
 
@@ -78,6 +79,12 @@ class MyClass:
 	for _, def := range file.Definitions {
 		if def.Name == "my_func" {
 			foundFunc = true
+			if !def.Exported || len(def.Decorators) != 1 || def.Decorators[0] != "app.route" {
+				t.Errorf("my_func: expected exported with decorator app.route, got exported=%v decorators=%v", def.Exported, def.Decorators)
+			}
+		}
+		if def.Name == "__init__" && def.Exported {
+			t.Error("__init__ should not be exported")
 		}
 		if def.Name == "MyClass" {
 			foundClass = true
@@ -91,6 +98,26 @@ class MyClass:
 	}
 }
 
+func TestPythonExtraction_MainGuard(t *testing.T) {
+	p := newDefaultParser(t)
+
+	file, err := p.ParseFile("tool.py", []byte("def run():\n    pass\n\nif __name__ == '__main__':\n    run()\n"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !file.MainGuard {
+		t.Error("expected __main__ guard to be detected")
+	}
+
+	file, err = p.ParseFile("lib.py", []byte("DEBUG = False\nif DEBUG:\n    pass\n"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if file.MainGuard {
+		t.Error("unexpected __main__ guard")
+	}
+}
+
 func TestGoExtraction(t *testing.T) {
 	p := newDefaultParser(t)
 
@@ -136,7 +163,9 @@ func Sum(base int, extra ...int) int {
 	for _, def := range file.Definitions {
 		if def.Name == "Main" {
 			foundMain = true
-			break
+		}
+		if (def.Name == "Main" || def.Name == "MyStruct") && !def.Exported {
+			t.Errorf("%s should be exported", def.Name)
 		}
 	}
 	if !foundMain {
@@ -192,6 +221,57 @@ func Sum(a, b int) int {
 	}
 }
 
+func TestGoExtraction_ReferenceCallers(t *testing.T) {
+	p := newDefaultParser(t)
+
+	code := `
+package main
+
+import "fmt"
+
+var banner = fmt.Sprint("x")
+
+type Server struct{}
+
+func (s *Server) Start() {
+	fmt.Println("start")
+}
+
+func main() {
+	run := func() {
+		helper()
+	}
+	run()
+}
+`
+	file, err := p.ParseFile("main.go", []byte(code))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	callers := make(map[string]string)
+	for _, ref := range file.References {
+		if strings.HasPrefix(ref.Context, string(TagRefCall)) {
+			callers[ref.Name] = ref.Caller
+		}
+	}
+	want := map[string]string{
+		"fmt.Sprint":  "",
+		"fmt.Println": "Start",
+		"helper":      "main",
+		"run":         "main",
+	}
+	for name, caller := range want {
+		got, ok := callers[name]
+		if !ok {
+			t.Fatalf("expected call reference %s, got %+v", name, file.References)
+		}
+		if got != caller {
+			t.Fatalf("expected %s to be called from %q, got %q", name, caller, got)
+		}
+	}
+}
+
 func TestProfileExtractor_MetadataParityAndBridgeContexts(t *testing.T) {
 	trueVal := true
 	registry, err := BuildLanguageRegistry(map[string]LanguageOverride{
@@ -384,3 +464,160 @@ func TestExtraction_MultiLanguage(t *testing.T) {
 		})
 	}
 }
+
+func TestExtraction_Visibility(t *testing.T) {
+	trueVal := true
+	registry, err := BuildLanguageRegistry(map[string]LanguageOverride{
+		"javascript": {Enabled: &trueVal},
+		"typescript": {Enabled: &trueVal},
+		"java":       {Enabled: &trueVal},
+		"rust":       {Enabled: &trueVal},
+	}, nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	loader, err := NewGrammarLoaderWithRegistry("./grammars", registry, false)
+	if err != nil {
+		t.Fatal(err)
+	}
+	p := NewParser(loader)
+	if err := p.RegisterDefaultExtractors(); err != nil {
+		t.Fatal(err)
+	}
+
+	tests := []struct {
+		path string
+		code string
+		want map[string]string
+	}{
+		{"a.go", "package a\nfunc Run() {}\nfunc helper() {}\ntype Config struct{}\n", map[string]string{"Run": "public", "helper": "private", "Config": "public"}},
+		{"a.py", "def run():\n    pass\n\ndef _helper():\n    pass\n\nclass C:\n    def __init__(self):\n        pass\n", map[string]string{"run": "public", "_helper": "private", "__init__": "public"}},
+		{"a.js", "function run(){}\nfunction _helper(){}\nclass K { #secret(){} }\n", map[string]string{"run": "public", "_helper": "private", "#secret": "private"}},
+		{"a.ts", "class K { private hidden(){} public shown(){} }\n", map[string]string{"hidden": "private", "shown": "public"}},
+		{"A.java", "package p; public class A { private void p(){} void pkg(){} public void q(){} }\n", map[string]string{"A": "public", "p": "private", "pkg": "internal", "q": "public"}},
+		{"a.rs", "pub fn a(){}\npub(crate) fn b(){}\nfn c(){}\npub(self) fn d(){}\npub(super) struct U;\n", map[string]string{"a": "public", "b": "internal", "c": "private", "d": "private", "U": "internal"}},
+	}
+	for _, tc := range tests {
+		t.Run(tc.path, func(t *testing.T) {
+			file, err := p.ParseFile(tc.path, []byte(tc.code))
+			if err != nil {
+				t.Fatal(err)
+			}
+			got := make(map[string]string)
+			for _, def := range file.Definitions {
+				got[def.Name] = def.Visibility
+			}
+			for name, want := range tc.want {
+				if got[name] != want {
+					t.Errorf("%s: expected visibility %q, got %q (all: %v)", name, want, got[name], got)
+				}
+			}
+		})
+	}
+}
+
+func TestCountImportUsage(t *testing.T) {
+	file := &File{
+		Language: "go",
+		Imports: []Import{
+			{Module: "example.com/app/store"},
+			{Module: "example.com/app/util", Alias: "u"},
+			{Module: "example.com/app/unused"},
+			{Module: "example.com/app/side", Alias: "_"},
+		},
+		References: []Reference{
+			{Name: "store.Open"},
+			{Name: "store.Open"},
+			{Name: "store"},
+			{Name: "u.Trim"},
+			{Name: "storefront.Open"},
+		},
+	}
+
+	CountImportUsage(file)
+
+	want := []int{3, 1, 0, 0}
+	for i, imp := range file.Imports {
+		if imp.UsageCount != want[i] {
+			t.Errorf("%s: UsageCount = %d, want %d", imp.Module, imp.UsageCount, want[i])
+		}
+		if imp.Used != (want[i] > 0) {
+			t.Errorf("%s: Used = %v, want %v", imp.Module, imp.Used, want[i] > 0)
+		}
+	}
+}
+
+func TestExtraction_AbstractTypes(t *testing.T) {
+	trueVal := true
+	registry, err := BuildLanguageRegistry(map[string]LanguageOverride{
+		"java":       {Enabled: &trueVal},
+		"typescript": {Enabled: &trueVal},
+	}, nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	loader, err := NewGrammarLoaderWithRegistry("./grammars", registry, false)
+	if err != nil {
+		t.Fatal(err)
+	}
+	p := NewParser(loader)
+	if err := p.RegisterDefaultExtractors(); err != nil {
+		t.Fatal(err)
+	}
+
+	tests := []struct {
+		path     string
+		code     string
+		kinds    map[string]DefinitionKind
+		abstract map[string]bool
+	}{
+		{
+			path:  "types.go",
+			code:  "package types\n\ntype Reader interface { Read() }\n\ntype (\n\tBuffer struct{}\n\tSize int\n)\n",
+			kinds: map[string]DefinitionKind{"Reader": KindInterface, "Buffer": KindType, "Size": KindType},
+		},
+		{
+			path:     "base.py",
+			code:     "from abc import ABC\n\nclass Base(ABC):\n    pass\n\nclass Impl(Base):\n    pass\n",
+			kinds:    map[string]DefinitionKind{"Base": KindClass, "Impl": KindClass},
+			abstract: map[string]bool{"Base": true},
+		},
+		{
+			path:     "Shape.java",
+			code:     "public abstract class Shape {}\nclass Circle extends Shape {}\n",
+			kinds:    map[string]DefinitionKind{"Shape": KindClass, "Circle": KindClass},
+			abstract: map[string]bool{"Shape": true},
+		},
+		{
+			path:     "shape.ts",
+			code:     "abstract class Shape {}\nclass Circle extends Shape {}\n",
+			kinds:    map[string]DefinitionKind{"Shape": KindClass, "Circle": KindClass},
+			abstract: map[string]bool{"Shape": true},
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.path, func(t *testing.T) {
+			file, err := p.ParseFile(tc.path, []byte(tc.code))
+			if err != nil {
+				t.Fatal(err)
+			}
+			found := make(map[string]Definition)
+			for _, def := range file.Definitions {
+				found[def.Name] = def
+			}
+			for name, kind := range tc.kinds {
+				def, ok := found[name]
+				if !ok {
+					t.Fatalf("expected definition %s in %+v", name, file.Definitions)
+				}
+				if def.Kind != kind {
+					t.Fatalf("expected %s kind %d, got %d", name, kind, def.Kind)
+				}
+				if got := def.TypeHint == TypeHintAbstract; got != tc.abstract[name] {
+					t.Fatalf("expected %s abstract=%v, got type hint %q", name, tc.abstract[name], def.TypeHint)
+				}
+			}
+		})
+	}
+}
@@ -17,6 +17,8 @@ func DefaultExtractorForLanguage(lang string) (Extractor, bool) {
 		return &goModProfileExtractor{}, true
 	case "gosum":
 		return &goSumProfileExtractor{}, true
+	case "markdown":
+		return &markdownProfileExtractor{}, true
 	default:
 		return nil, false
 	}
@@ -594,7 +596,13 @@ func javaVisibility(ctx *ExtractionContext, node *sitter.Node) string {
 	if node == nil || ctx == nil {
 		return "internal"
 	}
-	text := " " + strings.ToLower(ctx.Text(node)) + " "
+	return javaModifierVisibility(ctx.Text(node))
+}
+
+// javaModifierVisibility maps Java modifiers to a visibility; protected and
+// package-private members are "internal".
+func javaModifierVisibility(modifiers string) string {
+	text := " " + strings.ToLower(modifiers) + " "
 	switch {
 	case strings.Contains(text, " private "):
 		return "private"
@@ -647,11 +655,29 @@ func rustVisibility(ctx *ExtractionContext, node *sitter.Node) string {
 	if node == nil || ctx == nil {
 		return "private"
 	}
-	text := strings.TrimSpace(ctx.Text(node))
-	if strings.HasPrefix(text, "pub ") || strings.HasPrefix(text, "pub(") {
+	return rustModifierVisibility(ctx.Text(node))
+}
+
+// rustModifierVisibility maps a Rust visibility modifier (or an item starting
+// with one) to a visibility. pub(crate), pub(super), and pub(in path) stay
+// inside the crate and are "internal"; pub(self) is plain private.
+func rustModifierVisibility(text string) string {
+	text = strings.TrimSpace(text)
+	if !strings.HasPrefix(text, "pub") {
+		return "private"
+	}
+	rest := strings.TrimSpace(strings.TrimPrefix(text, "pub"))
+	if !strings.HasPrefix(rest, "(") {
 		return "public"
 	}
-	return "private"
+	scope := rest[1:]
+	if end := strings.IndexByte(scope, ')'); end >= 0 {
+		scope = scope[:end]
+	}
+	if strings.TrimSpace(scope) == "self" {
+		return "private"
+	}
+	return "internal"
 }
 
 func rustSignature(ctx *ExtractionContext, node *sitter.Node, name string) string {
@@ -820,24 +846,13 @@ func (e *goModProfileExtractor) ExtractRaw(source []byte, filePath string) (*Fil
 		ParsedAt:    time.Now(),
 	}
 
-	lines := strings.Split(string(source), "\n")
-	for _, line := range lines {
-		trimmed := strings.TrimSpace(line)
-		if trimmed == "" || strings.HasPrefix(trimmed, "//") {
-			continue
-		}
-		if strings.HasPrefix(trimmed, "module ") {
-			mod := strings.TrimSpace(strings.TrimPrefix(trimmed, "module "))
-			file.Module = mod
-			file.Definitions = append(file.Definitions, Definition{Name: mod, FullName: mod, Kind: KindVariable, Exported: true, Location: Location{File: filePath, Line: 1, Column: 1}})
-			continue
-		}
-		if strings.HasPrefix(trimmed, "require ") {
-			fields := strings.Fields(strings.TrimPrefix(trimmed, "require "))
-			if len(fields) > 0 {
-				file.Imports = append(file.Imports, Import{Module: fields[0], RawImport: trimmed, Location: Location{File: filePath, Line: 1, Column: 1}})
-			}
-		}
+	mod, requires := parseGoMod(source)
+	if mod != "" {
+		file.Module = mod
+		file.Definitions = append(file.Definitions, Definition{Name: mod, FullName: mod, Kind: KindVariable, Exported: true, Location: Location{File: filePath, Line: 1, Column: 1}})
+	}
+	for _, req := range requires {
+		file.Imports = append(file.Imports, Import{Module: req.Name, RawImport: strings.TrimSpace(req.Name + " " + req.Version), Location: Location{File: filePath, Line: req.Line, Column: 1}})
 	}
 
 	return file, nil
@@ -857,23 +872,25 @@ func (e *goSumProfileExtractor) ExtractRaw(source []byte, filePath string) (*Fil
 		ParsedAt:    time.Now(),
 	}
 
-	seen := make(map[string]bool)
-	lines := strings.Split(string(source), "\n")
-	for _, line := range lines {
-		fields := strings.Fields(line)
-		if len(fields) < 2 {
-			continue
-		}
-		mod := fields[0]
-		if before, ok := strings.CutSuffix(mod, "/go.mod"); ok {
-			mod = before
-		}
-		if mod == "" || seen[mod] {
-			continue
-		}
-		seen[mod] = true
-		file.Imports = append(file.Imports, Import{Module: mod, RawImport: line, Location: Location{File: filePath, Line: 1, Column: 1}})
+	for _, mod := range parseGoSum(source) {
+		file.Imports = append(file.Imports, Import{Module: mod.Name, RawImport: mod.Name + " " + mod.Version, Location: Location{File: filePath, Line: mod.Line, Column: 1}})
 	}
 
 	return file, nil
 }
+
+// markdownProfileExtractor records nothing of its own for Markdown documents;
+// their fenced code blocks are handled as injections.
+type markdownProfileExtractor struct{}
+
+func (e *markdownProfileExtractor) Extract(_ *sitter.Node, source []byte, filePath string) (*File, error) {
+	return e.ExtractRaw(source, filePath)
+}
+
+func (e *markdownProfileExtractor) ExtractRaw(_ []byte, filePath string) (*File, error) {
+	return &File{
+		Path:     filePath,
+		Language: "markdown",
+		ParsedAt: time.Now(),
+	}, nil
+}
@@ -28,6 +28,9 @@ type DynamicExtractorConfig struct {
 	NamespaceNode   string   `toml:"namespace_node"`
 	ImportNode      string   `toml:"import_node"`
 	DefinitionNodes []string `toml:"definition_nodes"`
+	// Queries lists tree-sitter query (.scm) files whose captures drive
+	// extraction instead of the node kinds above.
+	Queries []string `toml:"queries"`
 }
 
 type LanguageOverride struct {
@@ -95,6 +98,13 @@ func DefaultLanguageRegistry() map[string]LanguageSpec {
 			ExtractorReady:      true,
 			RequireVerification: true,
 		},
+		"markdown": {
+			Name:           "markdown",
+			GrammarDir:     "markdown",
+			Extensions:     []string{".md", ".markdown"},
+			Enabled:        false,
+			ExtractorReady: true,
+		},
 		"python": {
 			Name:                "python",
 			GrammarDir:          "python",
@@ -5,17 +5,27 @@ import (
 	"time"
 )
 
+// ExtractorVersion identifies the shape of extracted files. Bump it whenever
+// an extractor change alters what it records for unchanged source, so parses
+// persisted by an older build are not reused.
+const ExtractorVersion = 1
+
 type File struct {
-	Path         string
-	Language     string
-	Module       string // Fully qualified module name
-	PackageName  string // Local package/module name
-	Imports      []Import
-	Definitions  []Definition
-	References   []Reference // Function/symbol calls
-	Secrets      []Secret
-	LocalSymbols []string // Variables defined in local scope (vars, params, self)
-	ParsedAt     time.Time
+	Path             string
+	Language         string
+	Module           string // Fully qualified module name
+	PackageName      string // Local package/module name
+	Imports          []Import
+	SampleImports    []Import // imports of documentation code samples; not part of the module graph
+	Definitions      []Definition
+	References       []Reference // Function/symbol calls
+	Secrets          []Secret
+	LocalSymbols     []string // Variables defined in local scope (vars, params, self)
+	ParsedAt         time.Time
+	ContentHash      string // SHA-256 of the source the file was parsed from
+	ParseFingerprint string // extractor version and parse-affecting config the file was parsed under
+	LOC              int    // lines in the source the file was parsed from
+	MainGuard        bool   // Python module with a top-level `if __name__ == "__main__":` block
 }
 
 type Import struct {
@@ -54,6 +64,9 @@ type Reference struct {
 	Location Location
 	Context  string // Where this reference occurs
 	Resolved bool   // Did we find the definition?
+	// Caller names the innermost function or method containing the reference;
+	// empty for module-level code or extractors that do not track it.
+	Caller string
 }
 
 type Secret struct {
@@ -77,6 +90,31 @@ const (
 	KindInterface
 )
 
+func (k DefinitionKind) String() string {
+	switch k {
+	case KindFunction:
+		return "function"
+	case KindClass:
+		return "class"
+	case KindMethod:
+		return "method"
+	case KindVariable:
+		return "variable"
+	case KindConstant:
+		return "constant"
+	case KindType:
+		return "type"
+	case KindInterface:
+		return "interface"
+	default:
+		return "unknown"
+	}
+}
+
+// TypeHintAbstract marks class definitions declared abstract (abstract
+// modifier, or an ABC/Protocol base in Python).
+const TypeHintAbstract = "abstract"
+
 const (
 	RefContextDefault = ""
 	RefContextFFI     = "ffi_bridge"
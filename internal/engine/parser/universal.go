@@ -44,6 +44,8 @@ type TaggedSymbol struct {
 	// Ancestry is the chain of ancestor node kinds leading to this node,
 	// e.g. "source_file->function_declaration->block->call_expression".
 	Ancestry string
+	// Caller is the innermost enclosing function or method definition.
+	Caller   string
 	Location Location
 }
 
@@ -124,7 +126,89 @@ func (e *UniversalExtractor) Extract(root *sitter.Node, source []byte, filePath
 
 	// Pass 2: classify every node for definitions and references.
 	ancestry := make([]string, 0, 32)
-	walkUniversal(root, source, file, ancestry)
+	walkUniversal(root, source, file, ancestry, "")
+	return file, nil
+}
+
+// ExtractIncremental rebuilds the File for an edited source from previous,
+// the extraction of the source before the edit. Imports are re-extracted in
+// full; definitions and references are re-walked only for top-level nodes
+// that overlap the edit or a changed range, or share a row with the edit.
+// Those of the other top-level nodes are copied from previous, with lines
+// below the edit shifted by the number of lines it added or removed.
+func (e *UniversalExtractor) ExtractIncremental(root *sitter.Node, source []byte, filePath string, previous *File, edit SourceEdit) (*File, error) {
+	if root == nil || previous == nil || previous.Language != detectLangFromPath(filePath) {
+		return e.Extract(root, source, filePath)
+	}
+	if _, ok := classifyNodeKind(root.Kind()); ok {
+		return e.Extract(root, source, filePath)
+	}
+
+	file := &File{
+		Path:     filePath,
+		Language: previous.Language,
+		ParsedAt: time.Now(),
+	}
+	walkImports(root, source, file)
+
+	input := edit.Input
+	lineDelta := int(input.NewEndPosition.Row) - int(input.OldEndPosition.Row)
+	dirty := func(node *sitter.Node) bool {
+		if node.EndPosition().Row >= input.StartPosition.Row && node.StartPosition().Row <= input.NewEndPosition.Row {
+			return true
+		}
+		for _, r := range edit.Changed {
+			if node.StartByte() < r.EndByte && node.EndByte() > r.StartByte {
+				return true
+			}
+		}
+		return false
+	}
+
+	// Previous positions are 0-based (row, column) pairs in the old source.
+	type position struct{ row, column int }
+	before := func(a, b position) bool {
+		return a.row < b.row || (a.row == b.row && a.column < b.column)
+	}
+	within := func(loc Location, start, end position) bool {
+		column := loc.Column - 1
+		if column < 0 {
+			column = 0
+		}
+		p := position{row: loc.Line - 1, column: column}
+		return !before(p, start) && before(p, end)
+	}
+
+	ancestry := []string{root.Kind()}
+	for i := uint(0); i < root.ChildCount(); i++ {
+		node := root.Child(i)
+		if node == nil {
+			continue
+		}
+		if dirty(node) {
+			walkUniversal(node, source, file, ancestry, "")
+			continue
+		}
+
+		shift := 0
+		if node.StartPosition().Row > input.NewEndPosition.Row {
+			shift = lineDelta
+		}
+		start := position{row: int(node.StartPosition().Row) - shift, column: int(node.StartPosition().Column)}
+		end := position{row: int(node.EndPosition().Row) - shift, column: int(node.EndPosition().Column)}
+		for _, def := range previous.Definitions {
+			if within(def.Location, start, end) {
+				def.Location.Line += shift
+				file.Definitions = append(file.Definitions, def)
+			}
+		}
+		for _, ref := range previous.References {
+			if within(ref.Location, start, end) {
+				ref.Location.Line += shift
+				file.References = append(file.References, ref)
+			}
+		}
+	}
 	return file, nil
 }
 
@@ -201,10 +285,27 @@ func walkImports(root *sitter.Node, source []byte, file *File) {
 		case "import_from_statement":
 			// from auth.utils import login  |  from . import mod
 			extractPyFromImportStatement(node, source, file)
+
+		case "if_statement":
+			// if __name__ == "__main__":
+			if file.Language == "python" && isPyMainGuard(node.ChildByFieldName("condition"), source) {
+				file.MainGuard = true
+			}
 		}
 	}
 }
 
+// isPyMainGuard reports whether an if-condition compares __name__ with
+// "__main__" in either order.
+func isPyMainGuard(cond *sitter.Node, source []byte) bool {
+	if cond == nil || cond.Kind() != "comparison_operator" {
+		return false
+	}
+	text := strings.Join(strings.Fields(nodeText(cond, source)), "")
+	text = strings.ReplaceAll(text, "'", `"`)
+	return text == `__name__=="__main__"` || text == `"__main__"==__name__`
+}
+
 // extractGoImportDecl handles Go's import_declaration node (both single and
 // parenthesised forms).
 func extractGoImportDecl(node *sitter.Node, source []byte, file *File) {
@@ -336,7 +437,9 @@ func extractPyAliasedImport(node *sitter.Node, source []byte) (module, alias str
 
 // walkUniversal performs a depth-first traversal of the AST, classifying each
 // node and accumulating tagged symbols into file.References and file.Definitions.
-func walkUniversal(node *sitter.Node, source []byte, file *File, ancestry []string) {
+// caller is the innermost function or method enclosing node, attributed to
+// every reference below it.
+func walkUniversal(node *sitter.Node, source []byte, file *File, ancestry []string, caller string) {
 	if node == nil {
 		return
 	}
@@ -346,6 +449,14 @@ func walkUniversal(node *sitter.Node, source []byte, file *File, ancestry []stri
 
 	// Classify the current node.
 	if tag, ok := classifyNodeKind(kind); ok {
+		if tag == TagSymDef && kind == "type_declaration" {
+			// Go groups type specs under one unnamed declaration.
+			for _, def := range typeSpecDefinitions(node, source, file.Path, ancestryPath) {
+				def.Visibility = universalVisibility(file.Language, node, source, def.Name)
+				def.Exported = universalExported(file.Language, def.Name, def.Visibility)
+				file.Definitions = append(file.Definitions, def)
+			}
+		}
 		confidence := tagConfidence[tag]
 		name := extractNodeName(node, source)
 		if name != "" {
@@ -365,17 +476,25 @@ func walkUniversal(node *sitter.Node, source []byte, file *File, ancestry []stri
 				Confidence: confidence,
 				NodeKind:   kind,
 				Ancestry:   ancestryPath,
+				Caller:     caller,
 				Location:   loc,
 			}
 			if tag == TagSymDef {
 				defKind, ok := definitionKindFromNodeKind(kind)
 				if ok {
+					visibility := universalVisibility(file.Language, node, source, tagged.Name)
 					definition := Definition{
-						Name:     tagged.Name,
-						FullName: tagged.Name,
-						Kind:     defKind,
-						Location: tagged.Location,
-						Scope:    tagged.Ancestry,
+						Name:       tagged.Name,
+						FullName:   tagged.Name,
+						Kind:       defKind,
+						Location:   tagged.Location,
+						Exported:   universalExported(file.Language, tagged.Name, visibility),
+						Visibility: visibility,
+						Scope:      tagged.Ancestry,
+						Decorators: decoratorNames(node, source),
+					}
+					if defKind == KindClass && isAbstractClassNode(node, source) {
+						definition.TypeHint = TypeHintAbstract
 					}
 					if defKind == KindFunction || defKind == KindMethod {
 						branches, params, nesting, locCount := computeFunctionComplexity(node, source)
@@ -383,6 +502,7 @@ func walkUniversal(node *sitter.Node, source []byte, file *File, ancestry []stri
 						definition.ParameterCount = params
 						definition.NestingDepth = nesting
 						definition.LOC = locCount
+						caller = definition.Name
 					}
 					file.Definitions = append(file.Definitions, definition)
 				}
@@ -395,7 +515,7 @@ func walkUniversal(node *sitter.Node, source []byte, file *File, ancestry []stri
 	// Push this node kind onto the ancestry stack for children.
 	nextAncestry := append(ancestry, kind) //nolint:gocritic // intentional append
 	for i := uint(0); i < node.ChildCount(); i++ {
-		walkUniversal(node.Child(i), source, file, nextAncestry)
+		walkUniversal(node.Child(i), source, file, nextAncestry, caller)
 	}
 }
 
@@ -453,6 +573,144 @@ func extractNodeName(node *sitter.Node, source []byte) string {
 	return ""
 }
 
+// universalExported marks "public" definitions as exported. Python dunder
+// names are public but are protocol hooks rather than module exports.
+func universalExported(language, name, visibility string) bool {
+	if language == "python" && strings.HasPrefix(name, "_") {
+		return false
+	}
+	return visibility == "public"
+}
+
+// universalVisibility derives a definition's visibility from each language's
+// conventions: Go capitalization, Python and JavaScript leading underscores,
+// and the Java, TypeScript, and Rust visibility modifiers. Package-private
+// Java and crate-scoped Rust definitions are "internal".
+func universalVisibility(language string, node *sitter.Node, source []byte, name string) string {
+	switch language {
+	case "go":
+		if isExportedName(name) {
+			return "public"
+		}
+		return "private"
+	case "python":
+		if strings.HasPrefix(name, "_") && !(strings.HasPrefix(name, "__") && strings.HasSuffix(name, "__")) {
+			return "private"
+		}
+		return "public"
+	case "javascript", "typescript", "tsx":
+		if modifier := childTextOfKind(node, source, "accessibility_modifier"); modifier == "private" || modifier == "protected" {
+			return "private"
+		}
+		return jsVisibility(name)
+	case "java":
+		return javaModifierVisibility(childTextOfKind(node, source, "modifiers"))
+	case "rust":
+		return rustModifierVisibility(childTextOfKind(node, source, "visibility_modifier"))
+	default:
+		return ""
+	}
+}
+
+// childTextOfKind returns the text of node's first direct child of kind.
+func childTextOfKind(node *sitter.Node, source []byte, kind string) string {
+	for i := uint(0); i < node.ChildCount(); i++ {
+		if child := node.Child(i); child != nil && child.Kind() == kind {
+			return nodeText(child, source)
+		}
+	}
+	return ""
+}
+
+// decoratorNames returns the decorators applied to a Python definition
+// without the leading "@" or call arguments, e.g. "app.route".
+func decoratorNames(node *sitter.Node, source []byte) []string {
+	parent := node.Parent()
+	if parent == nil || parent.Kind() != "decorated_definition" {
+		return nil
+	}
+	var names []string
+	for i := uint(0); i < parent.NamedChildCount(); i++ {
+		child := parent.NamedChild(i)
+		if child == nil || child.Kind() != "decorator" {
+			continue
+		}
+		name := strings.TrimSpace(strings.TrimPrefix(nodeText(child, source), "@"))
+		if idx := strings.Index(name, "("); idx >= 0 {
+			name = strings.TrimSpace(name[:idx])
+		}
+		if name != "" {
+			names = append(names, name)
+		}
+	}
+	return names
+}
+
+// typeSpecDefinitions returns one definition per Go type_spec under a
+// type_declaration; interface types are reported as KindInterface.
+func typeSpecDefinitions(node *sitter.Node, source []byte, path, ancestry string) []Definition {
+	defs := make([]Definition, 0, 1)
+	for i := uint(0); i < node.NamedChildCount(); i++ {
+		spec := node.NamedChild(i)
+		if spec == nil || (spec.Kind() != "type_spec" && spec.Kind() != "type_alias") {
+			continue
+		}
+		name := nodeText(spec.ChildByFieldName("name"), source)
+		if name == "" {
+			continue
+		}
+		kind := KindType
+		if typ := spec.ChildByFieldName("type"); typ != nil && typ.Kind() == "interface_type" {
+			kind = KindInterface
+		}
+		defs = append(defs, Definition{
+			Name:     name,
+			FullName: name,
+			Kind:     kind,
+			Location: Location{
+				File:   path,
+				Line:   int(spec.StartPosition().Row) + 1,
+				Column: int(spec.StartPosition().Column) + 1,
+			},
+			Scope: ancestry,
+		})
+	}
+	return defs
+}
+
+// isAbstractClassNode reports whether a class node declares an abstract type:
+// an abstract modifier (Java, C#, PHP, TypeScript) or, for Python, an
+// ABC/ABCMeta/Protocol base.
+func isAbstractClassNode(node *sitter.Node, source []byte) bool {
+	if node.Kind() == "abstract_class_declaration" {
+		return true
+	}
+	if supers := node.ChildByFieldName("superclasses"); supers != nil {
+		for _, word := range strings.FieldsFunc(nodeText(supers, source), func(r rune) bool {
+			return r == '(' || r == ')' || r == ',' || r == '=' || r == ' ' || r == '.'
+		}) {
+			switch word {
+			case "ABC", "ABCMeta", "Protocol":
+				return true
+			}
+		}
+	}
+	for i := uint(0); i < node.ChildCount(); i++ {
+		child := node.Child(i)
+		if child == nil {
+			continue
+		}
+		if kind := child.Kind(); kind == "modifiers" || kind == "modifier" || kind == "abstract_modifier" {
+			for _, word := range strings.Fields(nodeText(child, source)) {
+				if word == "abstract" {
+					return true
+				}
+			}
+		}
+	}
+	return false
+}
+
 // nodeText returns the source bytes spanned by a node as a trimmed string.
 func nodeText(node *sitter.Node, source []byte) string {
 	if node == nil {
@@ -495,6 +753,7 @@ func applyTaggedSymbol(file *File, t TaggedSymbol) {
 			Name:     t.Name,
 			Location: t.Location,
 			Context:  context,
+			Caller:   t.Caller,
 		})
 	default:
 		// All reference tags are stored in References.
@@ -502,6 +761,7 @@ func applyTaggedSymbol(file *File, t TaggedSymbol) {
 			Name:     t.Name,
 			Location: t.Location,
 			Context:  string(t.Tag) + "|" + t.Ancestry,
+			Caller:   t.Caller,
 		})
 	}
 }
@@ -524,9 +784,10 @@ var methodNodeKinds = map[string]struct{}{
 }
 
 var classNodeKinds = map[string]struct{}{
-	"class_declaration": {},
-	"class_definition":  {},
-	"class_specifier":   {},
+	"class_declaration":          {},
+	"abstract_class_declaration": {},
+	"class_definition":           {},
+	"class_specifier":            {},
 }
 
 var interfaceNodeKinds = map[string]struct{}{
@@ -2,8 +2,15 @@
 package parser
 
 import (
+	"reflect"
 	"strings"
 	"testing"
+
+	sitter "github.com/tree-sitter/go-tree-sitter"
+	tree_sitter_go "github.com/tree-sitter/tree-sitter-go/bindings/go"
+	tree_sitter_java "github.com/tree-sitter/tree-sitter-java/bindings/go"
+	tree_sitter_python "github.com/tree-sitter/tree-sitter-python/bindings/go"
+	tree_sitter_typescript "github.com/tree-sitter/tree-sitter-typescript/bindings/go"
 )
 
 // TestClassifyNodeKind ensures all five tag types are reachable via the regex tiers.
@@ -117,3 +124,114 @@ func TestExtractNodeName_FallbackToLeaf(t *testing.T) {
 		t.Errorf("expected empty for nil node, got %q", got)
 	}
 }
+
+// parseWithGrammar parses source directly with a grammar, bypassing the
+// registry so helper functions can be tested per language.
+func parseWithGrammar(t *testing.T, grammar *sitter.Language, source []byte) *sitter.Tree {
+	t.Helper()
+	parser := sitter.NewParser()
+	t.Cleanup(parser.Close)
+	if err := parser.SetLanguage(grammar); err != nil {
+		t.Fatal(err)
+	}
+	tree := parser.Parse(source, nil)
+	if tree == nil {
+		t.Fatal("parse returned no tree")
+	}
+	t.Cleanup(tree.Close)
+	return tree
+}
+
+// TestTypeSpecDefinitions checks that every spec of a Go type declaration,
+// grouped or not, becomes a definition and interfaces are told apart.
+func TestTypeSpecDefinitions(t *testing.T) {
+	source := []byte("package types\n\ntype Reader interface{ Read() }\n\ntype (\n\tBuffer struct{}\n\tSize int\n\tAlias = Buffer\n\tempty interface{}\n)\n")
+	tree := parseWithGrammar(t, sitter.NewLanguage(tree_sitter_go.Language()), source)
+
+	type def struct {
+		Name string
+		Kind DefinitionKind
+		Line int
+	}
+	var got []def
+	root := tree.RootNode()
+	for i := uint(0); i < root.NamedChildCount(); i++ {
+		node := root.NamedChild(i)
+		if node.Kind() != "type_declaration" {
+			continue
+		}
+		for _, d := range typeSpecDefinitions(node, source, "types.go", "") {
+			if d.Location.File != "types.go" || d.FullName != d.Name {
+				t.Fatalf("unexpected definition metadata %+v", d)
+			}
+			got = append(got, def{d.Name, d.Kind, d.Location.Line})
+		}
+	}
+	want := []def{
+		{"Reader", KindInterface, 3},
+		{"Buffer", KindType, 6},
+		{"Size", KindType, 7},
+		{"Alias", KindType, 8},
+		{"empty", KindInterface, 9},
+	}
+	if !reflect.DeepEqual(got, want) {
+		t.Fatalf("unexpected type definitions\n got: %+v\nwant: %+v", got, want)
+	}
+}
+
+// TestIsAbstractClassNode checks abstract class detection for each language
+// that declares abstract types on the class node.
+func TestIsAbstractClassNode(t *testing.T) {
+	tests := []struct {
+		name    string
+		grammar *sitter.Language
+		code    string
+		want    map[string]bool
+	}{
+		{
+			name:    "java",
+			grammar: sitter.NewLanguage(tree_sitter_java.Language()),
+			code:    "public abstract class Shape {}\nfinal class Circle extends Shape {}\nclass Square {}\n",
+			want:    map[string]bool{"Shape": true, "Circle": false, "Square": false},
+		},
+		{
+			name:    "typescript",
+			grammar: sitter.NewLanguage(tree_sitter_typescript.LanguageTypescript()),
+			code:    "export abstract class Shape {}\nexport class Circle extends Shape {}\n",
+			want:    map[string]bool{"Shape": true, "Circle": false},
+		},
+		{
+			name:    "python",
+			grammar: sitter.NewLanguage(tree_sitter_python.Language()),
+			code: "import abc\nfrom typing import Protocol\n\n" +
+				"class Base(abc.ABC):\n    pass\n\n" +
+				"class Meta(metaclass=abc.ABCMeta):\n    pass\n\n" +
+				"class Readable(Protocol):\n    pass\n\n" +
+				"class Impl(Base):\n    pass\n\n" +
+				"class Plain:\n    pass\n",
+			want: map[string]bool{"Base": true, "Meta": true, "Readable": true, "Impl": false, "Plain": false},
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			source := []byte(tc.code)
+			tree := parseWithGrammar(t, tc.grammar, source)
+
+			got := make(map[string]bool)
+			var walk func(node *sitter.Node)
+			walk = func(node *sitter.Node) {
+				if _, ok := classNodeKinds[node.Kind()]; ok {
+					got[nodeText(node.ChildByFieldName("name"), source)] = isAbstractClassNode(node, source)
+				}
+				for i := uint(0); i < node.NamedChildCount(); i++ {
+					walk(node.NamedChild(i))
+				}
+			}
+			walk(tree.RootNode())
+			if !reflect.DeepEqual(got, tc.want) {
+				t.Fatalf("unexpected abstract classes\n got: %v\nwant: %v", got, tc.want)
+			}
+		})
+	}
+}
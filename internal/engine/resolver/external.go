@@ -0,0 +1,211 @@
+package resolver
+
+// internal/engine/resolver/external.go
+
+import (
+	"circular/internal/engine/parser"
+	"sort"
+	"strings"
+)
+
+// ExternalPackageResolver maps imports to the third-party packages providing
+// them, preferring packages declared in dependency manifests.
+type ExternalPackageResolver struct {
+	goModules   []string            // go.mod and go.sum module paths, longest first
+	mavenGroups map[string][]string // groupId -> sorted groupId:artifactId names
+	groupOrder  []string            // groupIds, longest first
+	declared    map[string]map[string]string
+	own         map[string]map[string]bool // ecosystem -> the project's own names
+}
+
+// NewExternalPackageResolver indexes the declared dependencies and own module
+// names of the given manifests.
+func NewExternalPackageResolver(manifests []parser.DependencyManifest) *ExternalPackageResolver {
+	r := &ExternalPackageResolver{
+		mavenGroups: make(map[string][]string),
+		declared:    make(map[string]map[string]string),
+		own:         make(map[string]map[string]bool),
+	}
+	goSeen := make(map[string]bool)
+	for _, manifest := range manifests {
+		if manifest.Module != "" {
+			if r.own[manifest.Ecosystem] == nil {
+				r.own[manifest.Ecosystem] = make(map[string]bool)
+			}
+			r.own[manifest.Ecosystem][normalizePackageName(manifest.Ecosystem, manifest.Module)] = true
+		}
+		for _, dep := range manifest.Dependencies {
+			switch manifest.Ecosystem {
+			case parser.EcosystemGo:
+				if !goSeen[dep.Name] {
+					goSeen[dep.Name] = true
+					r.goModules = append(r.goModules, dep.Name)
+				}
+				continue
+			case parser.EcosystemMaven:
+				group, _, _ := strings.Cut(dep.Name, ":")
+				if len(r.mavenGroups[group]) == 0 {
+					r.groupOrder = append(r.groupOrder, group)
+				}
+				r.mavenGroups[group] = append(r.mavenGroups[group], dep.Name)
+				continue
+			}
+			if r.declared[manifest.Ecosystem] == nil {
+				r.declared[manifest.Ecosystem] = make(map[string]string)
+			}
+			normalized := normalizePackageName(manifest.Ecosystem, dep.Name)
+			if _, ok := r.declared[manifest.Ecosystem][normalized]; !ok {
+				r.declared[manifest.Ecosystem][normalized] = dep.Name
+			}
+		}
+	}
+	byLength := func(names []string) {
+		sort.Slice(names, func(i, j int) bool {
+			if len(names[i]) != len(names[j]) {
+				return len(names[i]) > len(names[j])
+			}
+			return names[i] < names[j]
+		})
+	}
+	byLength(r.goModules)
+	byLength(r.groupOrder)
+	for _, names := range r.mavenGroups {
+		sort.Strings(names)
+	}
+	return r
+}
+
+// Resolve returns the package an import belongs to. Standard library,
+// relative, and own-project imports are rejected; imports matching no
+// declared dependency fall back to a name guessed from the import path.
+func (r *ExternalPackageResolver) Resolve(language, importPath string) (string, string, bool) {
+	importPath = strings.TrimSpace(importPath)
+	if importPath == "" {
+		return "", "", false
+	}
+	switch language {
+	case "go":
+		return r.resolveGo(importPath)
+	case "python":
+		return r.resolveNamed(parser.EcosystemPyPI, importPath, ".", pythonStdlib)
+	case "javascript", "typescript", "tsx":
+		return r.resolveJavaScript(importPath)
+	case "java":
+		return r.resolveJava(importPath)
+	case "rust":
+		return r.resolveNamed(parser.EcosystemCargo, importPath, "::", rustStdlib)
+	default:
+		return "", "", false
+	}
+}
+
+func (r *ExternalPackageResolver) resolveGo(importPath string) (string, string, bool) {
+	if importPath == "C" || goStdlib[importPath] {
+		return "", "", false
+	}
+	for own := range r.own[parser.EcosystemGo] {
+		if hasPathPrefix(importPath, own, "/") {
+			return "", "", false
+		}
+	}
+	for _, module := range r.goModules {
+		if hasPathPrefix(importPath, module, "/") {
+			return parser.EcosystemGo, module, true
+		}
+	}
+	segments := strings.Split(importPath, "/")
+	if !strings.Contains(segments[0], ".") {
+		return "", "", false
+	}
+	switch segments[0] {
+	case "github.com", "gitlab.com", "bitbucket.org":
+		if len(segments) > 3 {
+			segments = segments[:3]
+		}
+	}
+	return parser.EcosystemGo, strings.Join(segments, "/"), true
+}
+
+// resolveNamed handles ecosystems whose imports start with the package name:
+// Python (`yaml.loader`) and Rust (`serde::Serialize`).
+func (r *ExternalPackageResolver) resolveNamed(ecosystem, importPath, sep string, stdlib map[string]bool) (string, string, bool) {
+	if strings.HasPrefix(importPath, ".") {
+		return "", "", false
+	}
+	top, _, _ := strings.Cut(importPath, sep)
+	switch top {
+	case "", "crate", "self", "super":
+		return "", "", false
+	}
+	if stdlib[top] {
+		return "", "", false
+	}
+	return r.matchDeclared(ecosystem, top)
+}
+
+func (r *ExternalPackageResolver) resolveJavaScript(importPath string) (string, string, bool) {
+	if strings.HasPrefix(importPath, ".") || strings.HasPrefix(importPath, "/") || strings.HasPrefix(importPath, "node:") {
+		return "", "", false
+	}
+	segments := strings.Split(importPath, "/")
+	name := segments[0]
+	if strings.HasPrefix(name, "@") && len(segments) > 1 {
+		name += "/" + segments[1]
+	}
+	if javascriptStdlib[name] {
+		return "", "", false
+	}
+	return r.matchDeclared(parser.EcosystemNPM, name)
+}
+
+func (r *ExternalPackageResolver) resolveJava(importPath string) (string, string, bool) {
+	segments := strings.Split(strings.TrimPrefix(importPath, "static "), ".")
+	if javaStdlib[segments[0]] {
+		return "", "", false
+	}
+	path := strings.Join(segments, ".")
+	for own := range r.own[parser.EcosystemMaven] {
+		group, _, _ := strings.Cut(own, ":")
+		if hasPathPrefix(path, group, ".") {
+			return "", "", false
+		}
+	}
+	for _, group := range r.groupOrder {
+		if hasPathPrefix(path, group, ".") {
+			return parser.EcosystemMaven, r.mavenGroups[group][0], true
+		}
+	}
+	if len(segments) > 3 {
+		segments = segments[:3]
+	}
+	return parser.EcosystemMaven, strings.Join(segments, "."), true
+}
+
+func (r *ExternalPackageResolver) matchDeclared(ecosystem, name string) (string, string, bool) {
+	normalized := normalizePackageName(ecosystem, name)
+	if r.own[ecosystem][normalized] {
+		return "", "", false
+	}
+	if declared, ok := r.declared[ecosystem][normalized]; ok {
+		return ecosystem, declared, true
+	}
+	return ecosystem, name, true
+}
+
+// normalizePackageName folds the spellings an ecosystem treats as equal:
+// PyPI names are case-insensitive with `-`, `_`, and `.` interchangeable, and
+// Cargo crates are imported with `-` written as `_`.
+func normalizePackageName(ecosystem, name string) string {
+	switch ecosystem {
+	case parser.EcosystemPyPI:
+		return strings.NewReplacer("-", "_", ".", "_").Replace(strings.ToLower(name))
+	case parser.EcosystemCargo:
+		return strings.ReplaceAll(name, "-", "_")
+	default:
+		return name
+	}
+}
+
+func hasPathPrefix(path, prefix, sep string) bool {
+	return path == prefix || strings.HasPrefix(path, prefix+sep)
+}
@@ -0,0 +1,59 @@
+package resolver
+
+import (
+	"circular/internal/engine/parser"
+	"testing"
+)
+
+func TestExternalPackageResolver_Resolve(t *testing.T) {
+	r := NewExternalPackageResolver([]parser.DependencyManifest{
+		{Path: "go.mod", Ecosystem: parser.EcosystemGo, Module: "example.com/app", Dependencies: []parser.DeclaredDependency{
+			{Name: "github.com/aws/aws-sdk-go-v2"},
+			{Name: "github.com/aws/aws-sdk-go-v2/service/s3"},
+		}},
+		{Path: "requirements.txt", Ecosystem: parser.EcosystemPyPI, Dependencies: []parser.DeclaredDependency{{Name: "Typing-Extensions"}}},
+		{Path: "package.json", Ecosystem: parser.EcosystemNPM, Module: "web", Dependencies: []parser.DeclaredDependency{{Name: "@scope/ui"}}},
+		{Path: "pom.xml", Ecosystem: parser.EcosystemMaven, Module: "com.acme:shop", Dependencies: []parser.DeclaredDependency{
+			{Name: "com.google.guava:guava"},
+			{Name: "com.google.guava:failureaccess"},
+		}},
+		{Path: "Cargo.toml", Ecosystem: parser.EcosystemCargo, Module: "cli-tool", Dependencies: []parser.DeclaredDependency{{Name: "serde-json"}}},
+	})
+
+	tests := []struct {
+		language, importPath string
+		ecosystem, name      string
+		ok                   bool
+	}{
+		{"go", "fmt", "", "", false},
+		{"go", "C", "", "", false},
+		{"go", "example.com/app/internal/core", "", "", false},
+		{"go", "github.com/aws/aws-sdk-go-v2/service/s3/types", parser.EcosystemGo, "github.com/aws/aws-sdk-go-v2/service/s3", true},
+		{"go", "github.com/aws/aws-sdk-go-v2/aws", parser.EcosystemGo, "github.com/aws/aws-sdk-go-v2", true},
+		{"go", "github.com/acme/tool/pkg/x", parser.EcosystemGo, "github.com/acme/tool", true},
+		{"go", "internal/app", "", "", false},
+		{"python", "os.path", "", "", false},
+		{"python", ".sibling", "", "", false},
+		{"python", "typing_extensions", parser.EcosystemPyPI, "Typing-Extensions", true},
+		{"python", "yaml.loader", parser.EcosystemPyPI, "yaml", true},
+		{"javascript", "./local", "", "", false},
+		{"javascript", "node:fs", "", "", false},
+		{"typescript", "@scope/ui/button", parser.EcosystemNPM, "@scope/ui", true},
+		{"tsx", "react-dom/client", parser.EcosystemNPM, "react-dom", true},
+		{"java", "java.util.List", "", "", false},
+		{"java", "com.acme.shop.Cart", "", "", false},
+		{"java", "com.google.guava.collect.ImmutableList", parser.EcosystemMaven, "com.google.guava:failureaccess", true},
+		{"java", "org.slf4j.Logger", parser.EcosystemMaven, "org.slf4j.Logger", true},
+		{"rust", "crate::config", "", "", false},
+		{"rust", "std::collections::HashMap", "", "", false},
+		{"rust", "serde_json::Value", parser.EcosystemCargo, "serde-json", true},
+		{"rust", "cli_tool::run", "", "", false},
+		{"haskell", "Data.List", "", "", false},
+	}
+	for _, tt := range tests {
+		ecosystem, name, ok := r.Resolve(tt.language, tt.importPath)
+		if ok != tt.ok || ecosystem != tt.ecosystem || name != tt.name {
+			t.Errorf("Resolve(%q, %q) = (%q, %q, %v), want (%q, %q, %v)", tt.language, tt.importPath, ecosystem, name, ok, tt.ecosystem, tt.name, tt.ok)
+		}
+	}
+}
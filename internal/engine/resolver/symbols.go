@@ -0,0 +1,158 @@
+package resolver
+
+import (
+	"circular/internal/engine/graph"
+	"circular/internal/engine/parser"
+	"circular/internal/shared/observability"
+	"context"
+	"fmt"
+	"strings"
+)
+
+// LinkSymbols resolves every file's references to concrete definitions and
+// stores the resulting edges in the graph's symbol layer.
+func (r *Resolver) LinkSymbols(ctx context.Context) int {
+	ctx, span := observability.Tracer.Start(ctx, "Resolver.LinkSymbols")
+	defer span.End()
+
+	count := 0
+	for _, file := range r.graph.GetAllFiles() {
+		if ctx.Err() != nil {
+			return count
+		}
+		edges := r.linkFileSymbols(file)
+		r.graph.SetSymbolEdges(file.Path, edges)
+		count += len(edges)
+	}
+	return count
+}
+
+// LinkSymbolsForPaths relinks only the given files, leaving other edges intact.
+func (r *Resolver) LinkSymbolsForPaths(ctx context.Context, paths []string) int {
+	ctx, span := observability.Tracer.Start(ctx, "Resolver.LinkSymbolsForPaths")
+	defer span.End()
+
+	count := 0
+	seen := make(map[string]bool, len(paths))
+	for _, path := range paths {
+		if ctx.Err() != nil {
+			return count
+		}
+		if seen[path] {
+			continue
+		}
+		seen[path] = true
+
+		file, ok := r.graph.GetFile(path)
+		if !ok {
+			continue
+		}
+		edges := r.linkFileSymbols(file)
+		r.graph.SetSymbolEdges(file.Path, edges)
+		count += len(edges)
+	}
+	return count
+}
+
+func (r *Resolver) linkFileSymbols(file *parser.File) []graph.SymbolEdge {
+	edges := make([]graph.SymbolEdge, 0)
+	seen := make(map[string]bool)
+	for _, ref := range file.References {
+		if r.isLocalSymbol(file, ref.Name) {
+			continue
+		}
+		target, ok := r.resolveSymbolTarget(file, ref)
+		if !ok {
+			continue
+		}
+
+		from := graph.SymbolKey{Module: file.Module, Name: ref.Caller}
+		if from.Name == "" {
+			if def, ok := graph.EnclosingDefinition(file, ref.Location.Line); ok {
+				from.Name = def.Name
+			}
+		}
+		if from == target {
+			continue
+		}
+
+		key := fmt.Sprintf("%s|%s|%d:%d", from, target, ref.Location.Line, ref.Location.Column)
+		if seen[key] {
+			continue
+		}
+		seen[key] = true
+		edges = append(edges, graph.SymbolEdge{
+			From:     from,
+			To:       target,
+			File:     file.Path,
+			Location: ref.Location,
+		})
+	}
+	return edges
+}
+
+// resolveSymbolTarget mirrors resolveQualifiedReference but returns the
+// definition the reference lands on instead of a yes/no answer.
+func (r *Resolver) resolveSymbolTarget(file *parser.File, ref parser.Reference) (graph.SymbolKey, bool) {
+	if name, ok := r.findModuleDefinition(file.Module, ref.Name, true); ok {
+		return graph.SymbolKey{Module: file.Module, Name: name}, true
+	}
+
+	for _, imp := range file.Imports {
+		if !r.graph.HasDefinitions(imp.Module) {
+			continue
+		}
+		modBase := parser.ModuleReferenceBase(file.Language, imp.Module)
+
+		symbolName := ""
+		if imp.Alias != "" && strings.HasPrefix(ref.Name, imp.Alias+".") {
+			symbolName = strings.TrimPrefix(ref.Name, imp.Alias+".")
+		} else if modBase != "" && strings.HasPrefix(ref.Name, modBase+".") {
+			symbolName = strings.TrimPrefix(ref.Name, modBase+".")
+		}
+		if symbolName != "" {
+			if name, ok := r.findModuleDefinition(imp.Module, symbolName, false); ok {
+				return graph.SymbolKey{Module: imp.Module, Name: name}, true
+			}
+		}
+
+		for _, item := range imp.Items {
+			if ref.Name != item && !strings.HasPrefix(ref.Name, item+".") {
+				continue
+			}
+			if name, ok := r.findModuleDefinition(imp.Module, ref.Name, false); ok {
+				return graph.SymbolKey{Module: imp.Module, Name: name}, true
+			}
+		}
+	}
+
+	return graph.SymbolKey{}, false
+}
+
+// findModuleDefinition returns the definition key a (possibly dotted) symbol
+// refers to. "Type.Method" resolves to Method when the module defines it and
+// falls back to Type otherwise. Without allowUnexported, definitions must be
+// exported or, for languages whose extractor leaves Exported unset, not
+// private.
+func (r *Resolver) findModuleDefinition(moduleName, symbolName string, allowUnexported bool) (string, bool) {
+	if !r.graph.HasDefinitions(moduleName) {
+		return "", false
+	}
+
+	accept := func(name string) bool {
+		def, ok := r.graph.LookupDefinition(moduleName, name)
+		return ok && (allowUnexported || def.Exported || def.Visibility != "private")
+	}
+
+	if accept(symbolName) {
+		return symbolName, true
+	}
+	parts := strings.Split(symbolName, ".")
+	if len(parts) < 2 || !accept(parts[0]) {
+		return "", false
+	}
+	if last := parts[len(parts)-1]; accept(last) {
+		return last, true
+	}
+	return parts[0], true
+}
@@ -0,0 +1,167 @@
+package resolver
+
+import (
+	"circular/internal/engine/graph"
+	"circular/internal/engine/parser"
+	"context"
+	"reflect"
+	"sort"
+	"testing"
+)
+
+func TestResolver_LinkSymbols(t *testing.T) {
+	g := graph.NewGraph()
+	g.AddFile(&parser.File{
+		Path:     "store/store.go",
+		Language: "go",
+		Module:   "example.com/app/store",
+		Definitions: []parser.Definition{
+			{Name: "Store", Kind: parser.KindType, Exported: true, Location: parser.Location{File: "store/store.go", Line: 3}},
+			{Name: "Open", Kind: parser.KindFunction, Exported: true, Location: parser.Location{File: "store/store.go", Line: 8}, LOC: 4},
+			{Name: "Close", Kind: parser.KindMethod, Exported: true, Location: parser.Location{File: "store/store.go", Line: 14}, LOC: 3},
+		},
+	})
+	g.AddFile(&parser.File{
+		Path:     "api/handler.go",
+		Language: "go",
+		Module:   "example.com/app/api",
+		Imports: []parser.Import{
+			{Module: "example.com/app/store", Location: parser.Location{File: "api/handler.go", Line: 3}},
+		},
+		Definitions: []parser.Definition{
+			{Name: "Serve", Kind: parser.KindFunction, Exported: true, Location: parser.Location{File: "api/handler.go", Line: 6}, LOC: 10},
+			{Name: "helper", Kind: parser.KindFunction, Location: parser.Location{File: "api/handler.go", Line: 20}, LOC: 3},
+		},
+		References: []parser.Reference{
+			{Name: "store.Open", Location: parser.Location{File: "api/handler.go", Line: 7}},
+			{Name: "store.Store.Close", Location: parser.Location{File: "api/handler.go", Line: 8}},
+			{Name: "helper", Location: parser.Location{File: "api/handler.go", Line: 9}},
+			{Name: "fmt.Println", Location: parser.Location{File: "api/handler.go", Line: 10}},
+			{Name: "conn.Close", Location: parser.Location{File: "api/handler.go", Line: 11}},
+		},
+		LocalSymbols: []string{"conn"},
+	})
+
+	r := NewResolver(g, nil, nil)
+	count := r.LinkSymbols(context.Background())
+	if count != 3 {
+		t.Fatalf("expected 3 symbol edges, got %d: %+v", count, g.SymbolEdges())
+	}
+
+	serve := graph.SymbolKey{Module: "example.com/app/api", Name: "Serve"}
+	deps := g.SymbolDependencies(serve)
+	targets := make(map[string]bool, len(deps))
+	for _, dep := range deps {
+		targets[dep.To.String()] = true
+	}
+	for _, want := range []string{"example.com/app/store#Open", "example.com/app/store#Close", "example.com/app/api#helper"} {
+		if !targets[want] {
+			t.Fatalf("expected edge to %s, got %+v", want, deps)
+		}
+	}
+
+	if pending := g.PendingSymbolFiles(); len(pending) != 0 {
+		t.Fatalf("expected all files linked, got pending %v", pending)
+	}
+}
+
+func TestResolver_LinkSymbols_PrefersReferenceCaller(t *testing.T) {
+	g := graph.NewGraph()
+	g.AddFile(&parser.File{
+		Path:     "store/store.go",
+		Language: "go",
+		Module:   "example.com/app/store",
+		Definitions: []parser.Definition{
+			{Name: "Open", Kind: parser.KindFunction, Exported: true, Location: parser.Location{File: "store/store.go", Line: 3}},
+		},
+	})
+	g.AddFile(&parser.File{
+		Path:     "api/handler.go",
+		Language: "go",
+		Module:   "example.com/app/api",
+		Imports: []parser.Import{
+			{Module: "example.com/app/store", Location: parser.Location{File: "api/handler.go", Line: 3}},
+		},
+		Definitions: []parser.Definition{
+			// No LOC: the line span alone cannot place the calls below.
+			{Name: "Serve", Kind: parser.KindFunction, Exported: true, Location: parser.Location{File: "api/handler.go", Line: 6}},
+		},
+		References: []parser.Reference{
+			{Name: "store.Open", Caller: "Serve", Location: parser.Location{File: "api/handler.go", Line: 7}},
+			{Name: "store.Open", Location: parser.Location{File: "api/handler.go", Line: 12}},
+		},
+	})
+
+	r := NewResolver(g, nil, nil)
+	r.LinkSymbols(context.Background())
+
+	edges := g.SymbolEdges()
+	if len(edges) != 2 {
+		t.Fatalf("expected 2 symbol edges, got %+v", edges)
+	}
+	if got := edges[0].From.String(); got != "example.com/app/api#Serve" {
+		t.Fatalf("expected caller attribution to Serve, got %s", got)
+	}
+	if got := edges[1].From.String(); got != "example.com/app/api" {
+		t.Fatalf("expected module-level attribution without caller, got %s", got)
+	}
+}
+
+func TestResolver_LinkSymbols_ParsedTypeScript(t *testing.T) {
+	trueVal := true
+	registry, err := parser.BuildLanguageRegistry(map[string]parser.LanguageOverride{
+		"typescript": {Enabled: &trueVal},
+	}, nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	loader, err := parser.NewGrammarLoaderWithRegistry("./grammars", registry, false)
+	if err != nil {
+		t.Fatal(err)
+	}
+	p := parser.NewParser(loader)
+	if err := p.RegisterDefaultExtractors(); err != nil {
+		t.Fatal(err)
+	}
+
+	g := graph.NewGraph()
+	sources := []struct {
+		path, module, code string
+		imports            []parser.Import
+	}{
+		{
+			path:   "src/store.ts",
+			module: "app/store",
+			code:   "export function openStore(): void {}\nexport class Store {\n  private reset(): void {}\n  flush(): void {}\n}\n",
+		},
+		{
+			path:   "src/api.ts",
+			module: "app/api",
+			code:   "import * as store from \"./store\";\nexport function serve(): void {\n  store.openStore();\n  store.Store.reset();\n  store.Store.flush();\n}\n",
+			// Module resolution maps the relative import onto the store module.
+			imports: []parser.Import{{Module: "app/store", Alias: "store"}},
+		},
+	}
+	for _, src := range sources {
+		file, err := p.ParseFile(src.path, []byte(src.code))
+		if err != nil {
+			t.Fatal(err)
+		}
+		file.Module = src.module
+		file.Imports = src.imports
+		g.AddFile(file)
+	}
+
+	NewResolver(g, nil, nil).LinkSymbols(context.Background())
+	var targets []string
+	for _, dep := range g.SymbolDependencies(graph.SymbolKey{Module: "app/api", Name: "serve"}) {
+		targets = append(targets, dep.To.String())
+	}
+	// The private reset method is not reachable from another module, so
+	// store.Store.reset falls back to the class.
+	want := []string{"app/store#Store", "app/store#flush", "app/store#openStore"}
+	sort.Strings(targets)
+	if !reflect.DeepEqual(targets, want) {
+		t.Fatalf("unexpected cross-module edges\n got: %v\nwant: %v", targets, want)
+	}
+}
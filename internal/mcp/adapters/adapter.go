@@ -4,6 +4,8 @@ import (
 	domainErrors "circular/internal/core/errors"
 	"circular/internal/core/ports"
 	"circular/internal/data/history"
+	"circular/internal/data/query"
+	"circular/internal/engine/graph"
 	"circular/internal/engine/parser"
 	"circular/internal/engine/secrets"
 	"circular/internal/mcp/contracts"
@@ -145,13 +147,71 @@ func (a *Adapter) Cycles(ctx context.Context, limit int) (contracts.GraphCyclesO
 	if err != nil {
 		return contracts.GraphCyclesOutput{}, err
 	}
+	plans, err := a.analysis.SuggestCycleCuts(ctx, limit)
+	if err != nil {
+		return contracts.GraphCyclesOutput{}, err
+	}
 
 	return contracts.GraphCyclesOutput{
-		CycleCount: count,
-		Cycles:     cycles,
+		CycleCount:    count,
+		Cycles:        cycles,
+		SuggestedCuts: cycleCutPlans(plans),
 	}, nil
 }
 
+func (a *Adapter) Simulate(ctx context.Context, ops []contracts.SimulationOperation) (contracts.GraphSimulateOutput, error) {
+	if err := ctx.Err(); err != nil {
+		return contracts.GraphSimulateOutput{}, err
+	}
+
+	a.mu.RLock()
+	defer a.mu.RUnlock()
+	if a.analysis == nil {
+		return contracts.GraphSimulateOutput{}, fmt.Errorf("analysis service unavailable")
+	}
+
+	simOps := make([]graph.SimulationOp, 0, len(ops))
+	for _, op := range ops {
+		simOps = append(simOps, graph.SimulationOp{
+			Kind:   op.Op,
+			File:   op.File,
+			Module: op.Module,
+			Glob:   op.Glob,
+			From:   op.From,
+			To:     op.To,
+		})
+	}
+	result, err := a.analysis.SimulateRefactor(ctx, simOps)
+	if err != nil {
+		return contracts.GraphSimulateOutput{}, err
+	}
+
+	out := contracts.GraphSimulateOutput{
+		ModulesBefore:     result.ModulesBefore,
+		ModulesAfter:      result.ModulesAfter,
+		CyclesBefore:      result.CyclesBefore,
+		CyclesAfter:       result.CyclesAfter,
+		CyclesAdded:       result.CyclesAdded,
+		CyclesRemoved:     result.CyclesRemoved,
+		ViolationsAdded:   simulatedViolations(result.ViolationsAdded, result.RuleViolationsAdded),
+		ViolationsRemoved: simulatedViolations(result.ViolationsRemoved, result.RuleViolationsRemoved),
+	}
+	for _, delta := range result.MetricDeltas {
+		out.MetricDeltas = append(out.MetricDeltas, contracts.ModuleMetricDelta{
+			Module:       delta.Module,
+			FanInBefore:  delta.Before.FanIn,
+			FanInAfter:   delta.After.FanIn,
+			FanOutBefore: delta.Before.FanOut,
+			FanOutAfter:  delta.After.FanOut,
+			DepthBefore:  delta.Before.Depth,
+			DepthAfter:   delta.After.Depth,
+			Added:        delta.Added,
+			Removed:      delta.Removed,
+		})
+	}
+	return out, nil
+}
+
 func (a *Adapter) ListModules(ctx context.Context, filter string, limit int) (contracts.QueryModulesOutput, error) {
 	if err := ctx.Err(); err != nil {
 		return contracts.QueryModulesOutput{}, err
@@ -212,11 +272,18 @@ func (a *Adapter) ModuleDetails(ctx context.Context, module string) (contracts.Q
 			ExportedSymbols:     append([]string(nil), details.ExportedSymbols...),
 			Dependencies:        deps,
 			ReverseDependencies: append([]string(nil), details.ReverseDependencies...),
+			Coupling: contracts.CouplingMetrics{
+				Afferent:     details.Coupling.Afferent,
+				Efferent:     details.Coupling.Efferent,
+				Instability:  details.Coupling.Instability,
+				Abstractness: details.Coupling.Abstractness,
+				Distance:     details.Coupling.Distance,
+			},
 		},
 	}, nil
 }
 
-func (a *Adapter) Trace(ctx context.Context, from, to string, maxDepth int) (contracts.QueryTraceOutput, error) {
+func (a *Adapter) Trace(ctx context.Context, from, to string, maxDepth, paths int) (contracts.QueryTraceOutput, error) {
 	if err := ctx.Err(); err != nil {
 		return contracts.QueryTraceOutput{}, err
 	}
@@ -225,6 +292,37 @@ func (a *Adapter) Trace(ctx context.Context, from, to string, maxDepth int) (con
 		return contracts.QueryTraceOutput{}, fmt.Errorf("analysis service unavailable")
 	}
 
+	if paths > 0 {
+		result, err := svc.DependencyPaths(ctx, from, to, paths, maxDepth)
+		if err != nil {
+			return contracts.QueryTraceOutput{}, err
+		}
+		out := contracts.QueryTraceOutput{Paths: make([]contracts.TracePath, 0, len(result.Paths))}
+		for _, path := range result.Paths {
+			hops := make([]contracts.TraceHop, 0, len(path.Hops))
+			for _, hop := range path.Hops {
+				hops = append(hops, contracts.TraceHop{
+					From:   hop.From,
+					To:     hop.To,
+					File:   hop.File,
+					Line:   hop.Line,
+					Column: hop.Column,
+				})
+			}
+			out.Paths = append(out.Paths, contracts.TracePath{
+				Path:  append([]string(nil), path.Path...),
+				Depth: path.Depth,
+				Hops:  hops,
+			})
+		}
+		if len(out.Paths) > 0 {
+			out.Found = true
+			out.Path = out.Paths[0].Path
+			out.Depth = out.Paths[0].Depth
+		}
+		return out, nil
+	}
+
 	trace, err := svc.DependencyTrace(ctx, from, to, maxDepth)
 	if err != nil {
 		return contracts.QueryTraceOutput{}, err
@@ -237,6 +335,83 @@ func (a *Adapter) Trace(ctx context.Context, from, to string, maxDepth int) (con
 	}, nil
 }
 
+func (a *Adapter) CriticalModules(ctx context.Context, entryPoints []string) (contracts.QueryCriticalOutput, error) {
+	if err := ctx.Err(); err != nil {
+		return contracts.QueryCriticalOutput{}, err
+	}
+
+	a.mu.RLock()
+	defer a.mu.RUnlock()
+	if a.analysis == nil {
+		return contracts.QueryCriticalOutput{}, fmt.Errorf("analysis service unavailable")
+	}
+
+	report, err := a.analysis.CriticalModules(ctx, entryPoints)
+	if err != nil {
+		return contracts.QueryCriticalOutput{}, err
+	}
+
+	out := contracts.QueryCriticalOutput{
+		EntryPoints:        append([]string{}, report.EntryPoints...),
+		ArticulationPoints: append([]string{}, report.ArticulationPoints...),
+		Bridges:            make([]contracts.ModuleBridge, 0, len(report.Bridges)),
+		Dominators:         make([]contracts.ModuleDominator, 0, len(report.Dominators)),
+	}
+	for _, bridge := range report.Bridges {
+		out.Bridges = append(out.Bridges, contracts.ModuleBridge{From: bridge.From, To: bridge.To})
+	}
+	for _, dom := range report.Dominators {
+		out.Dominators = append(out.Dominators, contracts.ModuleDominator{
+			Module:    dom.Module,
+			Dominated: append([]string(nil), dom.Dominated...),
+		})
+	}
+	for _, module := range util.SortedStringKeys(report.ImmediateDominators) {
+		out.DominatorTree = append(out.DominatorTree, contracts.DominatorTreeNode{
+			Module:             module,
+			ImmediateDominator: report.ImmediateDominators[module],
+		})
+	}
+	return out, nil
+}
+
+func (a *Adapter) ExternalDependencies(ctx context.Context, name string, maxFiles int, unused bool, limit int) (contracts.QueryDependenciesOutput, error) {
+	if err := ctx.Err(); err != nil {
+		return contracts.QueryDependenciesOutput{}, err
+	}
+	svc := a.queryService()
+	if svc == nil {
+		return contracts.QueryDependenciesOutput{}, fmt.Errorf("analysis service unavailable")
+	}
+
+	deps, err := svc.ExternalDependencies(ctx, query.ExternalDependencyFilter{Name: name, MaxFiles: maxFiles, Unused: unused}, limit)
+	if err != nil {
+		return contracts.QueryDependenciesOutput{}, err
+	}
+
+	out := contracts.QueryDependenciesOutput{Dependencies: make([]contracts.ExternalDependency, 0, len(deps))}
+	for _, dep := range deps {
+		row := contracts.ExternalDependency{
+			Name:        dep.Name,
+			Ecosystem:   dep.Ecosystem,
+			Version:     dep.Version,
+			Status:      dep.Status(),
+			Manifests:   append([]string(nil), dep.Manifests...),
+			ImportPaths: append([]string(nil), dep.ImportPaths...),
+			Importers:   make([]contracts.ExternalImporter, 0, len(dep.Importers)),
+			FileCount:   dep.FileCount,
+		}
+		for _, importer := range dep.Importers {
+			row.Importers = append(row.Importers, contracts.ExternalImporter{
+				Module: importer.Module,
+				Files:  append([]string(nil), importer.Files...),
+			})
+		}
+		out.Dependencies = append(out.Dependencies, row)
+	}
+	return out, nil
+}
+
 func (a *Adapter) TrendSlice(ctx context.Context, since time.Time, limit int) (contracts.QueryTrendsOutput, error) {
 	if err := ctx.Err(); err != nil {
 		return contracts.QueryTrendsOutput{}, err
@@ -268,6 +443,65 @@ func (a *Adapter) TrendSlice(ctx context.Context, since time.Time, limit int) (c
 	return out, nil
 }
 
+func (a *Adapter) SnapshotDiff(ctx context.Context, fromRef, toRef string) (contracts.QueryDiffOutput, error) {
+	if err := ctx.Err(); err != nil {
+		return contracts.QueryDiffOutput{}, err
+	}
+	svc := a.queryService()
+	if svc == nil {
+		return contracts.QueryDiffOutput{}, fmt.Errorf("analysis service unavailable")
+	}
+
+	diff, err := svc.SnapshotDiff(ctx, fromRef, toRef)
+	if err != nil {
+		return contracts.QueryDiffOutput{}, err
+	}
+
+	out := contracts.QueryDiffOutput{
+		FromSnapshot:    diff.From.Timestamp.Format(time.RFC3339),
+		FromCommit:      diff.From.CommitHash,
+		ToSnapshot:      diff.To.Timestamp.Format(time.RFC3339),
+		ToCommit:        diff.To.CommitHash,
+		AddedModules:    diff.AddedModules,
+		RemovedModules:  diff.RemovedModules,
+		AddedEdges:      diffEdges(diff.AddedEdges),
+		RemovedEdges:    diffEdges(diff.RemovedEdges),
+		NewCycles:       diff.NewCycles,
+		FixedCycles:     diff.FixedCycles,
+		MetricDeltas:    make([]contracts.SnapshotMetricDelta, 0, len(diff.MetricDeltas)),
+		DeltaModules:    diff.DeltaModules,
+		DeltaFiles:      diff.DeltaFiles,
+		DeltaCycles:     diff.DeltaCycles,
+		DeltaUnresolved: diff.DeltaUnresolved,
+		DeltaViolations: diff.DeltaViolations,
+	}
+	for _, d := range diff.MetricDeltas {
+		out.MetricDeltas = append(out.MetricDeltas, contracts.SnapshotMetricDelta{
+			Module:       d.Module,
+			FanIn:        d.FanIn,
+			FanOut:       d.FanOut,
+			Depth:        d.Depth,
+			Instability:  d.Instability,
+			Abstractness: d.Abstractness,
+			Distance:     d.Distance,
+		})
+	}
+	return out, nil
+}
+
+func diffEdges(edges []history.SnapshotEdge) []contracts.DiffEdge {
+	out := make([]contracts.DiffEdge, 0, len(edges))
+	for _, edge := range edges {
+		out = append(out, contracts.DiffEdge{
+			From:        edge.From,
+			To:          edge.To,
+			ImportCount: edge.ImportCount,
+			UsageCount:  edge.UsageCount,
+		})
+	}
+	return out
+}
+
 func (a *Adapter) SyncOutputs(ctx context.Context, formats []string) ([]string, error) {
 	if err := ctx.Err(); err != nil {
 		return nil, err
@@ -426,6 +660,54 @@ func resolveDiagramPath(path, root, diagramsDir string) string {
 	return filepath.Join(diagramsDir, path)
 }
 
+func cycleCutPlans(plans []graph.CycleBreakPlan) []contracts.CycleCutPlan {
+	out := make([]contracts.CycleCutPlan, 0, len(plans))
+	for _, plan := range plans {
+		cuts := make([]contracts.CycleCut, 0, len(plan.Cuts))
+		for _, cut := range plan.Cuts {
+			cuts = append(cuts, contracts.CycleCut{
+				From:        cut.From,
+				To:          cut.To,
+				File:        cut.File,
+				Line:        cut.Line,
+				Column:      cut.Column,
+				ImportSites: cut.ImportSites,
+				SymbolRefs:  cut.SymbolRefs,
+				Weight:      cut.Weight,
+			})
+		}
+		out = append(out, contracts.CycleCutPlan{
+			Modules:     append([]string(nil), plan.Modules...),
+			Cuts:        cuts,
+			TotalWeight: plan.TotalWeight,
+			Exact:       plan.Exact,
+		})
+	}
+	return out
+}
+
+func simulatedViolations(layer []graph.ArchitectureViolation, rules []ports.ArchitectureRuleViolation) []contracts.SimulatedViolation {
+	out := make([]contracts.SimulatedViolation, 0, len(layer)+len(rules))
+	for _, v := range layer {
+		out = append(out, contracts.SimulatedViolation{
+			Rule: v.RuleName,
+			Type: "layer",
+			From: v.FromModule,
+			To:   v.ToModule,
+		})
+	}
+	for _, v := range rules {
+		out = append(out, contracts.SimulatedViolation{
+			Rule:    v.RuleName,
+			Type:    v.Type,
+			From:    v.Module,
+			To:      v.Target,
+			Message: v.Message,
+		})
+	}
+	return out
+}
+
 func secretFindings(files []*parser.File, limit int) ([]contracts.SecretFinding, int) {
 	findings := make([]contracts.SecretFinding, 0)
 	for _, file := range files {
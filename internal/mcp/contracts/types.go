@@ -15,9 +15,13 @@ const (
 	OperationSecretsList     OperationID = "secrets.list"
 	OperationGraphCycles     OperationID = "graph.cycles"
 	OperationGraphSyncDiag   OperationID = "graph.sync_diagrams"
+	OperationGraphSimulate   OperationID = "graph.simulate"
 	OperationQueryModules    OperationID = "query.modules"
 	OperationQueryDetails    OperationID = "query.module_details"
 	OperationQueryTrace      OperationID = "query.trace"
+	OperationQueryCritical   OperationID = "query.critical"
+	OperationQueryDiff       OperationID = "query.diff"
+	OperationQueryDeps       OperationID = "query.dependencies"
 	OperationSystemSyncOut   OperationID = "system.sync_outputs"
 	OperationSystemSyncCfg   OperationID = "system.sync_config"
 	OperationSystemGenCfg    OperationID = "system.generate_config"
@@ -89,8 +93,72 @@ type GraphCyclesInput struct {
 }
 
 type GraphCyclesOutput struct {
-	CycleCount int        `json:"cycle_count"`
-	Cycles     [][]string `json:"cycles,omitempty"`
+	CycleCount    int            `json:"cycle_count"`
+	Cycles        [][]string     `json:"cycles,omitempty"`
+	SuggestedCuts []CycleCutPlan `json:"suggested_cuts,omitempty"`
+}
+
+type CycleCut struct {
+	From        string `json:"from"`
+	To          string `json:"to"`
+	File        string `json:"file,omitempty"`
+	Line        int    `json:"line,omitempty"`
+	Column      int    `json:"column,omitempty"`
+	ImportSites int    `json:"import_sites"`
+	SymbolRefs  int    `json:"symbol_refs"`
+	Weight      int    `json:"weight"`
+}
+
+type CycleCutPlan struct {
+	Modules     []string   `json:"modules"`
+	Cuts        []CycleCut `json:"cuts"`
+	TotalWeight int        `json:"total_weight"`
+	Exact       bool       `json:"exact"`
+}
+
+type SimulationOperation struct {
+	Op     string `json:"op"`
+	File   string `json:"file,omitempty"`
+	Module string `json:"module,omitempty"`
+	Glob   string `json:"glob,omitempty"`
+	From   string `json:"from,omitempty"`
+	To     string `json:"to,omitempty"`
+}
+
+type GraphSimulateInput struct {
+	Operations []SimulationOperation `json:"operations"`
+}
+
+type SimulatedViolation struct {
+	Rule    string `json:"rule"`
+	Type    string `json:"type"`
+	From    string `json:"from"`
+	To      string `json:"to,omitempty"`
+	Message string `json:"message,omitempty"`
+}
+
+type ModuleMetricDelta struct {
+	Module       string `json:"module"`
+	FanInBefore  int    `json:"fan_in_before"`
+	FanInAfter   int    `json:"fan_in_after"`
+	FanOutBefore int    `json:"fan_out_before"`
+	FanOutAfter  int    `json:"fan_out_after"`
+	DepthBefore  int    `json:"depth_before"`
+	DepthAfter   int    `json:"depth_after"`
+	Added        bool   `json:"added,omitempty"`
+	Removed      bool   `json:"removed,omitempty"`
+}
+
+type GraphSimulateOutput struct {
+	ModulesBefore     int                  `json:"modules_before"`
+	ModulesAfter      int                  `json:"modules_after"`
+	CyclesBefore      int                  `json:"cycles_before"`
+	CyclesAfter       int                  `json:"cycles_after"`
+	CyclesAdded       [][]string           `json:"cycles_added,omitempty"`
+	CyclesRemoved     [][]string           `json:"cycles_removed,omitempty"`
+	ViolationsAdded   []SimulatedViolation `json:"violations_added,omitempty"`
+	ViolationsRemoved []SimulatedViolation `json:"violations_removed,omitempty"`
+	MetricDeltas      []ModuleMetricDelta  `json:"metric_deltas,omitempty"`
 }
 
 type QueryModulesInput struct {
@@ -128,6 +196,15 @@ type ModuleDetails struct {
 	ExportedSymbols     []string         `json:"exported_symbols"`
 	Dependencies        []DependencyEdge `json:"dependencies"`
 	ReverseDependencies []string         `json:"reverse_dependencies"`
+	Coupling            CouplingMetrics  `json:"coupling"`
+}
+
+type CouplingMetrics struct {
+	Afferent     int     `json:"ca"`
+	Efferent     int     `json:"ce"`
+	Instability  float64 `json:"instability"`
+	Abstractness float64 `json:"abstractness"`
+	Distance     float64 `json:"distance"`
 }
 
 type QueryModuleDetailsOutput struct {
@@ -138,12 +215,125 @@ type QueryTraceInput struct {
 	From     string `json:"from_module"`
 	To       string `json:"to_module"`
 	MaxDepth int    `json:"max_depth,omitempty"`
+	Paths    int    `json:"paths,omitempty"`
 }
 
 type QueryTraceOutput struct {
-	Found bool     `json:"found"`
-	Path  []string `json:"path,omitempty"`
-	Depth int      `json:"depth,omitempty"`
+	Found bool        `json:"found"`
+	Path  []string    `json:"path,omitempty"`
+	Depth int         `json:"depth,omitempty"`
+	Paths []TracePath `json:"paths,omitempty"`
+}
+
+type TraceHop struct {
+	From   string `json:"from"`
+	To     string `json:"to"`
+	File   string `json:"file,omitempty"`
+	Line   int    `json:"line,omitempty"`
+	Column int    `json:"column,omitempty"`
+}
+
+type TracePath struct {
+	Path  []string   `json:"path"`
+	Depth int        `json:"depth"`
+	Hops  []TraceHop `json:"hops"`
+}
+
+type QueryCriticalInput struct {
+	EntryPoints []string `json:"entry_points,omitempty"`
+	Limit       int      `json:"limit,omitempty"`
+}
+
+type ModuleBridge struct {
+	From string `json:"from"`
+	To   string `json:"to"`
+}
+
+type ModuleDominator struct {
+	Module    string   `json:"module"`
+	Dominated []string `json:"dominated"`
+}
+
+type DominatorTreeNode struct {
+	Module             string `json:"module"`
+	ImmediateDominator string `json:"immediate_dominator,omitempty"`
+}
+
+type QueryCriticalOutput struct {
+	EntryPoints        []string            `json:"entry_points"`
+	ArticulationPoints []string            `json:"articulation_points"`
+	Bridges            []ModuleBridge      `json:"bridges"`
+	Dominators         []ModuleDominator   `json:"dominators"`
+	DominatorTree      []DominatorTreeNode `json:"dominator_tree,omitempty"`
+}
+
+type QueryDiffInput struct {
+	From  string `json:"from,omitempty"`
+	To    string `json:"to,omitempty"`
+	Limit int    `json:"limit,omitempty"`
+}
+
+type DiffEdge struct {
+	From        string `json:"from"`
+	To          string `json:"to"`
+	ImportCount int    `json:"import_count"`
+	UsageCount  int    `json:"usage_count"`
+}
+
+type SnapshotMetricDelta struct {
+	Module       string  `json:"module"`
+	FanIn        int     `json:"fan_in"`
+	FanOut       int     `json:"fan_out"`
+	Depth        int     `json:"depth"`
+	Instability  float64 `json:"instability"`
+	Abstractness float64 `json:"abstractness"`
+	Distance     float64 `json:"distance"`
+}
+
+type QueryDiffOutput struct {
+	FromSnapshot    string                `json:"from_snapshot"`
+	FromCommit      string                `json:"from_commit,omitempty"`
+	ToSnapshot      string                `json:"to_snapshot"`
+	ToCommit        string                `json:"to_commit,omitempty"`
+	AddedModules    []string              `json:"added_modules"`
+	RemovedModules  []string              `json:"removed_modules"`
+	AddedEdges      []DiffEdge            `json:"added_edges"`
+	RemovedEdges    []DiffEdge            `json:"removed_edges"`
+	NewCycles       [][]string            `json:"new_cycles"`
+	FixedCycles     [][]string            `json:"fixed_cycles"`
+	MetricDeltas    []SnapshotMetricDelta `json:"metric_deltas"`
+	DeltaModules    int                   `json:"delta_modules"`
+	DeltaFiles      int                   `json:"delta_files"`
+	DeltaCycles     int                   `json:"delta_cycles"`
+	DeltaUnresolved int                   `json:"delta_unresolved"`
+	DeltaViolations int                   `json:"delta_violations"`
+}
+
+type QueryDependenciesInput struct {
+	Name     string `json:"name,omitempty"`
+	MaxFiles int    `json:"max_files,omitempty"`
+	Unused   bool   `json:"unused,omitempty"`
+	Limit    int    `json:"limit,omitempty"`
+}
+
+type ExternalImporter struct {
+	Module string   `json:"module"`
+	Files  []string `json:"files"`
+}
+
+type ExternalDependency struct {
+	Name        string             `json:"name"`
+	Ecosystem   string             `json:"ecosystem"`
+	Version     string             `json:"version,omitempty"`
+	Status      string             `json:"status"`
+	Manifests   []string           `json:"manifests,omitempty"`
+	ImportPaths []string           `json:"import_paths,omitempty"`
+	Importers   []ExternalImporter `json:"importers"`
+	FileCount   int                `json:"file_count"`
+}
+
+type QueryDependenciesOutput struct {
+	Dependencies []ExternalDependency `json:"dependencies"`
 }
 
 type SystemSyncOutputsInput struct {
@@ -54,12 +54,20 @@ func normalizeOperationAlias(raw string) contracts.OperationID {
 		return contracts.OperationSecretsList
 	case "graph.cycles", "detect_cycles":
 		return contracts.OperationGraphCycles
+	case "graph.simulate", "simulate_refactor":
+		return contracts.OperationGraphSimulate
 	case "query.modules":
 		return contracts.OperationQueryModules
 	case "query.module_details", "query.module-details":
 		return contracts.OperationQueryDetails
 	case "query.trace", "trace_import_chain":
 		return contracts.OperationQueryTrace
+	case "query.critical", "critical_modules":
+		return contracts.OperationQueryCritical
+	case "query.diff", "snapshot_diff":
+		return contracts.OperationQueryDiff
+	case "query.dependencies", "external_dependencies":
+		return contracts.OperationQueryDeps
 	case "system.sync_outputs", "generate_reports", "graph.sync_diagrams":
 		return contracts.OperationGraphSyncDiag
 	case "system.sync_config":
@@ -376,6 +376,9 @@ func (s *Server) dispatchOperation(ctx context.Context, raw map[string]any) (any
 	case contracts.OperationGraphCycles:
 		out, err := graph.HandleCycles(ctx, s.adapter, input.(contracts.GraphCyclesInput), maxItems)
 		return wrapToolResult(operation, out), err
+	case contracts.OperationGraphSimulate:
+		out, err := graph.HandleSimulate(ctx, s.adapter, input.(contracts.GraphSimulateInput))
+		return wrapToolResult(operation, out), err
 	case contracts.OperationGraphSyncDiag, contracts.OperationSystemSyncOut:
 		out, err := system.HandleSyncOutputs(ctx, s, s.cfg.MCP.AllowMutations, input.(contracts.SystemSyncOutputsInput))
 		return wrapToolResult(operation, out), err
@@ -388,6 +391,15 @@ func (s *Server) dispatchOperation(ctx context.Context, raw map[string]any) (any
 	case contracts.OperationQueryTrace:
 		out, err := query.HandleTrace(ctx, s.adapter, input.(contracts.QueryTraceInput))
 		return wrapToolResult(operation, out), err
+	case contracts.OperationQueryCritical:
+		out, err := query.HandleCritical(ctx, s.adapter, input.(contracts.QueryCriticalInput), maxItems)
+		return wrapToolResult(operation, out), err
+	case contracts.OperationQueryDiff:
+		out, err := query.HandleDiff(ctx, s.adapter, input.(contracts.QueryDiffInput), maxItems)
+		return wrapToolResult(operation, out), err
+	case contracts.OperationQueryDeps:
+		out, err := query.HandleDependencies(ctx, s.adapter, input.(contracts.QueryDependenciesInput), maxItems)
+		return wrapToolResult(operation, out), err
 	case contracts.OperationSystemSyncCfg:
 		out, err := system.HandleSyncConfig(ctx, s, s.cfg.MCP.AllowMutations)
 		return wrapToolResult(operation, out), err
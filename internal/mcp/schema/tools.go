@@ -27,9 +27,13 @@ func BuildToolDefinitions() []ToolDefinition {
 							string(contracts.OperationSecretsList),
 							string(contracts.OperationGraphCycles),
 							string(contracts.OperationGraphSyncDiag),
+							string(contracts.OperationGraphSimulate),
 							string(contracts.OperationQueryModules),
 							string(contracts.OperationQueryDetails),
 							string(contracts.OperationQueryTrace),
+							string(contracts.OperationQueryCritical),
+							string(contracts.OperationQueryDiff),
+							string(contracts.OperationQueryDeps),
 							string(contracts.OperationSystemSyncCfg),
 							string(contracts.OperationSystemGenCfg),
 							string(contracts.OperationSystemGenScript),
@@ -40,14 +44,14 @@ func BuildToolDefinitions() []ToolDefinition {
 						},
 					},
 					"params": map[string]any{
-						"type": "object",
+						"type":        "object",
 						"description": "Operation-specific parameters.",
 						"oneOf": []map[string]any{
 							{
 								"title": "scan.run",
 								"properties": map[string]any{
 									"paths": map[string]any{
-										"type": "array",
+										"type":  "array",
 										"items": map[string]any{"type": "string"},
 									},
 								},
@@ -58,12 +62,71 @@ func BuildToolDefinitions() []ToolDefinition {
 									"limit": map[string]any{"type": "integer"},
 								},
 							},
+							{
+								"title": "graph.simulate",
+								"properties": map[string]any{
+									"operations": map[string]any{
+										"type": "array",
+										"items": map[string]any{
+											"type": "object",
+											"properties": map[string]any{
+												"op": map[string]any{
+													"type": "string",
+													"enum": []string{"move_file", "delete_edge", "merge_modules", "split_module"},
+												},
+												"file":   map[string]any{"type": "string"},
+												"module": map[string]any{"type": "string"},
+												"glob":   map[string]any{"type": "string"},
+												"from":   map[string]any{"type": "string"},
+												"to":     map[string]any{"type": "string"},
+											},
+											"required": []string{"op"},
+										},
+									},
+								},
+							},
 							{
 								"title": "query.module",
 								"properties": map[string]any{
 									"module": map[string]any{"type": "string"},
 								},
 							},
+							{
+								"title": "query.trace",
+								"properties": map[string]any{
+									"from_module": map[string]any{"type": "string"},
+									"to_module":   map[string]any{"type": "string"},
+									"max_depth":   map[string]any{"type": "integer"},
+									"paths":       map[string]any{"type": "integer"},
+								},
+							},
+							{
+								"title": "query.critical",
+								"properties": map[string]any{
+									"entry_points": map[string]any{
+										"type":  "array",
+										"items": map[string]any{"type": "string"},
+									},
+									"limit": map[string]any{"type": "integer"},
+								},
+							},
+							{
+								"title": "query.diff",
+								"properties": map[string]any{
+									"from":  map[string]any{"type": "string"},
+									"to":    map[string]any{"type": "string"},
+									"limit": map[string]any{"type": "integer"},
+								},
+							},
+							{
+								"title": "query.dependencies",
+								"properties": map[string]any{
+									"name":      map[string]any{"type": "string"},
+									"max_files": map[string]any{"type": "integer"},
+									"unused":    map[string]any{"type": "boolean"},
+									"limit":     map[string]any{"type": "integer"},
+								},
+							},
 							// Add more as needed, but this shows the intent
 						},
 					},
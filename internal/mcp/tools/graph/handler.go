@@ -6,6 +6,10 @@ import (
 	"context"
 )
 
+func HandleSimulate(ctx context.Context, a *adapters.Adapter, in contracts.GraphSimulateInput) (contracts.GraphSimulateOutput, error) {
+	return a.Simulate(ctx, in.Operations)
+}
+
 func HandleCycles(ctx context.Context, a *adapters.Adapter, in contracts.GraphCyclesInput, maxItems int) (contracts.GraphCyclesOutput, error) {
 	limit := in.Limit
 	if limit <= 0 || (maxItems > 0 && limit > maxItems) {
@@ -44,4 +44,35 @@ func TestHandleCyclesLimits(t *testing.T) {
 	if len(out.Cycles) != 1 {
 		t.Fatalf("expected bounded cycles=1, got %d", len(out.Cycles))
 	}
+	if len(out.SuggestedCuts) != 1 || len(out.SuggestedCuts[0].Cuts) != 1 {
+		t.Fatalf("expected one suggested cut, got %+v", out.SuggestedCuts)
+	}
+}
+
+func TestHandleSimulate(t *testing.T) {
+	g := graph.NewGraph()
+	g.AddFile(&parser.File{Path: "a.go", Module: "app/a", Imports: []parser.Import{{Module: "app/b"}}})
+	g.AddFile(&parser.File{Path: "b.go", Module: "app/b", Imports: []parser.Import{{Module: "app/a"}}})
+
+	appInstance := &app.App{
+		Config: &config.Config{},
+		Graph:  g,
+	}
+	adapter := adapters.NewAdapter(appInstance.AnalysisService(), nil, "default")
+
+	out, err := HandleSimulate(context.Background(), adapter, contracts.GraphSimulateInput{
+		Operations: []contracts.SimulationOperation{{Op: "merge_modules", From: "app/b", To: "app/a"}},
+	})
+	if err != nil {
+		t.Fatalf("handle simulate: %v", err)
+	}
+	if out.CyclesBefore != 1 || out.CyclesAfter != 0 || len(out.CyclesRemoved) != 1 {
+		t.Fatalf("expected merge to remove the cycle, got %+v", out)
+	}
+	if out.ModulesBefore != 2 || out.ModulesAfter != 1 {
+		t.Fatalf("expected module count 2 -> 1, got %d -> %d", out.ModulesBefore, out.ModulesAfter)
+	}
+	if len(g.DetectCycles()) != 1 {
+		t.Fatal("expected live graph to be unchanged")
+	}
 }
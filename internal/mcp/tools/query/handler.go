@@ -31,7 +31,52 @@ func HandleModuleDetails(ctx context.Context, a *adapters.Adapter, in contracts.
 }
 
 func HandleTrace(ctx context.Context, a *adapters.Adapter, in contracts.QueryTraceInput) (contracts.QueryTraceOutput, error) {
-	return a.Trace(ctx, in.From, in.To, in.MaxDepth)
+	return a.Trace(ctx, in.From, in.To, in.MaxDepth, in.Paths)
+}
+
+func HandleCritical(ctx context.Context, a *adapters.Adapter, in contracts.QueryCriticalInput, maxItems int) (contracts.QueryCriticalOutput, error) {
+	out, err := a.CriticalModules(ctx, in.EntryPoints)
+	if err != nil {
+		return contracts.QueryCriticalOutput{}, err
+	}
+
+	limit := normalizeLimit(in.Limit, maxItems)
+	out.ArticulationPoints = limitStrings(out.ArticulationPoints, limit)
+	if limit > 0 && len(out.Bridges) > limit {
+		out.Bridges = out.Bridges[:limit]
+	}
+	if limit > 0 && len(out.Dominators) > limit {
+		out.Dominators = out.Dominators[:limit]
+	}
+	if limit > 0 && len(out.DominatorTree) > limit {
+		out.DominatorTree = out.DominatorTree[:limit]
+	}
+	return out, nil
+}
+
+func HandleDiff(ctx context.Context, a *adapters.Adapter, in contracts.QueryDiffInput, maxItems int) (contracts.QueryDiffOutput, error) {
+	out, err := a.SnapshotDiff(ctx, in.From, in.To)
+	if err != nil {
+		return contracts.QueryDiffOutput{}, err
+	}
+
+	limit := normalizeLimit(in.Limit, maxItems)
+	out.AddedModules = limitStrings(out.AddedModules, limit)
+	out.RemovedModules = limitStrings(out.RemovedModules, limit)
+	if limit > 0 && len(out.AddedEdges) > limit {
+		out.AddedEdges = out.AddedEdges[:limit]
+	}
+	if limit > 0 && len(out.RemovedEdges) > limit {
+		out.RemovedEdges = out.RemovedEdges[:limit]
+	}
+	if limit > 0 && len(out.MetricDeltas) > limit {
+		out.MetricDeltas = out.MetricDeltas[:limit]
+	}
+	return out, nil
+}
+
+func HandleDependencies(ctx context.Context, a *adapters.Adapter, in contracts.QueryDependenciesInput, maxItems int) (contracts.QueryDependenciesOutput, error) {
+	return a.ExternalDependencies(ctx, in.Name, in.MaxFiles, in.Unused, normalizeLimit(in.Limit, maxItems))
 }
 
 func HandleTrends(ctx context.Context, a *adapters.Adapter, in contracts.QueryTrendsInput, maxItems int) (contracts.QueryTrendsOutput, error) {
@@ -3,12 +3,16 @@ package query
 import (
 	"circular/internal/core/app"
 	"circular/internal/core/config"
+	"circular/internal/data/history"
 	"circular/internal/engine/graph"
 	"circular/internal/engine/parser"
 	"circular/internal/mcp/adapters"
 	"circular/internal/mcp/contracts"
 	"context"
+	"os"
+	"path/filepath"
 	"testing"
+	"time"
 )
 
 func TestHandleQueryModules(t *testing.T) {
@@ -44,6 +48,123 @@ func TestHandleQueryTrace(t *testing.T) {
 	}
 }
 
+func TestHandleQueryTrace_Paths(t *testing.T) {
+	adapter := testQueryAdapter()
+
+	out, err := HandleTrace(context.Background(), adapter, contracts.QueryTraceInput{
+		From:  "app/a",
+		To:    "app/c",
+		Paths: 3,
+	})
+	if err != nil {
+		t.Fatalf("handle trace paths: %v", err)
+	}
+	if !out.Found || len(out.Paths) != 1 || out.Depth != 2 {
+		t.Fatalf("expected one path of depth 2, got %+v", out)
+	}
+	hops := out.Paths[0].Hops
+	if len(hops) != 2 || hops[0].From != "app/a" || hops[0].To != "app/b" || hops[0].File != "a.go" {
+		t.Fatalf("expected located hops, got %+v", hops)
+	}
+}
+
+func TestHandleQueryCritical(t *testing.T) {
+	adapter := testQueryAdapter()
+
+	out, err := HandleCritical(context.Background(), adapter, contracts.QueryCriticalInput{}, 1)
+	if err != nil {
+		t.Fatalf("handle critical: %v", err)
+	}
+	if len(out.EntryPoints) != 1 || out.EntryPoints[0] != "app/a" {
+		t.Fatalf("expected root module as entry point, got %v", out.EntryPoints)
+	}
+	if len(out.ArticulationPoints) != 1 || out.ArticulationPoints[0] != "app/b" {
+		t.Fatalf("expected app/b articulation point, got %v", out.ArticulationPoints)
+	}
+	if len(out.Bridges) != 1 {
+		t.Fatalf("expected bridges bounded to 1, got %+v", out.Bridges)
+	}
+	if len(out.Dominators) != 1 || out.Dominators[0].Module != "app/b" || out.Dominators[0].Dominated[0] != "app/c" {
+		t.Fatalf("expected app/b to dominate app/c, got %+v", out.Dominators)
+	}
+}
+
+func TestHandleQueryDependencies(t *testing.T) {
+	tmpDir := t.TempDir()
+	manifest := `{"name": "web", "dependencies": {"react": "^18.2.0", "lodash": "^4.17.21"}}`
+	if err := os.WriteFile(filepath.Join(tmpDir, "package.json"), []byte(manifest), 0o644); err != nil {
+		t.Fatal(err)
+	}
+	g := graph.NewGraph()
+	g.AddFile(&parser.File{Path: filepath.Join(tmpDir, "src", "app.js"), Module: "src/app", Language: "javascript", Imports: []parser.Import{{Module: "react"}, {Module: "./util"}}})
+	g.AddFile(&parser.File{Path: filepath.Join(tmpDir, "src", "util.js"), Module: "src/util", Language: "javascript"})
+	appInstance := &app.App{Config: &config.Config{}, Graph: g}
+	adapter := adapters.NewAdapter(appInstance.AnalysisService(), nil, "default")
+
+	out, err := HandleDependencies(context.Background(), adapter, contracts.QueryDependenciesInput{}, 10)
+	if err != nil {
+		t.Fatalf("handle dependencies: %v", err)
+	}
+	if len(out.Dependencies) != 2 {
+		t.Fatalf("expected lodash and react, got %+v", out.Dependencies)
+	}
+	react := out.Dependencies[1]
+	if react.Name != "react" || react.Ecosystem != "npm" || react.Status != "declared" || react.FileCount != 1 || react.Importers[0].Module != "src/app" {
+		t.Fatalf("unexpected react dependency: %+v", react)
+	}
+
+	out, err = HandleDependencies(context.Background(), adapter, contracts.QueryDependenciesInput{Unused: true}, 10)
+	if err != nil {
+		t.Fatalf("handle unused dependencies: %v", err)
+	}
+	if len(out.Dependencies) != 1 || out.Dependencies[0].Name != "lodash" || out.Dependencies[0].Status != "unused" {
+		t.Fatalf("expected lodash unused, got %+v", out.Dependencies)
+	}
+}
+
+func TestHandleQueryDiff(t *testing.T) {
+	store, err := history.Open(filepath.Join(t.TempDir(), "history.db"))
+	if err != nil {
+		t.Fatalf("open history: %v", err)
+	}
+	defer store.Close()
+
+	base := time.Date(2026, 3, 1, 9, 0, 0, 0, time.UTC)
+	if err := store.SaveSnapshot("default", history.Snapshot{
+		Timestamp:   base,
+		ModuleCount: 2,
+		Modules:     []history.SnapshotModule{{Name: "app/a"}, {Name: "app/b"}},
+	}); err != nil {
+		t.Fatal(err)
+	}
+	if err := store.SaveSnapshot("default", history.Snapshot{
+		Timestamp:   base.Add(time.Hour),
+		ModuleCount: 3,
+		CycleCount:  1,
+		Modules:     []history.SnapshotModule{{Name: "app/a", FanIn: 1, FanOut: 1}, {Name: "app/b", FanIn: 1, FanOut: 1}, {Name: "app/c"}},
+		Edges:       []history.SnapshotEdge{{From: "app/a", To: "app/b", ImportCount: 1}, {From: "app/b", To: "app/a", ImportCount: 1}},
+		Cycles:      [][]string{{"app/a", "app/b"}},
+	}); err != nil {
+		t.Fatal(err)
+	}
+
+	appInstance := &app.App{Config: &config.Config{}, Graph: graph.NewGraph()}
+	adapter := adapters.NewAdapter(appInstance.AnalysisService(), store, "default")
+	out, err := HandleDiff(context.Background(), adapter, contracts.QueryDiffInput{From: "previous", To: "latest"}, 1)
+	if err != nil {
+		t.Fatalf("handle diff: %v", err)
+	}
+	if len(out.AddedModules) != 1 || out.AddedModules[0] != "app/c" {
+		t.Fatalf("expected app/c added, got %v", out.AddedModules)
+	}
+	if len(out.AddedEdges) != 1 || len(out.MetricDeltas) != 1 {
+		t.Fatalf("expected edges and metric deltas bounded to 1, got %+v", out)
+	}
+	if len(out.NewCycles) != 1 || out.DeltaCycles != 1 {
+		t.Fatalf("expected one new cycle, got %+v", out.NewCycles)
+	}
+}
+
 func testQueryAdapter() *adapters.Adapter {
 	g := graph.NewGraph()
 	g.AddFile(&parser.File{
@@ -15,8 +15,17 @@ const (
 	maxFilterLength = 200
 	maxLimitValue   = 5000
 	maxTraceDepth   = 100
+	maxTracePaths   = 50
+	maxSimulateOps  = 50
 )
 
+var simulationRequiredFields = map[string][]string{
+	"move_file":     {"file", "to"},
+	"delete_edge":   {"from", "to"},
+	"merge_modules": {"from", "to"},
+	"split_module":  {"module", "glob", "to"},
+}
+
 var allowedReportVerbosity = map[string]bool{
 	"summary":  true,
 	"standard": true,
@@ -98,6 +107,23 @@ func ParseToolArgs(tool string, raw map[string]any, projectRoot string) (contrac
 			return "", nil, invalidLimitError("limit")
 		}
 		return operation, input, nil
+	case contracts.OperationGraphSimulate:
+		var input contracts.GraphSimulateInput
+		if err := decodeParams(params, &input); err != nil {
+			return "", nil, err
+		}
+		if len(input.Operations) == 0 {
+			return "", nil, contracts.ToolError{Code: contracts.ErrorInvalidArgument, Message: "operations are required"}
+		}
+		if len(input.Operations) > maxSimulateOps {
+			return "", nil, contracts.ToolError{Code: contracts.ErrorInvalidArgument, Message: "too many operations requested"}
+		}
+		for i := range input.Operations {
+			if err := normalizeSimulationOperation(&input.Operations[i]); err != nil {
+				return "", nil, contracts.ToolError{Code: contracts.ErrorInvalidArgument, Message: fmt.Sprintf("operations[%d]: %s", i, err.Error())}
+			}
+		}
+		return operation, input, nil
 	case contracts.OperationGraphSyncDiag:
 		var input contracts.SystemSyncOutputsInput
 		if err := decodeParams(params, &input); err != nil {
@@ -144,6 +170,52 @@ func ParseToolArgs(tool string, raw map[string]any, projectRoot string) (contrac
 		if input.MaxDepth < 0 || input.MaxDepth > maxTraceDepth {
 			return "", nil, invalidLimitError("max_depth")
 		}
+		if input.Paths < 0 || input.Paths > maxTracePaths {
+			return "", nil, invalidLimitError("paths")
+		}
+		return operation, input, nil
+	case contracts.OperationQueryCritical:
+		var input contracts.QueryCriticalInput
+		if err := decodeParams(params, &input); err != nil {
+			return "", nil, err
+		}
+		input.EntryPoints = normalizeStrings(input.EntryPoints, maxPathCount)
+		if input.Limit < 0 || input.Limit > maxLimitValue {
+			return "", nil, invalidLimitError("limit")
+		}
+		return operation, input, nil
+	case contracts.OperationQueryDiff:
+		var input contracts.QueryDiffInput
+		if err := decodeParams(params, &input); err != nil {
+			return "", nil, err
+		}
+		input.From = strings.TrimSpace(input.From)
+		input.To = strings.TrimSpace(input.To)
+		if input.From == "" {
+			input.From = "previous"
+		}
+		if input.To == "" {
+			input.To = "latest"
+		}
+		if input.Limit < 0 || input.Limit > maxLimitValue {
+			return "", nil, invalidLimitError("limit")
+		}
+		return operation, input, nil
+	case contracts.OperationQueryDeps:
+		var input contracts.QueryDependenciesInput
+		if err := decodeParams(params, &input); err != nil {
+			return "", nil, err
+		}
+		input.Name = strings.TrimSpace(input.Name)
+		if input.MaxFiles < 0 || input.MaxFiles > maxLimitValue {
+			return "", nil, invalidLimitError("max_files")
+		}
+		if input.Unused && input.MaxFiles > 0 {
+			return "", nil, contracts.ToolError{Code: contracts.ErrorInvalidArgument, Message: "unused and max_files cannot be combined"}
+		}
+		if input.Limit < 0 || input.Limit > maxLimitValue {
+			return "", nil, invalidLimitError("limit")
+		}
 		return operation, input, nil
 	case contracts.OperationSystemSyncOut:
 		var input contracts.SystemSyncOutputsInput
@@ -304,6 +376,27 @@ func normalizeFormats(values []string) []string {
 	return out
 }
 
+func normalizeSimulationOperation(op *contracts.SimulationOperation) error {
+	op.Op = strings.ToLower(strings.TrimSpace(op.Op))
+	op.File = strings.TrimSpace(op.File)
+	op.Module = strings.TrimSpace(op.Module)
+	op.Glob = strings.TrimSpace(op.Glob)
+	op.From = strings.TrimSpace(op.From)
+	op.To = strings.TrimSpace(op.To)
+
+	required, ok := simulationRequiredFields[op.Op]
+	if !ok {
+		return fmt.Errorf("op must be one of: move_file, delete_edge, merge_modules, split_module")
+	}
+	values := map[string]string{"file": op.File, "module": op.Module, "glob": op.Glob, "from": op.From, "to": op.To}
+	for _, field := range required {
+		if values[field] == "" {
+			return fmt.Errorf("%s requires %s", op.Op, strings.Join(required, ", "))
+		}
+	}
+	return nil
+}
+
 func invalidLimitError(field string) error {
 	return contracts.ToolError{Code: contracts.ErrorInvalidArgument, Message: fmt.Sprintf("%s is out of range", field)}
 }
@@ -71,6 +71,46 @@ func TestParseToolArgs_SecretsList_InvalidLimit(t *testing.T) {
 	}
 }
 
+func TestParseToolArgs_QueryTrace_InvalidPaths(t *testing.T) {
+	raw := map[string]any{
+		"operation": string(contracts.OperationQueryTrace),
+		"params": map[string]any{
+			"from_module": "app/a",
+			"to_module":   "app/c",
+			"paths":       51,
+		},
+	}
+
+	_, _, err := ParseToolArgs(contracts.ToolNameCircular, raw, "")
+	if err == nil {
+		t.Fatal("expected error")
+	}
+}
+
+func TestParseToolArgs_QueryDependencies(t *testing.T) {
+	raw := map[string]any{
+		"operation": string(contracts.OperationQueryDeps),
+		"params": map[string]any{
+			"name":      " react ",
+			"max_files": 2,
+		},
+	}
+
+	_, parsed, err := ParseToolArgs(contracts.ToolNameCircular, raw, "")
+	if err != nil {
+		t.Fatalf("parse: %v", err)
+	}
+	input := parsed.(contracts.QueryDependenciesInput)
+	if input.Name != "react" || input.MaxFiles != 2 {
+		t.Fatalf("unexpected input: %+v", input)
+	}
+
+	raw["params"] = map[string]any{"unused": true, "max_files": 1}
+	if _, _, err := ParseToolArgs(contracts.ToolNameCircular, raw, ""); err == nil {
+		t.Fatal("expected error when combining unused and max_files")
+	}
+}
+
 func TestParseToolArgs_InvalidOperation(t *testing.T) {
 	raw := map[string]any{"operation": "nope"}
 	_, _, err := ParseToolArgs(contracts.ToolNameCircular, raw, "")
@@ -79,6 +119,43 @@ func TestParseToolArgs_InvalidOperation(t *testing.T) {
 	}
 }
 
+func TestParseToolArgs_GraphSimulate(t *testing.T) {
+	raw := map[string]any{
+		"operation": string(contracts.OperationGraphSimulate),
+		"params": map[string]any{
+			"operations": []any{
+				map[string]any{"op": " Move_File ", "file": " a/x.go ", "to": "b"},
+			},
+		},
+	}
+
+	op, input, err := ParseToolArgs(contracts.ToolNameCircular, raw, "")
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if op != contracts.OperationGraphSimulate {
+		t.Fatalf("expected operation %s, got %s", contracts.OperationGraphSimulate, op)
+	}
+	simInput, ok := input.(contracts.GraphSimulateInput)
+	if !ok {
+		t.Fatalf("expected GraphSimulateInput, got %T", input)
+	}
+	if got := simInput.Operations[0]; got.Op != "move_file" || got.File != "a/x.go" {
+		t.Fatalf("expected normalized operation, got %+v", got)
+	}
+
+	for _, params := range []map[string]any{
+		{},
+		{"operations": []any{map[string]any{"op": "rename", "from": "a", "to": "b"}}},
+		{"operations": []any{map[string]any{"op": "split_module", "module": "a", "to": "b"}}},
+	} {
+		raw := map[string]any{"operation": string(contracts.OperationGraphSimulate), "params": params}
+		if _, _, err := ParseToolArgs(contracts.ToolNameCircular, raw, ""); err == nil {
+			t.Fatalf("expected error for params %v", params)
+		}
+	}
+}
+
 func TestValidateToolArgs_ModuleDetails(t *testing.T) {
 	raw := map[string]any{
 		"operation": string(contracts.OperationQueryDetails),
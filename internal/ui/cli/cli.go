@@ -13,7 +13,10 @@ type cliOptions struct {
 	once           bool
 	ui             bool
 	trace          bool
+	diff           bool
 	impact         string
+	movePlan       string
+	simulate       string
 	history        bool
 	since          string
 	historyWindow  string
@@ -22,9 +25,16 @@ type cliOptions struct {
 	queryModules   bool
 	queryFilter    string
 	queryModule    string
+	querySymbol    string
 	queryTrace     string
 	queryTrends    bool
+	queryDeps      bool
+	depsUnused     bool
+	depsMaxFiles   int
 	queryLimit     int
+	paths          int
+	granularity    string
+	depth          int
 	includeTests   bool
 	verifyGrammars bool
 	reportMarkdown bool
@@ -43,19 +53,29 @@ func parseOptions(args []string) (cliOptions, error) {
 	fs.StringVar(&opts.configPath, "config", defaultConfigPath, "Path to config file")
 	fs.BoolVar(&opts.once, "once", false, "Run single scan and exit")
 	fs.BoolVar(&opts.ui, "ui", false, "Enable terminal UI mode")
-	fs.BoolVar(&opts.trace, "trace", false, "Trace shortest import chain between two modules")
-	fs.StringVar(&opts.impact, "impact", "", "Analyze change impact for a file path or module")
+	fs.BoolVar(&opts.trace, "trace", false, "Trace shortest import chain between two modules (or two module#Symbol definitions)")
+	fs.BoolVar(&opts.diff, "diff", false, "Compare two history snapshots (latest, previous, timestamp, or commit hash prefix); requires --history")
+	fs.StringVar(&opts.impact, "impact", "", "Analyze change impact for a file path, module, or module#Symbol definition")
+	fs.StringVar(&opts.movePlan, "move-plan", "", "Propose definition moves that break a cycle given as comma-separated modules (<a>,<b>[,...])")
+	fs.StringVar(&opts.simulate, "simulate", "", "Simulate refactoring operations from a JSON file and report cycle, violation, and metric changes")
 	fs.BoolVar(&opts.history, "history", false, "Enable local history snapshots and trend reporting")
 	fs.StringVar(&opts.since, "since", "", "Include historical snapshots at/after this timestamp (RFC3339 or YYYY-MM-DD)")
 	fs.StringVar(&opts.historyWindow, "history-window", "24h", "Moving-window duration for trend summaries (requires --history)")
 	fs.StringVar(&opts.historyTSV, "history-tsv", "", "Write trend report TSV to this path (requires --history)")
 	fs.StringVar(&opts.historyJSON, "history-json", "", "Write trend report JSON to this path (requires --history)")
 	fs.BoolVar(&opts.queryModules, "query-modules", false, "List modules from shared query service")
-	fs.StringVar(&opts.queryFilter, "query-filter", "", "Optional substring filter for --query-modules")
+	fs.StringVar(&opts.queryFilter, "query-filter", "", "Optional substring filter for --query-modules and --query-deps")
 	fs.StringVar(&opts.queryModule, "query-module", "", "Print module details from shared query service")
+	fs.StringVar(&opts.querySymbol, "query-symbol", "", "Print symbol-level dependencies and dependents for <module>#<symbol>")
 	fs.StringVar(&opts.queryTrace, "query-trace", "", "Print dependency trace from shared query service (<from>:<to>)")
 	fs.BoolVar(&opts.queryTrends, "query-trends", false, "Print historical trend slice from shared query service (requires --history)")
+	fs.BoolVar(&opts.queryDeps, "query-deps", false, "List third-party dependencies with the modules and files importing them")
+	fs.BoolVar(&opts.depsUnused, "query-deps-unused", false, "With --query-deps, list only declared dependencies no file imports")
+	fs.IntVar(&opts.depsMaxFiles, "query-deps-max-files", 0, "With --query-deps, list only dependencies imported by at most N files")
 	fs.IntVar(&opts.queryLimit, "query-limit", 0, "Optional limit/depth control for query modes")
+	fs.IntVar(&opts.paths, "paths", 0, "With --trace or --query-trace, list up to N shortest import paths with import locations (--query-limit caps depth)")
+	fs.StringVar(&opts.granularity, "granularity", "", "Graph nodes for cycles, metrics, diagrams, and --trace: module, file, or directory (overrides graph.granularity)")
+	fs.IntVar(&opts.depth, "depth", 0, "Roll graph nodes and --query-modules rows up to their first N path segments (overrides graph.depth)")
 	fs.BoolVar(&opts.includeTests, "include-tests", false, "Include test files in analysis (Go: _test.go, Python: test_*.py)")
 	fs.BoolVar(&opts.verifyGrammars, "verify-grammars", false, "Verify grammar artifacts against grammars/manifest.toml and exit")
 	fs.BoolVar(&opts.reportMarkdown, "report-md", false, "Generate markdown analysis report output (uses output.markdown or analysis-report.md)")
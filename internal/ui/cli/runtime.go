@@ -5,6 +5,8 @@ import (
 	"circular/internal/core/config"
 	"circular/internal/core/ports"
 	"circular/internal/data/history"
+	"circular/internal/data/query"
+	"circular/internal/engine/graph"
 	"circular/internal/engine/parser"
 	"circular/internal/engine/secrets"
 	mcpruntime "circular/internal/mcp/runtime"
@@ -13,6 +15,7 @@ import (
 	"circular/internal/ui/report"
 	"circular/internal/ui/report/formats"
 	"context"
+	"encoding/json"
 	"errors"
 	"fmt"
 	"log/slog"
@@ -226,7 +229,7 @@ func Run(args []string) int {
 		return 1
 	}
 
-	if stop, code := runQueryCommand(analysis, opts, queryHistoryStore, activeProject.Key); stop {
+	if stop, code := runQueryCommand(analysis, opts, cfg.Graph.Depth, queryHistoryStore, activeProject.Key); stop {
 		return code
 	}
 
@@ -285,7 +288,13 @@ func runSingleCommand(analysis ports.AnalysisService, opts cliOptions) (bool, in
 	}
 
 	if opts.trace {
-		out, err := analysis.TraceImportChain(context.Background(), opts.args[0], opts.args[1])
+		var out string
+		var err error
+		if opts.paths > 0 {
+			out, err = analysis.TraceImportPaths(context.Background(), opts.args[0], opts.args[1], opts.paths, opts.queryLimit)
+		} else {
+			out, err = analysis.TraceImportChain(context.Background(), opts.args[0], opts.args[1])
+		}
 		if err != nil {
 			fmt.Fprintln(os.Stderr, err.Error())
 			return true, 1
@@ -304,11 +313,41 @@ func runSingleCommand(analysis ports.AnalysisService, opts cliOptions) (bool, in
 		return true, 0
 	}
 
+	if opts.movePlan != "" {
+		cycle, err := parseMovePlanCycle(opts.movePlan)
+		if err != nil {
+			fmt.Fprintln(os.Stderr, err.Error())
+			return true, 1
+		}
+		plan, err := analysis.PlanCycleRelocation(context.Background(), cycle)
+		if err != nil {
+			fmt.Fprintln(os.Stderr, err.Error())
+			return true, 1
+		}
+		fmt.Print(coreapp.FormatRelocationPlan(plan))
+		return true, 0
+	}
+
+	if opts.simulate != "" {
+		ops, err := loadSimulationOps(opts.simulate)
+		if err != nil {
+			fmt.Fprintln(os.Stderr, err.Error())
+			return true, 1
+		}
+		result, err := analysis.SimulateRefactor(context.Background(), ops)
+		if err != nil {
+			fmt.Fprintln(os.Stderr, err.Error())
+			return true, 1
+		}
+		fmt.Print(coreapp.FormatSimulationResult(result))
+		return true, 0
+	}
+
 	return false, 0
 }
 
-func runQueryCommand(analysis ports.AnalysisService, opts cliOptions, historyStore ports.HistoryStore, projectKey string) (bool, int) {
-	if !opts.queryModules && opts.queryModule == "" && opts.queryTrace == "" && !opts.queryTrends {
+func runQueryCommand(analysis ports.AnalysisService, opts cliOptions, depth int, historyStore ports.HistoryStore, projectKey string) (bool, int) {
+	if !opts.queryModules && opts.queryModule == "" && opts.querySymbol == "" && opts.queryTrace == "" && !opts.queryTrends && !opts.queryDeps && !opts.diff {
 		return false, 0
 	}
 
@@ -324,6 +363,18 @@ func runQueryCommand(analysis ports.AnalysisService, opts cliOptions, historySto
 	ctx := context.Background()
 
 	switch {
+	case opts.diff:
+		if historyStore == nil {
+			fmt.Fprintln(os.Stderr, "--diff requires --history")
+			return true, 1
+		}
+		diff, err := svc.SnapshotDiff(ctx, opts.args[0], opts.args[1])
+		if err != nil {
+			fmt.Fprintln(os.Stderr, err.Error())
+			return true, 1
+		}
+		fmt.Print(coreapp.FormatSnapshotDiff(diff))
+		return true, 0
 	case opts.queryModule != "":
 		details, err := svc.ModuleDetails(ctx, strings.TrimSpace(opts.queryModule))
 		if err != nil {
@@ -333,6 +384,8 @@ func runQueryCommand(analysis ports.AnalysisService, opts cliOptions, historySto
 		fmt.Printf("Module: %s\n", details.Name)
 		fmt.Printf("Files: %d, Exports: %d, Dependencies: %d, ReverseDependencies: %d\n",
 			len(details.Files), len(details.ExportedSymbols), len(details.Dependencies), len(details.ReverseDependencies))
+		c := details.Coupling
+		fmt.Printf("Coupling: Ca=%d Ce=%d I=%.2f A=%.2f D=%.2f\n", c.Afferent, c.Efferent, c.Instability, c.Abstractness, c.Distance)
 		if len(details.Files) > 0 {
 			fmt.Println("File list:")
 			for _, file := range details.Files {
@@ -340,12 +393,42 @@ func runQueryCommand(analysis ports.AnalysisService, opts cliOptions, historySto
 			}
 		}
 		return true, 0
+	case opts.querySymbol != "":
+		details, err := svc.SymbolDetails(ctx, strings.TrimSpace(opts.querySymbol))
+		if err != nil {
+			fmt.Fprintln(os.Stderr, err.Error())
+			return true, 1
+		}
+		fmt.Printf("Symbol: %s (%s:%d)\n", details.Symbol, details.File, details.Line)
+		fmt.Printf("Dependencies (%d):\n", len(details.Dependencies))
+		for _, dep := range details.Dependencies {
+			fmt.Printf("  -> %s (%s:%d)\n", dep.To, dep.File, dep.Line)
+		}
+		fmt.Printf("Dependents (%d):\n", len(details.Dependents))
+		for _, dep := range details.Dependents {
+			fmt.Printf("  <- %s (%s:%d)\n", dep.From, dep.File, dep.Line)
+		}
+		return true, 0
 	case opts.queryTrace != "":
 		from, to, err := parseQueryTrace(opts.queryTrace)
 		if err != nil {
 			fmt.Fprintln(os.Stderr, err.Error())
 			return true, 1
 		}
+		if opts.paths > 0 {
+			result, err := svc.DependencyPaths(ctx, from, to, opts.paths, opts.queryLimit)
+			if err != nil {
+				fmt.Fprintln(os.Stderr, err.Error())
+				return true, 1
+			}
+			for i, path := range result.Paths {
+				fmt.Printf("Path %d depth=%d: %s\n", i+1, path.Depth, strings.Join(path.Path, " -> "))
+				for _, hop := range path.Hops {
+					fmt.Printf("  %s -> %s (%s:%d)\n", hop.From, hop.To, hop.File, hop.Line)
+				}
+			}
+			return true, 0
+		}
 		trace, err := svc.DependencyTrace(ctx, from, to, opts.queryLimit)
 		if err != nil {
 			fmt.Fprintln(os.Stderr, err.Error())
@@ -353,6 +436,18 @@ func runQueryCommand(analysis ports.AnalysisService, opts cliOptions, historySto
 		}
 		fmt.Printf("Trace depth=%d: %s\n", trace.Depth, strings.Join(trace.Path, " -> "))
 		return true, 0
+	case opts.queryDeps:
+		deps, err := svc.ExternalDependencies(ctx, query.ExternalDependencyFilter{
+			Name:     strings.TrimSpace(opts.queryFilter),
+			MaxFiles: opts.depsMaxFiles,
+			Unused:   opts.depsUnused,
+		}, opts.queryLimit)
+		if err != nil {
+			fmt.Fprintln(os.Stderr, err.Error())
+			return true, 1
+		}
+		fmt.Print(coreapp.FormatExternalDependencies(deps))
+		return true, 0
 	case opts.queryTrends:
 		if historyStore == nil {
 			fmt.Fprintln(os.Stderr, "--query-trends requires --history")
@@ -382,15 +477,20 @@ func runQueryCommand(analysis ports.AnalysisService, opts cliOptions, historySto
 		return true, 0
 	default:
 		filter := strings.TrimSpace(opts.queryFilter)
-		modules, err := svc.ListModules(ctx, filter, opts.queryLimit)
+		modules, err := svc.ListModulesAtDepth(ctx, filter, depth, opts.queryLimit)
 		if err != nil {
 			fmt.Fprintln(os.Stderr, err.Error())
 			return true, 1
 		}
 		fmt.Printf("Modules (%d):\n", len(modules))
 		for _, module := range modules {
-			fmt.Printf("  %s files=%d exports=%d deps=%d imported_by=%d\n",
+			rolled := ""
+			if depth > 0 {
+				rolled = fmt.Sprintf(" modules=%d", module.ModuleCount)
+			}
+			fmt.Printf("  %s%s files=%d exports=%d deps=%d imported_by=%d\n",
 				module.Name,
+				rolled,
 				module.FileCount,
 				module.ExportCount,
 				module.DependencyCount,
@@ -460,11 +560,39 @@ func applyModeOptions(opts *cliOptions, cfg *config.Config) error {
 	if opts.impact != "" {
 		modeCount++
 	}
-	if opts.queryModules || opts.queryModule != "" || opts.queryTrace != "" || opts.queryTrends {
+	if opts.movePlan != "" {
+		modeCount++
+	}
+	if opts.simulate != "" {
+		modeCount++
+	}
+	if opts.diff {
+		modeCount++
+	}
+	if opts.queryModules || opts.queryModule != "" || opts.querySymbol != "" || opts.queryTrace != "" || opts.queryTrends || opts.queryDeps {
 		modeCount++
 	}
 	if modeCount > 1 {
-		return fmt.Errorf("--verify-grammars, --trace, --impact, and --query-* modes cannot be combined")
+		return fmt.Errorf("--verify-grammars, --trace, --impact, --move-plan, --simulate, --diff, and --query-* modes cannot be combined")
+	}
+	if opts.paths < 0 {
+		return fmt.Errorf("--paths must be >= 0")
+	}
+	if opts.granularity != "" {
+		level, err := graph.ParseGranularity(opts.granularity)
+		if err != nil {
+			return fmt.Errorf("--granularity: %w", err)
+		}
+		cfg.Graph.Granularity = string(level)
+	}
+	if opts.depth < 0 {
+		return fmt.Errorf("--depth must be >= 0")
+	}
+	if opts.depth > 0 {
+		cfg.Graph.Depth = opts.depth
+	}
+	if opts.paths > 0 && !opts.trace && opts.queryTrace == "" {
+		return fmt.Errorf("--paths requires --trace or --query-trace")
 	}
 
 	if opts.verifyGrammars {
@@ -481,6 +609,16 @@ func applyModeOptions(opts *cliOptions, cfg *config.Config) error {
 		return nil
 	}
 
+	if opts.diff {
+		if len(opts.args) != 2 {
+			return fmt.Errorf("diff mode requires two snapshot arguments: circular --history --diff <snapA> <snapB>")
+		}
+		if !opts.history {
+			return fmt.Errorf("--diff requires --history")
+		}
+		return nil
+	}
+
 	if len(opts.args) > 0 {
 		cfg.WatchPaths = []string{opts.args[0]}
 	}
@@ -490,6 +628,16 @@ func applyModeOptions(opts *cliOptions, cfg *config.Config) error {
 			return err
 		}
 	}
+	if opts.movePlan != "" {
+		if _, err := parseMovePlanCycle(opts.movePlan); err != nil {
+			return err
+		}
+	}
+	if opts.simulate != "" {
+		if _, err := loadSimulationOps(opts.simulate); err != nil {
+			return err
+		}
+	}
 
 	if (opts.historyTSV != "" || opts.historyJSON != "") && !opts.history {
 		return fmt.Errorf("--history-tsv/--history-json require --history")
@@ -497,6 +645,9 @@ func applyModeOptions(opts *cliOptions, cfg *config.Config) error {
 	if opts.queryTrends && !opts.history {
 		return fmt.Errorf("--query-trends requires --history")
 	}
+	if (opts.depsUnused || opts.depsMaxFiles != 0) && !opts.queryDeps {
+		return fmt.Errorf("--query-deps-unused/--query-deps-max-files require --query-deps")
+	}
 	if opts.history {
 		if _, err := parseHistoryWindow(opts.historyWindow); err != nil {
 			return err
@@ -595,6 +746,9 @@ func runHistoryMode(
 			latest.DeltaUnresolved,
 		)
 	}
+	for _, provenance := range trendReport.Cycles {
+		fmt.Printf("Cycle %s\n", coreapp.FormatCycleProvenance(provenance))
+	}
 
 	if opts.historyTSV != "" {
 		tsv, err := report.RenderTrendTSV(*trendReport)
@@ -680,8 +834,8 @@ func resolveRuntimeProject(cfg *config.Config, paths config.ResolvedPaths, cwd s
 
 func validateModeCompatibility(opts cliOptions, cfg *config.Config) error {
 	if cfg.MCP.Enabled {
-		if opts.ui || opts.once || opts.verifyGrammars || opts.trace || opts.impact != "" || opts.history || opts.reportMarkdown ||
-			opts.queryModules || opts.queryModule != "" || opts.queryTrace != "" || opts.queryTrends || len(opts.args) > 0 {
+		if opts.ui || opts.once || opts.verifyGrammars || opts.trace || opts.impact != "" || opts.movePlan != "" || opts.simulate != "" || opts.diff || opts.history || opts.reportMarkdown ||
+			opts.queryModules || opts.queryModule != "" || opts.querySymbol != "" || opts.queryTrace != "" || opts.queryTrends || opts.queryDeps || len(opts.args) > 0 {
 			return fmt.Errorf("mcp.enabled=true cannot be combined with CLI modes or positional path arguments")
 		}
 	}
@@ -783,6 +937,7 @@ func buildGrammarRegistry(cfg *config.Config) (map[string]parser.LanguageSpec, e
 				NamespaceNode:   dg.NamespaceNode,
 				ImportNode:      dg.ImportNode,
 				DefinitionNodes: dg.DefinitionNodes,
+				Queries:         dg.Queries,
 			},
 		})
 	}
@@ -798,6 +953,59 @@ func parseQueryTrace(raw string) (string, string, error) {
 	return strings.TrimSpace(parts[0]), strings.TrimSpace(parts[1]), nil
 }
 
+func parseMovePlanCycle(raw string) ([]string, error) {
+	modules := make([]string, 0)
+	for _, part := range strings.Split(raw, ",") {
+		if part = strings.TrimSpace(part); part != "" {
+			modules = append(modules, part)
+		}
+	}
+	if len(modules) < 2 {
+		return nil, fmt.Errorf("--move-plan must list at least two modules: <a>,<b>[,...]")
+	}
+	return modules, nil
+}
+
+// simulationOpFile is one entry of a --simulate operations file.
+type simulationOpFile struct {
+	Op     string `json:"op"`
+	File   string `json:"file"`
+	Module string `json:"module"`
+	Glob   string `json:"glob"`
+	From   string `json:"from"`
+	To     string `json:"to"`
+}
+
+func loadSimulationOps(path string) ([]graph.SimulationOp, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("read --simulate file: %w", err)
+	}
+	var entries []simulationOpFile
+	if err := json.Unmarshal(data, &entries); err != nil {
+		return nil, fmt.Errorf("parse --simulate file %s: %w", path, err)
+	}
+	if len(entries) == 0 {
+		return nil, fmt.Errorf("--simulate file %s lists no operations", path)
+	}
+	ops := make([]graph.SimulationOp, 0, len(entries))
+	for i, entry := range entries {
+		op := graph.SimulationOp{
+			Kind:   strings.TrimSpace(entry.Op),
+			File:   strings.TrimSpace(entry.File),
+			Module: strings.TrimSpace(entry.Module),
+			Glob:   strings.TrimSpace(entry.Glob),
+			From:   strings.TrimSpace(entry.From),
+			To:     strings.TrimSpace(entry.To),
+		}
+		if err := op.Validate(); err != nil {
+			return nil, fmt.Errorf("--simulate operation %d: %w", i+1, err)
+		}
+		ops = append(ops, op)
+	}
+	return ops, nil
+}
+
 func configureLogging(uiMode, verbose bool) func() {
 	logLevel := slog.LevelInfo
 	if verbose {
@@ -864,6 +1072,9 @@ func writeSARIFReport(
 	data, err := formats.GenerateSARIF(
 		projectRoot,
 		snapshot.Cycles,
+		snapshot.CycleCuts,
+		snapshot.DeadCode,
+		snapshot.Encapsulation,
 		snapshot.Violations,
 		snapshot.RuleViolations,
 		allSecrets,
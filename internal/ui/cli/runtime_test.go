@@ -37,6 +37,52 @@ func TestApplyModeOptions_RejectsTraceAndImpact(t *testing.T) {
 	}
 }
 
+func TestApplyModeOptions_MovePlanRequiresTwoModules(t *testing.T) {
+	opts := &cliOptions{movePlan: "app/a"}
+	cfg := &config.Config{}
+
+	err := applyModeOptions(opts, cfg)
+	if err == nil {
+		t.Fatal("expected error")
+	}
+	if !strings.Contains(err.Error(), "at least two modules") {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	cycle, err := parseMovePlanCycle(" app/a, app/b ,")
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if len(cycle) != 2 || cycle[0] != "app/a" || cycle[1] != "app/b" {
+		t.Fatalf("unexpected cycle: %v", cycle)
+	}
+}
+
+func TestLoadSimulationOps(t *testing.T) {
+	dir := t.TempDir()
+	valid := filepath.Join(dir, "ops.json")
+	content := `[{"op":"move_file","file":"internal/a/x.go","to":"internal/b"},{"op":"delete_edge","from":"b","to":"a"}]`
+	if err := os.WriteFile(valid, []byte(content), 0o644); err != nil {
+		t.Fatal(err)
+	}
+	ops, err := loadSimulationOps(valid)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if len(ops) != 2 || ops[0].Kind != graph.SimulateMoveFile || ops[1].From != "b" {
+		t.Fatalf("unexpected ops: %+v", ops)
+	}
+
+	invalid := filepath.Join(dir, "bad.json")
+	if err := os.WriteFile(invalid, []byte(`[{"op":"split_module","module":"a"}]`), 0o644); err != nil {
+		t.Fatal(err)
+	}
+	err = applyModeOptions(&cliOptions{simulate: invalid}, &config.Config{})
+	if err == nil || !strings.Contains(err.Error(), "operation 1") {
+		t.Fatalf("expected operation validation error, got %v", err)
+	}
+}
+
 func TestApplyModeOptions_TraceRequiresTwoArgs(t *testing.T) {
 	opts := &cliOptions{trace: true, args: []string{"only-one"}}
 	cfg := &config.Config{}
@@ -50,6 +96,71 @@ func TestApplyModeOptions_TraceRequiresTwoArgs(t *testing.T) {
 	}
 }
 
+func TestApplyModeOptions_PathsRequiresTrace(t *testing.T) {
+	err := applyModeOptions(&cliOptions{paths: 3}, &config.Config{})
+	if err == nil || !strings.Contains(err.Error(), "--paths requires --trace or --query-trace") {
+		t.Fatalf("expected trace requirement error, got %v", err)
+	}
+
+	err = applyModeOptions(&cliOptions{trace: true, paths: -1, args: []string{"a", "b"}}, &config.Config{})
+	if err == nil || !strings.Contains(err.Error(), "--paths must be >= 0") {
+		t.Fatalf("expected negative paths error, got %v", err)
+	}
+
+	if err := applyModeOptions(&cliOptions{trace: true, paths: 3, args: []string{"a", "b"}}, &config.Config{}); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if err := applyModeOptions(&cliOptions{queryTrace: "a:b", paths: 3}, &config.Config{}); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+}
+
+func TestApplyModeOptions_GranularityOverridesConfig(t *testing.T) {
+	cfg := &config.Config{Graph: config.GraphSettings{Granularity: "module"}}
+	if err := applyModeOptions(&cliOptions{granularity: "File"}, cfg); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if cfg.Graph.Granularity != "file" {
+		t.Fatalf("expected file granularity, got %q", cfg.Graph.Granularity)
+	}
+	if err := applyModeOptions(&cliOptions{granularity: "package"}, cfg); err == nil || !strings.Contains(err.Error(), "--granularity") {
+		t.Fatalf("expected granularity error, got %v", err)
+	}
+}
+
+func TestApplyModeOptions_DepthOverridesConfig(t *testing.T) {
+	cfg := &config.Config{Graph: config.GraphSettings{Depth: 1}}
+	if err := applyModeOptions(&cliOptions{}, cfg); err != nil || cfg.Graph.Depth != 1 {
+		t.Fatalf("expected config depth to be kept, got %d (%v)", cfg.Graph.Depth, err)
+	}
+	if err := applyModeOptions(&cliOptions{depth: 3}, cfg); err != nil || cfg.Graph.Depth != 3 {
+		t.Fatalf("expected --depth to override config, got %d (%v)", cfg.Graph.Depth, err)
+	}
+	if err := applyModeOptions(&cliOptions{depth: -1}, cfg); err == nil || !strings.Contains(err.Error(), "--depth") {
+		t.Fatalf("expected depth error, got %v", err)
+	}
+}
+
+func TestApplyModeOptions_DiffRequiresHistoryAndTwoSnapshots(t *testing.T) {
+	err := applyModeOptions(&cliOptions{diff: true, history: true, args: []string{"latest"}}, &config.Config{})
+	if err == nil || !strings.Contains(err.Error(), "requires two snapshot arguments") {
+		t.Fatalf("expected argument count error, got %v", err)
+	}
+
+	err = applyModeOptions(&cliOptions{diff: true, args: []string{"previous", "latest"}}, &config.Config{})
+	if err == nil || !strings.Contains(err.Error(), "--diff requires --history") {
+		t.Fatalf("expected history requirement error, got %v", err)
+	}
+
+	cfg := &config.Config{WatchPaths: []string{"./original"}}
+	if err := applyModeOptions(&cliOptions{diff: true, history: true, args: []string{"previous", "latest"}}, cfg); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if cfg.WatchPaths[0] != "./original" {
+		t.Fatalf("diff arguments must not override watch paths, got %v", cfg.WatchPaths)
+	}
+}
+
 func TestApplyModeOptions_OverridesWatchPathWithPositionalArg(t *testing.T) {
 	opts := &cliOptions{args: []string{"./override"}}
 	cfg := &config.Config{WatchPaths: []string{"./original"}}
@@ -222,6 +333,9 @@ func TestRunHistoryMode_SQLiteIntegration(t *testing.T) {
 	if snapshots[0].ModuleCount != 1 || snapshots[0].FileCount != 1 {
 		t.Fatalf("unexpected saved snapshot counts: %+v", snapshots[0])
 	}
+	if len(snapshots[0].Modules) != 1 || snapshots[0].Modules[0].Name != "app/a" || snapshots[0].Modules[0].Files != 1 {
+		t.Fatalf("expected per-module detail in saved snapshot, got %+v", snapshots[0].Modules)
+	}
 }
 
 func TestLoadConfig_DefaultDiscoveryOrder(t *testing.T) {
@@ -391,3 +505,16 @@ func (f *recordingAnalysisFactory) New(_ *config.Config, includeTests bool) (por
 	f.includeTests = includeTests
 	return nil, f.err
 }
+
+func TestApplyModeOptions_QueryDepsFiltersRequireQueryDeps(t *testing.T) {
+	err := applyModeOptions(&cliOptions{depsUnused: true}, &config.Config{})
+	if err == nil {
+		t.Fatal("expected error")
+	}
+	if !strings.Contains(err.Error(), "require --query-deps") {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if err := applyModeOptions(&cliOptions{queryDeps: true, depsMaxFiles: 1}, &config.Config{}); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+}
@@ -4,6 +4,7 @@ import (
 	"circular/internal/core/ports"
 	"circular/internal/data/history"
 	"circular/internal/data/query"
+	"circular/internal/engine/graph"
 	"circular/internal/engine/resolver"
 	"fmt"
 	"strings"
@@ -67,6 +68,9 @@ type model struct {
 	moduleDetailsErr string
 	selectedDepIndex int
 	sourceJumpStatus string
+
+	moduleDepth    int // path segments module rows are rolled up to; 0 lists full names
+	maxModuleDepth int
 }
 
 type panelMode int
@@ -109,7 +113,6 @@ func (m model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 	case updateMsg:
 		m.cycles = msg.cycles
 		m.hallucinations = msg.hallucinations
-		m.modules = msg.modules
 		m.moduleCount = msg.moduleCount
 		m.fileCount = msg.fileCount
 		m.lastUpdate = time.Now()
@@ -130,20 +133,13 @@ func (m model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 		}
 		m.issueList.SetItems(items)
 
-		moduleItems := make([]list.Item, 0, len(m.modules))
-		for _, module := range m.modules {
-			moduleItems = append(moduleItems, item{
-				title: module.Name,
-				desc: fmt.Sprintf(
-					"files=%d exports=%d deps=%d imported_by=%d",
-					module.FileCount,
-					module.ExportCount,
-					module.DependencyCount,
-					module.ReverseDependencyCount,
-				),
-			})
+		m.maxModuleDepth = maxModuleDepth(msg.modules)
+		if m.moduleDepth > 0 && m.querySvc != nil {
+			m = loadModuleDepth(m)
+		} else {
+			m.moduleDepth = 0
+			m = setModuleRows(m, msg.modules)
 		}
-		m.moduleList.SetItems(moduleItems)
 		if m.hasModuleDetails {
 			m, _ = refreshModuleDetails(m)
 		}
@@ -194,6 +190,37 @@ func (m model) View() string {
 	return docStyle.Render(header + "\n" + help + "\n\n" + body)
 }
 
+// setModuleRows replaces the module explorer rows.
+func setModuleRows(m model, modules []query.ModuleSummary) model {
+	m.modules = modules
+	moduleItems := make([]list.Item, 0, len(modules))
+	for _, module := range modules {
+		desc := fmt.Sprintf(
+			"files=%d exports=%d deps=%d imported_by=%d",
+			module.FileCount,
+			module.ExportCount,
+			module.DependencyCount,
+			module.ReverseDependencyCount,
+		)
+		if m.moduleDepth > 0 {
+			desc = fmt.Sprintf("modules=%d %s", module.ModuleCount, desc)
+		}
+		moduleItems = append(moduleItems, item{title: module.Name, desc: desc})
+	}
+	m.moduleList.SetItems(moduleItems)
+	return m
+}
+
+func maxModuleDepth(modules []query.ModuleSummary) int {
+	depth := 0
+	for _, module := range modules {
+		if n := len(graph.ModuleSegments(module.Name)); n > depth {
+			depth = n
+		}
+	}
+	return depth
+}
+
 func initialModel(service ports.QueryService, trendReport *history.TrendReport) model {
 	issueList := list.New([]list.Item{}, list.NewDefaultDelegate(), 0, 0)
 	issueList.Title = "Detected Issues"
@@ -1,6 +1,7 @@
 package cli
 
 import (
+	"circular/internal/engine/graph"
 	"context"
 	"fmt"
 	"os"
@@ -34,7 +35,14 @@ func handleKeyActions(msg tea.KeyMsg, m model) (tea.Model, tea.Cmd) {
 
 	switch msg.String() {
 	case "enter":
+		if m.moduleDepth > 0 {
+			return expandModulePrefix(m)
+		}
 		return refreshModuleDetails(m)
+	case "[":
+		return changeModuleDepth(m, -1), nil
+	case "]":
+		return changeModuleDepth(m, 1), nil
 	case "esc", "backspace":
 		m.hasModuleDetails = false
 		m.moduleDetailsErr = ""
@@ -92,6 +100,64 @@ func refreshModuleDetails(m model) (model, tea.Cmd) {
 	return m, nil
 }
 
+// changeModuleDepth moves the module list one level up (-1) or down (1) the
+// module path hierarchy. Full module names sit below the deepest prefix level.
+func changeModuleDepth(m model, step int) model {
+	if m.querySvc == nil || m.maxModuleDepth < 2 {
+		return m
+	}
+	depth := m.moduleDepth
+	if depth == 0 {
+		depth = m.maxModuleDepth
+	}
+	depth += step
+	if depth < 1 {
+		depth = 1
+	}
+	if depth >= m.maxModuleDepth {
+		depth = 0
+	}
+	m.moduleDepth = depth
+	m.hasModuleDetails = false
+	m.selectedDepIndex = 0
+	return loadModuleDepth(m)
+}
+
+// loadModuleDepth lists modules rolled up to the current depth.
+func loadModuleDepth(m model) model {
+	modules, err := m.querySvc.ListModulesAtDepth(context.Background(), "", m.moduleDepth, 0)
+	if err != nil {
+		m.moduleDetailsErr = err.Error()
+		return m
+	}
+	return setModuleRows(m, modules)
+}
+
+// expandModulePrefix opens the selected row one level deeper, or shows its
+// details when the row is already a full module name.
+func expandModulePrefix(m model) (model, tea.Cmd) {
+	if len(m.modules) == 0 {
+		return m, nil
+	}
+	idx := m.moduleList.Index()
+	if idx < 0 || idx >= len(m.modules) {
+		idx = 0
+	}
+	prefix := m.modules[idx].Name
+	segments := len(graph.ModuleSegments(prefix))
+	if segments < m.moduleDepth {
+		return refreshModuleDetails(m)
+	}
+	m = changeModuleDepth(m, 1)
+	for i, module := range m.modules {
+		if graph.ModulePrefix(module.Name, segments) == prefix {
+			m.moduleList.Select(i)
+			break
+		}
+	}
+	return m, nil
+}
+
 type sourceTarget struct {
 	file string
 	line int
@@ -5,6 +5,7 @@ import (
 	"circular/internal/engine/graph"
 	"circular/internal/engine/parser"
 	"circular/internal/engine/resolver"
+	"context"
 	"testing"
 
 	tea "github.com/charmbracelet/bubbletea"
@@ -105,3 +106,43 @@ func TestModel_ModuleDrillDownAndTrendToggle(t *testing.T) {
 		t.Fatal("expected module details to close on esc")
 	}
 }
+
+func TestModel_ModuleTreeDepth(t *testing.T) {
+	g := graph.NewGraph()
+	g.AddFile(&parser.File{Path: "g.go", Module: "app/engine/graph", Imports: []parser.Import{{Module: "app/shared"}}})
+	g.AddFile(&parser.File{Path: "p.go", Module: "app/engine/parser", Imports: []parser.Import{{Module: "app/shared"}}})
+	g.AddFile(&parser.File{Path: "s.go", Module: "app/shared"})
+	svc := query.NewService(g, nil, "default")
+	modules, err := svc.ListModules(context.Background(), "", 0)
+	if err != nil {
+		t.Fatalf("list modules: %v", err)
+	}
+
+	updated, _ := initialModel(svc, nil).Update(updateMsg{modules: modules, moduleCount: 3, fileCount: 3})
+	state := updated.(model)
+	updated, _ = state.Update(tea.KeyMsg{Type: tea.KeyTab})
+	state = updated.(model)
+
+	updated, _ = state.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune{'['}})
+	state = updated.(model)
+	if state.moduleDepth != 2 || len(state.modules) != 2 {
+		t.Fatalf("expected two depth-2 rows, got depth=%d rows=%+v", state.moduleDepth, state.modules)
+	}
+	if state.modules[0].Name != "app/engine" || state.modules[0].ModuleCount != 2 || state.modules[0].DependencyCount != 1 {
+		t.Fatalf("unexpected rolled-up row: %+v", state.modules[0])
+	}
+
+	updated, _ = state.Update(tea.KeyMsg{Type: tea.KeyEnter})
+	state = updated.(model)
+	if state.moduleDepth != 0 || len(state.modules) != 3 || state.moduleList.Index() != 0 {
+		t.Fatalf("expected enter to expand app/engine to full names, got depth=%d index=%d", state.moduleDepth, state.moduleList.Index())
+	}
+
+	updated, _ = state.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune{'['}})
+	state = updated.(model)
+	updated, _ = state.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune{'['}})
+	state = updated.(model)
+	if state.moduleDepth != 1 || len(state.modules) != 1 || state.modules[0].Name != "app" {
+		t.Fatalf("expected single app root at depth 1, got depth=%d rows=%+v", state.moduleDepth, state.modules)
+	}
+}
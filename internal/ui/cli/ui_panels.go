@@ -7,7 +7,7 @@ import (
 )
 
 func renderHelp(m model) string {
-	keys := "Keys: tab panel | / filter | enter details | esc back | t trend overlay | j/k dependency cursor | o open source | q quit"
+	keys := "Keys: tab panel | / filter | enter details | esc back | [/] tree depth | t trend overlay | j/k dependency cursor | o open source | q quit"
 	if m.mode == panelIssues {
 		keys = "Keys: tab panel | / filter | q quit"
 	}
@@ -32,6 +32,18 @@ func renderModuleSummary(m model) string {
 		idx = 0
 	}
 	selected := m.modules[idx]
+	if m.moduleDepth > 0 {
+		return strings.Join([]string{
+			fmt.Sprintf("Selected Prefix (depth %d)", m.moduleDepth),
+			fmt.Sprintf("  Name: %s", selected.Name),
+			fmt.Sprintf("  Modules: %d", selected.ModuleCount),
+			fmt.Sprintf("  Files: %d", selected.FileCount),
+			fmt.Sprintf("  Exports: %d", selected.ExportCount),
+			fmt.Sprintf("  Dependencies: %d", selected.DependencyCount),
+			fmt.Sprintf("  Imported by: %d", selected.ReverseDependencyCount),
+			"  Press enter to expand, [ and ] to change depth.",
+		}, "\n")
+	}
 	return strings.Join([]string{
 		"Selected Module",
 		fmt.Sprintf("  Name: %s", selected.Name),
@@ -53,6 +65,7 @@ func renderModuleDetails(m model) string {
 		fmt.Sprintf("  Files (%d): %s", len(d.Files), strings.Join(d.Files, ", ")),
 		fmt.Sprintf("  Exports (%d): %s", len(d.ExportedSymbols), strings.Join(d.ExportedSymbols, ", ")),
 		fmt.Sprintf("  Reverse dependencies (%d): %s", len(d.ReverseDependencies), strings.Join(d.ReverseDependencies, ", ")),
+		fmt.Sprintf("  Coupling: Ca=%d Ce=%d I=%.2f A=%.2f D=%.2f", d.Coupling.Afferent, d.Coupling.Efferent, d.Coupling.Instability, d.Coupling.Abstractness, d.Coupling.Distance),
 		fmt.Sprintf("  Dependencies (%d):", len(d.Dependencies)),
 	}
 	for i, edge := range d.Dependencies {
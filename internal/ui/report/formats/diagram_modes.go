@@ -12,6 +12,9 @@ import (
 
 const maxDefinitionNodesPerModule = 12
 
+// clusterPalette holds the fill colours cycled through by cluster diagrams.
+var clusterPalette = []string{"#dbeafe", "#dcfce7", "#fef9c3", "#fde2e4", "#ede9fe", "#ffedd5", "#cffafe", "#f5f5f4"}
+
 type componentEdge struct {
 	From       string
 	To         string
@@ -27,6 +30,12 @@ type componentDiagramData struct {
 	Edges       []componentEdge
 }
 
+type clusterDiagramData struct {
+	componentDiagramData
+	Communities graph.CommunityReport
+	Misplaced   map[string]bool
+}
+
 type flowNode struct {
 	Name  string
 	Depth int
@@ -178,6 +187,28 @@ func buildComponentDiagramData(g *graph.Graph, showInternal bool) componentDiagr
 	}
 }
 
+// buildClusterDiagramData pairs the component view's weighted module edges with
+// the communities found over the same graph.
+func buildClusterDiagramData(g *graph.Graph) clusterDiagramData {
+	data := clusterDiagramData{
+		componentDiagramData: buildComponentDiagramData(g, false),
+		Communities:          g.DetectCommunities(),
+		Misplaced:            make(map[string]bool),
+	}
+	for _, suggestion := range data.Communities.Misplaced {
+		data.Misplaced[suggestion.Module] = true
+	}
+	return data
+}
+
+func clusterFill(id int) string {
+	return clusterPalette[id%len(clusterPalette)]
+}
+
+func clusterTitle(community graph.ModuleCommunity) string {
+	return fmt.Sprintf("cluster %d: %s", community.ID+1, community.Group)
+}
+
 func buildFlowDiagramData(g *graph.Graph, entryPoints []string, maxDepth int) (flowDiagramData, error) {
 	if maxDepth < 1 {
 		return flowDiagramData{}, fmt.Errorf("flow diagram max depth must be >= 1")
@@ -135,7 +135,7 @@ func (d *DOTGenerator) Generate(cycles [][]string) (string, error) {
 
 	// Edges
 	for from, targets := range allImports {
-		for to := range targets {
+		for to, edge := range targets {
 			isCycle := cycleEdges[from] != nil && cycleEdges[from][to]
 			isInternalFrom := internalModules[from] != nil
 			isInternalTo := internalModules[to] != nil
@@ -143,7 +143,7 @@ func (d *DOTGenerator) Generate(cycles [][]string) (string, error) {
 			if isCycle {
 				buf.WriteString(fmt.Sprintf("  \"%s\" -> \"%s\" [color=\"red\", penwidth=3.0, label=\"CYCLE\"];\n", from, to))
 			} else if isInternalFrom && isInternalTo {
-				buf.WriteString(fmt.Sprintf("  \"%s\" -> \"%s\" [color=\"forestgreen\", penwidth=1.8];\n", from, to))
+				buf.WriteString(fmt.Sprintf("  \"%s\" -> \"%s\" [color=\"forestgreen\", penwidth=%d, label=\"%s\"];\n", from, to, edgeWidth(edge), edgeWeightLabel(edge)))
 			} else {
 				buf.WriteString(fmt.Sprintf("  \"%s\" -> \"%s\" [color=\"grey\", style=dashed];\n", from, to))
 			}
@@ -157,7 +157,7 @@ func (d *DOTGenerator) Generate(cycles [][]string) (string, error) {
 	buf.WriteString("    legend_internal [label=\"Internal Module\", fillcolor=\"white\", style=\"rounded,filled\"];\n")
 	buf.WriteString("    legend_external [label=\"External/Stdlib\", fillcolor=\"gainsboro\", style=\"rounded,filled\"];\n")
 	buf.WriteString("    legend_cycle [label=\"Circular Import\", fillcolor=\"mistyrose\", color=\"red\", style=\"rounded,filled\"];\n")
-	buf.WriteString("    legend_edge_internal [label=\"Internal Edge\", shape=plaintext, fontcolor=\"forestgreen\"];\n")
+	buf.WriteString("    legend_edge_internal [label=\"Internal Edge (width = imports + refs)\", shape=plaintext, fontcolor=\"forestgreen\"];\n")
 	buf.WriteString("    legend_edge_external [label=\"External Edge\", shape=plaintext, fontcolor=\"grey\"];\n")
 	buf.WriteString("  }\n")
 
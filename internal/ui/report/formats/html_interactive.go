@@ -4,7 +4,6 @@ package formats
 
 import (
 	"circular/internal/engine/graph"
-	"circular/internal/shared/util"
 	"encoding/json"
 	"fmt"
 	"strings"
@@ -20,14 +19,14 @@ type htmlTreemapNode struct {
 }
 
 // GenerateInteractiveReport produces a self-contained HTML page embedding a
-// D3 v7 zoomable treemap. Each tile represents a module:
+// D3 v7 zoomable treemap. Each tile represents a module, nested under its path
+// prefixes (internal → internal/engine → internal/engine/graph):
 //   - tile area    ∝ number of source files in the module
 //   - tile colour  = blue (low) → red (high) based on the complexity hotspot score
 //
 // The report requires no server — it embeds all data as JSON and loads D3 from CDN.
 func GenerateInteractiveReport(g *graph.Graph, metrics map[string]graph.ModuleMetrics, hotspots []graph.ComplexityHotspot) (string, error) {
 	modules := g.Modules()
-	moduleNames := util.SortedStringKeys(modules)
 
 	// Build hotspot lookup: module -> max complexity score.
 	hotspotScore := make(map[string]int, len(hotspots))
@@ -37,42 +36,28 @@ func GenerateInteractiveReport(g *graph.Graph, metrics map[string]graph.ModuleMe
 		}
 	}
 
-	// Group modules by their top-level path component (cluster).
-	clusterMap := make(map[string][]htmlTreemapNode)
-	for _, name := range moduleNames {
-		mod := modules[name]
-		fileCount := 0
-		if mod != nil {
-			fileCount = len(mod.Files)
+	// Nest modules by their full path hierarchy.
+	var build func(nodes []*graph.ModuleTreeNode) []htmlTreemapNode
+	build = func(nodes []*graph.ModuleTreeNode) []htmlTreemapNode {
+		out := make([]htmlTreemapNode, 0, len(nodes))
+		for _, node := range nodes {
+			children := build(node.Children)
+			if node.IsModule {
+				leaf := htmlTreemapLeaf(node.Path, modules[node.Path], metrics, hotspotScore)
+				if len(children) == 0 {
+					out = append(out, leaf)
+					continue
+				}
+				children = append([]htmlTreemapNode{leaf}, children...)
+			}
+			out = append(out, htmlTreemapNode{
+				Name:     node.Path,
+				Children: children,
+			})
 		}
-		if fileCount == 0 {
-			fileCount = 1 // always give a tile some area
-		}
-		imp := 0.0
-		if m, ok := metrics[name]; ok {
-			imp = m.ImportanceScore
-		}
-
-		parts := strings.SplitN(name, "/", 2)
-		cluster := parts[0]
-
-		clusterMap[cluster] = append(clusterMap[cluster], htmlTreemapNode{
-			Name:       name,
-			Size:       fileCount,
-			Complexity: hotspotScore[name],
-			Importance: imp,
-		})
-	}
-
-	// Build root children (one per cluster).
-	rootChildren := make([]htmlTreemapNode, 0, len(clusterMap))
-	clusterNames := util.SortedStringKeys(clusterMap)
-	for _, cl := range clusterNames {
-		rootChildren = append(rootChildren, htmlTreemapNode{
-			Name:     cl,
-			Children: clusterMap[cl],
-		})
+		return out
 	}
+	rootChildren := build(g.ModuleTree(hotspots, nil))
 
 	root := htmlTreemapNode{
 		Name:     "root",
@@ -184,3 +169,25 @@ node.on('mousemove', (event, d) => {
 
 	return sb.String(), nil
 }
+
+// htmlTreemapLeaf sizes a module tile by file count (at least one) and colours
+// it by its highest hotspot score.
+func htmlTreemapLeaf(name string, mod *graph.Module, metrics map[string]graph.ModuleMetrics, hotspotScore map[string]int) htmlTreemapNode {
+	fileCount := 0
+	if mod != nil {
+		fileCount = len(mod.Files)
+	}
+	if fileCount == 0 {
+		fileCount = 1 // always give a tile some area
+	}
+	imp := 0.0
+	if m, ok := metrics[name]; ok {
+		imp = m.ImportanceScore
+	}
+	return htmlTreemapNode{
+		Name:       name,
+		Size:       fileCount,
+		Complexity: hotspotScore[name],
+		Importance: imp,
+	}
+}
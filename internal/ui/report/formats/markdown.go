@@ -16,14 +16,25 @@ type MarkdownReportData struct {
 	TotalFiles   int
 
 	Cycles            [][]string
+	CycleCuts         []graph.CycleBreakPlan
 	ProbableBridges   []resolver.ProbableBridgeReference
 	Unresolved        []resolver.UnresolvedReference
 	UnusedImports     []resolver.UnusedImport
+	DeadCode          []graph.DeadDefinition
+	Encapsulation     []graph.EncapsulationViolation
+	StaleSamples      []graph.StaleSampleImport
 	Violations        []graph.ArchitectureViolation
 	ArchitectureRules []ports.ArchitectureRule
 	RuleViolations    []ports.ArchitectureRuleViolation
 	RuleSummary       ports.ArchitectureRuleSummary
 	Hotspots          []graph.ComplexityHotspot
+	Metrics           map[string]graph.ModuleMetrics
+	Hierarchy         []*graph.ModuleTreeNode
+	ImportCosts       []graph.EdgeCost
+	Critical          *graph.CriticalModuleReport
+	Boundaries        *graph.CommunityReport
+	Reachability      *graph.ReachabilityReport
+	External          []graph.ExternalDependency
 }
 
 type MarkdownReportOptions struct {
@@ -69,9 +80,32 @@ func (m *MarkdownGenerator) Generate(data MarkdownReportData, opts MarkdownRepor
 		if len(data.Hotspots) > 0 {
 			b.WriteString("- [Complexity Hotspots](#complexity-hotspots)\n")
 		}
+		if len(data.Metrics) > 0 {
+			b.WriteString("- [Package Coupling](#package-coupling)\n")
+		}
+		if len(data.Metrics) > 0 {
+			b.WriteString("- [Import Cost](#import-cost)\n")
+		}
+		if len(data.Hierarchy) > 0 {
+			b.WriteString("- [Module Hierarchy](#module-hierarchy)\n")
+		}
+		if data.Critical != nil {
+			b.WriteString("- [Critical Modules](#critical-modules)\n")
+		}
+		if data.Boundaries != nil {
+			b.WriteString("- [Suggested Boundaries](#suggested-boundaries)\n")
+		}
+		if data.Reachability != nil {
+			b.WriteString("- [Orphan Modules](#orphan-modules)\n")
+		}
+		if len(data.External) > 0 {
+			b.WriteString("- [External Dependencies](#external-dependencies)\n")
+		}
 		b.WriteString("- [Probable Bridge References](#probable-bridge-references)\n")
 		b.WriteString("- [Unresolved References](#unresolved-references)\n")
 		b.WriteString("- [Unused Imports](#unused-imports)\n")
+		b.WriteString("- [Dead Code](#dead-code)\n")
+		b.WriteString("- [Encapsulation Violations](#encapsulation-violations)\n")
 		if opts.IncludeMermaid && strings.TrimSpace(opts.MermaidDiagram) != "" {
 			b.WriteString("- [Dependency Diagram](#dependency-diagram)\n")
 		}
@@ -90,18 +124,49 @@ func (m *MarkdownGenerator) Generate(data MarkdownReportData, opts MarkdownRepor
 	b.WriteString(fmt.Sprintf("| Complexity Hotspots | %d |\n", len(data.Hotspots)))
 	b.WriteString(fmt.Sprintf("| Probable Bridge References | %d |\n", len(data.ProbableBridges)))
 	b.WriteString(fmt.Sprintf("| Unresolved References | %d |\n", len(data.Unresolved)))
-	b.WriteString(fmt.Sprintf("| Unused Imports | %d |\n\n", len(data.UnusedImports)))
+	b.WriteString(fmt.Sprintf("| Unused Imports | %d |\n", len(data.UnusedImports)))
+	b.WriteString(fmt.Sprintf("| Dead Code | %d |\n", len(data.DeadCode)))
+	b.WriteString(fmt.Sprintf("| Encapsulation Violations | %d |\n\n", len(data.Encapsulation)))
 
 	m.writeCycles(&b, data.Cycles, opts.CollapsibleSections)
+	if len(data.Cycles) > 0 {
+		m.writeCycleCuts(&b, data.CycleCuts, opts.ProjectRoot, opts.CollapsibleSections)
+	}
 	m.writeArchitectureRules(&b, data.ArchitectureRules, data.RuleSummary, opts.CollapsibleSections)
 	m.writeViolations(&b, data.Violations, opts.ProjectRoot, opts.CollapsibleSections)
 	m.writeRuleViolations(&b, data.RuleViolations, opts.ProjectRoot, opts.CollapsibleSections)
 	if len(data.Hotspots) > 0 {
 		m.writeHotspots(&b, data.Hotspots, opts.ProjectRoot, opts.CollapsibleSections, verbosity)
 	}
+	if len(data.Metrics) > 0 {
+		m.writeCoupling(&b, data.Metrics, opts.CollapsibleSections, verbosity)
+	}
+	if len(data.Metrics) > 0 {
+		m.writeImportCost(&b, data.Metrics, data.ImportCosts, opts.CollapsibleSections, verbosity)
+	}
+	if len(data.Hierarchy) > 0 {
+		m.writeHierarchy(&b, data.Hierarchy, opts.CollapsibleSections, verbosity)
+	}
+	if data.Critical != nil {
+		m.writeCritical(&b, *data.Critical, opts.CollapsibleSections)
+	}
+	if data.Boundaries != nil {
+		m.writeBoundaries(&b, *data.Boundaries, opts.CollapsibleSections)
+	}
+	if data.Reachability != nil {
+		m.writeOrphans(&b, *data.Reachability, opts.CollapsibleSections)
+	}
+	if len(data.External) > 0 {
+		m.writeExternalDependencies(&b, data.External, opts.CollapsibleSections, verbosity)
+	}
 	m.writeProbableBridges(&b, data.ProbableBridges, opts.ProjectRoot, opts.CollapsibleSections)
 	m.writeUnresolved(&b, data.Unresolved, opts.ProjectRoot, opts.CollapsibleSections)
 	m.writeUnusedImports(&b, data.UnusedImports, opts.ProjectRoot, opts.CollapsibleSections, verbosity)
+	m.writeDeadCode(&b, data.DeadCode, opts.ProjectRoot, opts.CollapsibleSections)
+	m.writeEncapsulation(&b, data.Encapsulation, opts.ProjectRoot, opts.CollapsibleSections)
+	if len(data.StaleSamples) > 0 {
+		m.writeStaleSamples(&b, data.StaleSamples, opts.ProjectRoot, opts.CollapsibleSections)
+	}
 
 	if opts.IncludeMermaid && strings.TrimSpace(opts.MermaidDiagram) != "" {
 		b.WriteString("## Dependency Diagram\n")
@@ -169,6 +234,42 @@ func (m *MarkdownGenerator) writeCycles(b *strings.Builder, cycles [][]string, c
 	)
 }
 
+func (m *MarkdownGenerator) writeCycleCuts(b *strings.Builder, plans []graph.CycleBreakPlan, projectRoot string, collapsible bool) {
+	b.WriteString("### Suggested Cuts\n")
+	rendered := make([]string, 0)
+	for i, plan := range plans {
+		for _, cut := range plan.Cuts {
+			location := "-"
+			if cut.File != "" {
+				location = fmt.Sprintf("`%s:%d:%d`", relPath(projectRoot, cut.File), cut.Line, cut.Column)
+			}
+			rendered = append(rendered, fmt.Sprintf(
+				"| %d | `%s -> %s` | %d | %d | %d | %s |\n",
+				i+1,
+				cut.From,
+				cut.To,
+				cut.ImportSites,
+				cut.SymbolRefs,
+				cut.Weight,
+				location,
+			))
+		}
+	}
+	if len(rendered) == 0 {
+		b.WriteString("No cut suggestions available.\n\n")
+		return
+	}
+	b.WriteString("Removing these imports breaks every cycle; weight is import sites plus referenced symbols.\n\n")
+	m.writeTableWithCollapse(
+		b,
+		"Suggested cut details",
+		collapsible,
+		len(rendered) > 10,
+		[]string{"| Component | Import | Import Sites | Symbol Refs | Weight | Location |\n", "| --- | --- | --- | --- | --- | --- |\n"},
+		rendered,
+	)
+}
+
 func (m *MarkdownGenerator) writeViolations(b *strings.Builder, rows []graph.ArchitectureViolation, projectRoot string, collapsible bool) {
 	b.WriteString("## Architecture Violations\n")
 	if len(rows) == 0 {
@@ -345,6 +446,317 @@ func (m *MarkdownGenerator) writeHotspots(b *strings.Builder, hotspots []graph.C
 	)
 }
 
+// writeCoupling lists Martin's coupling metrics, furthest from the main
+// sequence first. Summary verbosity keeps the ten worst modules.
+func (m *MarkdownGenerator) writeCoupling(b *strings.Builder, metrics map[string]graph.ModuleMetrics, collapsible bool, verbosity string) {
+	b.WriteString("## Package Coupling\n")
+	b.WriteString("Ca/Ce: afferent/efferent couplings; I = Ce/(Ca+Ce); A = abstract share of type definitions; D = |A+I-1|.\n\n")
+
+	names := make([]string, 0, len(metrics))
+	for name := range metrics {
+		names = append(names, name)
+	}
+	sort.Slice(names, func(i, j int) bool {
+		if metrics[names[i]].Distance != metrics[names[j]].Distance {
+			return metrics[names[i]].Distance > metrics[names[j]].Distance
+		}
+		return names[i] < names[j]
+	})
+	if verbosity == "summary" && len(names) > 10 {
+		names = names[:10]
+	}
+
+	rendered := make([]string, 0, len(names))
+	for _, name := range names {
+		row := metrics[name]
+		rendered = append(rendered, fmt.Sprintf("| `%s` | %d | %d | %.2f | %.2f | %.2f |\n",
+			name, row.FanIn, row.FanOut, row.Instability, row.Abstractness, row.Distance))
+	}
+	m.writeTableWithCollapse(
+		b,
+		"Coupling details",
+		collapsible,
+		len(rendered) > 15,
+		[]string{"| Module | Ca | Ce | I | A | D |\n", "| --- | --- | --- | --- | --- | --- |\n"},
+		rendered,
+	)
+}
+
+// writeImportCost lists the modules with the largest transitive dependency
+// closures and the imports whose removal would shrink a closure the most.
+// Summary verbosity keeps the ten heaviest of each.
+func (m *MarkdownGenerator) writeImportCost(b *strings.Builder, metrics map[string]graph.ModuleMetrics, edges []graph.EdgeCost, collapsible bool, verbosity string) {
+	b.WriteString("## Import Cost\n")
+	b.WriteString("Transitive closure: internal modules, files, and lines a module pulls in directly or through other imports.\n\n")
+
+	names := make([]string, 0, len(metrics))
+	for name, row := range metrics {
+		if row.TransitiveModules > 0 {
+			names = append(names, name)
+		}
+	}
+	if len(names) == 0 {
+		b.WriteString("No module imports another internal module.\n\n")
+		return
+	}
+	sort.Slice(names, func(i, j int) bool {
+		a, c := metrics[names[i]], metrics[names[j]]
+		if a.TransitiveLOC != c.TransitiveLOC {
+			return a.TransitiveLOC > c.TransitiveLOC
+		}
+		if a.TransitiveModules != c.TransitiveModules {
+			return a.TransitiveModules > c.TransitiveModules
+		}
+		return names[i] < names[j]
+	})
+	if verbosity == "summary" && len(names) > 10 {
+		names = names[:10]
+	}
+	rendered := make([]string, 0, len(names))
+	for _, name := range names {
+		row := metrics[name]
+		rendered = append(rendered, fmt.Sprintf("| `%s` | %d | %d | %d |\n", name, row.TransitiveModules, row.TransitiveFiles, row.TransitiveLOC))
+	}
+	m.writeTableWithCollapse(
+		b,
+		"Closure details",
+		collapsible,
+		len(rendered) > 15,
+		[]string{"| Module | Modules | Files | LOC |\n", "| --- | --- | --- | --- |\n"},
+		rendered,
+	)
+
+	b.WriteString("### Heaviest Imports\n")
+	b.WriteString("Marginal cost: what the importer's closure loses when only this import is removed.\n\n")
+	rendered = rendered[:0]
+	for _, edge := range edges {
+		if edge.Modules == 0 {
+			continue
+		}
+		if verbosity == "summary" && len(rendered) == 10 {
+			break
+		}
+		rendered = append(rendered, fmt.Sprintf("| `%s` | `%s` | %d | %d | %d |\n", edge.From, edge.To, edge.Modules, edge.Files, edge.LOC))
+	}
+	if len(rendered) == 0 {
+		b.WriteString("Every import's target stays reachable through another import.\n\n")
+		return
+	}
+	m.writeTableWithCollapse(
+		b,
+		"Import cost details",
+		collapsible,
+		len(rendered) > 15,
+		[]string{"| From | To | Modules | Files | LOC |\n", "| --- | --- | --- | --- | --- |\n"},
+		rendered,
+	)
+}
+
+// writeHierarchy lists module path prefixes depth-first with their rolled-up
+// metrics. Summary verbosity leaves out modules without submodules.
+func (m *MarkdownGenerator) writeHierarchy(b *strings.Builder, roots []*graph.ModuleTreeNode, collapsible bool, verbosity string) {
+	b.WriteString("## Module Hierarchy\n")
+	b.WriteString("Metrics roll up over every module under a prefix; fan-in/fan-out count prefixes of the same depth.\n\n")
+
+	var rendered []string
+	var walk func([]*graph.ModuleTreeNode)
+	walk = func(nodes []*graph.ModuleTreeNode) {
+		for _, node := range nodes {
+			if verbosity != "summary" || len(node.Children) > 0 {
+				rendered = append(rendered, fmt.Sprintf("| %s`%s` | %d | %d | %d | %d | %d | %d |\n",
+					strings.Repeat("&nbsp;&nbsp;", node.Depth-1), node.Path, node.ModuleCount, node.FileCount,
+					node.FanIn, node.FanOut, node.HotspotScore, node.Violations))
+			}
+			walk(node.Children)
+		}
+	}
+	walk(roots)
+	m.writeTableWithCollapse(
+		b,
+		"Hierarchy details",
+		collapsible,
+		len(rendered) > 15,
+		[]string{"| Prefix | Modules | Files | Fan-in | Fan-out | Hotspot Score | Violations |\n", "| --- | --- | --- | --- | --- | --- | --- |\n"},
+		rendered,
+	)
+}
+
+// writeCritical lists single points of failure: cut vertices and bridges of
+// the undirected module graph, and modules dominating others from the entry
+// points.
+func (m *MarkdownGenerator) writeCritical(b *strings.Builder, report graph.CriticalModuleReport, collapsible bool) {
+	b.WriteString("## Critical Modules\n")
+	if len(report.ArticulationPoints) == 0 && len(report.Bridges) == 0 && len(report.Dominators) == 0 {
+		b.WriteString("No single points of failure detected.\n\n")
+		return
+	}
+
+	if len(report.ArticulationPoints) > 0 {
+		names := make([]string, 0, len(report.ArticulationPoints))
+		for _, name := range report.ArticulationPoints {
+			names = append(names, "`"+name+"`")
+		}
+		b.WriteString("Articulation points: " + strings.Join(names, ", ") + "\n\n")
+	}
+
+	if len(report.Bridges) > 0 {
+		b.WriteString("### Bridge Imports\n")
+		rendered := make([]string, 0, len(report.Bridges))
+		for _, bridge := range report.Bridges {
+			rendered = append(rendered, fmt.Sprintf("| `%s -> %s` |\n", bridge.From, bridge.To))
+		}
+		m.writeTableWithCollapse(
+			b,
+			"Bridge details",
+			collapsible,
+			len(rendered) > 15,
+			[]string{"| Import |\n", "| --- |\n"},
+			rendered,
+		)
+	}
+
+	if len(report.Dominators) > 0 {
+		entries := make([]string, 0, len(report.EntryPoints))
+		for _, entry := range report.EntryPoints {
+			entries = append(entries, "`"+entry+"`")
+		}
+		b.WriteString("### Dominators\n")
+		b.WriteString("Entry points: " + strings.Join(entries, ", ") + "\n\n")
+		rendered := make([]string, 0, len(report.Dominators))
+		for _, dom := range report.Dominators {
+			rendered = append(rendered, fmt.Sprintf("| `%s` | %d | `%s` |\n",
+				dom.Module, len(dom.Dominated), strings.Join(dom.Dominated, "`, `")))
+		}
+		m.writeTableWithCollapse(
+			b,
+			"Dominator details",
+			collapsible,
+			len(rendered) > 15,
+			[]string{"| Module | Dominated | Modules |\n", "| --- | --- | --- |\n"},
+			rendered,
+		)
+	}
+}
+
+// writeOrphans lists modules no entry point reaches through the import graph.
+func (m *MarkdownGenerator) writeOrphans(b *strings.Builder, report graph.ReachabilityReport, collapsible bool) {
+	b.WriteString("## Orphan Modules\n")
+	if len(report.EntryPoints) == 0 {
+		b.WriteString("No entry points matched; reachability was not analyzed.\n\n")
+		return
+	}
+	entries := make([]string, 0, len(report.EntryPoints))
+	for _, entry := range report.EntryPoints {
+		entries = append(entries, "`"+entry+"`")
+	}
+	b.WriteString("Entry points: " + strings.Join(entries, ", ") + "\n\n")
+	if len(report.Orphans) == 0 {
+		b.WriteString("Every module is reachable from an entry point.\n\n")
+		return
+	}
+	rendered := make([]string, 0, len(report.Orphans))
+	for _, row := range report.Orphans {
+		modified := "-"
+		if !row.LastModified.IsZero() {
+			modified = row.LastModified.UTC().Format("2006-01-02")
+		}
+		rendered = append(rendered, fmt.Sprintf("| `%s` | %d | %s |\n", row.Module, len(row.Files), modified))
+	}
+	m.writeTableWithCollapse(
+		b,
+		"Orphan module details",
+		collapsible,
+		len(rendered) > 15,
+		[]string{"| Module | Files | Last Modified |\n", "| --- | --- | --- |\n"},
+		rendered,
+	)
+}
+
+// writeExternalDependencies lists third-party packages with the files and
+// modules importing them. Summary verbosity keeps only unused, undeclared, and
+// single-file dependencies.
+func (m *MarkdownGenerator) writeExternalDependencies(b *strings.Builder, deps []graph.ExternalDependency, collapsible bool, verbosity string) {
+	b.WriteString("## External Dependencies\n")
+	undeclared, unused, single := 0, 0, 0
+	for _, dep := range deps {
+		switch {
+		case dep.Unused():
+			unused++
+		case !dep.Declared():
+			undeclared++
+		}
+		if dep.FileCount == 1 {
+			single++
+		}
+	}
+	b.WriteString(fmt.Sprintf("%d third-party packages: %d undeclared, %d declared but unused, %d imported by a single file.\n\n", len(deps), undeclared, unused, single))
+
+	rendered := make([]string, 0, len(deps))
+	for _, dep := range deps {
+		if verbosity == "summary" && dep.Declared() && !dep.Unused() && dep.FileCount != 1 {
+			continue
+		}
+		version := dep.Version
+		if version == "" {
+			version = "-"
+		}
+		rendered = append(rendered, fmt.Sprintf("| `%s` | %s | %s | %d | %d | %s |\n", dep.Name, dep.Ecosystem, version, dep.FileCount, len(dep.Importers), dep.Status()))
+	}
+	if len(rendered) == 0 {
+		return
+	}
+	m.writeTableWithCollapse(
+		b,
+		"External dependency details",
+		collapsible,
+		len(rendered) > 15,
+		[]string{"| Package | Ecosystem | Version | Files | Modules | Status |\n", "| --- | --- | --- | --- | --- | --- |\n"},
+		rendered,
+	)
+}
+
+// writeBoundaries compares the detected module clusters with the directory
+// layout and lists modules that sit outside their cluster's directory.
+func (m *MarkdownGenerator) writeBoundaries(b *strings.Builder, report graph.CommunityReport, collapsible bool) {
+	b.WriteString("## Suggested Boundaries\n")
+	b.WriteString(fmt.Sprintf("%d clusters detected over the weighted import graph (modularity %.2f).\n\n", len(report.Communities), report.Modularity))
+	if len(report.Misplaced) == 0 {
+		b.WriteString("Module layout matches the detected clusters.\n\n")
+	} else {
+		rendered := make([]string, 0, len(report.Misplaced))
+		for _, row := range report.Misplaced {
+			rendered = append(rendered, fmt.Sprintf("| `%s` | `%s` | %d | `%s` |\n", row.Module, row.CurrentGroup, row.Community+1, row.SuggestedGroup))
+		}
+		m.writeTableWithCollapse(
+			b,
+			"Misplaced module details",
+			collapsible,
+			len(rendered) > 15,
+			[]string{"| Module | Current Directory | Cluster | Suggested Directory |\n", "| --- | --- | --- | --- |\n"},
+			rendered,
+		)
+	}
+
+	rendered := make([]string, 0, len(report.Communities))
+	for _, community := range report.Communities {
+		if len(community.Modules) < 2 {
+			continue
+		}
+		rendered = append(rendered, fmt.Sprintf("| %d | `%s` | %d |\n", community.ID+1, community.Group, len(community.Modules)))
+	}
+	if len(rendered) == 0 {
+		return
+	}
+	m.writeTableWithCollapse(
+		b,
+		"Cluster details",
+		collapsible,
+		len(rendered) > 15,
+		[]string{"| Cluster | Directory | Modules |\n", "| --- | --- | --- |\n"},
+		rendered,
+	)
+}
+
 func (m *MarkdownGenerator) writeUnresolved(b *strings.Builder, rows []resolver.UnresolvedReference, projectRoot string, collapsible bool) {
 	b.WriteString("## Unresolved References\n")
 	if len(rows) == 0 {
@@ -411,6 +823,82 @@ func (m *MarkdownGenerator) writeUnusedImports(b *strings.Builder, rows []resolv
 	)
 }
 
+func (m *MarkdownGenerator) writeDeadCode(b *strings.Builder, rows []graph.DeadDefinition, projectRoot string, collapsible bool) {
+	b.WriteString("## Dead Code\n")
+	if len(rows) == 0 {
+		b.WriteString("No unreferenced exported definitions detected.\n\n")
+		return
+	}
+	rendered := make([]string, 0, len(rows))
+	for _, row := range rows {
+		rendered = append(rendered, fmt.Sprintf(
+			"| `%s` | `%s` | %s | `%s:%d` |\n",
+			row.Module,
+			row.Name,
+			row.Kind,
+			relPath(projectRoot, row.File),
+			row.Line,
+		))
+	}
+	m.writeTableWithCollapse(
+		b,
+		"Dead code details",
+		collapsible,
+		len(rendered) > 15,
+		[]string{"| Module | Symbol | Kind | Location |\n", "| --- | --- | --- | --- |\n"},
+		rendered,
+	)
+}
+
+func (m *MarkdownGenerator) writeEncapsulation(b *strings.Builder, rows []graph.EncapsulationViolation, projectRoot string, collapsible bool) {
+	b.WriteString("## Encapsulation Violations\n")
+	if len(rows) == 0 {
+		b.WriteString("No references into private or internal code of other modules detected.\n\n")
+		return
+	}
+	rendered := make([]string, 0, len(rows))
+	for _, row := range rows {
+		target := fmt.Sprintf("`%s`", row.To)
+		if row.Symbol != "" {
+			target = fmt.Sprintf("`%s` (%s)", row.To+"#"+row.Symbol, row.Visibility)
+		}
+		rendered = append(rendered, fmt.Sprintf(
+			"| %s | `%s` | %s | `%s:%d` |\n",
+			row.Kind,
+			row.From,
+			target,
+			relPath(projectRoot, row.File),
+			row.Line,
+		))
+	}
+	m.writeTableWithCollapse(
+		b,
+		"Encapsulation violation details",
+		collapsible,
+		len(rendered) > 15,
+		[]string{"| Kind | From | Target | Location |\n", "| --- | --- | --- | --- |\n"},
+		rendered,
+	)
+}
+
+// writeStaleSamples lists Markdown code-sample imports of internal modules
+// that no longer exist.
+func (m *MarkdownGenerator) writeStaleSamples(b *strings.Builder, rows []graph.StaleSampleImport, projectRoot string, collapsible bool) {
+	b.WriteString("## Stale Code-Sample Imports\n")
+	rendered := make([]string, 0, len(rows))
+	for _, row := range rows {
+		rendered = append(rendered, fmt.Sprintf("| `%s` | `%s:%d` |\n", row.Module, relPath(projectRoot, row.File), row.Line))
+	}
+	m.writeTableWithCollapse(
+		b,
+		"Stale code-sample import details",
+		collapsible,
+		len(rendered) > 15,
+		[]string{"| Module | Location |\n", "| --- | --- |\n"},
+		rendered,
+	)
+}
+
 func (m *MarkdownGenerator) writeTableWithCollapse(
 	b *strings.Builder,
 	summary string,
@@ -2,8 +2,10 @@ package formats
 
 import (
 	"circular/internal/engine/graph"
+	"circular/internal/engine/parser"
 	"strings"
 	"testing"
+	"time"
 )
 
 func TestMarkdownGenerator_OmitsComplexitySectionWhenNoHotspots(t *testing.T) {
@@ -44,3 +46,293 @@ func TestMarkdownGenerator_IncludesComplexitySectionWhenHotspotsPresent(t *testi
 		t.Fatal("expected complexity hotspot section to be included")
 	}
 }
+
+func TestMarkdownGenerator_IncludesSuggestedCuts(t *testing.T) {
+	gen := NewMarkdownGenerator()
+	out, err := gen.Generate(MarkdownReportData{
+		Cycles: [][]string{{"app/a", "app/b"}},
+		CycleCuts: []graph.CycleBreakPlan{{
+			Modules: []string{"app/a", "app/b"},
+			Cuts: []graph.CycleCut{{
+				From: "app/b", To: "app/a", File: "/repo/b.go", Line: 3, Column: 1,
+				ImportSites: 1, SymbolRefs: 4, Weight: 5,
+			}},
+			TotalWeight: 5,
+		}},
+	}, MarkdownReportOptions{ProjectRoot: "/repo"})
+	if err != nil {
+		t.Fatalf("generate markdown: %v", err)
+	}
+	if !strings.Contains(out, "### Suggested Cuts") {
+		t.Fatalf("expected suggested cuts section, got:\n%s", out)
+	}
+	if !strings.Contains(out, "| 1 | `app/b -> app/a` | 1 | 4 | 5 | `b.go:3:1` |") {
+		t.Fatalf("expected suggested cut row, got:\n%s", out)
+	}
+}
+
+func TestMarkdownGenerator_IncludesPackageCoupling(t *testing.T) {
+	gen := NewMarkdownGenerator()
+	out, err := gen.Generate(MarkdownReportData{
+		Metrics: map[string]graph.ModuleMetrics{
+			"app/api":  {FanIn: 2, Abstractness: 1, Distance: 0},
+			"app/impl": {FanIn: 1, FanOut: 1, Instability: 0.5, Distance: 0.5},
+		},
+	}, MarkdownReportOptions{TableOfContents: true})
+	if err != nil {
+		t.Fatalf("generate markdown: %v", err)
+	}
+	if !strings.Contains(out, "- [Package Coupling](#package-coupling)") {
+		t.Fatal("expected package coupling TOC entry")
+	}
+	impl := strings.Index(out, "| `app/impl` | 1 | 1 | 0.50 | 0.00 | 0.50 |")
+	api := strings.Index(out, "| `app/api` | 2 | 0 | 0.00 | 1.00 | 0.00 |")
+	if impl < 0 || api < 0 || impl > api {
+		t.Fatalf("expected coupling rows ordered by distance, got:\n%s", out)
+	}
+}
+
+func TestMarkdownGenerator_IncludesImportCost(t *testing.T) {
+	gen := NewMarkdownGenerator()
+	out, err := gen.Generate(MarkdownReportData{
+		Metrics: map[string]graph.ModuleMetrics{
+			"app/main": {TransitiveModules: 3, TransitiveFiles: 4, TransitiveLOC: 1050},
+			"app/api":  {TransitiveModules: 2, TransitiveFiles: 3, TransitiveLOC: 1020},
+			"app/util": {},
+		},
+		ImportCosts: []graph.EdgeCost{
+			{From: "app/main", To: "app/api", Modules: 2, Files: 3, LOC: 1020},
+			{From: "app/main", To: "app/util", Modules: 0},
+		},
+	}, MarkdownReportOptions{TableOfContents: true})
+	if err != nil {
+		t.Fatalf("generate markdown: %v", err)
+	}
+	if !strings.Contains(out, "- [Import Cost](#import-cost)") {
+		t.Fatal("expected import cost TOC entry")
+	}
+	main := strings.Index(out, "| `app/main` | 3 | 4 | 1050 |")
+	api := strings.Index(out, "| `app/api` | 2 | 3 | 1020 |")
+	if main < 0 || api < 0 || main > api || strings.Contains(out, "| `app/util` | 0 | 0 | 0 |") {
+		t.Fatalf("expected closures ordered by LOC without empty ones, got:\n%s", out)
+	}
+	if !strings.Contains(out, "| `app/main` | `app/api` | 2 | 3 | 1020 |") || strings.Contains(out, "| `app/main` | `app/util` |") {
+		t.Fatalf("expected only imports with a marginal cost, got:\n%s", out)
+	}
+}
+
+func TestMarkdownGenerator_IncludesExternalDependencies(t *testing.T) {
+	deps := []graph.ExternalDependency{
+		{Name: "github.com/pkg/errors", Ecosystem: "go", Version: "v0.9.1", Manifests: []string{"go.mod"}},
+		{Name: "github.com/spf13/cobra", Ecosystem: "go", Version: "v1.8.0", Manifests: []string{"go.mod"}, FileCount: 3, Importers: []graph.ExternalImporter{{Module: "cmd"}, {Module: "cli"}}},
+		{Name: "github.com/acme/tool", Ecosystem: "go", FileCount: 1, Importers: []graph.ExternalImporter{{Module: "cmd"}}},
+	}
+
+	gen := NewMarkdownGenerator()
+	out, err := gen.Generate(MarkdownReportData{External: deps}, MarkdownReportOptions{TableOfContents: true})
+	if err != nil {
+		t.Fatalf("generate markdown: %v", err)
+	}
+	for _, want := range []string{
+		"- [External Dependencies](#external-dependencies)",
+		"3 third-party packages: 1 undeclared, 1 declared but unused, 1 imported by a single file.",
+		"| `github.com/pkg/errors` | go | v0.9.1 | 0 | 0 | unused |",
+		"| `github.com/spf13/cobra` | go | v1.8.0 | 3 | 2 | declared |",
+		"| `github.com/acme/tool` | go | - | 1 | 1 | undeclared |",
+	} {
+		if !strings.Contains(out, want) {
+			t.Fatalf("expected %q in markdown:\n%s", want, out)
+		}
+	}
+
+	summary, err := gen.Generate(MarkdownReportData{External: deps}, MarkdownReportOptions{Verbosity: "summary"})
+	if err != nil {
+		t.Fatalf("generate summary markdown: %v", err)
+	}
+	if strings.Contains(summary, "`github.com/spf13/cobra`") || !strings.Contains(summary, "`github.com/acme/tool`") {
+		t.Fatalf("expected summary to keep only flagged dependencies, got:\n%s", summary)
+	}
+}
+
+func TestMarkdownGenerator_IncludesModuleHierarchy(t *testing.T) {
+	leaf := &graph.ModuleTreeNode{Path: "app/api", Depth: 2, IsModule: true, ModuleCount: 1, FileCount: 2, FanOut: 1}
+	root := &graph.ModuleTreeNode{Path: "app", Depth: 1, ModuleCount: 1, FileCount: 2, HotspotScore: 12, Violations: 1, Children: []*graph.ModuleTreeNode{leaf}}
+
+	gen := NewMarkdownGenerator()
+	out, err := gen.Generate(MarkdownReportData{Hierarchy: []*graph.ModuleTreeNode{root}}, MarkdownReportOptions{TableOfContents: true})
+	if err != nil {
+		t.Fatalf("generate markdown: %v", err)
+	}
+	if !strings.Contains(out, "- [Module Hierarchy](#module-hierarchy)") {
+		t.Fatal("expected module hierarchy TOC entry")
+	}
+	if !strings.Contains(out, "| `app` | 1 | 2 | 0 | 0 | 12 | 1 |") || !strings.Contains(out, "| &nbsp;&nbsp;`app/api` | 1 | 2 | 0 | 1 | 0 | 0 |") {
+		t.Fatalf("expected nested hierarchy rows, got:\n%s", out)
+	}
+
+	summary, err := gen.Generate(MarkdownReportData{Hierarchy: []*graph.ModuleTreeNode{root}}, MarkdownReportOptions{Verbosity: "summary"})
+	if err != nil {
+		t.Fatalf("generate markdown: %v", err)
+	}
+	if strings.Contains(summary, "`app/api`") {
+		t.Fatalf("expected summary verbosity to omit leaf modules, got:\n%s", summary)
+	}
+}
+
+func TestMarkdownGenerator_IncludesCriticalModules(t *testing.T) {
+	gen := NewMarkdownGenerator()
+	out, err := gen.Generate(MarkdownReportData{
+		Critical: &graph.CriticalModuleReport{
+			EntryPoints:        []string{"cmd"},
+			ArticulationPoints: []string{"svc"},
+			Bridges:            []graph.ModuleBridge{{From: "cmd", To: "svc"}},
+			Dominators:         []graph.ModuleDominator{{Module: "svc", Dominated: []string{"db", "repo"}}},
+		},
+	}, MarkdownReportOptions{TableOfContents: true})
+	if err != nil {
+		t.Fatalf("generate markdown: %v", err)
+	}
+	for _, want := range []string{
+		"- [Critical Modules](#critical-modules)",
+		"Articulation points: `svc`",
+		"| `cmd -> svc` |",
+		"| `svc` | 2 | `db`, `repo` |",
+	} {
+		if !strings.Contains(out, want) {
+			t.Fatalf("expected %q in critical modules section, got:\n%s", want, out)
+		}
+	}
+}
+
+func TestMarkdownGenerator_IncludesSuggestedBoundaries(t *testing.T) {
+	gen := NewMarkdownGenerator()
+	out, err := gen.Generate(MarkdownReportData{
+		Boundaries: &graph.CommunityReport{
+			Communities: []graph.ModuleCommunity{
+				{ID: 0, Group: "billing", Modules: []string{"billing/api", "billing/store", "users/tax"}},
+			},
+			Modularity: 0.42,
+			Misplaced: []graph.BoundarySuggestion{
+				{Module: "users/tax", CurrentGroup: "users", SuggestedGroup: "billing", Community: 0},
+			},
+		},
+	}, MarkdownReportOptions{TableOfContents: true})
+	if err != nil {
+		t.Fatalf("generate markdown: %v", err)
+	}
+	for _, want := range []string{
+		"- [Suggested Boundaries](#suggested-boundaries)",
+		"1 clusters detected over the weighted import graph (modularity 0.42).",
+		"| `users/tax` | `users` | 1 | `billing` |",
+		"| 1 | `billing` | 3 |",
+	} {
+		if !strings.Contains(out, want) {
+			t.Fatalf("expected %q in suggested boundaries section, got:\n%s", want, out)
+		}
+	}
+}
+
+func TestMarkdownGenerator_IncludesDeadCode(t *testing.T) {
+	gen := NewMarkdownGenerator()
+	out, err := gen.Generate(MarkdownReportData{
+		DeadCode: []graph.DeadDefinition{
+			{Module: "app/util", Name: "Helper", Kind: parser.KindFunction, File: "/repo/util/helper.go", Line: 12},
+		},
+	}, MarkdownReportOptions{ProjectRoot: "/repo", TableOfContents: true})
+	if err != nil {
+		t.Fatalf("generate markdown: %v", err)
+	}
+	for _, want := range []string{
+		"- [Dead Code](#dead-code)",
+		"| Dead Code | 1 |",
+		"| `app/util` | `Helper` | function | `util/helper.go:12` |",
+	} {
+		if !strings.Contains(out, want) {
+			t.Fatalf("expected %q in dead code section, got:\n%s", want, out)
+		}
+	}
+}
+
+func TestMarkdownGenerator_IncludesEncapsulationViolations(t *testing.T) {
+	gen := NewMarkdownGenerator()
+	out, err := gen.Generate(MarkdownReportData{
+		Encapsulation: []graph.EncapsulationViolation{
+			{Kind: graph.EncapsulationPrivateSymbol, From: "app.main", To: "pkg.core", Symbol: "_cache", Visibility: "private", File: "/repo/app/main.py", Line: 4},
+			{Kind: graph.EncapsulationInternalImport, From: "example.com/tools", To: "example.com/svc/internal/store", File: "/repo/tools/gen.go", Line: 3},
+		},
+	}, MarkdownReportOptions{ProjectRoot: "/repo", TableOfContents: true})
+	if err != nil {
+		t.Fatalf("generate markdown: %v", err)
+	}
+	for _, want := range []string{
+		"- [Encapsulation Violations](#encapsulation-violations)",
+		"| Encapsulation Violations | 2 |",
+		"| private_symbol | `app.main` | `pkg.core#_cache` (private) | `app/main.py:4` |",
+		"| internal_import | `example.com/tools` | `example.com/svc/internal/store` | `tools/gen.go:3` |",
+	} {
+		if !strings.Contains(out, want) {
+			t.Fatalf("expected %q in encapsulation section, got:\n%s", want, out)
+		}
+	}
+
+	empty, err := gen.Generate(MarkdownReportData{}, MarkdownReportOptions{})
+	if err != nil {
+		t.Fatalf("generate markdown: %v", err)
+	}
+	if !strings.Contains(empty, "No references into private or internal code of other modules detected.") {
+		t.Fatalf("expected empty encapsulation section, got:\n%s", empty)
+	}
+}
+
+func TestMarkdownGenerator_IncludesStaleSampleImports(t *testing.T) {
+	gen := NewMarkdownGenerator()
+	out, err := gen.Generate(MarkdownReportData{
+		StaleSamples: []graph.StaleSampleImport{
+			{File: "/repo/docs/guide.md", Module: "example.com/app/cache", Line: 12, Column: 8},
+		},
+	}, MarkdownReportOptions{ProjectRoot: "/repo"})
+	if err != nil {
+		t.Fatalf("generate markdown: %v", err)
+	}
+	for _, want := range []string{
+		"## Stale Code-Sample Imports",
+		"| `example.com/app/cache` | `docs/guide.md:12` |",
+	} {
+		if !strings.Contains(out, want) {
+			t.Fatalf("expected %q in stale sample section, got:\n%s", want, out)
+		}
+	}
+
+	empty, err := gen.Generate(MarkdownReportData{}, MarkdownReportOptions{})
+	if err != nil {
+		t.Fatalf("generate markdown: %v", err)
+	}
+	if strings.Contains(empty, "Stale Code-Sample Imports") {
+		t.Fatalf("expected no stale sample section without findings, got:\n%s", empty)
+	}
+}
+
+func TestMarkdownGenerator_IncludesOrphanModules(t *testing.T) {
+	gen := NewMarkdownGenerator()
+	out, err := gen.Generate(MarkdownReportData{
+		Reachability: &graph.ReachabilityReport{
+			EntryPoints: []string{"cmd/app"},
+			Reachable:   []string{"cmd/app", "svc"},
+			Orphans: []graph.OrphanModule{
+				{Module: "legacy", Files: []string{"legacy/a.go", "legacy/b.go"}, LastModified: time.Date(2026, 3, 1, 10, 0, 0, 0, time.UTC)},
+			},
+		},
+	}, MarkdownReportOptions{TableOfContents: true})
+	if err != nil {
+		t.Fatalf("generate markdown: %v", err)
+	}
+	for _, want := range []string{
+		"- [Orphan Modules](#orphan-modules)",
+		"Entry points: `cmd/app`",
+		"| `legacy` | 2 | 2026-03-01 |",
+	} {
+		if !strings.Contains(out, want) {
+			t.Fatalf("expected %q in orphan modules section, got:\n%s", want, out)
+		}
+	}
+}
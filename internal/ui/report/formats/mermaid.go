@@ -159,6 +159,7 @@ func (m *MermaidGenerator) Generate(cycles [][]string, violations []graph.Archit
 	cycleLinkIndexes := make([]int, 0)
 	violationLinkIndexes := make([]int, 0)
 	externalLinkIndexes := make([]int, 0)
+	weightedLinkIndexes := make(map[int][]int)
 	for _, from := range util.SortedStringKeys(imports) {
 		targets := util.SortedStringKeys(imports[from])
 		for _, to := range targets {
@@ -174,6 +175,12 @@ func (m *MermaidGenerator) Generate(cycles [][]string, violations []graph.Archit
 				violationLinkIndexes = append(violationLinkIndexes, linkIndex)
 			} else if !moduleSet[to] {
 				externalLinkIndexes = append(externalLinkIndexes, linkIndex)
+			} else {
+				edge := imports[from][to]
+				edgeLabel = "|" + edgeWeightLabel(edge) + "|"
+				if width := edgeWidth(edge); width > 1 {
+					weightedLinkIndexes[width] = append(weightedLinkIndexes[width], linkIndex)
+				}
 			}
 			b.WriteString(fmt.Sprintf("  %s -->%s %s\n", ids[from], edgeLabel, ids[to]))
 			linkIndex++
@@ -191,9 +198,14 @@ func (m *MermaidGenerator) Generate(cycles [][]string, violations []graph.Archit
 		}
 	}
 
-	if len(cycleLinkIndexes) > 0 || len(violationLinkIndexes) > 0 || len(externalLinkIndexes) > 0 {
+	if len(cycleLinkIndexes) > 0 || len(violationLinkIndexes) > 0 || len(externalLinkIndexes) > 0 || len(weightedLinkIndexes) > 0 {
 		b.WriteString("\n")
 	}
+	for width := 2; width <= maxEdgeWidth; width++ {
+		if indexes := weightedLinkIndexes[width]; len(indexes) > 0 {
+			b.WriteString(fmt.Sprintf("  linkStyle %s stroke-width:%dpx;\n", joinInts(indexes), width))
+		}
+	}
 	if len(cycleLinkIndexes) > 0 {
 		b.WriteString(fmt.Sprintf("  linkStyle %s stroke:#cc0000,stroke-width:3px;\n", joinInts(cycleLinkIndexes)))
 	}
@@ -206,7 +218,7 @@ func (m *MermaidGenerator) Generate(cycles [][]string, violations []graph.Archit
 	b.WriteString("\n")
 	b.WriteString("  subgraph legend_info[\"Legend\"]\n")
 	b.WriteString("    legend_metrics[\"Node line 1: module\\nline 2: funcs/files\\n(d=depth in=fan-in out=fan-out)\\n(cx=complexity hotspot score)\"]\n")
-	b.WriteString("    legend_edges[\"Edge labels: CYCLE=import cycle, VIOLATION=architecture rule violation, ext:N=external dependency count\"]\n")
+	b.WriteString("    legend_edges[\"Edge labels: N imports, M refs=aggregated import sites and symbol references (wider=heavier), CYCLE=import cycle, VIOLATION=architecture rule violation, ext:N=external dependency count\"]\n")
 	b.WriteString("  end\n")
 	b.WriteString("  classDef legendNode fill:#fff8dc,stroke:#b8a24c,stroke-width:1px,color:#000000;\n")
 	b.WriteString("  class legend_metrics,legend_edges legendNode;\n")
@@ -424,6 +436,61 @@ func (m *MermaidGenerator) GenerateFlow(entryPoints []string, maxDepth int) (str
 	return b.String(), nil
 }
 
+// GenerateClusters draws one subgraph per community found by
+// graph.DetectCommunities, filling modules by cluster. Modules whose directory
+// differs from their cluster's dominant directory get a dashed red outline.
+func (m *MermaidGenerator) GenerateClusters() (string, error) {
+	data := buildClusterDiagramData(m.graph)
+	moduleIDs := makeIDs(data.ModuleNames)
+
+	var b strings.Builder
+	b.WriteString("%%{init: {'theme': 'base', 'themeVariables': {'textColor': '#000000', 'primaryTextColor': '#000000', 'lineColor': '#333333'}, 'flowchart': {'nodeSpacing': 80, 'rankSpacing': 110, 'curve': 'basis'}}}%%\n")
+	b.WriteString("flowchart LR\n")
+	for _, community := range data.Communities.Communities {
+		b.WriteString(fmt.Sprintf("  subgraph cluster_%d[\"%s\"]\n", community.ID+1, escapeLabel(clusterTitle(community))))
+		for _, moduleName := range community.Modules {
+			b.WriteString(fmt.Sprintf("    %s[\"%s\"]\n", moduleIDs[moduleName], escapeLabel(moduleLabel(moduleName, data.Modules[moduleName], m.metrics, m.hotspot))))
+		}
+		b.WriteString("  end\n")
+	}
+
+	b.WriteString("\n")
+	for _, edge := range data.Edges {
+		label := fmt.Sprintf("|deps:%d|", edge.Imports)
+		if edge.SymbolRefs > 0 {
+			label = fmt.Sprintf("|deps:%d refs:%d|", edge.Imports, edge.SymbolRefs)
+		}
+		b.WriteString(fmt.Sprintf("  %s -->%s %s\n", moduleIDs[edge.From], label, moduleIDs[edge.To]))
+	}
+
+	b.WriteString("\n")
+	for _, community := range data.Communities.Communities {
+		b.WriteString(fmt.Sprintf("  classDef cluster%d fill:%s,stroke:#4d6480,stroke-width:1px,color:#000000;\n", community.ID+1, clusterFill(community.ID)))
+		b.WriteString("  class ")
+		b.WriteString(strings.Join(toIDs(community.Modules, moduleIDs), ","))
+		b.WriteString(fmt.Sprintf(" cluster%d;\n", community.ID+1))
+	}
+	if len(data.Communities.Misplaced) > 0 {
+		misplaced := make([]string, 0, len(data.Communities.Misplaced))
+		for _, suggestion := range data.Communities.Misplaced {
+			misplaced = append(misplaced, suggestion.Module)
+		}
+		b.WriteString("  classDef misplacedNode stroke:#c62828,stroke-width:2px,stroke-dasharray:4 3;\n")
+		b.WriteString("  class ")
+		b.WriteString(strings.Join(toIDs(misplaced, moduleIDs), ","))
+		b.WriteString(" misplacedNode;\n")
+	}
+
+	b.WriteString("\n")
+	b.WriteString("  subgraph legend_info[\"Legend\"]\n")
+	b.WriteString(fmt.Sprintf("    legend_clusters[\"Subgraph = detected cluster (dominant directory), modularity %.2f\"]\n", data.Communities.Modularity))
+	b.WriteString("    legend_misplaced[\"Dashed red outline = module outside its cluster's directory\"]\n")
+	b.WriteString("  end\n")
+	b.WriteString("  classDef legendNode fill:#fff8dc,stroke:#b8a24c,stroke-width:1px,color:#000000;\n")
+	b.WriteString("  class legend_clusters,legend_misplaced legendNode;\n")
+	return b.String(), nil
+}
+
 // GenerateC4 produces a C4-style, cluster-aggregated Mermaid flowchart.
 //
 // Each layer (from the ArchitectureModel, or auto-clustered by path prefix when
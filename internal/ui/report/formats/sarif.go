@@ -22,6 +22,9 @@ const (
 	ruleIDSecret        = "CIRC002"
 	ruleIDViolation     = "CIRC003"
 	ruleIDArchRuleError = "CIRC004"
+	ruleIDCycleCut      = "CIRC005"
+	ruleIDDeadCode      = "CIRC006"
+	ruleIDEncapsulation = "CIRC007"
 )
 
 // sarifReport is the top-level SARIF document.
@@ -93,11 +96,14 @@ type sarifRegion struct {
 func GenerateSARIF(
 	projectRoot string,
 	cycles [][]string,
+	cycleCuts []graph.CycleBreakPlan,
+	deadCode []graph.DeadDefinition,
+	encapsulation []graph.EncapsulationViolation,
 	violations []graph.ArchitectureViolation,
 	ruleViolations []ports.ArchitectureRuleViolation,
 	secrets []parser.Secret,
 ) ([]byte, error) {
-	rules := buildSARIFRules(cycles, violations, ruleViolations, secrets)
+	rules := buildSARIFRules(cycles, cycleCuts, deadCode, encapsulation, violations, ruleViolations, secrets)
 	results := make([]sarifResult, 0)
 
 	// --- Cycles → CIRC001 ---
@@ -116,6 +122,91 @@ func GenerateSARIF(
 		results = append(results, result)
 	}
 
+	// --- Suggested cycle cuts → CIRC005 ---
+	for _, plan := range cycleCuts {
+		for _, cut := range plan.Cuts {
+			msg := fmt.Sprintf("Suggested cut to break cycle among %s: remove import %s → %s (weight %d: %d import sites, %d symbol refs)",
+				strings.Join(plan.Modules, ", "), cut.From, cut.To, cut.Weight, cut.ImportSites, cut.SymbolRefs)
+			result := sarifResult{
+				RuleID:  ruleIDCycleCut,
+				Level:   "note",
+				Message: sarifMessage{Text: msg},
+			}
+			if cut.File != "" {
+				loc := sarifLocation{
+					PhysicalLocation: sarifPhysicalLocation{
+						ArtifactLocation: sarifArtifactLocation{
+							URI:       relativeURI(projectRoot, cut.File),
+							URIBaseID: "%SRCROOT%",
+						},
+					},
+				}
+				if cut.Line > 0 {
+					loc.PhysicalLocation.Region = &sarifRegion{
+						StartLine:   cut.Line,
+						StartColumn: cut.Column,
+					}
+				}
+				result.Locations = []sarifLocation{loc}
+			} else {
+				result.Locations = []sarifLocation{moduleLocation(projectRoot, cut.From)}
+			}
+			results = append(results, result)
+		}
+	}
+
+	// --- Dead code → CIRC006 ---
+	for _, d := range deadCode {
+		msg := fmt.Sprintf("Exported %s %q in module %s is not referenced by any other module", d.Kind, d.Name, d.Module)
+		result := sarifResult{
+			RuleID:  ruleIDDeadCode,
+			Level:   "note",
+			Message: sarifMessage{Text: msg},
+		}
+		if d.File != "" {
+			loc := sarifLocation{
+				PhysicalLocation: sarifPhysicalLocation{
+					ArtifactLocation: sarifArtifactLocation{
+						URI:       relativeURI(projectRoot, d.File),
+						URIBaseID: "%SRCROOT%",
+					},
+				},
+			}
+			if d.Line > 0 {
+				loc.PhysicalLocation.Region = &sarifRegion{StartLine: d.Line}
+			}
+			result.Locations = []sarifLocation{loc}
+		} else {
+			result.Locations = []sarifLocation{moduleLocation(projectRoot, d.Module)}
+		}
+		results = append(results, result)
+	}
+
+	// --- Encapsulation violations → CIRC007 ---
+	for _, v := range encapsulation {
+		msg := fmt.Sprintf("Module %s imports internal module %s from outside its parent tree", v.From, v.To)
+		if v.Symbol != "" {
+			msg = fmt.Sprintf("Module %s uses %s %q of module %s", v.From, v.Visibility, v.Symbol, v.To)
+		}
+		loc := sarifLocation{
+			PhysicalLocation: sarifPhysicalLocation{
+				ArtifactLocation: sarifArtifactLocation{
+					URI:       relativeURI(projectRoot, v.File),
+					URIBaseID: "%SRCROOT%",
+				},
+			},
+		}
+		if v.Line > 0 {
+			loc.PhysicalLocation.Region = &sarifRegion{StartLine: v.Line, StartColumn: v.Column}
+		}
+		results = append(results, sarifResult{
+			RuleID:    ruleIDEncapsulation,
+			Level:     "warning",
+			Message:   sarifMessage{Text: msg},
+			Locations: []sarifLocation{loc},
+		})
+	}
+
 	// --- Architecture violations → CIRC003 ---
 	for _, v := range violations {
 		msg := fmt.Sprintf("Architecture rule %q violated: %s (%s) → %s (%s)",
@@ -230,7 +321,7 @@ func GenerateSARIF(
 }
 
 // buildSARIFRules returns only the rules that are relevant for the given findings.
-func buildSARIFRules(cycles [][]string, violations []graph.ArchitectureViolation, ruleViolations []ports.ArchitectureRuleViolation, secrets []parser.Secret) []sarifRule {
+func buildSARIFRules(cycles [][]string, cycleCuts []graph.CycleBreakPlan, deadCode []graph.DeadDefinition, encapsulation []graph.EncapsulationViolation, violations []graph.ArchitectureViolation, ruleViolations []ports.ArchitectureRuleViolation, secrets []parser.Secret) []sarifRule {
 	rules := make([]sarifRule, 0, 3)
 	if len(cycles) > 0 {
 		rules = append(rules, sarifRule{
@@ -240,6 +331,30 @@ func buildSARIFRules(cycles [][]string, violations []graph.ArchitectureViolation
 			DefaultConfig:    sarifRuleDefaultConfig{Level: "error"},
 		})
 	}
+	if hasCycleCuts(cycleCuts) {
+		rules = append(rules, sarifRule{
+			ID:               ruleIDCycleCut,
+			Name:             "SuggestedCycleCut",
+			ShortDescription: sarifMessage{Text: "Import edge whose removal helps break a circular dependency at the lowest estimated cost."},
+			DefaultConfig:    sarifRuleDefaultConfig{Level: "note"},
+		})
+	}
+	if len(deadCode) > 0 {
+		rules = append(rules, sarifRule{
+			ID:               ruleIDDeadCode,
+			Name:             "DeadCode",
+			ShortDescription: sarifMessage{Text: "Exported definition with no inbound references from other modules."},
+			DefaultConfig:    sarifRuleDefaultConfig{Level: "note"},
+		})
+	}
+	if len(encapsulation) > 0 {
+		rules = append(rules, sarifRule{
+			ID:               ruleIDEncapsulation,
+			Name:             "EncapsulationViolation",
+			ShortDescription: sarifMessage{Text: "Reference or import crossing a module boundary into private or internal code."},
+			DefaultConfig:    sarifRuleDefaultConfig{Level: "warning"},
+		})
+	}
 	if len(secrets) > 0 {
 		rules = append(rules, sarifRule{
 			ID:               ruleIDSecret,
@@ -296,6 +411,15 @@ func moduleLocation(projectRoot, moduleName string) sarifLocation {
 	}
 }
 
+func hasCycleCuts(plans []graph.CycleBreakPlan) bool {
+	for _, plan := range plans {
+		if len(plan.Cuts) > 0 {
+			return true
+		}
+	}
+	return false
+}
+
 // secretSeverityToLevel maps circular severity strings to SARIF levels.
 func secretSeverityToLevel(severity string) string {
 	switch strings.ToLower(severity) {
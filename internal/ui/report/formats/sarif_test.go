@@ -11,7 +11,7 @@ import (
 )
 
 func TestGenerateSARIF_EmptyResults(t *testing.T) {
-	data, err := GenerateSARIF("", nil, nil, nil, nil)
+	data, err := GenerateSARIF("", nil, nil, nil, nil, nil, nil, nil)
 	if err != nil {
 		t.Fatalf("GenerateSARIF returned error: %v", err)
 	}
@@ -35,7 +35,7 @@ func TestGenerateSARIF_EmptyResults(t *testing.T) {
 
 func TestGenerateSARIF_SingleCycle(t *testing.T) {
 	cycles := [][]string{{"a", "b", "a"}}
-	data, err := GenerateSARIF("/project", cycles, nil, nil, nil)
+	data, err := GenerateSARIF("/project", cycles, nil, nil, nil, nil, nil, nil)
 	if err != nil {
 		t.Fatalf("unexpected error: %v", err)
 	}
@@ -79,7 +79,7 @@ func TestGenerateSARIF_SecretUsesRelativeURI(t *testing.T) {
 			},
 		},
 	}
-	data, err := GenerateSARIF("/project", nil, nil, nil, secrets)
+	data, err := GenerateSARIF("/project", nil, nil, nil, nil, nil, nil, secrets)
 	if err != nil {
 		t.Fatalf("unexpected error: %v", err)
 	}
@@ -133,7 +133,7 @@ func TestGenerateSARIF_ArchViolation(t *testing.T) {
 			Line:       10,
 		},
 	}
-	data, err := GenerateSARIF("/project", nil, violations, nil, nil)
+	data, err := GenerateSARIF("/project", nil, nil, nil, nil, violations, nil, nil)
 	if err != nil {
 		t.Fatalf("unexpected error: %v", err)
 	}
@@ -163,7 +163,7 @@ func TestGenerateSARIF_ArchitectureRuleViolation(t *testing.T) {
 			Actual:   7,
 		},
 	}
-	data, err := GenerateSARIF("/project", nil, nil, violations, nil)
+	data, err := GenerateSARIF("/project", nil, nil, nil, nil, nil, violations, nil)
 	if err != nil {
 		t.Fatalf("unexpected error: %v", err)
 	}
@@ -216,3 +216,101 @@ func TestSecretSeverityToLevel(t *testing.T) {
 		}
 	}
 }
+
+func TestGenerateSARIF_CycleCutUsesImportLocation(t *testing.T) {
+	cycles := [][]string{{"a", "b"}}
+	cuts := []graph.CycleBreakPlan{{
+		Modules: []string{"a", "b"},
+		Cuts: []graph.CycleCut{{
+			From: "b", To: "a", File: "/project/b/b.go", Line: 4, Column: 2,
+			ImportSites: 1, SymbolRefs: 2, Weight: 3,
+		}},
+		TotalWeight: 3,
+	}}
+	data, err := GenerateSARIF("/project", cycles, cuts, nil, nil, nil, nil, nil)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	var report sarifReport
+	if err := json.Unmarshal(data, &report); err != nil {
+		t.Fatalf("invalid JSON: %v", err)
+	}
+	results := report.Runs[0].Results
+	if len(results) != 2 {
+		t.Fatalf("expected cycle and cut results, got %d", len(results))
+	}
+	r := results[1]
+	if r.RuleID != ruleIDCycleCut || r.Level != "note" {
+		t.Fatalf("unexpected cut result rule/level: %s/%s", r.RuleID, r.Level)
+	}
+	loc := r.Locations[0].PhysicalLocation
+	if loc.ArtifactLocation.URI != "b/b.go" || loc.Region == nil || loc.Region.StartLine != 4 {
+		t.Fatalf("unexpected cut location: %+v", loc)
+	}
+	if len(report.Runs[0].Tool.Driver.Rules) != 2 {
+		t.Fatalf("expected cycle and cut rules, got %d", len(report.Runs[0].Tool.Driver.Rules))
+	}
+}
+
+func TestGenerateSARIF_DeadCode(t *testing.T) {
+	dead := []graph.DeadDefinition{{
+		Module: "app/util", Name: "Helper", Kind: parser.KindFunction, File: "/project/util/helper.go", Line: 12,
+	}}
+	data, err := GenerateSARIF("/project", nil, nil, dead, nil, nil, nil, nil)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	var report sarifReport
+	if err := json.Unmarshal(data, &report); err != nil {
+		t.Fatalf("invalid JSON: %v", err)
+	}
+	results := report.Runs[0].Results
+	if len(results) != 1 || results[0].RuleID != ruleIDDeadCode || results[0].Level != "note" {
+		t.Fatalf("unexpected dead code results: %+v", results)
+	}
+	if !strings.Contains(results[0].Message.Text, `function "Helper"`) {
+		t.Fatalf("unexpected dead code message: %s", results[0].Message.Text)
+	}
+	loc := results[0].Locations[0].PhysicalLocation
+	if loc.ArtifactLocation.URI != "util/helper.go" || loc.Region == nil || loc.Region.StartLine != 12 {
+		t.Fatalf("unexpected dead code location: %+v", loc)
+	}
+	if rules := report.Runs[0].Tool.Driver.Rules; len(rules) != 1 || rules[0].ID != ruleIDDeadCode {
+		t.Fatalf("expected only the dead code rule, got %+v", rules)
+	}
+}
+
+func TestGenerateSARIF_Encapsulation(t *testing.T) {
+	violations := []graph.EncapsulationViolation{
+		{Kind: graph.EncapsulationPrivateSymbol, From: "app.main", To: "pkg.core", Symbol: "_cache", Visibility: "private", File: "/project/app/main.py", Line: 4, Column: 5},
+		{Kind: graph.EncapsulationInternalImport, From: "tools", To: "svc/internal/store", File: "/project/tools/gen.go", Line: 3, Column: 2},
+	}
+	data, err := GenerateSARIF("/project", nil, nil, nil, violations, nil, nil, nil)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	var report sarifReport
+	if err := json.Unmarshal(data, &report); err != nil {
+		t.Fatalf("invalid JSON: %v", err)
+	}
+	results := report.Runs[0].Results
+	if len(results) != 2 || results[0].RuleID != ruleIDEncapsulation || results[0].Level != "warning" {
+		t.Fatalf("unexpected encapsulation results: %+v", results)
+	}
+	if results[0].Message.Text != `Module app.main uses private "_cache" of module pkg.core` {
+		t.Fatalf("unexpected symbol message: %s", results[0].Message.Text)
+	}
+	if !strings.Contains(results[1].Message.Text, "imports internal module svc/internal/store") {
+		t.Fatalf("unexpected import message: %s", results[1].Message.Text)
+	}
+	loc := results[0].Locations[0].PhysicalLocation
+	if loc.ArtifactLocation.URI != "app/main.py" || loc.Region == nil || loc.Region.StartLine != 4 || loc.Region.StartColumn != 5 {
+		t.Fatalf("unexpected encapsulation location: %+v", loc)
+	}
+	if rules := report.Runs[0].Tool.Driver.Rules; len(rules) != 1 || rules[0].ID != ruleIDEncapsulation {
+		t.Fatalf("expected only the encapsulation rule, got %+v", rules)
+	}
+}
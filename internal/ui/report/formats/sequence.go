@@ -14,16 +14,19 @@ import (
 type sequenceHop struct {
 	From   string
 	To     string
+	Caller string // calling function or method; empty for module-level code
 	Symbol string
 }
 
 // TraceCallSequence generates a Mermaid sequenceDiagram showing how modules
-// call each other's exported symbols, starting from entryModule and walking
-// the reference graph up to maxDepth hops.
+// call each other's symbols, starting from entryModule and following calls up
+// to maxDepth cross-module hops.
 //
-// It uses the graph's References (cross-module symbol references) to build
-// the sequence. When a module references a symbol that belongs to another
-// module, a message arrow is emitted from caller to callee.
+// When the graph carries linked symbol edges, the trace follows the call graph:
+// only functions reached from entryModule contribute further calls, and each
+// message names its calling function. Otherwise it falls back to module-level
+// references to exported symbols, where every reference made anywhere in a
+// visited module produces a message.
 //
 // Returns an error only if entryModule is not found in the graph.
 func TraceCallSequence(g *graph.Graph, entryModule string, maxDepth int) (string, error) {
@@ -36,6 +39,123 @@ func TraceCallSequence(g *graph.Graph, entryModule string, maxDepth int) (string
 		maxDepth = 5
 	}
 
+	var hops []sequenceHop
+	if edges := g.SymbolEdges(); len(edges) > 0 {
+		hops = traceSymbolCalls(edges, entryModule, maxDepth)
+	} else {
+		hops = traceModuleReferences(g, entryModule, maxDepth)
+	}
+
+	// De-duplicate hops (same caller→callee→symbol).
+	hops = deduplicateHops(hops)
+
+	participants := []string{entryModule}
+	participantSeen := map[string]bool{entryModule: true}
+	for _, hop := range hops {
+		for _, module := range []string{hop.From, hop.To} {
+			if !participantSeen[module] {
+				participantSeen[module] = true
+				participants = append(participants, module)
+			}
+		}
+	}
+
+	var b strings.Builder
+	b.WriteString("sequenceDiagram\n")
+	b.WriteString("  autonumber\n")
+	sort.Strings(participants)
+
+	// Preserve entry module as first participant.
+	sortedParticipants := make([]string, 0, len(participants))
+	sortedParticipants = append(sortedParticipants, entryModule)
+	for _, p := range participants {
+		if p != entryModule {
+			sortedParticipants = append(sortedParticipants, p)
+		}
+	}
+
+	for _, p := range sortedParticipants {
+		// Use the last path segment as the display alias for readability.
+		alias := p
+		if idx := strings.LastIndex(p, "/"); idx >= 0 {
+			alias = p[idx+1:]
+		}
+		b.WriteString(fmt.Sprintf("  participant %s as %s\n", sanitizeID(p), escapeLabel(alias)))
+	}
+
+	b.WriteString("\n")
+	for _, hop := range hops {
+		fromID := sanitizeID(hop.From)
+		toID := sanitizeID(hop.To)
+		message := hop.Symbol + "()"
+		if hop.Caller != "" {
+			message = hop.Caller + " → " + message
+		}
+		b.WriteString(fmt.Sprintf("  %s->>%s: %s\n", fromID, toID, escapeLabel(message)))
+	}
+
+	return b.String(), nil
+}
+
+// traceSymbolCalls walks linked symbol edges from every symbol of
+// entryModule. Calls within a module are followed without a message or a
+// depth step; each call into another module emits a hop. Since intra-module
+// calls are free, symbols are expanded one depth level at a time (a 0-1 BFS),
+// so each is reached at its lowest depth whatever order edges are found in.
+func traceSymbolCalls(edges []graph.SymbolEdge, entryModule string, maxDepth int) []sequenceHop {
+	outgoing := make(map[graph.SymbolKey][]graph.SymbolEdge)
+	level := make([]graph.SymbolKey, 0)
+	queued := make(map[graph.SymbolKey]bool)
+	for _, edge := range edges {
+		outgoing[edge.From] = append(outgoing[edge.From], edge)
+		if edge.From.Module == entryModule && !queued[edge.From] {
+			queued[edge.From] = true
+			level = append(level, edge.From)
+		}
+	}
+
+	hops := make([]sequenceHop, 0)
+	visited := make(map[graph.SymbolKey]bool)
+	for depth := 0; len(level) > 0; depth++ {
+		next := make([]graph.SymbolKey, 0)
+		// level grows while it is walked: intra-module callees share the
+		// caller's depth.
+		for i := 0; i < len(level); i++ {
+			key := level[i]
+			if visited[key] {
+				continue
+			}
+			visited[key] = true
+
+			for _, edge := range outgoing[key] {
+				if edge.To.Module == edge.From.Module {
+					if !visited[edge.To] {
+						level = append(level, edge.To)
+					}
+					continue
+				}
+				if depth >= maxDepth {
+					continue
+				}
+				hops = append(hops, sequenceHop{
+					From:   edge.From.Module,
+					To:     edge.To.Module,
+					Caller: edge.From.Name,
+					Symbol: edge.To.Name,
+				})
+				if !visited[edge.To] {
+					next = append(next, edge.To)
+				}
+			}
+		}
+		level = next
+	}
+	return hops
+}
+
+// traceModuleReferences guesses hops from symbol ownership: any reference in
+// a visited module to another module's exported definition is a call.
+func traceModuleReferences(g *graph.Graph, entryModule string, maxDepth int) []sequenceHop {
 	// Build a lookup: symbol name → owning module.
 	// We iterate all files and record (exported) definitions.
 	symbolOwner := make(map[string]string) // symbol -> module
@@ -58,8 +178,6 @@ func TraceCallSequence(g *graph.Graph, entryModule string, maxDepth int) (string
 
 	visited := make(map[string]bool)
 	queue := []bfsItem{{module: entryModule, depth: 0}}
-	participants := []string{entryModule}
-	participantSeen := map[string]bool{entryModule: true}
 	hops := []sequenceHop{}
 
 	for len(queue) > 0 {
@@ -90,50 +208,12 @@ func TraceCallSequence(g *graph.Graph, entryModule string, maxDepth int) (string
 				Symbol: ref.Name,
 			})
 
-			if !participantSeen[owner] {
-				participantSeen[owner] = true
-				participants = append(participants, owner)
-			}
 			if !visited[owner] {
 				queue = append(queue, bfsItem{module: owner, depth: item.depth + 1})
 			}
 		}
 	}
-
-	// De-duplicate hops (same caller→callee→symbol).
-	hops = deduplicateHops(hops)
-
-	var b strings.Builder
-	b.WriteString("sequenceDiagram\n")
-	b.WriteString("  autonumber\n")
-	sort.Strings(participants)
-
-	// Preserve entry module as first participant.
-	sortedParticipants := make([]string, 0, len(participants))
-	sortedParticipants = append(sortedParticipants, entryModule)
-	for _, p := range participants {
-		if p != entryModule {
-			sortedParticipants = append(sortedParticipants, p)
-		}
-	}
-
-	for _, p := range sortedParticipants {
-		// Use the last path segment as the display alias for readability.
-		alias := p
-		if idx := strings.LastIndex(p, "/"); idx >= 0 {
-			alias = p[idx+1:]
-		}
-		b.WriteString(fmt.Sprintf("  participant %s as %s\n", sanitizeID(p), escapeLabel(alias)))
-	}
-
-	b.WriteString("\n")
-	for _, hop := range hops {
-		fromID := sanitizeID(hop.From)
-		toID := sanitizeID(hop.To)
-		b.WriteString(fmt.Sprintf("  %s->>%s: %s()\n", fromID, toID, escapeLabel(hop.Symbol)))
-	}
-
-	return b.String(), nil
+	return hops
 }
 
 // collectReferences returns all parser.Reference entries from files belonging
@@ -165,7 +245,7 @@ func deduplicateHops(hops []sequenceHop) []sequenceHop {
 	seen := make(map[string]bool, len(hops))
 	out := make([]sequenceHop, 0, len(hops))
 	for _, h := range hops {
-		key := h.From + "|" + h.To + "|" + h.Symbol
+		key := h.From + "|" + h.To + "|" + h.Caller + "|" + h.Symbol
 		if seen[key] {
 			continue
 		}
@@ -0,0 +1,120 @@
+package formats
+
+import (
+	"circular/internal/engine/graph"
+	"circular/internal/engine/parser"
+	"reflect"
+	"strings"
+	"testing"
+)
+
+func sequenceTestGraph() *graph.Graph {
+	g := graph.NewGraph()
+	files := []*parser.File{
+		{
+			Path: "app/main.go", Language: "go", Module: "app",
+			Definitions: []parser.Definition{{Name: "main", Kind: parser.KindFunction}},
+			References:  []parser.Reference{{Name: "Run", Caller: "main"}},
+		},
+		{
+			Path: "svc/svc.go", Language: "go", Module: "svc",
+			Definitions: []parser.Definition{
+				{Name: "Run", Kind: parser.KindFunction, Exported: true},
+				{Name: "Audit", Kind: parser.KindFunction, Exported: true},
+			},
+			References: []parser.Reference{{Name: "Query", Caller: "Run"}, {Name: "Write", Caller: "Audit"}},
+		},
+		{
+			Path: "db/db.go", Language: "go", Module: "db",
+			Definitions: []parser.Definition{{Name: "Query", Kind: parser.KindFunction, Exported: true}},
+		},
+		{
+			Path: "log/log.go", Language: "go", Module: "log",
+			Definitions: []parser.Definition{{Name: "Write", Kind: parser.KindFunction, Exported: true}},
+		},
+	}
+	for _, file := range files {
+		g.AddFile(file)
+	}
+	return g
+}
+
+func TestTraceCallSequence_FollowsCallGraph(t *testing.T) {
+	t.Parallel()
+
+	g := sequenceTestGraph()
+	g.SetSymbolEdges("app/main.go", []graph.SymbolEdge{
+		{From: graph.SymbolKey{Module: "app", Name: "main"}, To: graph.SymbolKey{Module: "svc", Name: "Run"}, File: "app/main.go"},
+	})
+	g.SetSymbolEdges("svc/svc.go", []graph.SymbolEdge{
+		{From: graph.SymbolKey{Module: "svc", Name: "Run"}, To: graph.SymbolKey{Module: "db", Name: "Query"}, File: "svc/svc.go"},
+		{From: graph.SymbolKey{Module: "svc", Name: "Audit"}, To: graph.SymbolKey{Module: "log", Name: "Write"}, File: "svc/svc.go"},
+	})
+
+	out, err := TraceCallSequence(g, "app", 5)
+	if err != nil {
+		t.Fatal(err)
+	}
+	for _, want := range []string{"app->>svc: main → Run()", "svc->>db: Run → Query()"} {
+		if !strings.Contains(out, want) {
+			t.Fatalf("expected %q in sequence:\n%s", want, out)
+		}
+	}
+	// Audit is never called from app, so its call into log is not part of the trace.
+	if strings.Contains(out, "Write") || strings.Contains(out, "participant log") {
+		t.Fatalf("expected uncalled Audit to be skipped:\n%s", out)
+	}
+
+	out, err = TraceCallSequence(g, "app", 1)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if strings.Contains(out, "Query") {
+		t.Fatalf("expected depth 1 to stop after the first hop:\n%s", out)
+	}
+}
+
+func TestTraceCallSequence_FallsBackToModuleReferences(t *testing.T) {
+	t.Parallel()
+
+	out, err := TraceCallSequence(sequenceTestGraph(), "app", 5)
+	if err != nil {
+		t.Fatal(err)
+	}
+	for _, want := range []string{"app->>svc: Run()", "svc->>db: Query()", "svc->>log: Write()"} {
+		if !strings.Contains(out, want) {
+			t.Fatalf("expected %q in sequence:\n%s", want, out)
+		}
+	}
+
+	if _, err := TraceCallSequence(sequenceTestGraph(), "missing", 5); err == nil {
+		t.Fatal("expected error for unknown entry module")
+	}
+}
+
+func TestTraceSymbolCalls_UsesLowestDepthRoute(t *testing.T) {
+	t.Parallel()
+
+	key := func(module, name string) graph.SymbolKey { return graph.SymbolKey{Module: module, Name: name} }
+	// db.Query is first found through svc.Run at depth 2, then through the
+	// intra-module call db.Open -> db.Query at depth 1. Only the second route
+	// leaves a hop for the call into log within maxDepth 2.
+	edges := []graph.SymbolEdge{
+		{From: key("app", "main"), To: key("svc", "Run")},
+		{From: key("app", "main"), To: key("db", "Open")},
+		{From: key("svc", "Run"), To: key("db", "Query")},
+		{From: key("db", "Open"), To: key("db", "Query")},
+		{From: key("db", "Query"), To: key("log", "Write")},
+	}
+
+	got := traceSymbolCalls(edges, "app", 2)
+	want := []sequenceHop{
+		{From: "app", To: "svc", Caller: "main", Symbol: "Run"},
+		{From: "app", To: "db", Caller: "main", Symbol: "Open"},
+		{From: "svc", To: "db", Caller: "Run", Symbol: "Query"},
+		{From: "db", To: "log", Caller: "Query", Symbol: "Write"},
+	}
+	if !reflect.DeepEqual(got, want) {
+		t.Fatalf("unexpected hops\n got: %+v\nwant: %+v", got, want)
+	}
+}
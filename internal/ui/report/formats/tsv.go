@@ -7,8 +7,10 @@ import (
 	"circular/internal/engine/parser"
 	"circular/internal/engine/resolver"
 	"circular/internal/engine/secrets"
+	"circular/internal/shared/util"
 	"fmt"
 	"strings"
+	"time"
 )
 
 type TSVGenerator struct {
@@ -22,13 +24,17 @@ func NewTSVGenerator(g *graph.Graph) *TSVGenerator {
 func (t *TSVGenerator) Generate() (string, error) {
 	var buf strings.Builder
 
-	buf.WriteString("From\tTo\tFile\tLine\tColumn\n")
+	buf.WriteString("From\tTo\tFile\tLine\tColumn\tImports\tUsage\tFiles\tSymbols\n")
 
 	imports := t.graph.GetImports()
-	for from, targets := range imports {
-		for to, edge := range targets {
-			buf.WriteString(fmt.Sprintf("%s\t%s\t%s\t%d\t%d\n",
-				from, to, edge.ImportedBy, edge.Location.Line, edge.Location.Column))
+	for _, from := range util.SortedStringKeys(imports) {
+		targets := imports[from]
+		for _, to := range util.SortedStringKeys(targets) {
+			edge := targets[to]
+			buf.WriteString(fmt.Sprintf("%s\t%s\t%s\t%d\t%d\t%d\t%d\t%s\t%s\n",
+				from, to, edge.ImportedBy, edge.Location.Line, edge.Location.Column,
+				edge.ImportCount, edge.UsageCount,
+				strings.Join(edge.Files, ","), strings.Join(edge.Symbols, ",")))
 		}
 	}
 
@@ -55,6 +61,78 @@ func (t *TSVGenerator) GenerateUnusedImports(rows []resolver.UnusedImport) (stri
 	return buf.String(), nil
 }
 
+func (t *TSVGenerator) GenerateDeadCode(rows []graph.DeadDefinition) (string, error) {
+	var buf strings.Builder
+
+	buf.WriteString("Type\tModule\tSymbol\tKind\tFile\tLine\n")
+	for _, row := range rows {
+		buf.WriteString(fmt.Sprintf("dead_code\t%s\t%s\t%s\t%s\t%d\n",
+			row.Module,
+			row.Name,
+			row.Kind,
+			row.File,
+			row.Line,
+		))
+	}
+
+	return buf.String(), nil
+}
+
+func (t *TSVGenerator) GenerateEncapsulationViolations(rows []graph.EncapsulationViolation) (string, error) {
+	var buf strings.Builder
+
+	buf.WriteString("Type\tKind\tFrom\tTo\tSymbol\tVisibility\tFile\tLine\tColumn\n")
+	for _, row := range rows {
+		buf.WriteString(fmt.Sprintf("encapsulation_violation\t%s\t%s\t%s\t%s\t%s\t%s\t%d\t%d\n",
+			row.Kind,
+			row.From,
+			row.To,
+			row.Symbol,
+			row.Visibility,
+			row.File,
+			row.Line,
+			row.Column,
+		))
+	}
+
+	return buf.String(), nil
+}
+
+func (t *TSVGenerator) GenerateStaleSampleImports(rows []graph.StaleSampleImport) (string, error) {
+	var buf strings.Builder
+
+	buf.WriteString("Type\tModule\tFile\tLine\tColumn\n")
+	for _, row := range rows {
+		buf.WriteString(fmt.Sprintf("stale_sample_import\t%s\t%s\t%d\t%d\n",
+			row.Module,
+			row.File,
+			row.Line,
+			row.Column,
+		))
+	}
+
+	return buf.String(), nil
+}
+
+func (t *TSVGenerator) GenerateOrphanModules(rows []graph.OrphanModule) (string, error) {
+	var buf strings.Builder
+
+	buf.WriteString("Type\tModule\tFiles\tLastModified\n")
+	for _, row := range rows {
+		modified := "-"
+		if !row.LastModified.IsZero() {
+			modified = row.LastModified.UTC().Format(time.RFC3339)
+		}
+		buf.WriteString(fmt.Sprintf("orphan_module\t%s\t%d\t%s\n",
+			row.Module,
+			len(row.Files),
+			modified,
+		))
+	}
+
+	return buf.String(), nil
+}
+
 func (t *TSVGenerator) GenerateArchitectureViolations(rows []graph.ArchitectureViolation) (string, error) {
 	var buf strings.Builder
 
@@ -124,6 +202,29 @@ func (t *TSVGenerator) GenerateProbableBridges(rows []resolver.ProbableBridgeRef
 	return buf.String(), nil
 }
 
+func (t *TSVGenerator) GenerateModuleMetrics(metrics map[string]graph.ModuleMetrics) (string, error) {
+	var buf strings.Builder
+
+	buf.WriteString("Type\tModule\tCa\tCe\tInstability\tAbstractness\tDistance\tDepth\tTransitiveModules\tTransitiveFiles\tTransitiveLOC\n")
+	for _, name := range util.SortedStringKeys(metrics) {
+		m := metrics[name]
+		buf.WriteString(fmt.Sprintf("module_metrics\t%s\t%d\t%d\t%.4f\t%.4f\t%.4f\t%d\t%d\t%d\t%d\n",
+			name,
+			m.FanIn,
+			m.FanOut,
+			m.Instability,
+			m.Abstractness,
+			m.Distance,
+			m.Depth,
+			m.TransitiveModules,
+			m.TransitiveFiles,
+			m.TransitiveLOC,
+		))
+	}
+
+	return buf.String(), nil
+}
+
 func (t *TSVGenerator) GenerateSecrets(rows []parser.Secret) (string, error) {
 	var buf strings.Builder
 
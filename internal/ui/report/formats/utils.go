@@ -4,6 +4,7 @@ import (
 	"circular/internal/engine/graph"
 	"circular/internal/shared/util"
 	"fmt"
+	"math/bits"
 	"sort"
 	"strings"
 	"unicode"
@@ -30,6 +31,29 @@ func moduleLabel(module string, mod *graph.Module, metrics map[string]graph.Modu
 	return strings.Join(parts, "\\n")
 }
 
+// edgeWeightLabel summarises an aggregated import edge for diagram labels.
+func edgeWeightLabel(edge *graph.ImportEdge) string {
+	if edge == nil {
+		return ""
+	}
+	return fmt.Sprintf("%d imports, %d refs", edge.ImportCount, edge.UsageCount)
+}
+
+// edgeWidth scales a stroke width logarithmically with the edge weight, from 1
+// for a single import up to maxEdgeWidth.
+func edgeWidth(edge *graph.ImportEdge) int {
+	if edge == nil {
+		return 1
+	}
+	width := 1 + bits.Len(uint(edge.Weight()))/2
+	if width > maxEdgeWidth {
+		return maxEdgeWidth
+	}
+	return width
+}
+
+const maxEdgeWidth = 5
+
 func sanitizeID(module string) string {
 	if module == "" {
 		return "m"
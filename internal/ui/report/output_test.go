@@ -127,6 +127,32 @@ func TestTSVGenerator(t *testing.T) {
 	}
 }
 
+func TestTSVGenerator_IncludesEdgeWeights(t *testing.T) {
+	g := graph.NewGraph()
+	g.AddFile(&parser.File{
+		Path:    "b.go",
+		Module:  "modA",
+		Imports: []parser.Import{{Module: "modB", Items: []string{"Run"}, UsageCount: 3}},
+	})
+	g.AddFile(&parser.File{
+		Path:    "a.go",
+		Module:  "modA",
+		Imports: []parser.Import{{Module: "modB", Items: []string{"New"}, Location: parser.Location{Line: 2, Column: 1}}},
+	})
+
+	tsv, err := NewTSVGenerator(g).Generate()
+	if err != nil {
+		t.Fatal(err)
+	}
+	lines := strings.Split(strings.TrimSpace(tsv), "\n")
+	if lines[0] != "From\tTo\tFile\tLine\tColumn\tImports\tUsage\tFiles\tSymbols" {
+		t.Fatalf("unexpected TSV header: %s", lines[0])
+	}
+	if len(lines) != 2 || lines[1] != "modA\tmodB\ta.go\t2\t1\t2\t3\ta.go,b.go\tNew,Run" {
+		t.Fatalf("unexpected weighted TSV rows: %q", lines[1:])
+	}
+}
+
 func TestTSVGenerator_GenerateUnusedImports(t *testing.T) {
 	g := graph.NewGraph()
 	gen := NewTSVGenerator(g)
@@ -190,6 +216,102 @@ func TestTSVGenerator_GenerateArchitectureViolations(t *testing.T) {
 	}
 }
 
+func TestTSVGenerator_GenerateDeadCode(t *testing.T) {
+	g := graph.NewGraph()
+	gen := NewTSVGenerator(g)
+
+	tsv, err := gen.GenerateDeadCode([]graph.DeadDefinition{
+		{Module: "app/util", Name: "Helper", Kind: parser.KindFunction, File: "util/helper.go", Line: 12},
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	lines := strings.Split(strings.TrimSpace(tsv), "\n")
+	if len(lines) != 2 {
+		t.Fatalf("Expected 2 lines in dead code TSV, got %d", len(lines))
+	}
+	if lines[0] != "Type\tModule\tSymbol\tKind\tFile\tLine" {
+		t.Fatalf("Unexpected dead code TSV header: %s", lines[0])
+	}
+	if lines[1] != "dead_code\tapp/util\tHelper\tfunction\tutil/helper.go\t12" {
+		t.Fatalf("Unexpected dead code TSV row: %s", lines[1])
+	}
+}
+
+func TestTSVGenerator_GenerateEncapsulationViolations(t *testing.T) {
+	g := graph.NewGraph()
+	gen := NewTSVGenerator(g)
+
+	tsv, err := gen.GenerateEncapsulationViolations([]graph.EncapsulationViolation{
+		{Kind: graph.EncapsulationPrivateSymbol, From: "app.main", To: "pkg.core", Symbol: "_cache", Visibility: "private", File: "app/main.py", Line: 4, Column: 5},
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	lines := strings.Split(strings.TrimSpace(tsv), "\n")
+	if len(lines) != 2 {
+		t.Fatalf("Expected 2 lines in encapsulation TSV, got %d", len(lines))
+	}
+	if lines[0] != "Type\tKind\tFrom\tTo\tSymbol\tVisibility\tFile\tLine\tColumn" {
+		t.Fatalf("Unexpected encapsulation TSV header: %s", lines[0])
+	}
+	if lines[1] != "encapsulation_violation\tprivate_symbol\tapp.main\tpkg.core\t_cache\tprivate\tapp/main.py\t4\t5" {
+		t.Fatalf("Unexpected encapsulation TSV row: %s", lines[1])
+	}
+}
+
+func TestTSVGenerator_GenerateStaleSampleImports(t *testing.T) {
+	g := graph.NewGraph()
+	gen := NewTSVGenerator(g)
+
+	tsv, err := gen.GenerateStaleSampleImports([]graph.StaleSampleImport{
+		{File: "docs/guide.md", Module: "app.gone", Line: 9, Column: 6},
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	lines := strings.Split(strings.TrimSpace(tsv), "\n")
+	if len(lines) != 2 {
+		t.Fatalf("Expected 2 lines in stale sample TSV, got %d", len(lines))
+	}
+	if lines[0] != "Type\tModule\tFile\tLine\tColumn" {
+		t.Fatalf("Unexpected stale sample TSV header: %s", lines[0])
+	}
+	if lines[1] != "stale_sample_import\tapp.gone\tdocs/guide.md\t9\t6" {
+		t.Fatalf("Unexpected stale sample TSV row: %s", lines[1])
+	}
+}
+
+func TestTSVGenerator_GenerateOrphanModules(t *testing.T) {
+	g := graph.NewGraph()
+	gen := NewTSVGenerator(g)
+
+	tsv, err := gen.GenerateOrphanModules([]graph.OrphanModule{
+		{Module: "legacy", Files: []string{"legacy/a.go", "legacy/b.go"}, LastModified: time.Date(2026, 3, 1, 10, 0, 0, 0, time.UTC)},
+		{Module: "scratch", Files: []string{"scratch/x.py"}},
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	lines := strings.Split(strings.TrimSpace(tsv), "\n")
+	if len(lines) != 3 {
+		t.Fatalf("Expected 3 lines in orphan TSV, got %d", len(lines))
+	}
+	if lines[0] != "Type\tModule\tFiles\tLastModified" {
+		t.Fatalf("Unexpected orphan TSV header: %s", lines[0])
+	}
+	if lines[1] != "orphan_module\tlegacy\t2\t2026-03-01T10:00:00Z" {
+		t.Fatalf("Unexpected orphan TSV row: %s", lines[1])
+	}
+	if lines[2] != "orphan_module\tscratch\t1\t-" {
+		t.Fatalf("Unexpected untracked orphan TSV row: %q", lines[2])
+	}
+}
+
 func TestTSVGenerator_GenerateArchitectureRuleViolations(t *testing.T) {
 	g := graph.NewGraph()
 	gen := NewTSVGenerator(g)
@@ -250,6 +372,33 @@ func TestTSVGenerator_GenerateProbableBridges(t *testing.T) {
 	}
 }
 
+func TestTSVGenerator_GenerateModuleMetrics(t *testing.T) {
+	g := graph.NewGraph()
+	gen := NewTSVGenerator(g)
+
+	tsv, err := gen.GenerateModuleMetrics(map[string]graph.ModuleMetrics{
+		"app/b": {FanIn: 1, FanOut: 3, Instability: 0.75, Abstractness: 0.5, Distance: 0.25, Depth: 1, TransitiveModules: 2, TransitiveFiles: 3, TransitiveLOC: 120},
+		"app/a": {FanIn: 2},
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	lines := strings.Split(strings.TrimSpace(tsv), "\n")
+	if len(lines) != 3 {
+		t.Fatalf("Expected 3 lines in module-metrics TSV, got %d", len(lines))
+	}
+	if lines[0] != "Type\tModule\tCa\tCe\tInstability\tAbstractness\tDistance\tDepth\tTransitiveModules\tTransitiveFiles\tTransitiveLOC" {
+		t.Fatalf("Unexpected module-metrics TSV header: %s", lines[0])
+	}
+	if lines[1] != "module_metrics\tapp/a\t2\t0\t0.0000\t0.0000\t0.0000\t0\t0\t0\t0" {
+		t.Fatalf("Unexpected module-metrics TSV row: %s", lines[1])
+	}
+	if lines[2] != "module_metrics\tapp/b\t1\t3\t0.7500\t0.5000\t0.2500\t1\t2\t3\t120" {
+		t.Fatalf("Unexpected module-metrics TSV row: %s", lines[2])
+	}
+}
+
 func TestTSVGenerator_GenerateSecrets(t *testing.T) {
 	g := graph.NewGraph()
 	gen := NewTSVGenerator(g)
@@ -422,6 +571,45 @@ func TestMermaidGenerator_GenerateArchitecture(t *testing.T) {
 	}
 }
 
+func TestDiagramGenerators_RenderEdgeWeights(t *testing.T) {
+	g := graph.NewGraph()
+	g.AddFile(&parser.File{
+		Path:    "a.go",
+		Module:  "modA",
+		Imports: []parser.Import{{Module: "modB", UsageCount: 6}},
+	})
+	g.AddFile(&parser.File{
+		Path:    "a2.go",
+		Module:  "modA",
+		Imports: []parser.Import{{Module: "modB", UsageCount: 1}},
+	})
+	g.AddFile(&parser.File{Path: "b.go", Module: "modB"})
+
+	dot, err := NewDOTGenerator(g).Generate(nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !strings.Contains(dot, `"modA" -> "modB" [color="forestgreen", penwidth=3, label="2 imports, 7 refs"]`) {
+		t.Fatalf("expected weighted DOT edge, got: %s", dot)
+	}
+
+	mermaid, err := NewMermaidGenerator(g).Generate(nil, nil, graph.ArchitectureModel{})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !strings.Contains(mermaid, "modA -->|2 imports, 7 refs| modB") || !strings.Contains(mermaid, "linkStyle 0 stroke-width:3px;") {
+		t.Fatalf("expected weighted mermaid edge, got: %s", mermaid)
+	}
+
+	plantuml, err := NewPlantUMLGenerator(g).Generate(nil, nil, graph.ArchitectureModel{})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !strings.Contains(plantuml, "-[thickness=3]->") || !strings.Contains(plantuml, " : 2 imports, 7 refs") {
+		t.Fatalf("expected weighted plantuml edge, got: %s", plantuml)
+	}
+}
+
 func TestPlantUMLGenerator_GenerateArchitecture(t *testing.T) {
 	g := graph.NewGraph()
 	g.AddFile(&parser.File{
@@ -505,6 +693,56 @@ func TestMermaidGenerator_GenerateComponent(t *testing.T) {
 	}
 }
 
+func clusterTestGraph() *graph.Graph {
+	g := graph.NewGraph()
+	add := func(module string, imports ...string) {
+		file := &parser.File{Path: module + "/x.go", Module: module}
+		for _, imp := range imports {
+			file.Imports = append(file.Imports, parser.Import{Module: imp})
+		}
+		g.AddFile(file)
+	}
+	add("billing/api", "billing/store", "users/tax")
+	add("billing/store", "users/tax")
+	add("users/tax", "billing/api")
+	add("users/api", "users/store", "users/auth", "billing/api")
+	add("users/store", "users/auth")
+	add("users/auth", "users/api")
+	return g
+}
+
+func TestMermaidGenerator_GenerateClusters(t *testing.T) {
+	gen := NewMermaidGenerator(clusterTestGraph())
+	out, err := gen.GenerateClusters()
+	if err != nil {
+		t.Fatal(err)
+	}
+	for _, want := range []string{
+		"subgraph cluster_1[\"cluster 1: billing\"]",
+		"subgraph cluster_2[\"cluster 2: users\"]",
+		"classDef misplacedNode",
+		"deps:1",
+	} {
+		if !strings.Contains(out, want) {
+			t.Fatalf("expected %q in cluster diagram, got: %s", want, out)
+		}
+	}
+}
+
+func TestPlantUMLGenerator_GenerateClusters(t *testing.T) {
+	gen := NewPlantUMLGenerator(clusterTestGraph())
+	out, err := gen.GenerateClusters()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !strings.Contains(out, "package \"cluster 1: billing\" #DBEAFE {") {
+		t.Fatalf("expected coloured cluster package, got: %s", out)
+	}
+	if !strings.Contains(out, "<<misplaced>>") {
+		t.Fatalf("expected misplaced stereotype, got: %s", out)
+	}
+}
+
 func TestPlantUMLGenerator_GenerateFlow(t *testing.T) {
 	g := graph.NewGraph()
 	g.AddFile(&parser.File{